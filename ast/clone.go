@@ -0,0 +1,141 @@
+package ast
+
+import "reflect"
+
+// Clone returns a deep copy of the node, which is not attached to any parent. The children of the copy are
+// attached to their copied parents. A registered Macro is recreated from a copy of its parameters, so that its
+// closure refers to the copied nodes. A Macro without a Kind cannot be recreated, so its copy just delegates to
+// the original. The Values of each node are copied shallowly.
+func Clone(n Node) Node {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return n
+	}
+
+	return cloneValue(reflect.ValueOf(n)).Interface().(Node)
+}
+
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+
+		if m, ok := v.Interface().(*Macro); ok {
+			return reflect.ValueOf(cloneMacro(m))
+		}
+
+		if v.Elem().Kind() != reflect.Struct {
+			return v
+		}
+
+		res := reflect.New(v.Elem().Type())
+		cloneStruct(res.Elem(), v.Elem())
+		if node, ok := res.Interface().(Node); ok {
+			attachChildren(node)
+		}
+
+		return res
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		res := reflect.New(v.Type()).Elem()
+		res.Set(cloneValue(v.Elem()))
+
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(cloneValue(v.Index(i)))
+		}
+
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		cloneStruct(res, v)
+
+		return res
+	default:
+		return v
+	}
+}
+
+// cloneStruct copies all exported fields. The embedded Obj is detached from its parent.
+func cloneStruct(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if field.Type == objType {
+			obj := src.Field(i).Interface().(Obj)
+			dst.Field(i).Set(reflect.ValueOf(*obj.Clone()))
+			continue
+		}
+
+		dst.Field(i).Set(cloneValue(src.Field(i)))
+	}
+}
+
+// cloneMacro recreates the macro from its copied parameters or delegates to the original.
+func cloneMacro(m *Macro) *Macro {
+	var res *Macro
+	if m.Kind != "" {
+		params, _ := cloneValue(reflect.ValueOf(m.Params)).Interface().(MacroParams)
+		if made, err := MakeMacro(m.Kind, params); err == nil {
+			res = made
+		}
+	}
+
+	if res == nil {
+		res = &Macro{Func: func(*Macro) []Node { return m.Children() }}
+	}
+
+	res.ID = m.ID
+	res.CacheFunc = m.CacheFunc
+	res.Obj = *m.Obj.Clone()
+	if res.ObjComment != nil {
+		res.ObjComment.SetParent(res)
+	}
+
+	return res
+}
+
+// attachChildren updates the parent of the copied children and of the comment. A macro is not inspected, because
+// its children are evaluated lazily.
+func attachChildren(n Node) {
+	if _, ok := n.(*Macro); ok {
+		return
+	}
+
+	if c := n.Comment(); c != nil && c != n {
+		c.SetParent(n)
+	}
+
+	if p, ok := n.(Parent); ok {
+		for _, child := range p.Children() {
+			if settable, ok := child.(SettableParent); ok {
+				settable.SetParent(n)
+			}
+		}
+	}
+}
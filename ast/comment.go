@@ -4,6 +4,16 @@ package ast
 // an exact positioning of the actual text characters is not possible. A comment may consists of multiple
 // single line comments or one large multiline comment.
 //   * Go/Java: // for single line and /* .. */ for multiline, however the text is stripped.
+//
+// The Text may contain structured content, which is encoded in the Go doc comment syntax, so that it survives
+// any serialization. See also Blocks and the Add* methods:
+//   * paragraphs are separated by blank lines.
+//   * list items are indented lines starting with a bullet (- * + •) or a number (1. or 1)). A list item
+//     continues on the following indented lines.
+//   * other indented lines are code blocks.
+//   * a paragraph starting with "Deprecated:" is a deprecation notice.
+//   * doc links are written as [Name], [pkg.Name] or [pkg.Type.Method], where pkg is a qualifier like
+//     github.com/myproject/mymodule/api or java.util.
 type Comment struct {
 	Text string // the actual comment text, may include newlines.
 	Obj
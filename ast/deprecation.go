@@ -0,0 +1,48 @@
+package ast
+
+import "strings"
+
+// A Deprecation marks a declaration as deprecated. Renderers emit it as a deprecation notice in the
+// documentation and as a language specific marker, e.g. the @Deprecated annotation in Java.
+type Deprecation struct {
+	Reason      string // Reason describes why the declaration should not be used anymore.
+	Replacement Name   // Replacement optionally refers to the declaration which should be used instead.
+}
+
+// NewDeprecation allocates a new Deprecation. The replacement may be empty.
+func NewDeprecation(reason string, replacement Name) *Deprecation {
+	return &Deprecation{Reason: reason, Replacement: replacement}
+}
+
+// Text returns the notice without the Deprecated: prefix, e.g. "the name is misleading. Use [Greet] instead.".
+// The replacement is a doc link, see also Comment.
+func (d *Deprecation) Text() string {
+	text := strings.TrimSpace(d.Reason)
+	if d.Replacement != "" {
+		if text != "" && !strings.HasSuffix(text, ".") {
+			text += "."
+		}
+
+		text = strings.TrimSpace(text + " Use " + DocLink(d.Replacement) + " instead.")
+	}
+
+	return text
+}
+
+// DocWithDeprecation returns the comment text with an appended deprecation notice, if d is not nil and the text
+// does not already contain a notice.
+func DocWithDeprecation(text string, d *Deprecation) string {
+	if d == nil {
+		return text
+	}
+
+	for _, block := range ParseDoc(text) {
+		if block.Kind == DocDeprecated {
+			return text
+		}
+	}
+
+	c := &Comment{Text: text}
+
+	return c.AddDeprecated(d.Text()).Text
+}
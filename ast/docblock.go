@@ -0,0 +1,271 @@
+package ast
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DeprecatedPrefix starts the paragraph of a Comment which declares a deprecation notice.
+const DeprecatedPrefix = "Deprecated:"
+
+// DocKind determines the type of a DocBlock.
+type DocKind int
+
+const (
+	// DocParagraph is a span of text lines. Line breaks are kept, but renderers may wrap long lines.
+	DocParagraph DocKind = iota
+	// DocList is a bullet or numbered list.
+	DocList
+	// DocCode is a preformatted block, which is never wrapped.
+	DocCode
+	// DocDeprecated is a deprecation notice, whose Text contains the reason and the replacement.
+	DocDeprecated
+)
+
+// A DocBlock is a structural element of a Comment. The Text of paragraphs, list items and deprecation notices
+// may contain doc links like [fmt.Println] or [github.com/myproject/mymodule/api.Hello], see also DocSpans.
+type DocBlock struct {
+	Kind     DocKind
+	Text     string   // Text of a paragraph, code block or deprecation notice.
+	Items    []string // Items of a list.
+	Numbered bool     // Numbered is true for an ordered list.
+}
+
+// AddParagraph appends a paragraph.
+func (n *Comment) AddParagraph(text string) *Comment {
+	return n.addBlock(strings.TrimSpace(text))
+}
+
+// AddList appends a bullet list. Just like in Go, a list should be followed by a paragraph, because a directly
+// following list or code block is considered to continue the list by godoc.
+func (n *Comment) AddList(items ...string) *Comment {
+	return n.addList(false, items)
+}
+
+// AddNumberedList appends an ordered list. See also AddList.
+func (n *Comment) AddNumberedList(items ...string) *Comment {
+	return n.addList(true, items)
+}
+
+// AddCode appends a preformatted code block.
+func (n *Comment) AddCode(code string) *Comment {
+	lines := strings.Split(strings.Trim(code, "\n"), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+
+		lines[i] = "\t" + line
+	}
+
+	return n.addBlock(strings.Join(lines, "\n"))
+}
+
+// AddDeprecated appends a deprecation notice, which should describe the reason and the replacement.
+func (n *Comment) AddDeprecated(text string) *Comment {
+	return n.addBlock(DeprecatedPrefix + " " + strings.TrimSpace(text))
+}
+
+// Blocks parses the Text into its structural elements.
+func (n *Comment) Blocks() []DocBlock {
+	if n == nil {
+		return nil
+	}
+
+	return ParseDoc(n.Text)
+}
+
+func (n *Comment) addList(numbered bool, items []string) *Comment {
+	sb := &strings.Builder{}
+	for i, item := range items {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		if numbered {
+			sb.WriteString(" " + strconv.Itoa(i+1) + ". ")
+		} else {
+			sb.WriteString("  - ")
+		}
+
+		// continuation lines must stay indented
+		sb.WriteString(strings.ReplaceAll(strings.TrimSpace(item), "\n", "\n    "))
+	}
+
+	return n.addBlock(sb.String())
+}
+
+func (n *Comment) addBlock(block string) *Comment {
+	if strings.TrimSpace(n.Text) != "" {
+		n.Text = strings.TrimRight(n.Text, "\n") + "\n\n"
+	}
+
+	n.Text += block
+
+	return n
+}
+
+// DocLink returns the doc link syntax for the given name, e.g. [java.util.List].
+func DocLink(name Name) string {
+	return "[" + string(name) + "]"
+}
+
+// ParseDoc splits a comment text into its structural elements, see also Comment.
+func ParseDoc(text string) []DocBlock {
+	var res []DocBlock
+	lines := strings.Split(strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n")
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case listMarker(line) != "":
+			block := DocBlock{Kind: DocList, Numbered: isNumbered(listMarker(line))}
+			for i < len(lines) {
+				marker := listMarker(lines[i])
+				if marker != "" {
+					block.Items = append(block.Items, strings.TrimSpace(strings.TrimSpace(lines[i])[len(marker):]))
+				} else if isIndented(lines[i]) && strings.TrimSpace(lines[i]) != "" {
+					block.Items[len(block.Items)-1] += "\n" + strings.TrimSpace(lines[i])
+				} else {
+					break
+				}
+
+				i++
+			}
+
+			res = append(res, block)
+		case isIndented(line):
+			var code []string
+			for i < len(lines) && (isIndented(lines[i]) || strings.TrimSpace(lines[i]) == "") {
+				code = append(code, lines[i])
+				i++
+			}
+
+			// trailing blank lines separate the next block
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+
+			res = append(res, DocBlock{Kind: DocCode, Text: unindent(code)})
+		default:
+			var para []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && !isIndented(lines[i]) && (len(para) == 0 || listMarker(lines[i]) == "") {
+				para = append(para, strings.TrimSpace(lines[i]))
+				i++
+			}
+
+			text := strings.Join(para, "\n")
+			if strings.HasPrefix(text, DeprecatedPrefix) {
+				res = append(res, DocBlock{Kind: DocDeprecated, Text: strings.TrimSpace(text[len(DeprecatedPrefix):])})
+			} else {
+				res = append(res, DocBlock{Kind: DocParagraph, Text: text})
+			}
+		}
+	}
+
+	return res
+}
+
+var numberedMarker = regexp.MustCompile(`^[0-9]+[.)]\s`)
+
+// listMarker returns the bullet or number of a list item line, including the separating white space. Besides
+// the indented items of the Go syntax, unindented bullet items are accepted as well.
+func listMarker(line string) string {
+	trimmed := strings.TrimSpace(line) + " "
+	for _, bullet := range []string{"- ", "* ", "+ ", "• "} {
+		if strings.HasPrefix(trimmed, bullet) {
+			return bullet
+		}
+	}
+
+	if isIndented(line) {
+		return numberedMarker.FindString(trimmed)
+	}
+
+	return ""
+}
+
+func isNumbered(marker string) bool {
+	return marker != "" && unicode.IsDigit(rune(marker[0]))
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// unindent removes the longest common white space prefix.
+func unindent(lines []string) string {
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			prefix = indent
+			first = false
+		}
+
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	res := make([]string, 0, len(lines))
+	for _, line := range lines {
+		res = append(res, strings.TrimPrefix(line, prefix))
+	}
+
+	return strings.Join(res, "\n")
+}
+
+// A DocSpan is a part of a doc text, which is either plain text or a link.
+type DocSpan struct {
+	Text string // Text is the plain text, or the link text including the brackets.
+	Link Name   // Link is the target, e.g. java.util.List, or empty for plain text.
+}
+
+var docLink = regexp.MustCompile(`\[\*?([A-Za-z_][A-Za-z0-9_./-]*)\]`)
+
+// DocSpans splits a paragraph, list item or deprecation text at its doc links. Brackets are only links, if the
+// name ends with an identifier, e.g. [1] or [a, b] are kept as plain text.
+func DocSpans(text string) []DocSpan {
+	var res []DocSpan
+	last := 0
+	for _, m := range docLink.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[2]:m[3]]
+		ident := name[strings.LastIndexAny(name, "./")+1:]
+		if ident == "" || !isIdent(ident) || m[1] < len(text) && (text[m[1]] == '(' || text[m[1]] == ':') {
+			continue
+		}
+
+		if m[0] > last {
+			res = append(res, DocSpan{Text: text[last:m[0]]})
+		}
+
+		res = append(res, DocSpan{Text: text[m[0]:m[1]], Link: Name(name)})
+		last = m[1]
+	}
+
+	if last < len(text) {
+		res = append(res, DocSpan{Text: text[last:]})
+	}
+
+	return res
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !(unicode.IsLetter(r) || r == '_' || i > 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return s != ""
+}
@@ -9,6 +9,30 @@ type Enum struct {
 	Obj
 }
 
+// NewEnum allocates a new enumeration. If baseType is empty, the renderer decides the natural representation,
+// which is usually an integer.
+func NewEnum(name string, baseType Name) *Enum {
+	return &Enum{TypeName: name, BaseType: baseType}
+}
+
+// SetComment sets the nodes comment.
+func (n *Enum) SetComment(text string) *Enum {
+	n.ObjComment = NewComment(text)
+	n.ObjComment.SetParent(n)
+	return n
+}
+
+// AddCases appends and attaches the given cases.
+func (n *Enum) AddCases(cases ...*EnumCase) *Enum {
+	for _, enumCase := range cases {
+		assertNotAttached(enumCase)
+		assertSettableParent(enumCase).SetParent(n)
+		n.Cases = append(n.Cases, enumCase)
+	}
+
+	return n
+}
+
 func (n *Enum) Identifier() string {
 	return n.TypeName
 }
@@ -34,6 +58,36 @@ type EnumCase struct {
 	Obj
 }
 
+// NewEnumCase allocates a new case. Without a value, the renderer assigns a unique one.
+func NewEnumCase(name string) *EnumCase {
+	return &EnumCase{TypeName: name}
+}
+
+// SetValue sets and attaches the literal value of the case.
+func (n *EnumCase) SetValue(lit *BasicLit) *EnumCase {
+	assertNotAttached(lit)
+	assertSettableParent(lit).SetParent(n)
+	n.EnumValue = lit
+
+	return n
+}
+
+// SetComment sets the nodes comment.
+func (n *EnumCase) SetComment(text string) *EnumCase {
+	n.ObjComment = NewComment(text)
+	n.ObjComment.SetParent(n)
+	return n
+}
+
 func (n *EnumCase) Name() string {
 	return n.TypeName
 }
+
+// Children returns a defensive copy of the underlying slice. However the Node references are shared.
+func (n *EnumCase) Children() []Node {
+	if n.EnumValue == nil {
+		return nil
+	}
+
+	return []Node{n.EnumValue}
+}
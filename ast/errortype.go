@@ -0,0 +1,145 @@
+package ast
+
+import (
+	"strings"
+	"unicode"
+)
+
+// An ErrorType is a sealed (or sum) type of a finite set of error cases, each of which may carry properties. It is
+// rendered natively by languages which can express such a type in a single declaration, e.g. as a sealed class in
+// Kotlin, an enum with associated values in Swift or Rust or as a discriminated union in TypeScript. All names are
+// kept as declared, so that each renderer can apply its own naming conventions. The message of a case consists
+// of the declared case name and the declared name and value of each property.
+type ErrorType struct {
+	TypeName string // TypeName denotes the declared name of the group, e.g. NotFound.
+	Cases    []*ErrorTypeCase
+	Obj
+}
+
+// NewErrorType allocates a new error group.
+func NewErrorType(name string) *ErrorType {
+	return &ErrorType{TypeName: name}
+}
+
+// SetComment sets the nodes comment.
+func (n *ErrorType) SetComment(text string) *ErrorType {
+	n.ObjComment = NewComment(text)
+	n.ObjComment.SetParent(n)
+	return n
+}
+
+// AddCases appends and attaches the given cases.
+func (n *ErrorType) AddCases(cases ...*ErrorTypeCase) *ErrorType {
+	for _, errorCase := range cases {
+		assertNotAttached(errorCase)
+		assertSettableParent(errorCase).SetParent(n)
+		n.Cases = append(n.Cases, errorCase)
+	}
+
+	return n
+}
+
+// Doc returns the comment text or a default documentation, if the group has no comment.
+func (n *ErrorType) Doc() string {
+	if n.ObjComment == nil || strings.TrimSpace(n.ObjComment.Text) == "" {
+		return "...represents the sum type of all " + n.TypeName + " errors."
+	}
+
+	return n.ObjComment.Text
+}
+
+func (n *ErrorType) Identifier() string {
+	return n.TypeName
+}
+
+func (n *ErrorType) sealedNamedType() {
+	panic("implement me")
+}
+
+// Children returns a defensive copy of the underlying slice. However the Node references are shared.
+func (n *ErrorType) Children() []Node {
+	tmp := make([]Node, 0, len(n.Cases))
+	for _, errorCase := range n.Cases {
+		tmp = append(tmp, errorCase)
+	}
+
+	return tmp
+}
+
+// An ErrorTypeCase declares a unique case of the error group. Its properties are fields, whose comment is optional.
+type ErrorTypeCase struct {
+	TypeName   string
+	Properties []*Field
+	Obj
+}
+
+// NewErrorTypeCase allocates a new case without properties.
+func NewErrorTypeCase(name string) *ErrorTypeCase {
+	return &ErrorTypeCase{TypeName: name}
+}
+
+// SetComment sets the nodes comment.
+func (n *ErrorTypeCase) SetComment(text string) *ErrorTypeCase {
+	n.ObjComment = NewComment(text)
+	n.ObjComment.SetParent(n)
+	return n
+}
+
+// AddProperties appends and attaches the given properties.
+func (n *ErrorTypeCase) AddProperties(properties ...*Field) *ErrorTypeCase {
+	for _, property := range properties {
+		assertNotAttached(property)
+		assertSettableParent(property).SetParent(n)
+		n.Properties = append(n.Properties, property)
+	}
+
+	return n
+}
+
+// Doc returns the comment text of the case, followed by a sentence which relates the case to its group, e.g.
+// Ticket is also a NotFoundError. The names are the rendered identifiers of the case and of the group.
+func (n *ErrorTypeCase) Doc(name, groupName string) string {
+	text := ""
+	if n.ObjComment != nil {
+		text = n.ObjComment.Text
+	}
+
+	article := "a "
+	if groupName != "" && strings.ContainsRune("aeiou", unicode.ToLower(rune(groupName[0]))) {
+		article = "an "
+	}
+
+	return strings.TrimSpace(text + "\n" + name + " is also " + article + groupName + ".")
+}
+
+// Message returns the message of the case, which consists of its name and each property name, followed by the
+// value expression of the property, e.g. Ticket: id=${id}.
+func (n *ErrorTypeCase) Message(value func(property *Field) string) string {
+	sb := &strings.Builder{}
+	sb.WriteString(n.TypeName)
+	for i, property := range n.Properties {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(property.Identifier() + "=" + value(property))
+	}
+
+	return sb.String()
+}
+
+func (n *ErrorTypeCase) Name() string {
+	return n.TypeName
+}
+
+// Children returns a defensive copy of the underlying slice. However the Node references are shared.
+func (n *ErrorTypeCase) Children() []Node {
+	tmp := make([]Node, 0, len(n.Properties))
+	for _, property := range n.Properties {
+		tmp = append(tmp, property)
+	}
+
+	return tmp
+}
@@ -7,6 +7,7 @@ type Field struct {
 	FieldType        TypeDecl
 	FieldAnnotations []*Annotation
 	FieldDefault     *BasicLit
+	FieldDeprecated  *Deprecation
 	Obj
 }
 
@@ -45,6 +46,17 @@ func (f *Field) SetComment(text string) *Field {
 	return f
 }
 
+// SetDeprecated marks the field as deprecated. The replacement may be empty.
+func (f *Field) SetDeprecated(reason string, replacement Name) *Field {
+	f.FieldDeprecated = NewDeprecation(reason, replacement)
+	return f
+}
+
+// Deprecated returns the deprecation or nil.
+func (f *Field) Deprecated() *Deprecation {
+	return f.FieldDeprecated
+}
+
 // SetVisibility updates the fields Visibility. The Go renderer will override the rendered name to match the visibility.
 func (f *Field) SetVisibility(v Visibility) *Field {
 	f.FieldVisibility = v
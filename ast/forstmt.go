@@ -47,11 +47,11 @@ func (n *ForStmt) Children() []Node {
 	}
 
 	if n.Cond != nil {
-		tmp = append(tmp, n.Init)
+		tmp = append(tmp, n.Cond)
 	}
 
 	if n.Post != nil {
-		tmp = append(tmp, n.Init)
+		tmp = append(tmp, n.Post)
 	}
 
 	if n.Body != nil {
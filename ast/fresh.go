@@ -0,0 +1,147 @@
+package ast
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// freshIdentsKey is the Value key of the freshIdents of a scope.
+type freshIdentsKey struct{}
+
+// freshIdents contains the identifiers which have been generated by FreshIdent within a scope.
+type freshIdents struct {
+	owned map[freshIdentOwner]string
+	taken map[string]bool
+}
+
+type freshIdentOwner struct {
+	owner Node
+	hint  string
+}
+
+// FreshIdent returns an identifier based on hint, which is not yet used within the func which encloses scope,
+// e.g. err, err2 or err3. If there is no enclosing func, the enclosing file is inspected instead. Usually the
+// scope is the asking Macro itself and its own parameters are not considered as usages.
+//
+// The identifiers of all other nodes, including the node parameters of other macros and the text of templates,
+// are considered as used. A string parameter of a macro is only considered, if it is an identifier itself, e.g.
+// the name of a declared variable, because free text like an error message contains no names. Identifiers which
+// have been returned to other scopes are never returned again, so that two macros within the same block cannot
+// clash. Repeated calls with the same scope and hint return the same identifier, which keeps re-evaluated macros
+// stable and allows macros to share a variable deliberately, e.g. by passing the enclosing func as scope.
+func FreshIdent(scope Node, hint string) string {
+	if hint == "" {
+		hint = "v"
+	}
+
+	root := freshIdentRoot(scope)
+	idents, _ := root.Value(freshIdentsKey{}).(*freshIdents)
+	if idents == nil {
+		idents = &freshIdents{owned: map[freshIdentOwner]string{}, taken: map[string]bool{}}
+		root.PutValue(freshIdentsKey{}, idents)
+	}
+
+	key := freshIdentOwner{owner: scope, hint: hint}
+	if name, ok := idents.owned[key]; ok {
+		return name
+	}
+
+	used := map[string]bool{}
+	collectUsedNames(used, root, scope)
+
+	name := hint
+	for i := 2; used[name] || idents.taken[name]; i++ {
+		name = hint + strconv.Itoa(i)
+	}
+
+	idents.owned[key] = name
+	idents.taken[name] = true
+
+	return name
+}
+
+// freshIdentRoot returns the enclosing Func, File or the root.
+func freshIdentRoot(scope Node) Node {
+	for n := scope; n != nil; n = n.Parent() {
+		switch n.(type) {
+		case *Func, *File:
+			return n
+		}
+
+		if n.Parent() == nil {
+			return n
+		}
+	}
+
+	return scope
+}
+
+var (
+	identifierToken = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+	identifier      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// collectUsedNames inspects the node recursively, without evaluating macros, because a macro may be just
+// asking for a fresh identifier. Instead, the macro parameters are inspected, except those of the skipped one.
+func collectUsedNames(dst map[string]bool, node Node, skip Node) {
+	switch t := node.(type) {
+	case *Func:
+		dst[t.FunReceiverName] = true
+	case *Ident:
+		dst[t.Name] = true
+	case *QualIdent:
+		collectUsedTokens(dst, t.Qualifier)
+	case *Tpl:
+		collectUsedTokens(dst, t.Template)
+		for _, v := range t.Values {
+			collectUsedValue(dst, v, skip)
+		}
+	case *Macro:
+		if t != skip {
+			for _, v := range t.Params {
+				collectUsedValue(dst, v, skip)
+			}
+		}
+
+		return
+	}
+
+	if named, ok := node.(interface{ Identifier() string }); ok {
+		dst[named.Identifier()] = true
+	}
+
+	if p, ok := node.(Parent); ok {
+		for _, child := range p.Children() {
+			collectUsedNames(dst, child, skip)
+		}
+	}
+}
+
+func collectUsedValue(dst map[string]bool, v interface{}, skip Node) {
+	switch t := v.(type) {
+	case string:
+		collectUsedIdentifier(dst, t)
+	case []string:
+		for _, s := range t {
+			collectUsedIdentifier(dst, s)
+		}
+	case Node:
+		collectUsedNames(dst, t, skip)
+	case []Node:
+		for _, n := range t {
+			collectUsedNames(dst, n, skip)
+		}
+	}
+}
+
+func collectUsedIdentifier(dst map[string]bool, text string) {
+	if identifier.MatchString(text) {
+		dst[text] = true
+	}
+}
+
+func collectUsedTokens(dst map[string]bool, text string) {
+	for _, token := range identifierToken.FindAllString(text, -1) {
+		dst[token] = true
+	}
+}
@@ -19,6 +19,7 @@ type Func struct {
 	FunVariadic     bool
 	FunAnnotations  []*Annotation
 	ErrorHintRefs   []ErrorRef //optional reference (not owned) to documented error cases.
+	FunDeprecated   *Deprecation
 	Obj
 }
 
@@ -34,6 +35,17 @@ func (s *Func) SetComment(text string) *Func {
 	return s
 }
 
+// SetDeprecated marks the function as deprecated. The replacement may be empty.
+func (s *Func) SetDeprecated(reason string, replacement Name) *Func {
+	s.FunDeprecated = NewDeprecation(reason, replacement)
+	return s
+}
+
+// Deprecated returns the deprecation or nil.
+func (s *Func) Deprecated() *Deprecation {
+	return s.FunDeprecated
+}
+
 // AddErrorCaseRefs refers to ErrorCases without taking ownership.
 func (s *Func) AddErrorCaseRefs(cases ...ErrorRef) *Func {
 	s.ErrorHintRefs = append(s.ErrorHintRefs, cases...)
@@ -73,7 +85,8 @@ func (s *Func) SetRecName(recName string) *Func {
 	return s
 }
 
-// PtrReceiver is currently only applicable for Go. In java this may be introduced with Valhalla.
+// PtrReceiver is applicable for Go and Swift, where a method of a struct with a pointer receiver is mutating. In
+// java this may be introduced with Valhalla.
 func (s *Func) PtrReceiver() bool {
 	return s.FunPtrReceiver
 }
@@ -156,6 +169,7 @@ func (s *Func) AddAnnotations(a ...*Annotation) *Func {
 	for _, annotation := range a {
 		assertNotAttached(annotation)
 		assertSettableParent(annotation).SetParent(s)
+		s.FunAnnotations = append(s.FunAnnotations, annotation)
 	}
 
 	return s
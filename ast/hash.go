@@ -0,0 +1,141 @@
+package ast
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+)
+
+var (
+	macroType = reflect.TypeOf(Macro{})
+	objType   = reflect.TypeOf(Obj{})
+)
+
+// Hash returns a stable content hash of the given node and all of its descendants. Positions, parents and the
+// transient Values of each Obj are ignored, so that equal models have equal hashes, independent of where they
+// have been parsed from. Macros contribute their resolved Children instead of their function, so the hash
+// reflects the actual output of a macro in its current context. Evaluating a cached macro has the same
+// side effects as rendering it.
+func Hash(node Node) [sha256.Size]byte {
+	h := &hasher{h: sha256.New(), visited: map[uintptr]int{}}
+	h.value(reflect.ValueOf(node))
+
+	var res [sha256.Size]byte
+	copy(res[:], h.h.Sum(nil))
+
+	return res
+}
+
+type hasher struct {
+	h       hash.Hash
+	visited map[uintptr]int // pointer => visit number, to emit back references for shared or cyclic nodes
+}
+
+func (h *hasher) str(s string) {
+	h.int(int64(len(s)))
+	h.h.Write([]byte(s))
+}
+
+func (h *hasher) int(i int64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(i))
+	h.h.Write(buf[:])
+}
+
+func (h *hasher) value(v reflect.Value) {
+	if !v.IsValid() {
+		h.str("nil")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.int(1)
+		} else {
+			h.int(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.int(int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		h.int(int64(math.Float64bits(v.Float())))
+	case reflect.String:
+		h.str(v.String())
+	case reflect.Interface:
+		if v.IsNil() {
+			h.str("nil")
+			return
+		}
+
+		h.str(v.Elem().Type().String())
+		h.value(v.Elem())
+	case reflect.Ptr:
+		if v.IsNil() {
+			h.str("nil")
+			return
+		}
+
+		if n, ok := h.visited[v.Pointer()]; ok {
+			h.str("ref")
+			h.int(int64(n))
+			return
+		}
+
+		h.visited[v.Pointer()] = len(h.visited)
+		h.value(v.Elem())
+	case reflect.Slice, reflect.Array:
+		h.int(int64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			h.value(v.Index(i))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		h.int(int64(len(keys)))
+		for _, key := range keys {
+			h.value(key)
+			h.value(v.MapIndex(key))
+		}
+	case reflect.Struct:
+		h.structValue(v)
+	default:
+		// functions and channels have no content which can be hashed
+		h.str(v.Type().String())
+	}
+}
+
+func (h *hasher) structValue(v reflect.Value) {
+	t := v.Type()
+	if t == objType {
+		// only the comment is a part of the content, everything else is either positional or transient
+		h.value(v.FieldByName("ObjComment"))
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported fields are caches or other implementation details
+			continue
+		}
+
+		if t == macroType && f.Name == "Func" && v.CanAddr() {
+			// the function is replaced by its actual and context dependent result
+			macro := v.Addr().Interface().(*Macro)
+			h.value(reflect.ValueOf(macro.Children()))
+			continue
+		}
+
+		h.str(f.Name)
+		h.value(v.Field(i))
+	}
+}
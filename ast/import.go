@@ -3,8 +3,9 @@ package ast
 // Import declares an explicit import statement. Note that the imports are usually generated automatically, but
 // one can do it by hand also.
 type Import struct {
-	Ident string
-	Name  Name
+	Ident  string
+	Name   Name
+	Static bool // Static imports a member instead of a type, e.g. java.util.concurrent.TimeUnit.SECONDS. Only for Java.
 	Obj
 }
 
@@ -18,3 +19,9 @@ func (s *Import) SetComment(text string) *Import {
 	s.ObjComment.SetParent(s)
 	return s
 }
+
+// SetStatic updates the static flag. Only for Java.
+func (s *Import) SetStatic(static bool) *Import {
+	s.Static = static
+	return s
+}
@@ -9,9 +9,12 @@ type Interface struct {
 	TypeName        string
 	TypeVisibility  Visibility
 	TypeMethods     []*Func
+	TypeProperties  []*Property // TypeProperties are only rendered by languages with first class properties, like Kotlin.
 	TypeAnnotations []*Annotation
 	Types           []NamedType // only valid for language which can declare named nested type like java
 	Embedded        []TypeDecl  // Embedded is only valid for languages which supports composition at a language level
+	Permits         []Name      // Permits declares the exclusive set of implementations, if the target supports sealed types (Java 17+).
+	TypeDeprecated  *Deprecation
 	Obj
 }
 
@@ -29,6 +32,17 @@ func (s *Interface) SetComment(text string) *Interface {
 	return s
 }
 
+// SetDeprecated marks the interface as deprecated. The replacement may be empty.
+func (s *Interface) SetDeprecated(reason string, replacement Name) *Interface {
+	s.TypeDeprecated = NewDeprecation(reason, replacement)
+	return s
+}
+
+// Deprecated returns the deprecation or nil.
+func (s *Interface) Deprecated() *Deprecation {
+	return s.TypeDeprecated
+}
+
 // Identifier returns the declared identifier which must be unique per package.
 func (s *Interface) Identifier() string {
 	return s.TypeName
@@ -55,6 +69,13 @@ func (s *Interface) AddEmbedded(t ...TypeDecl) *Interface {
 	return s
 }
 
+// AddPermits appends the given names to the set of permitted implementations. A renderer which does not support
+// sealed types emits an ordinary interface.
+func (s *Interface) AddPermits(names ...Name) *Interface {
+	s.Permits = append(s.Permits, names...)
+	return s
+}
+
 // SetVisibility sets the visibility. The default is Public.
 func (s *Interface) SetVisibility(v Visibility) *Interface {
 	s.TypeVisibility = v
@@ -82,6 +103,22 @@ func (s *Interface) AddMethods(f ...*Func) *Interface {
 	return s
 }
 
+// AddProperties appends more properties to this interfaces contract.
+func (s *Interface) AddProperties(properties ...*Property) *Interface {
+	for _, property := range properties {
+		assertNotAttached(property)
+		assertSettableParent(property).SetParent(s)
+		s.TypeProperties = append(s.TypeProperties, property)
+	}
+
+	return s
+}
+
+// Properties returns all available properties.
+func (s *Interface) Properties() []*Property {
+	return s.TypeProperties
+}
+
 // Annotations returns the backing slice of all annotations.
 func (s *Interface) Annotations() []*Annotation {
 	return s.TypeAnnotations
@@ -118,7 +155,7 @@ func (s *Interface) NamedTypes() []NamedType {
 
 // Children returns a defensive copy of the underlying slice. However the Node references are shared.
 func (s *Interface) Children() []Node {
-	tmp := make([]Node, 0, +len(s.TypeAnnotations)+len(s.TypeMethods)+len(s.Types)+len(s.Embedded))
+	tmp := make([]Node, 0, +len(s.TypeAnnotations)+len(s.TypeMethods)+len(s.TypeProperties)+len(s.Types)+len(s.Embedded))
 	for _, param := range s.TypeAnnotations {
 		tmp = append(tmp, param)
 	}
@@ -127,6 +164,10 @@ func (s *Interface) Children() []Node {
 		tmp = append(tmp, param)
 	}
 
+	for _, param := range s.TypeProperties {
+		tmp = append(tmp, param)
+	}
+
 	for _, namedType := range s.Types {
 		tmp = append(tmp, namedType)
 	}
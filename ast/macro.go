@@ -5,6 +5,10 @@ package ast
 type Macro struct {
 	ID   string                // an arbitrary ID
 	Func func(m *Macro) []Node // Func should always return a new defensive copy with shared Node instances.
+	// Kind refers to the MacroFactory which has been registered with RegisterMacro and which is able to recreate
+	// this macro from its Params. Macros without a Kind cannot be serialized.
+	Kind   string
+	Params MacroParams // Params contains the arguments which have been passed to the MacroFactory.
 	// if true, the result of Func is ever evaluated once. This improves performance but also makes stateful macros
 	// easier to implement when called multiple times. However, when rendering for multiple platforms, this may
 	// cause wrong results. See also Invalidate.
@@ -27,6 +31,14 @@ func (n *Macro) SetID(id string) *Macro {
 	return n
 }
 
+// SetKind declares the registered MacroFactory and the parameters to recreate this macro. See also RegisterMacro.
+func (n *Macro) SetKind(kind string, params MacroParams) *Macro {
+	n.Kind = kind
+	n.Params = params
+
+	return n
+}
+
 // Invalidate purges any node cache.
 func (n *Macro) Invalidate() *Macro {
 	n.funcCache = nil
@@ -88,12 +100,13 @@ func MatchTargetLanguage(lang Lang, nodes ...Node) func(m *Macro) (bool, []Node)
 	})
 }
 
+// MatchTargetLanguageWithContext is like MatchTargetLanguage but the nodes are created by f, which is only invoked
+// if the target language matches. So f may inspect the context and may create language specific nodes lazily.
 func MatchTargetLanguageWithContext(lang Lang, f func(m *Macro) []Node) func(m *Macro) (bool, []Node) {
 	return func(m *Macro) (bool, []Node) {
-		nodes := f(m)
-
 		target := m.Target()
 		if target.Lang == lang {
+			nodes := f(m)
 			for _, node := range nodes {
 				if node.Parent() != nil && node.Parent() != m {
 					assertNotAttached(node)
@@ -0,0 +1,146 @@
+package ast
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MacroParams contains the arguments of a registered macro. Valid values are string, bool, int, Node, []Node,
+// []string, MacroParams and []MacroParams. Other values cannot be serialized.
+type MacroParams map[string]interface{}
+
+// String returns the string value for the key or the empty string.
+func (p MacroParams) String(key string) string {
+	s, _ := p[key].(string)
+	return s
+}
+
+// Bool returns the bool value for the key or false.
+func (p MacroParams) Bool(key string) bool {
+	b, _ := p[key].(bool)
+	return b
+}
+
+// Int returns the int value for the key or 0.
+func (p MacroParams) Int(key string) int {
+	i, _ := p[key].(int)
+	return i
+}
+
+// Strings returns the string slice for the key or nil.
+func (p MacroParams) Strings(key string) []string {
+	s, _ := p[key].([]string)
+	return s
+}
+
+// Node returns the node for the key or nil.
+func (p MacroParams) Node(key string) Node {
+	n, _ := p[key].(Node)
+	return n
+}
+
+// Expr returns the node for the key as an expression or nil.
+func (p MacroParams) Expr(key string) Expr {
+	n, _ := p[key].(Expr)
+	return n
+}
+
+// Nodes returns the node slice for the key or nil.
+func (p MacroParams) Nodes(key string) []Node {
+	n, _ := p[key].([]Node)
+	return n
+}
+
+// Exprs returns the node slice for the key as expressions. Nodes which are not expressions are omitted.
+func (p MacroParams) Exprs(key string) []Expr {
+	var res []Expr
+	for _, node := range p.Nodes(key) {
+		if e, ok := node.(Expr); ok {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
+
+// Params returns the nested parameters for the key or nil.
+func (p MacroParams) Params(key string) MacroParams {
+	m, _ := p[key].(MacroParams)
+	return m
+}
+
+// ParamsList returns the nested parameter list for the key or nil.
+func (p MacroParams) ParamsList(key string) []MacroParams {
+	m, _ := p[key].([]MacroParams)
+	return m
+}
+
+// ExprNodes is a helper to convert expressions into a node slice, e.g. to be used as a MacroParams value.
+func ExprNodes(expr ...Expr) []Node {
+	tmp := make([]Node, 0, len(expr))
+	for _, e := range expr {
+		tmp = append(tmp, e)
+	}
+
+	return tmp
+}
+
+// A MacroFactory creates a new Macro from the given parameters.
+type MacroFactory func(params MacroParams) (*Macro, error)
+
+var (
+	macroFactoriesMutex sync.RWMutex
+	macroFactories      = map[string]MacroFactory{}
+)
+
+// RegisterMacro makes a MacroFactory available by the given kind. This is usually called from an init function
+// of a macro providing package, so that a serialized Macro can be recreated. If RegisterMacro is called twice with
+// the same kind, it panics.
+func RegisterMacro(kind string, f MacroFactory) {
+	macroFactoriesMutex.Lock()
+	defer macroFactoriesMutex.Unlock()
+
+	if f == nil {
+		panic("ast: macro factory is nil")
+	}
+
+	if _, ok := macroFactories[kind]; ok {
+		panic("ast: RegisterMacro called twice for " + kind)
+	}
+
+	macroFactories[kind] = f
+}
+
+// MakeMacro creates a new Macro using the registered MacroFactory of the given kind.
+func MakeMacro(kind string, params MacroParams) (*Macro, error) {
+	macroFactoriesMutex.RLock()
+	f, ok := macroFactories[kind]
+	macroFactoriesMutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown macro kind '%s' (forgotten import?)", kind)
+	}
+
+	m, err := f(params)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create macro '%s': %w", kind, err)
+	}
+
+	return m, nil
+}
+
+// MacroKinds returns the sorted kinds of all registered macros.
+func MacroKinds() []string {
+	macroFactoriesMutex.RLock()
+	defer macroFactoriesMutex.RUnlock()
+
+	var res []string
+	for kind := range macroFactories {
+		res = append(res, kind)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -23,13 +23,14 @@ const (
 type Lang string
 
 const (
-	LangJava   Lang = "java"
-	LangGo     Lang = "go"
-	LangRust   Lang = "rust"
-	LangSwift  Lang = "swift"
-	LangKotlin Lang = "kotlin"
-	LangC      Lang = "c"
-	LangCPP    Lang = "c++"
+	LangJava       Lang = "java"
+	LangGo         Lang = "go"
+	LangRust       Lang = "rust"
+	LangSwift      Lang = "swift"
+	LangKotlin     Lang = "kotlin"
+	LangTypeScript Lang = "typescript"
+	LangC          Lang = "c"
+	LangCPP        Lang = "c++"
 )
 
 // LangVersion specifies an arbitrary version string for a specific language. There is no guarantee of a semantic
@@ -60,6 +61,8 @@ type Target struct {
 	Framework      Framework   // the framework to use. Empty means only use the default standard library things.
 	Require        struct { // require directive
 		GoMod []string // go mod specific directive strings (e.g. github.com/golangee/sql v0.0.0-20210531101020-33021aed64c2)
+		Maven []string // maven coordinates of java or kotlin dependencies (e.g. com.google.guava:guava:30.1-jre)
+		Cargo []string // cargo dependency lines of rust crates (e.g. serde_json = "1")
 	}
 }
 
@@ -68,8 +71,14 @@ func (t Target) Equals(o Target) bool {
 }
 
 // A Mod is the root of a project and describes a module with packages.
-//  * Java: denotes a gradle module (build.gradle).
+//  * Java: denotes a gradle module (build.gradle) or a maven module (pom.xml). The name is either a maven
+//    groupId:artifactId[:version] or a Java package like name (com.example.app) or a Go like module path
+//    (example.com/app), from which the coordinates are derived.
 //  * Go: describes a Go module (go.mod).
+//  * Kotlin: denotes a gradle module (build.gradle.kts), whose name is interpreted like for Java.
+//  * Swift: denotes a Swift package (Package.swift), whose packages are targets.
+//  * Rust: denotes a Cargo crate (Cargo.toml), whose packages become a module hierarchy below the module name.
+//  * TypeScript: denotes a source tree, whose packages become directories with an index.ts below the module name.
 type Mod struct {
 	Name   string // Name refers to a unique module name. In go this is the module name.
 	Target Target
@@ -94,19 +103,33 @@ func (n *Mod) SetOutputDirectory(dir string) *Mod {
 	return n
 }
 
+// SetOS updates the Target.Os, e.g. OSIOS declares the platform of a Swift package.
+func (n *Mod) SetOS(os OS) *Mod {
+	n.Target.Os = os
+	return n
+}
+
 // SetLangVersion updates the Target.MinLangVersion.
 func (n *Mod) SetLangVersion(version LangVersion) *Mod {
 	n.Target.MinLangVersion = version
 	return n
 }
 
-// Require expects a language to decide how to handle the dependency.
+// Require expects a language to decide how to handle the dependency. Go expects a go mod require directive,
+// Java and Kotlin expect maven coordinates (groupId:artifactId:version) and Rust expects a line of the Cargo.toml
+// dependencies table (name = "version").
 func (n *Mod) Require(dep string) *Mod {
-	if n.Target.Lang != LangGo {
-		panic("invalid state: Require currently only supports Go")
+	switch n.Target.Lang {
+	case LangGo:
+		n.Target.Require.GoMod = append(n.Target.Require.GoMod, dep)
+	case LangJava, LangKotlin:
+		n.Target.Require.Maven = append(n.Target.Require.Maven, dep)
+	case LangRust:
+		n.Target.Require.Cargo = append(n.Target.Require.Cargo, dep)
+	default:
+		panic("invalid state: Require currently only supports Go, Java, Kotlin and Rust")
 	}
 
-	n.Target.Require.GoMod = append(n.Target.Require.GoMod, dep)
 	return n
 }
 
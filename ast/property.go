@@ -1,11 +1,11 @@
 package ast
 
 // A Property represents a (usually named) attribute or member of a struct or class. The field has always
-// private semantics.
+// private semantics and is only accessible by the enabled reader and writer.
 //
-// Go
-//  renders as a private field with public getter.
-// TODO is this actually required?
+// Kotlin
+//  renders as a val, if only the reader is enabled, otherwise as a var. A writer with a different visibility
+//  restricts the setter, e.g. var name: String = "" private set.
 type Property struct {
 	FieldName string
 	FieldType TypeDecl
@@ -37,6 +37,16 @@ func (p *Property) SetComment(text string) *Property {
 	return p
 }
 
+// Identifier returns the name of the property.
+func (p *Property) Identifier() string {
+	return p.FieldName
+}
+
+// TypeDecl returns the type of the property.
+func (p *Property) TypeDecl() TypeDecl {
+	return p.FieldType
+}
+
 func (p *Property) Reader(enabled bool, visibility Visibility) *Property {
 	p.Read.Enabled = enabled
 	p.Read.Visibility = visibility
@@ -11,6 +11,7 @@ type RawFile struct {
 	Name     string
 	MimeType string
 	Data     func(file *RawFile) ([]byte, error)
+	Tpl      *Tpl // Tpl is only set, if the file has been created by NewRawTpl.
 	Obj
 }
 
@@ -31,6 +32,7 @@ func NewRawTpl(name, mimeType string, n *Tpl) *RawFile {
 	return &RawFile{
 		Name:     name,
 		MimeType: mimeType,
+		Tpl:      n,
 		Data: func(f *RawFile) ([]byte, error) {
 			return f.renderText(n)
 		},
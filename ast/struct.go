@@ -7,25 +7,30 @@ var _ NamedType = (*Struct)(nil)
 // performance by avoiding heap allocation (and potentially GC overhead). Inheritance is not possible, but other
 // types may be embedded (e.g. in Go). Languages like Java use just simple classes (PoJos), because records have no
 // exclusive use (they are just syntax sugar for a class with final members). In contrast to that, Go cannot express
-// final fields.
+// final fields. However, a struct may opt-in to be rendered as a record, if the target supports it (Java 16+).
 type Struct struct {
 	TypeName        string
 	TypeVisibility  Visibility
 	TypeFields      []*Field
+	TypeProperties  []*Property // TypeProperties are only rendered by languages with first class properties, like Kotlin.
 	TypeStatic      bool
+	TypeFinal       bool // TypeFinal prevents subclassing. Only for Java.
+	TypeRecord      bool // TypeRecord declares an immutable record, if supported by the target. Only for Java 16+.
 	TypeAnnotations []*Annotation
 	TypeMethods     []*Func
 	Types           []NamedType // only valid for language which can declare named nested type like java
 	Implements      []Name      // Implements denotes a bunch of interfaces which must be implemented by this struct. Depending on the renderer (like Go) this has no effect.
+	Extends         Name        // Extends denotes an optional base class. Only for Java, other renderers should ignore it.
 	Embedded        []TypeDecl  // Embedded is only valid for languages which supports composition at a language level
 	FactoryRefs     []*Func     // FactoryRefs are NOT considered children of a struct. They are still connected to a file, however they are considered to be a kind of constructor.
 	DefaultRecName  string      // useful to transport a standard receiver name. However, you need to care yourself.
+	TypeDeprecated  *Deprecation
 	Obj
 }
 
 // NewStruct returns a new named struct type. A struct is always mutable, but may be used either in a value
-// or pointer context. Structs are straightforward in Go but in Java just a PoJo. We do not use records, because
-// they have a different semantic (read only).
+// or pointer context. Structs are straightforward in Go but in Java just a PoJo. We do not use records by default,
+// because they have a different semantic (read only), see also SetRecord.
 func NewStruct(name string) *Struct {
 	return &Struct{TypeName: name}
 }
@@ -48,6 +53,17 @@ func (s *Struct) SetComment(text string) *Struct {
 	return s
 }
 
+// SetDeprecated marks the struct as deprecated. The replacement may be empty.
+func (s *Struct) SetDeprecated(reason string, replacement Name) *Struct {
+	s.TypeDeprecated = NewDeprecation(reason, replacement)
+	return s
+}
+
+// Deprecated returns the deprecation or nil.
+func (s *Struct) Deprecated() *Deprecation {
+	return s.TypeDeprecated
+}
+
 func (s *Struct) SetDefaultRecName(n string) *Struct {
 	s.DefaultRecName = n
 	return s
@@ -64,6 +80,35 @@ func (s *Struct) SetStatic(static bool) *Struct {
 	return s
 }
 
+// Final returns true, if this class must not be subclassed. This is only for Java.
+func (s *Struct) Final() bool {
+	return s.TypeFinal
+}
+
+// SetFinal updates the final flag. Only for Java.
+func (s *Struct) SetFinal(final bool) *Struct {
+	s.TypeFinal = final
+	return s
+}
+
+// Record returns true, if this struct should be rendered as an immutable record.
+func (s *Struct) Record() bool {
+	return s.TypeRecord
+}
+
+// SetRecord updates the record flag. The fields become the record components and the renderer falls back
+// to a class, if the target does not support records (e.g. Java before 16). Other renderers ignore it.
+func (s *Struct) SetRecord(record bool) *Struct {
+	s.TypeRecord = record
+	return s
+}
+
+// SetExtends updates the base class. Only for Java.
+func (s *Struct) SetExtends(name Name) *Struct {
+	s.Extends = name
+	return s
+}
+
 // Identifier returns the declared identifier which must be unique per package.
 func (s *Struct) Identifier() string {
 	return s.TypeName
@@ -94,6 +139,22 @@ func (s *Struct) AddFields(fields ...*Field) *Struct {
 	return s
 }
 
+// AddProperties appends the given properties to the struct.
+func (s *Struct) AddProperties(properties ...*Property) *Struct {
+	for _, property := range properties {
+		assertNotAttached(property)
+		assertSettableParent(property).SetParent(s)
+		s.TypeProperties = append(s.TypeProperties, property)
+	}
+
+	return s
+}
+
+// Properties returns the currently configured properties.
+func (s *Struct) Properties() []*Property {
+	return s.TypeProperties
+}
+
 // AddFactoryRefs just appends the given funcs for the purpose of factories or constructors. Most importantly
 // Struct does not take the ownership and the parent is still unset (usually a file or another type).
 func (s *Struct) AddFactoryRefs(f ...*Func) *Struct {
@@ -118,6 +179,7 @@ func (s *Struct) AddAnnotations(a ...*Annotation) *Struct {
 	for _, annotation := range a {
 		assertNotAttached(annotation)
 		assertSettableParent(annotation).SetParent(s)
+		s.TypeAnnotations = append(s.TypeAnnotations, annotation)
 	}
 
 	return s
@@ -156,7 +218,7 @@ func (s *Struct) AddNamedTypes(t ...NamedType) *Struct {
 // Children returns a defensive copy of the underlying slice. However the Node references are shared.
 // FactoryRefs are not considered children, to avoid recursive loops in the AST.
 func (s *Struct) Children() []Node {
-	tmp := make([]Node, 0, len(s.TypeFields)+len(s.TypeAnnotations)+len(s.TypeMethods)+len(s.Types)+len(s.Embedded))
+	tmp := make([]Node, 0, len(s.TypeFields)+len(s.TypeProperties)+len(s.TypeAnnotations)+len(s.TypeMethods)+len(s.Types)+len(s.Embedded))
 	for _, param := range s.TypeAnnotations {
 		tmp = append(tmp, param)
 	}
@@ -165,6 +227,10 @@ func (s *Struct) Children() []Node {
 		tmp = append(tmp, param)
 	}
 
+	for _, param := range s.TypeProperties {
+		tmp = append(tmp, param)
+	}
+
 	for _, param := range s.TypeMethods {
 		tmp = append(tmp, param)
 	}
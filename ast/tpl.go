@@ -2,7 +2,9 @@ package ast
 
 // Tpl contains uninterpreted code and should be used with enormous care. It is passed on an as-is basis to
 // the renderer and may break severely. It cannot be inspected, transformed or validated in any way. Especially
-// automatic imports (and thus avoiding collisions) will not be done.
+// automatic imports (and thus avoiding collisions) will not be done. The Go renderer reconciles the imports of
+// each file afterwards, so unused imports are removed and unresolved qualifiers of standard library packages
+// are imported, but without any collision handling.
 //
 // Why is this useful? There may be large templated parts like helper code which is complex and inspecting
 // or transpiling the implementation is not relevant or possible.
@@ -555,8 +555,8 @@ func (t *ChanTypeDecl) Clone() TypeDecl {
 
 //======
 
-// A FuncTypeDecl is only valid for Go, because In Java this is not directly expressible, and requires a
-// "functional interface" which would be just a SimpleTypeDecl.
+// A FuncTypeDecl declares a function signature. In Java this is not directly expressible and the renderer maps
+// it to a "functional interface", either from java.util.function or a synthesized one.
 type FuncTypeDecl struct {
 	In  []*Param
 	Out []*Param
@@ -572,7 +572,7 @@ func NewFuncTypeDecl() *FuncTypeDecl {
 func (f *FuncTypeDecl) AddInputParams(p ...*Param) *FuncTypeDecl {
 	for _, param := range p {
 		assertNotAttached(param)
-		assertSettableParent(param).SetParent(param)
+		assertSettableParent(param).SetParent(f)
 		f.In = append(f.In, param)
 	}
 
@@ -588,7 +588,7 @@ func (f *FuncTypeDecl) InputParams() []*Param {
 func (f *FuncTypeDecl) AddOutputParams(p ...*Param) *FuncTypeDecl {
 	for _, param := range p {
 		assertNotAttached(param)
-		assertSettableParent(param).SetParent(param)
+		assertSettableParent(param).SetParent(f)
 		f.Out = append(f.Out, param)
 	}
 
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/dsl"
+	"github.com/golangee/src/encoding"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	// import all macro factories, so that serialized models can be decoded
+	_ "github.com/golangee/src/stdlib/lang"
+	_ "github.com/golangee/src/stdlib/strings"
+)
+
+// load reads the project model from the given file. The format is detected by the file extension.
+func load(fname string) (*ast.Prj, error) {
+	buf, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read model: %w", err)
+	}
+
+	var prj *ast.Prj
+	switch strings.ToLower(filepath.Ext(fname)) {
+	case ".json":
+		prj, err = encoding.UnmarshalJSON(buf)
+	case ".yaml", ".yml":
+		prj, err = encoding.UnmarshalYAML(buf)
+	default:
+		prj, err = dsl.Parse(fname, buf)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot load model '%s': %w", fname, err)
+	}
+
+	return prj, nil
+}
+
+// splitList returns the trimmed and non-empty comma separated values.
+func splitList(s string) []string {
+	var res []string
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,81 @@
+// Command src loads a serialized project model, renders all of its modules and writes or verifies the result.
+//
+// Usage:
+//
+//	src [flags] <model.json|model.yaml|model.src>
+//
+// The model format is detected by the file extension: .json and .yaml (or .yml) are decoded by the encoding
+// package and everything else is parsed as DSL (see package dsl).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// options contains the parsed command line flags.
+type options struct {
+	model  string
+	out    string
+	clean  bool
+	dryRun bool
+	diff   bool
+	verify bool
+	langs  []string
+	magic  string
+}
+
+func run(args []string) error {
+	opts := options{}
+	var langs string
+
+	flags := flag.NewFlagSet("src", flag.ContinueOnError)
+	flags.StringVar(&opts.out, "out", ".", "the base directory, to which the module output directories are relative")
+	flags.BoolVar(&opts.clean, "clean", false, "delete generated files (see -magic) in each module directory before writing")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "only print which files would be created or updated")
+	flags.BoolVar(&opts.diff, "diff", false, "print a unified diff between the existing and the rendered files, instead of writing")
+	flags.BoolVar(&opts.verify, "verify", false, "fail, if any rendered file differs from the existing one, instead of writing")
+	flags.StringVar(&langs, "lang", "", "comma separated list of languages to render, e.g. go,java,kotlin,swift,rust,typescript. Default is all")
+	flags.StringVar(&opts.magic, "magic", "DO NOT EDIT", "the marker which identifies a generated file for -clean")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: src [flags] <model.json|model.yaml|model.src>\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one model file")
+	}
+
+	opts.model = flags.Arg(0)
+	opts.langs = splitList(langs)
+
+	prj, err := load(opts.model)
+	if err != nil {
+		return err
+	}
+
+	files, err := renderProject(prj, opts.langs)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case opts.diff, opts.verify, opts.dryRun:
+		return check(os.Stdout, opts, prj, files)
+	default:
+		return write(opts, prj, files)
+	}
+}
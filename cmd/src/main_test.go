@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"github.com/golangee/src/dsl"
+	"github.com/golangee/src/encoding"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testModel = `// ...is a test.
+project "Test"
+
+module "example.com/test" {
+	lang go
+	version "1.16"
+	out "test"
+
+	package "example.com/test/api" {
+		preamble "Code generated by golangee/src. DO NOT EDIT."
+
+		file "api.go" {
+			// ...is a user.
+			struct User {
+				ID int64!
+			}
+		}
+	}
+}
+`
+
+// writeModel writes the test model into the directory, encoded as required by the file extension.
+func writeModel(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	prj, err := dsl.Parse("test.src", []byte(testModel))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf []byte
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		buf, err = encoding.MarshalJSON(prj)
+	case ".yaml", ".yml":
+		buf, err = encoding.MarshalYAML(prj)
+	default:
+		buf = []byte(testModel)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fname := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(fname, buf, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return fname
+}
+
+func readTestFile(t *testing.T, fname string) string {
+	t.Helper()
+
+	buf, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(buf)
+}
+
+func writeTestFile(t *testing.T, fname, text string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(fname), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(fname, []byte(text), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"model.src", "model.json", "model.yaml", "model.YML", "model"} {
+		prj, err := load(writeModel(t, dir, name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if prj.Name != "Test" || prj.CommentText() != "...is a test." || prj.Mods[0].Target.Out != "test" {
+			t.Fatalf("%s: unexpected project %s", name, prj.Name)
+		}
+	}
+
+	// the extension decides and not the content
+	fname := filepath.Join(dir, "dsl.json")
+	writeTestFile(t, fname, testModel)
+	if _, err := load(fname); err == nil {
+		t.Fatalf("expected a json error")
+	}
+
+	if _, err := load(filepath.Join(dir, "missing.src")); err == nil {
+		t.Fatalf("expected a read error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	model := writeModel(t, dir, "model.src")
+
+	if err := run([]string{"-out", out, "-verify", model}); err == nil {
+		t.Fatalf("expected a verification error before writing")
+	}
+
+	if err := run([]string{"-out", out, model}); err != nil {
+		t.Fatal(err)
+	}
+
+	if src := readTestFile(t, filepath.Join(out, "test", "api", "api.go")); !strings.Contains(src, "type User struct {\n") {
+		t.Fatalf("unexpected file\n%s", src)
+	}
+
+	if err := run([]string{"-out", out, "-verify", model}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{"-out", out, "-lang", "java", model}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{"-out", out}); err == nil {
+		t.Fatalf("expected a usage error")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	out := t.TempDir()
+	prj, err := dsl.Parse("test.src", []byte(testModel))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := renderProject(prj, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := options{out: out, magic: "DO NOT EDIT"}
+	apiFile := filepath.Join(out, "test", "api", "api.go")
+	staleFile := filepath.Join(out, "test", "api", "stale.go")
+	manualFile := filepath.Join(out, "test", "api", "manual.go")
+	writeTestFile(t, apiFile, "package api\n")
+	writeTestFile(t, staleFile, "// DO NOT EDIT.\npackage api\n")
+	writeTestFile(t, manualFile, "package api\n")
+
+	// without clean, the stale file is ignored
+	w := &bytes.Buffer{}
+	opts.dryRun = true
+	if err := check(w, opts, prj, files); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := w.String(), "update test/api/api.go\ncreate test/api/doc.go\ncreate test/go.mod\n"; got != want {
+		t.Fatalf("dry-run = %q, want %q", got, want)
+	}
+
+	w.Reset()
+	opts.clean = true
+	if err := check(w, opts, prj, files); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := w.String(), "update test/api/api.go\ncreate test/api/doc.go\ndelete test/api/stale.go\ncreate test/go.mod\n"; got != want {
+		t.Fatalf("dry-run with clean = %q, want %q", got, want)
+	}
+
+	w.Reset()
+	opts.dryRun = false
+	opts.diff = true
+	if err := check(w, opts, prj, files); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"--- a/test/api/api.go\n+++ b/test/api/api.go\n",
+		"+type User struct {\n",
+		"--- a/test/api/stale.go\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-// DO NOT EDIT.\n-package api\n",
+		"--- /dev/null\n+++ b/test/go.mod\n@@ -0,0 +1,",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Fatalf("expected diff to contain %q but got\n%s", want, w.String())
+		}
+	}
+
+	// nothing has been written so far
+	if readTestFile(t, apiFile) != "package api\n" {
+		t.Fatalf("check must not write files")
+	}
+
+	opts.diff = false
+	opts.verify = true
+	if err := check(ioutil.Discard, opts, prj, files); err == nil || !strings.Contains(err.Error(), "4 files") {
+		t.Fatalf("expected a verification error but got %v", err)
+	}
+
+	if err := write(opts, prj, files); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(staleFile); !os.IsNotExist(err) {
+		t.Fatalf("expected stale file to be deleted: %v", err)
+	}
+
+	if readTestFile(t, manualFile) != "package api\n" {
+		t.Fatalf("expected manual file to be kept")
+	}
+
+	w.Reset()
+	if err := check(w, opts, prj, files); err != nil || w.Len() != 0 {
+		t.Fatalf("expected no changes after writing: %v\n%s", err, w.String())
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/internal/diff"
+	"github.com/golangee/src/render"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// change describes the difference between a rendered file and the file system.
+type change struct {
+	kind string // create, update or delete
+	path string // slash separated and relative to the output directory
+	old  []byte
+	new  []byte
+}
+
+// changes compares the rendered files with the file system. If clean is enabled, generated files which have not
+// been rendered again are reported for deletion.
+func changes(opts options, prj *ast.Prj, files []outFile) ([]change, error) {
+	var res []change
+	rendered := map[string]bool{}
+	for _, file := range files {
+		rendered[file.path] = true
+		old, err := ioutil.ReadFile(filepath.Join(opts.out, filepath.FromSlash(file.path)))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("cannot read existing file: %w", err)
+			}
+
+			res = append(res, change{kind: "create", path: file.path, new: file.buf})
+			continue
+		}
+
+		if !bytes.Equal(old, file.buf) {
+			res = append(res, change{kind: "update", path: file.path, old: old, new: file.buf})
+		}
+	}
+
+	if !opts.clean {
+		return res, nil
+	}
+
+	stale, err := generatedFiles(opts, prj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fname := range stale {
+		if rendered[fname] {
+			continue
+		}
+
+		old, err := ioutil.ReadFile(filepath.Join(opts.out, filepath.FromSlash(fname)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read existing file: %w", err)
+		}
+
+		res = append(res, change{kind: "delete", path: fname, old: old})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].path < res[j].path
+	})
+
+	return res, nil
+}
+
+// generatedFiles returns the slash separated paths of all files within the selected module directories which
+// contain the magic marker.
+func generatedFiles(opts options, prj *ast.Prj) ([]string, error) {
+	var res []string
+	for _, mod := range selectedMods(prj, opts.langs) {
+		dir := filepath.Join(opts.out, mod.Target.Out)
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		files, err := render.FindGenerated(dir, []byte(opts.magic))
+		if err != nil {
+			return nil, fmt.Errorf("cannot find generated files of module '%s': %w", mod.Name, err)
+		}
+
+		for _, fname := range files {
+			rel, err := filepath.Rel(opts.out, fname)
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, filepath.ToSlash(rel))
+		}
+	}
+
+	return res, nil
+}
+
+// check prints the changes as requested by the options. If verify is enabled, any change is an error.
+func check(w io.Writer, opts options, prj *ast.Prj, files []outFile) error {
+	diffs, err := changes(opts, prj, files)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range diffs {
+		if opts.dryRun {
+			fmt.Fprintf(w, "%s %s\n", c.kind, c.path)
+		}
+
+		if opts.diff {
+			oldName, newName := "a/"+c.path, "b/"+c.path
+			switch c.kind {
+			case "create":
+				oldName = "/dev/null"
+			case "delete":
+				newName = "/dev/null"
+			}
+
+			fmt.Fprint(w, diff.Unified(oldName, newName, c.old, c.new))
+		}
+	}
+
+	if opts.verify && len(diffs) > 0 {
+		return fmt.Errorf("%d files are not up to date", len(diffs))
+	}
+
+	return nil
+}
+
+// write applies all changes to the file system. Unchanged files are not touched.
+func write(opts options, prj *ast.Prj, files []outFile) error {
+	diffs, err := changes(opts, prj, files)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range diffs {
+		fname := filepath.Join(opts.out, filepath.FromSlash(c.path))
+		if c.kind == "delete" {
+			if err := os.Remove(fname); err != nil {
+				return fmt.Errorf("unable to delete file: %w", err)
+			}
+
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(fname), 0700); err != nil {
+			return fmt.Errorf("unable to create directory: %w", err)
+		}
+
+		if err := ioutil.WriteFile(fname, c.new, 0600); err != nil {
+			return fmt.Errorf("unable to emit file: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"path"
+	"sort"
+
+	// register all available renderers
+	_ "github.com/golangee/src/golang"
+	_ "github.com/golangee/src/java"
+	_ "github.com/golangee/src/kotlin"
+	_ "github.com/golangee/src/rust"
+	_ "github.com/golangee/src/swift"
+	_ "github.com/golangee/src/typescript"
+)
+
+// outFile is a rendered file with a slash separated path, relative to the output directory.
+type outFile struct {
+	path string
+	buf  []byte
+}
+
+// selectedMods returns all modules of the project whose language is accepted by the filter. An empty filter
+// accepts all languages.
+func selectedMods(prj *ast.Prj, langs []string) []*ast.Mod {
+	var res []*ast.Mod
+	for _, mod := range prj.Mods {
+		if acceptLang(mod.Target.Lang, langs) {
+			res = append(res, mod)
+		}
+	}
+
+	return res
+}
+
+func acceptLang(lang ast.Lang, langs []string) bool {
+	if len(langs) == 0 {
+		return true
+	}
+
+	for _, l := range langs {
+		if ast.Lang(l) == lang {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderProject renders each selected module of the project and returns all files sorted by path.
+func renderProject(prj *ast.Prj, langs []string) ([]outFile, error) {
+	root, err := render.Mods(selectedMods(prj, langs)...)
+	if err != nil {
+		var unsupported *render.UnsupportedError
+		if errors.As(err, &unsupported) {
+			return nil, fmt.Errorf("%w (use -lang to filter)", err)
+		}
+
+		return nil, err
+	}
+
+	files := appendFiles(nil, "", root)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+
+	return files, nil
+}
+
+// appendFiles flattens the artifact tree.
+func appendFiles(dst []outFile, parent string, artifact render.Artifact) []outFile {
+	switch t := artifact.(type) {
+	case *render.File:
+		dst = append(dst, outFile{path: path.Join(parent, t.FileName), buf: t.Buf})
+	case *render.Dir:
+		dir := path.Join(parent, t.DirName)
+		for _, file := range t.Files {
+			dst = appendFiles(dst, dir, file)
+		}
+
+		for _, d := range t.Dirs {
+			dst = appendFiles(dst, dir, d)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,56 @@
+// Package dsl provides a small declarative text format which is parsed into an ast.Prj. It is intended for domain
+// experts who want to edit models without writing Go. Only declarations can be expressed, there are no
+// statements or function bodies. A line comment or a block comment which directly precedes a declaration becomes its
+// documentation. Each node carries the position of its source, so that diagnostics of later stages can refer to it.
+//
+//	// ...is the root of the example.
+//	project "Example"
+//
+//	module "example.com/shop" {
+//	    lang go
+//	    version "1.16"
+//	    out "shop"
+//	    require "github.com/golangee/uuid v1.0.0"
+//
+//	    // ...contains the domain model.
+//	    package "example.com/shop/domain" {
+//	        name domain
+//	        preamble "Code generated by golangee/src. DO NOT EDIT."
+//
+//	        file "model.go" {
+//	            // ...is an article.
+//	            struct Article implements Named {
+//	                // ...is the unique id.
+//	                @json("id")
+//	                ID uuid!
+//	                internal tags map[string!][]string!
+//	                embed sync.Mutex
+//	            }
+//
+//	            interface Named {
+//	                // ...returns the name.
+//	                Name() string!
+//	                Find(id uuid!, opts ...string!) (*Article, error!)
+//	            }
+//
+//	            // ...contains all shop errors.
+//	            error Shop {
+//	                NotFound
+//	                OutOfStock {
+//	                    // ...is the affected article.
+//	                    id uuid!
+//	                }
+//	            }
+//
+//	            enum Color string! {
+//	                Red = "red"
+//	                Green = "green"
+//	            }
+//	        }
+//	    }
+//	}
+//
+// Declarations are public by default and may be prefixed by one of the visibility modifiers internal, protected or
+// private. Types are written Go-like: *T, []T, [4]T, map[K]V, chan T and generic types as T<A, B>. Names
+// may be qualified like sync.Mutex or refer to the stdlib types like string!.
+package dsl
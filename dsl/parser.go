@@ -0,0 +1,765 @@
+package dsl
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"strconv"
+	"strings"
+	"text/scanner"
+)
+
+// An Error describes a syntax or semantic problem at a specific position of the parsed source.
+type Error struct {
+	Pos ast.Pos
+	Msg string
+}
+
+// Error returns the message in the "file:line:col: msg" format.
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// bailout is used to unwind the recursive descent parser on the first error.
+type bailout struct {
+	err *Error
+}
+
+// Parse reads the given source and returns the declared project. The filename is only used for the positions of
+// the returned nodes and errors, which are of type *Error.
+func Parse(filename string, src []byte) (prj *ast.Prj, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bailout)
+			if !ok {
+				panic(r)
+			}
+
+			prj = nil
+			err = b.err
+		}
+	}()
+
+	p := newParser(filename, src)
+
+	return p.parseProject(), nil
+}
+
+// parser is a recursive descent parser based on the go like tokens of the text/scanner package.
+type parser struct {
+	filename string
+	s        scanner.Scanner
+
+	tok     rune    // current token
+	text    string  // text of the current token
+	pos     ast.Pos // start of the current token
+	doc     string  // documentation which directly precedes the current token
+	hasDoc  bool    // true, if doc belongs to the current token
+	prevEnd ast.Pos // end of the previously consumed token
+	end     ast.Pos // end of the current token
+}
+
+func newParser(filename string, src []byte) *parser {
+	p := &parser{filename: filename}
+	p.s.Init(strings.NewReader(string(src)))
+	p.s.Filename = filename
+	p.s.Mode = scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanChars | scanner.ScanStrings |
+		scanner.ScanRawStrings | scanner.ScanComments
+	p.s.IsIdentRune = func(ch rune, i int) bool {
+		if ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch > 127 && ch != scanner.EOF {
+			return true
+		}
+
+		// qualified names like github.com/golangee/uuid.UUID or stdlib types like string!
+		return i > 0 && (ch >= '0' && ch <= '9' || ch == '.' || ch == '/' || ch == '!')
+	}
+	p.s.Error = func(s *scanner.Scanner, msg string) {
+		p.fail(p.toPos(s.Position), msg)
+	}
+
+	p.next()
+
+	return p
+}
+
+func (p *parser) toPos(pos scanner.Position) ast.Pos {
+	return ast.Pos{File: p.filename, Line: pos.Line, Col: pos.Column}
+}
+
+func (p *parser) fail(pos ast.Pos, format string, args ...interface{}) {
+	panic(bailout{err: &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}})
+}
+
+// next advances to the next non-comment token and collects the documentation comments in front of it. Comments
+// on the same line as the previous token or separated by an empty line are discarded.
+func (p *parser) next() {
+	p.prevEnd = p.end
+
+	var docLines []string
+	docEndLine := -1
+	for {
+		tok := p.s.Scan()
+		pos := p.toPos(p.s.Position)
+		if tok == scanner.Comment {
+			if pos.Line == p.prevEnd.Line {
+				continue // trailing comment
+			}
+
+			if docEndLine >= 0 && pos.Line > docEndLine+1 {
+				docLines = nil
+			}
+
+			docLines = append(docLines, commentText(p.s.TokenText()))
+			docEndLine = p.s.Pos().Line
+
+			continue
+		}
+
+		p.tok = tok
+		p.text = p.s.TokenText()
+		p.pos = pos
+		p.end = p.toPos(p.s.Pos())
+		p.hasDoc = docEndLine >= 0 && pos.Line <= docEndLine+1
+		p.doc = strings.Join(docLines, "\n")
+
+		return
+	}
+}
+
+// takeDoc returns the documentation of the current token, if any.
+func (p *parser) takeDoc() (string, bool) {
+	doc, ok := p.doc, p.hasDoc
+	p.hasDoc = false
+
+	return doc, ok
+}
+
+func (p *parser) is(keyword string) bool {
+	return p.tok == scanner.Ident && p.text == keyword
+}
+
+func (p *parser) describe() string {
+	if p.tok == scanner.EOF {
+		return "end of file"
+	}
+
+	return "'" + p.text + "'"
+}
+
+func (p *parser) expect(tok rune) {
+	if p.tok != tok {
+		p.fail(p.pos, "expected '%s' but found %s", string(tok), p.describe())
+	}
+
+	p.next()
+}
+
+func (p *parser) expectKeyword(keyword string) {
+	if !p.is(keyword) {
+		p.fail(p.pos, "expected '%s' but found %s", keyword, p.describe())
+	}
+
+	p.next()
+}
+
+func (p *parser) ident() string {
+	if p.tok != scanner.Ident {
+		p.fail(p.pos, "expected identifier but found %s", p.describe())
+	}
+
+	name := p.text
+	p.next()
+
+	return name
+}
+
+func (p *parser) str() string {
+	if p.tok != scanner.String && p.tok != scanner.RawString {
+		p.fail(p.pos, "expected string but found %s", p.describe())
+	}
+
+	s, err := strconv.Unquote(p.text)
+	if err != nil {
+		p.fail(p.pos, "invalid string: %v", err)
+	}
+
+	p.next()
+
+	return s
+}
+
+// strOrIdent accepts strings and identifiers, which is convenient for things like versions or package names.
+func (p *parser) strOrIdent() string {
+	switch p.tok {
+	case scanner.Ident, scanner.Float, scanner.Int:
+		v := p.text
+		p.next()
+		return v
+	default:
+		return p.str()
+	}
+}
+
+// setPos applies the positional information from start to the end of the last consumed token.
+func (p *parser) setPos(obj *ast.Obj, start ast.Pos) {
+	obj.ObjPos = start
+	obj.ObjEnd = p.prevEnd
+}
+
+// project := 'project' STRING module*
+func (p *parser) parseProject() *ast.Prj {
+	start := p.pos
+	doc, hasDoc := p.takeDoc()
+	p.expectKeyword("project")
+	prj := ast.NewPrj(p.str())
+	if hasDoc {
+		setComment(&prj.Obj, prj, doc)
+	}
+
+	for p.tok != scanner.EOF {
+		prj.AddModules(p.parseModule())
+	}
+
+	p.setPos(&prj.Obj, start)
+
+	return prj
+}
+
+// module := 'module' STRING '{' ( 'lang' IDENT | 'version' STRING | 'out' STRING | 'framework' STRING |
+//
+//	'require' STRING | package )* '}'
+func (p *parser) parseModule() *ast.Mod {
+	start := p.pos
+	doc, hasDoc := p.takeDoc()
+	p.expectKeyword("module")
+	mod := ast.NewMod(p.str())
+	if hasDoc {
+		setComment(&mod.Obj, mod, doc)
+	}
+
+	p.expect('{')
+	for p.tok != '}' {
+		switch {
+		case p.is("lang"):
+			p.next()
+			mod.SetLang(ast.Lang(p.strOrIdent()))
+		case p.is("version"):
+			p.next()
+			mod.SetLangVersion(ast.LangVersion(p.strOrIdent()))
+		case p.is("out"):
+			p.next()
+			mod.SetOutputDirectory(p.str())
+		case p.is("framework"):
+			p.next()
+			mod.Target.Framework = ast.Framework(p.strOrIdent())
+		case p.is("require"):
+			pos := p.pos
+			p.next()
+			if mod.Target.Lang != ast.LangGo && mod.Target.Lang != ast.LangJava && mod.Target.Lang != ast.LangKotlin && mod.Target.Lang != ast.LangRust {
+				p.fail(pos, "require is only supported for go, java, kotlin and rust modules, declare 'lang go', 'lang java', 'lang kotlin' or 'lang rust' first")
+			}
+
+			mod.Require(p.str())
+		case p.is("package"):
+			mod.AddPackages(p.parsePackage())
+		default:
+			p.fail(p.pos, "expected module declaration but found %s", p.describe())
+		}
+	}
+
+	p.expect('}')
+	p.setPos(&mod.Obj, start)
+
+	return mod
+}
+
+// package := 'package' STRING '{' ( 'name' IDENT | 'preamble' STRING | file )* '}'
+func (p *parser) parsePackage() *ast.Pkg {
+	start := p.pos
+	doc, hasDoc := p.takeDoc()
+	p.expectKeyword("package")
+	pkg := ast.NewPkg(p.str())
+	if hasDoc {
+		pkg.SetComment(doc)
+	}
+
+	p.expect('{')
+	for p.tok != '}' {
+		switch {
+		case p.is("name"):
+			p.next()
+			pkg.SetName(p.strOrIdent())
+		case p.is("preamble"):
+			p.next()
+			pkg.SetPreamble(p.str())
+		case p.is("file"):
+			pkg.AddFiles(p.parseFile())
+		default:
+			p.fail(p.pos, "expected package declaration but found %s", p.describe())
+		}
+	}
+
+	p.expect('}')
+	p.setPos(&pkg.Obj, start)
+
+	return pkg
+}
+
+// file := 'file' STRING '{' ( 'preamble' STRING | struct | interface | error | enum )* '}'
+func (p *parser) parseFile() *ast.File {
+	start := p.pos
+	doc, hasDoc := p.takeDoc()
+	p.expectKeyword("file")
+	file := ast.NewFile(p.str())
+	if hasDoc {
+		file.SetComment(doc)
+	}
+
+	p.expect('{')
+	for p.tok != '}' {
+		if p.is("preamble") {
+			p.next()
+			file.SetPreamble(p.str())
+			continue
+		}
+
+		file.AddNodes(p.parseTypeDecl())
+	}
+
+	p.expect('}')
+	p.setPos(&file.Obj, start)
+
+	return file
+}
+
+// parseTypeDecl parses any kind of named type declaration, including its documentation and annotations.
+func (p *parser) parseTypeDecl() ast.Node {
+	start := p.pos
+	doc, hasDoc := p.takeDoc()
+	annotations := p.parseAnnotations()
+	visibility := p.parseVisibility()
+
+	switch {
+	case p.is("struct"):
+		s := p.parseStruct()
+		s.SetVisibility(visibility).AddAnnotations(annotations...)
+		if hasDoc {
+			s.SetComment(doc)
+		}
+
+		p.setPos(&s.Obj, start)
+		return s
+	case p.is("interface"):
+		iface := p.parseInterface()
+		iface.SetVisibility(visibility).AddAnnotations(annotations...)
+		if hasDoc {
+			iface.SetComment(doc)
+		}
+
+		p.setPos(&iface.Obj, start)
+		return iface
+	case p.is("error") || p.is("enum"):
+		if len(annotations) > 0 {
+			p.fail(annotations[0].Pos(), "annotations are not supported for %s", p.text)
+		}
+
+		if visibility != ast.Public {
+			p.fail(start, "visibility modifiers are not supported for %s", p.text)
+		}
+
+		if p.is("error") {
+			m := p.parseError(doc)
+			p.setPos(&m.Obj, start)
+			return m
+		}
+
+		enum := p.parseEnum()
+		if hasDoc {
+			enum.SetComment(doc)
+		}
+
+		p.setPos(&enum.Obj, start)
+		return enum
+	default:
+		p.fail(p.pos, "expected struct, interface, error or enum but found %s", p.describe())
+		return nil
+	}
+}
+
+// annotation := '@' IDENT [ '(' ( STRING | IDENT '=' STRING { ',' IDENT '=' STRING } ) ')' ]
+func (p *parser) parseAnnotations() []*ast.Annotation {
+	var res []*ast.Annotation
+	for p.tok == '@' {
+		start := p.pos
+		p.next()
+		a := ast.NewAnnotation(ast.Name(p.ident()))
+		if p.tok == '(' {
+			p.next()
+			if p.tok == scanner.String || p.tok == scanner.RawString {
+				a.SetDefault(p.str())
+			} else {
+				for {
+					key := p.ident()
+					p.expect('=')
+					a.PutLiteral(key, p.str())
+					if p.tok != ',' {
+						break
+					}
+
+					p.next()
+				}
+			}
+
+			p.expect(')')
+		}
+
+		p.setPos(&a.Obj, start)
+		res = append(res, a)
+	}
+
+	return res
+}
+
+func (p *parser) parseVisibility() ast.Visibility {
+	switch {
+	case p.is("public"):
+		p.next()
+		return ast.Public
+	case p.is("internal"):
+		p.next()
+		return ast.PackagePrivate
+	case p.is("protected"):
+		p.next()
+		return ast.Protected
+	case p.is("private"):
+		p.next()
+		return ast.Private
+	default:
+		return ast.Public
+	}
+}
+
+// struct := 'struct' IDENT [ 'implements' IDENT { ',' IDENT } ] '{' field* '}'
+// field := [doc] annotation* [visibility] ( IDENT type | 'embed' type )
+func (p *parser) parseStruct() *ast.Struct {
+	p.expectKeyword("struct")
+	s := ast.NewStruct(p.ident())
+	if p.is("implements") {
+		p.next()
+		s.Implements = append(s.Implements, ast.Name(p.ident()))
+		for p.tok == ',' {
+			p.next()
+			s.Implements = append(s.Implements, ast.Name(p.ident()))
+		}
+	}
+
+	p.expect('{')
+	for p.tok != '}' {
+		start := p.pos
+		doc, hasDoc := p.takeDoc()
+		if p.is("embed") {
+			p.next()
+			s.AddEmbedded(p.parseType())
+			continue
+		}
+
+		annotations := p.parseAnnotations()
+		visibility := p.parseVisibility()
+		name := p.ident()
+		field := ast.NewField(name, p.parseType()).SetVisibility(visibility).AddAnnotations(annotations...)
+		if hasDoc {
+			field.SetComment(doc)
+		}
+
+		p.setPos(&field.Obj, start)
+		s.AddFields(field)
+	}
+
+	p.expect('}')
+
+	return s
+}
+
+// interface := 'interface' IDENT '{' method* '}'
+// method := [doc] annotation* IDENT '(' [ param { ',' param } ] ')' [ type | '(' result { ',' result } ')' ]
+func (p *parser) parseInterface() *ast.Interface {
+	p.expectKeyword("interface")
+	iface := ast.NewInterface(p.ident())
+	p.expect('{')
+	for p.tok != '}' {
+		start := p.pos
+		doc, hasDoc := p.takeDoc()
+		annotations := p.parseAnnotations()
+		fun := ast.NewFunc(p.ident()).AddAnnotations(annotations...)
+		if hasDoc {
+			fun.SetComment(doc)
+		}
+
+		p.expect('(')
+		for p.tok != ')' {
+			if len(fun.Params()) > 0 {
+				p.expect(',')
+			}
+
+			if fun.Variadic() {
+				p.fail(p.pos, "variadic parameter must be the last one")
+			}
+
+			paramStart := p.pos
+			paramAnnotations := p.parseAnnotations()
+			name := p.ident()
+			if p.tok == '.' {
+				p.expectEllipsis()
+				fun.SetVariadic(true)
+			}
+
+			param := ast.NewParam(name, p.parseType()).AddAnnotations(paramAnnotations...)
+			p.setPos(&param.Obj, paramStart)
+			fun.AddParams(param)
+		}
+
+		p.expect(')')
+		fun.AddResults(p.parseResults()...)
+
+		p.setPos(&fun.Obj, start)
+		iface.AddMethods(fun)
+	}
+
+	p.expect('}')
+
+	return iface
+}
+
+func (p *parser) expectEllipsis() {
+	for i := 0; i < 3; i++ {
+		p.expect('.')
+	}
+}
+
+// parseResults parses either nothing, a single type or a parenthesized list of optionally named types.
+func (p *parser) parseResults() []*ast.Param {
+	if p.tok == '}' || p.tok == '@' || p.tok == scanner.EOF || p.pos.Line != p.prevEnd.Line {
+		return nil
+	}
+
+	if p.tok != '(' {
+		start := p.pos
+		param := ast.NewParam("", p.parseType())
+		p.setPos(&param.Obj, start)
+		return []*ast.Param{param}
+	}
+
+	p.next()
+	var res []*ast.Param
+	for p.tok != ')' {
+		if len(res) > 0 {
+			p.expect(',')
+		}
+
+		start := p.pos
+		var param *ast.Param
+		t := p.parseType()
+		if simple, ok := t.(*ast.SimpleTypeDecl); ok && p.tok != ',' && p.tok != ')' {
+			// a named result like (n int!, err error!)
+			param = ast.NewParam(string(simple.Name()), p.parseType())
+		} else {
+			param = ast.NewParam("", t)
+		}
+
+		p.setPos(&param.Obj, start)
+		res = append(res, param)
+	}
+
+	p.expect(')')
+
+	return res
+}
+
+// error := 'error' IDENT '{' case* '}'
+// case := [doc] IDENT [ '{' ( [doc] IDENT type )* '}' ]
+func (p *parser) parseError(doc string) *ast.Macro {
+	p.expectKeyword("error")
+	e := lang.NewError(p.ident()).SetComment(doc)
+	p.expect('{')
+	for p.tok != '}' {
+		caseDoc, _ := p.takeDoc()
+		errorCase := lang.NewErrorCase(p.ident()).SetComment(caseDoc)
+		if p.tok == '{' {
+			p.next()
+			for p.tok != '}' {
+				propDoc, _ := p.takeDoc()
+				name := p.ident()
+				errorCase.AddProperty(name, p.parseType(), propDoc)
+			}
+
+			p.expect('}')
+		}
+
+		e.AddCase(errorCase)
+	}
+
+	p.expect('}')
+
+	return e.TypeDecl()
+}
+
+// enum := 'enum' IDENT [ IDENT ] '{' ( [doc] IDENT [ '=' literal ] )* '}'
+func (p *parser) parseEnum() *ast.Enum {
+	p.expectKeyword("enum")
+	enum := ast.NewEnum(p.ident(), "")
+	if p.tok == scanner.Ident {
+		enum.BaseType = ast.Name(p.ident())
+	}
+
+	p.expect('{')
+	for p.tok != '}' {
+		start := p.pos
+		doc, hasDoc := p.takeDoc()
+		enumCase := ast.NewEnumCase(p.ident())
+		if hasDoc {
+			enumCase.SetComment(doc)
+		}
+
+		if p.tok == '=' {
+			p.next()
+			enumCase.SetValue(p.parseLiteral())
+		}
+
+		p.setPos(&enumCase.Obj, start)
+		enum.AddCases(enumCase)
+	}
+
+	p.expect('}')
+
+	return enum
+}
+
+// literal := [ '-' ] ( INT | FLOAT | CHAR | STRING )
+func (p *parser) parseLiteral() *ast.BasicLit {
+	start := p.pos
+	sign := ""
+	if p.tok == '-' {
+		sign = "-"
+		p.next()
+	}
+
+	var lit *ast.BasicLit
+	switch p.tok {
+	case scanner.Int:
+		lit = ast.NewBasicLit(ast.TokenInt, sign+p.text)
+	case scanner.Float:
+		lit = ast.NewBasicLit(ast.TokenFloat, sign+p.text)
+	case scanner.Char:
+		lit = ast.NewBasicLit(ast.TokenChar, p.text)
+	case scanner.String, scanner.RawString:
+		lit = ast.NewBasicLit(ast.TokenString, p.text)
+	default:
+		p.fail(p.pos, "expected literal but found %s", p.describe())
+	}
+
+	if sign != "" && lit.Kind != ast.TokenInt && lit.Kind != ast.TokenFloat {
+		p.fail(start, "unexpected sign")
+	}
+
+	p.next()
+	p.setPos(&lit.Obj, start)
+
+	return lit
+}
+
+// type := '*' type | '[' [ INT ] ']' type | 'map' '[' type ']' type | 'chan' type |
+//
+//	IDENT [ '<' type { ',' type } '>' ]
+func (p *parser) parseType() ast.TypeDecl {
+	start := p.pos
+	var t ast.TypeDecl
+	var obj *ast.Obj
+	switch {
+	case p.tok == '*':
+		p.next()
+		decl := ast.NewTypeDeclPtr(p.parseType())
+		t, obj = decl, &decl.Obj
+	case p.tok == '[':
+		p.next()
+		if p.tok == ']' {
+			p.next()
+			decl := ast.NewSliceTypeDecl(p.parseType())
+			t, obj = decl, &decl.Obj
+			break
+		}
+
+		if p.tok != scanner.Int {
+			p.fail(p.pos, "expected array length but found %s", p.describe())
+		}
+
+		length, err := strconv.Atoi(p.text)
+		if err != nil {
+			p.fail(p.pos, "invalid array length: %v", err)
+		}
+
+		p.next()
+		p.expect(']')
+		decl := ast.NewArrayTypeDecl(length, p.parseType())
+		t, obj = decl, &decl.Obj
+	case p.is("map"):
+		p.next()
+		p.expect('[')
+		key := p.parseType()
+		p.expect(']')
+		decl := ast.NewGenericDecl(ast.NewSimpleTypeDecl(stdlib.Map), key, p.parseType())
+		t, obj = decl, &decl.Obj
+	case p.is("chan"):
+		p.next()
+		decl := ast.NewChanTypeDecl(p.parseType())
+		t, obj = decl, &decl.Obj
+	case p.tok == scanner.Ident:
+		decl := ast.NewSimpleTypeDecl(ast.Name(p.ident()))
+		t, obj = decl, &decl.Obj
+		if p.tok == '<' {
+			p.setPos(obj, start)
+			p.next()
+			var params []ast.TypeDecl
+			for p.tok != '>' {
+				if len(params) > 0 {
+					p.expect(',')
+				}
+
+				params = append(params, p.parseType())
+			}
+
+			p.expect('>')
+			generic := ast.NewGenericDecl(decl, params...)
+			t, obj = generic, &generic.Obj
+		}
+	default:
+		p.fail(p.pos, "expected type but found %s", p.describe())
+	}
+
+	p.setPos(obj, start)
+
+	return t
+}
+
+// setComment attaches a comment to nodes which provide no SetComment builder.
+func setComment(obj *ast.Obj, n ast.Node, doc string) {
+	obj.ObjComment = ast.NewComment(doc)
+	obj.ObjComment.SetParent(n)
+}
+
+// commentText removes the comment markers and a single leading space of each line.
+func commentText(text string) string {
+	if strings.HasPrefix(text, "//") {
+		return strings.TrimPrefix(text[2:], " ")
+	}
+
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "*")
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,114 @@
+package dsl
+
+import (
+	"errors"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/golang"
+	"testing"
+)
+
+const testSrc = `// ...is a test.
+project "Test"
+
+module "example.com/test" {
+	lang go
+	version "1.16"
+	out "test"
+
+	// ...is the api.
+	package "example.com/test/api" {
+		file "api.go" {
+			// ...is a user.
+			@table("users")
+			struct User implements Named {
+				// ...is the id.
+				@json("id") @db(name = "id", pk = "true")
+				ID int64!
+				internal secret []byte! // trailing comments are ignored
+
+				embed sync.Mutex
+			}
+
+			interface Named {
+				Name() string!
+				Rename(names ...string!) (n int!, err error!)
+			}
+
+			enum Status {
+				Active
+				Disabled
+			}
+		}
+	}
+}
+`
+
+func TestParse(t *testing.T) {
+	prj, err := Parse("test.src", []byte(testSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prj.Name != "Test" || prj.CommentText() != "...is a test." {
+		t.Fatalf("unexpected project: %s %s", prj.Name, prj.CommentText())
+	}
+
+	mod := prj.Mods[0]
+	if mod.Target.Lang != ast.LangGo || mod.Target.MinLangVersion != ast.LangVersionGo16 || mod.Target.Out != "test" {
+		t.Fatalf("unexpected target: %+v", mod.Target)
+	}
+
+	pkg := mod.Pkgs[0]
+	if pkg.Name != "api" || pkg.CommentText() != "...is the api." {
+		t.Fatalf("unexpected package: %s %s", pkg.Name, pkg.CommentText())
+	}
+
+	user := pkg.PkgFiles[0].Nodes[0].(*ast.Struct)
+	if user.Pos() != (ast.Pos{File: "test.src", Line: 13, Col: 4}) || user.End().Line != 21 {
+		t.Fatalf("unexpected struct position: %v - %v", user.Pos(), user.End())
+	}
+
+	if user.CommentText() != "...is a user." || user.Annotations()[0].GetLiteral("") != "users" {
+		t.Fatalf("unexpected struct: %s", user.CommentText())
+	}
+
+	id := user.Fields()[0]
+	if id.Pos() != (ast.Pos{File: "test.src", Line: 16, Col: 5}) || id.CommentText() != "...is the id." {
+		t.Fatalf("unexpected field: %v %s", id.Pos(), id.CommentText())
+	}
+
+	if len(id.Annotations()) != 2 || id.Annotations()[1].GetLiteral("pk") != "true" {
+		t.Fatalf("unexpected annotations: %v", id.Annotations())
+	}
+
+	secret := user.Fields()[1]
+	if secret.Visibility() != ast.PackagePrivate || secret.Comment() != nil {
+		t.Fatalf("unexpected field: %v %v", secret.Visibility(), secret.Comment())
+	}
+
+	typeDecl := secret.TypeDecl().(*ast.SliceTypeDecl)
+	if typeDecl.Pos() != (ast.Pos{File: "test.src", Line: 18, Col: 21}) {
+		t.Fatalf("unexpected type position: %v", typeDecl.Pos())
+	}
+
+	rename := pkg.PkgFiles[0].Nodes[1].(*ast.Interface).Methods()[1]
+	if !rename.Variadic() || len(rename.Results()) != 2 || rename.Results()[1].Identifier() != "err" {
+		t.Fatalf("unexpected method: %v", rename)
+	}
+
+	if _, err := golang.NewRenderer(golang.Options{}).Render(prj); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse("broken.src", []byte("project \"Test\"\n\nmodule \"x\" {\n\tlang go\n\tpackage \"x\" {\n\t\tfile \"x.go\" {\n\t\t\tstruct {\n"))
+	var posErr *Error
+	if !errors.As(err, &posErr) {
+		t.Fatalf("expected positional error but got %v", err)
+	}
+
+	if posErr.Pos != (ast.Pos{File: "broken.src", Line: 7, Col: 11}) {
+		t.Fatalf("unexpected error position: %v", posErr)
+	}
+}
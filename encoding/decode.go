@@ -0,0 +1,1114 @@
+package encoding
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"math"
+	"reflect"
+)
+
+// decodedErrorRef is the deserialized form of an ast.ErrorRef.
+type decodedErrorRef struct {
+	name    string
+	comment string
+}
+
+func (r decodedErrorRef) GetComment() string {
+	return r.comment
+}
+
+func (r decodedErrorRef) Name() string {
+	return r.name
+}
+
+// factoryRefs is a not yet resolved list of function names for a struct.
+type factoryRefs struct {
+	pkg   *ast.Pkg
+	s     *ast.Struct
+	names []string
+}
+
+// decoder converts the serialization model into an ast tree.
+type decoder struct {
+	factoryRefs []factoryRefs
+	pkg         *ast.Pkg
+}
+
+func (d *decoder) nodes(n []*node) ([]ast.Node, error) {
+	var res []ast.Node
+	for _, child := range n {
+		c, err := d.node(child)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) exprs(n []*node) ([]ast.Expr, error) {
+	var res []ast.Expr
+	for _, child := range n {
+		e, err := d.expr(child)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) expr(n *node) (ast.Expr, error) {
+	c, err := d.node(n)
+	if err != nil {
+		return nil, err
+	}
+
+	e, ok := c.(ast.Expr)
+	if !ok {
+		return nil, fmt.Errorf("%s: kind '%s' is not an expression", posOf(n), n.Kind)
+	}
+
+	return e, nil
+}
+
+func (d *decoder) typeDecl(n *node) (ast.TypeDecl, error) {
+	c, err := d.node(n)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := c.(ast.TypeDecl)
+	if !ok {
+		return nil, fmt.Errorf("%s: kind '%s' is not a type declaration", posOf(n), n.Kind)
+	}
+
+	return t, nil
+}
+
+func (d *decoder) typeDecls(n []*node) ([]ast.TypeDecl, error) {
+	var res []ast.TypeDecl
+	for _, child := range n {
+		t, err := d.typeDecl(child)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) block(n *node) (*ast.Block, error) {
+	if n == nil {
+		return nil, nil
+	}
+
+	c, err := d.node(n)
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := c.(*ast.Block)
+	if !ok {
+		return nil, fmt.Errorf("%s: kind '%s' is not a block", posOf(n), n.Kind)
+	}
+
+	return b, nil
+}
+
+// optNode returns nil, if n is nil.
+func (d *decoder) optNode(n *node) (ast.Node, error) {
+	if n == nil {
+		return nil, nil
+	}
+
+	return d.node(n)
+}
+
+func (d *decoder) optExpr(n *node) (ast.Expr, error) {
+	if n == nil {
+		return nil, nil
+	}
+
+	return d.expr(n)
+}
+
+func (d *decoder) funcs(n []*node) ([]*ast.Func, error) {
+	var res []*ast.Func
+	for _, child := range n {
+		c, err := d.node(child)
+		if err != nil {
+			return nil, err
+		}
+
+		f, ok := c.(*ast.Func)
+		if !ok {
+			return nil, fmt.Errorf("%s: kind '%s' is not a func", posOf(child), child.Kind)
+		}
+
+		res = append(res, f)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) params(n []*node) ([]*ast.Param, error) {
+	var res []*ast.Param
+	for _, child := range n {
+		c, err := d.node(child)
+		if err != nil {
+			return nil, err
+		}
+
+		p, ok := c.(*ast.Param)
+		if !ok {
+			return nil, fmt.Errorf("%s: kind '%s' is not a param", posOf(child), child.Kind)
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) annotations(n []*node) ([]*ast.Annotation, error) {
+	var res []*ast.Annotation
+	for _, child := range n {
+		c, err := d.node(child)
+		if err != nil {
+			return nil, err
+		}
+
+		a, ok := c.(*ast.Annotation)
+		if !ok {
+			return nil, fmt.Errorf("%s: kind '%s' is not an annotation", posOf(child), child.Kind)
+		}
+
+		res = append(res, a)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) namedTypes(n []*node) ([]ast.NamedType, error) {
+	var res []ast.NamedType
+	for _, child := range n {
+		c, err := d.node(child)
+		if err != nil {
+			return nil, err
+		}
+
+		t, ok := c.(ast.NamedType)
+		if !ok {
+			return nil, fmt.Errorf("%s: kind '%s' is not a named type", posOf(child), child.Kind)
+		}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+// node converts the serialized node into an ast.Node and applies the common positional and comment information.
+func (d *decoder) node(n *node) (ast.Node, error) {
+	if n == nil {
+		return nil, fmt.Errorf("unexpected null node")
+	}
+
+	res, err := d.decode(n)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := objOf(res)
+	if n.Pos != nil {
+		obj.ObjPos = ast.Pos{File: n.Pos.File, Line: n.Pos.Line, Col: n.Pos.Col}
+	}
+
+	if n.End != nil {
+		obj.ObjEnd = ast.Pos{File: n.End.File, Line: n.End.Line, Col: n.End.Col}
+	}
+
+	if n.Comment != nil {
+		obj.ObjComment = ast.NewComment(*n.Comment)
+		obj.ObjComment.SetParent(res)
+	}
+
+	return res, nil
+}
+
+func (d *decoder) decode(n *node) (ast.Node, error) {
+	switch n.Kind {
+	case kindPrj:
+		prj := ast.NewPrj(n.Name)
+		for _, child := range n.Mods {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			mod, ok := c.(*ast.Mod)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a module", posOf(child), child.Kind)
+			}
+
+			prj.AddModules(mod)
+		}
+
+		return prj, nil
+	case kindMod:
+		mod := ast.NewMod(n.Name)
+		if n.Target != nil {
+			mod.Target.Out = n.Target.Out
+			mod.Target.Arch = ast.Arch(n.Target.Arch)
+			mod.Target.Os = ast.OS(n.Target.Os)
+			mod.Target.Lang = ast.Lang(n.Target.Lang)
+			mod.Target.MinLangVersion = ast.LangVersion(n.Target.MinLangVersion)
+			mod.Target.MaxLangVersion = ast.LangVersion(n.Target.MaxLangVersion)
+			mod.Target.Framework = ast.Framework(n.Target.Framework)
+			mod.Target.Require.GoMod = n.Target.RequireGoMod
+			mod.Target.Require.Maven = n.Target.RequireMaven
+			mod.Target.Require.Cargo = n.Target.RequireCargo
+		}
+
+		for _, child := range n.Pkgs {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			pkg, ok := c.(*ast.Pkg)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a package", posOf(child), child.Kind)
+			}
+
+			mod.AddPackages(pkg)
+		}
+
+		return mod, nil
+	case kindPkg:
+		pkg := ast.NewPkg(n.Path)
+		if n.Name != "" {
+			pkg.SetName(n.Name)
+		}
+
+		if n.Preamble != nil {
+			pkg.SetPreamble(*n.Preamble)
+		}
+
+		d.pkg = pkg
+		for _, child := range n.Files {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			file, ok := c.(*ast.File)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a file", posOf(child), child.Kind)
+			}
+
+			pkg.AddFiles(file)
+		}
+
+		for _, child := range n.RawFiles {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			file, ok := c.(*ast.RawFile)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a raw file", posOf(child), child.Kind)
+			}
+
+			pkg.AddRawFiles(file)
+		}
+
+		d.pkg = nil
+
+		return pkg, nil
+	case kindFile:
+		file := ast.NewFile(n.Name)
+		if n.Preamble != nil {
+			file.SetPreamble(*n.Preamble)
+		}
+
+		nodes, err := d.nodes(n.Nodes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode file '%s': %w", n.Name, err)
+		}
+
+		file.AddNodes(nodes...)
+
+		return file, nil
+	case kindRawFile:
+		if n.Template != nil {
+			c, err := d.node(n.Template)
+			if err != nil {
+				return nil, err
+			}
+
+			tpl, ok := c.(*ast.Tpl)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a template", posOf(n.Template), n.Template.Kind)
+			}
+
+			return ast.NewRawTpl(n.Name, n.MimeType, tpl), nil
+		}
+
+		var buf []byte
+		if n.Text != nil {
+			buf = []byte(*n.Text)
+		} else {
+			b, err := base64.StdEncoding.DecodeString(n.Base64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid base64 data: %w", posOf(n), err)
+			}
+
+			buf = b
+		}
+
+		return ast.NewRawFile(n.Name, n.MimeType, buf), nil
+	case kindImport:
+		return ast.NewImport(n.Ident, ast.Name(n.Name)).SetStatic(n.Static), nil
+	case kindStruct:
+		s := ast.NewStruct(n.Name).SetStatic(n.Static).SetFinal(n.Final).SetRecord(n.Record).
+			SetExtends(ast.Name(n.Extends)).SetDefaultRecName(n.DefaultRecName)
+		v, err := decodeVisibility(n.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		s.SetVisibility(v)
+		s.TypeDeprecated = decodeDeprecation(n.Deprecated)
+		for _, name := range n.Implements {
+			s.Implements = append(s.Implements, ast.Name(name))
+		}
+
+		annotations, err := d.annotations(n.Annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		s.AddAnnotations(annotations...)
+
+		for _, child := range n.Fields {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			field, ok := c.(*ast.Field)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a field", posOf(child), child.Kind)
+			}
+
+			s.AddFields(field)
+		}
+
+		methods, err := d.funcs(n.Methods)
+		if err != nil {
+			return nil, err
+		}
+
+		s.AddMethods(methods...)
+
+		types, err := d.namedTypes(n.Types)
+		if err != nil {
+			return nil, err
+		}
+
+		s.AddNamedTypes(types...)
+
+		embedded, err := d.typeDecls(n.Embedded)
+		if err != nil {
+			return nil, err
+		}
+
+		s.AddEmbedded(embedded...)
+
+		if len(n.FactoryRefs) > 0 {
+			d.factoryRefs = append(d.factoryRefs, factoryRefs{pkg: d.pkg, s: s, names: n.FactoryRefs})
+		}
+
+		return s, nil
+	case kindInterface:
+		iface := ast.NewInterface(n.Name)
+		v, err := decodeVisibility(n.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		iface.SetVisibility(v)
+		iface.TypeDeprecated = decodeDeprecation(n.Deprecated)
+		for _, name := range n.Permits {
+			iface.AddPermits(ast.Name(name))
+		}
+
+		annotations, err := d.annotations(n.Annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		iface.AddAnnotations(annotations...)
+
+		methods, err := d.funcs(n.Methods)
+		if err != nil {
+			return nil, err
+		}
+
+		iface.AddMethods(methods...)
+
+		types, err := d.namedTypes(n.Types)
+		if err != nil {
+			return nil, err
+		}
+
+		iface.AddNamedTypes(types...)
+
+		embedded, err := d.typeDecls(n.Embedded)
+		if err != nil {
+			return nil, err
+		}
+
+		iface.AddEmbedded(embedded...)
+
+		return iface, nil
+	case kindEnum:
+		enum := ast.NewEnum(n.Name, ast.Name(n.BaseType))
+		for _, name := range n.Implements {
+			enum.Implements = append(enum.Implements, ast.Name(name))
+		}
+
+		for _, child := range n.Cases {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			enumCase, ok := c.(*ast.EnumCase)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not an enum case", posOf(child), child.Kind)
+			}
+
+			enum.AddCases(enumCase)
+		}
+
+		return enum, nil
+	case kindEnumCase:
+		enumCase := ast.NewEnumCase(n.Name)
+		if n.Value != nil {
+			c, err := d.node(n.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			lit, ok := c.(*ast.BasicLit)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a literal", posOf(n.Value), n.Value.Kind)
+			}
+
+			enumCase.SetValue(lit)
+		}
+
+		return enumCase, nil
+	case kindFunc:
+		fun := ast.NewFunc(n.Name).
+			SetStatic(n.Static).
+			SetRecName(n.RecName).
+			SetPtrReceiver(n.PtrReceiver).
+			SetVariadic(n.Variadic)
+
+		v, err := decodeVisibility(n.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		fun.SetVisibility(v)
+		fun.FunDeprecated = decodeDeprecation(n.Deprecated)
+
+		for _, ref := range n.ErrorRefs {
+			fun.AddErrorCaseRefs(decodedErrorRef{name: ref.Name, comment: ref.Comment})
+		}
+
+		annotations, err := d.annotations(n.Annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		fun.AddAnnotations(annotations...)
+
+		params, err := d.params(n.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		fun.AddParams(params...)
+
+		results, err := d.params(n.Results)
+		if err != nil {
+			return nil, err
+		}
+
+		fun.AddResults(results...)
+
+		body, err := d.block(n.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if body != nil {
+			fun.SetBody(body)
+		}
+
+		return fun, nil
+	case kindField:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		field := ast.NewField(n.Name, t)
+		v, err := decodeVisibility(n.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		field.SetVisibility(v)
+		field.FieldDeprecated = decodeDeprecation(n.Deprecated)
+
+		annotations, err := d.annotations(n.Annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		field.AddAnnotations(annotations...)
+
+		if n.Default != nil {
+			c, err := d.node(n.Default)
+			if err != nil {
+				return nil, err
+			}
+
+			lit, ok := c.(*ast.BasicLit)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not a literal", posOf(n.Default), n.Default.Kind)
+			}
+
+			field.SetDefault(lit)
+		}
+
+		return field, nil
+	case kindParam:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations, err := d.annotations(n.Annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewParam(n.Name, t).AddAnnotations(annotations...), nil
+	case kindProperty:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		prop := ast.NewProperty(n.Name, t)
+		if n.Read != nil {
+			v, err := decodeVisibility(n.Read.Visibility)
+			if err != nil {
+				return nil, err
+			}
+
+			prop.Reader(n.Read.Enabled, v)
+		}
+
+		if n.Write != nil {
+			v, err := decodeVisibility(n.Write.Visibility)
+			if err != nil {
+				return nil, err
+			}
+
+			prop.Writer(n.Write.Enabled, v)
+		}
+
+		return prop, nil
+	case kindAnnotation:
+		a := ast.NewAnnotation(ast.Name(n.Name))
+		for k, v := range n.Attributes {
+			a.Values[k] = v
+		}
+
+		return a, nil
+	case kindConstDecl:
+		decl := ast.NewConstDecl()
+		for _, child := range n.Nodes {
+			c, err := d.node(child)
+			if err != nil {
+				return nil, err
+			}
+
+			assign, ok := c.(*ast.Assign)
+			if !ok {
+				return nil, fmt.Errorf("%s: kind '%s' is not an assignment", posOf(child), child.Kind)
+			}
+
+			decl.Add(assign)
+		}
+
+		return decl, nil
+	case kindVarDecl:
+		nodes, err := d.nodes(n.Nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewVarDecl(nodes...), nil
+	case kindSimpleTypeDecl:
+		return ast.NewSimpleTypeDecl(ast.Name(n.Name)), nil
+	case kindTypeDeclPtr:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewTypeDeclPtr(t), nil
+	case kindGenericTypeDecl:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		params, err := d.typeDecls(n.TypeParams)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewGenericDecl(t, params...), nil
+	case kindNamedTypeDecl:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		decl := ast.NewNamedTypeDecl(n.Name, t)
+		decl.SetBound(ast.TypeBound(n.Bound))
+
+		return decl, nil
+	case kindSliceTypeDecl:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewSliceTypeDecl(t), nil
+	case kindArrayTypeDecl:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewArrayTypeDecl(n.Len, t), nil
+	case kindChanTypeDecl:
+		t, err := d.typeDecl(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		decl := ast.NewChanTypeDecl(t)
+		decl.ChanDir = ast.ChanDir(n.Dir)
+
+		return decl, nil
+	case kindFuncTypeDecl:
+		params, err := d.params(n.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		results, err := d.params(n.Results)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewFuncTypeDecl().AddInputParams(params...).AddOutputParams(results...), nil
+	case kindBlock:
+		nodes, err := d.nodes(n.Nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewBlock(nodes...), nil
+	case kindMacro:
+		params, err := d.macroParams(n.MacroParams)
+		if err != nil {
+			return nil, fmt.Errorf("%s: cannot decode parameters of macro '%s': %w", posOf(n), n.Macro, err)
+		}
+
+		m, err := ast.MakeMacro(n.Macro, params)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", posOf(n), err)
+		}
+
+		if n.ID != "" {
+			m.SetID(n.ID)
+		}
+
+		return m, nil
+	case kindTpl:
+		tpl := ast.NewTpl("")
+		if n.Text != nil {
+			tpl.Template = *n.Text
+		}
+
+		for k, v := range n.Values {
+			tpl.Put(k, normalizeValue(v))
+		}
+
+		return tpl, nil
+	case kindSym:
+		kind, err := decodeSymKind(n.Sym)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewSym(kind), nil
+	case kindIdent:
+		return ast.NewIdent(n.Name), nil
+	case kindQualIdent:
+		return ast.NewQualIdent(n.Qualifier), nil
+	case kindSelExpr:
+		x, err := d.expr(n.X)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := d.node(n.Sel)
+		if err != nil {
+			return nil, err
+		}
+
+		sel, ok := c.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("%s: kind '%s' is not an identifier", posOf(n.Sel), n.Sel.Kind)
+		}
+
+		return ast.NewSelExpr(x, sel), nil
+	case kindCallExpr:
+		fun, err := d.expr(n.Fun)
+		if err != nil {
+			return nil, err
+		}
+
+		args, err := d.exprs(n.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		call := ast.NewCallExpr(fun, args...)
+		call.Ellipsis = n.Ellipsis
+
+		return call, nil
+	case kindBasicLit:
+		kind, err := decodeTokenKind(n.Tok)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewBasicLit(kind, n.Lit), nil
+	case kindCompLit:
+		typ, err := d.optExpr(n.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		elems, err := d.exprs(n.Elements)
+		if err != nil {
+			return nil, err
+		}
+
+		if typ == nil {
+			// NewCompLit does not accept anonymous literals
+			return (&ast.CompLit{}).AddElements(elems...), nil
+		}
+
+		return ast.NewCompLit(typ, elems...), nil
+	case kindUnaryExpr:
+		op, err := decodeOperator(n.Op)
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := d.expr(n.X)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewUnaryExpr(x, op), nil
+	case kindBinaryExpr:
+		op, err := decodeOperator(n.Op)
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := d.expr(n.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := d.expr(n.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewBinaryExpr(x, op, y), nil
+	case kindAssign:
+		kind, err := decodeAssignKind(n.Op)
+		if err != nil {
+			return nil, err
+		}
+
+		lhs, err := d.exprs(n.Lhs)
+		if err != nil {
+			return nil, err
+		}
+
+		rhs, err := d.exprs(n.Rhs)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewAssign(lhs, kind, rhs), nil
+	case kindIfStmt:
+		cond, err := d.expr(n.Cond)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := d.block(n.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if body == nil {
+			body = ast.NewBlock()
+		}
+
+		stmt := ast.NewIfStmt(cond, body)
+		if n.Init != nil {
+			init, err := d.node(n.Init)
+			if err != nil {
+				return nil, err
+			}
+
+			stmt.SetInit(init)
+		}
+
+		if n.Else != nil {
+			elseStmt, err := d.node(n.Else)
+			if err != nil {
+				return nil, err
+			}
+
+			objOf(elseStmt).SetParent(stmt)
+			stmt.Else = elseStmt
+		}
+
+		return stmt, nil
+	case kindForStmt:
+		init, err := d.optNode(n.Init)
+		if err != nil {
+			return nil, err
+		}
+
+		cond, err := d.optNode(n.Cond)
+		if err != nil {
+			return nil, err
+		}
+
+		post, err := d.optNode(n.Post)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := d.block(n.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewForStmt(init, cond, post, body), nil
+	case kindRangeStmt:
+		key, err := d.optNode(n.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := d.optNode(n.Val)
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := d.optNode(n.X)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := d.block(n.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewRangeStmt(key, val, x, body), nil
+	case kindReturnStmt:
+		results, err := d.exprs(n.Results)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewReturnStmt(results...), nil
+	case kindDeferStmt:
+		x, err := d.node(n.X)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.NewDeferStmt(x), nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported node kind '%s'", posOf(n), n.Kind)
+	}
+}
+
+func (d *decoder) macroParams(p map[string]*param) (ast.MacroParams, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	res := ast.MacroParams{}
+	for k, v := range p {
+		if v == nil {
+			continue
+		}
+
+		switch v.Type {
+		case paramString:
+			res[k] = v.String
+		case paramBool:
+			res[k] = v.Bool
+		case paramInt:
+			res[k] = v.Int
+		case paramStrings:
+			res[k] = v.Strings
+		case paramNode:
+			n, err := d.node(v.Node)
+			if err != nil {
+				return nil, fmt.Errorf("parameter '%s': %w", k, err)
+			}
+
+			res[k] = n
+		case paramNodes:
+			n, err := d.nodes(v.Nodes)
+			if err != nil {
+				return nil, fmt.Errorf("parameter '%s': %w", k, err)
+			}
+
+			res[k] = n
+		case paramParams:
+			m, err := d.macroParams(v.Params)
+			if err != nil {
+				return nil, fmt.Errorf("parameter '%s': %w", k, err)
+			}
+
+			if m == nil {
+				m = ast.MacroParams{}
+			}
+
+			res[k] = m
+		case paramParamsList:
+			var list []ast.MacroParams
+			for _, params := range v.ParamsList {
+				m, err := d.macroParams(params)
+				if err != nil {
+					return nil, fmt.Errorf("parameter '%s': %w", k, err)
+				}
+
+				if m == nil {
+					m = ast.MacroParams{}
+				}
+
+				list = append(list, m)
+			}
+
+			res[k] = list
+		default:
+			return nil, fmt.Errorf("parameter '%s' has unsupported type '%s'", k, v.Type)
+		}
+	}
+
+	return res, nil
+}
+
+// resolveFactoryRefs connects the factory functions by name. This must be done after all files of a package
+// have been decoded.
+func (d *decoder) resolveFactoryRefs() error {
+	for _, ref := range d.factoryRefs {
+		for _, name := range ref.names {
+			fun := findFunc(ref.pkg, name)
+			if fun == nil {
+				return fmt.Errorf("%s: factory func '%s' of struct '%s' not found", ref.s.Pos(), name, ref.s.TypeName)
+			}
+
+			ref.s.AddFactoryRefs(fun)
+		}
+	}
+
+	return nil
+}
+
+func findFunc(pkg *ast.Pkg, name string) *ast.Func {
+	if pkg == nil {
+		return nil
+	}
+
+	for _, file := range pkg.PkgFiles {
+		for _, fun := range file.Funcs() {
+			if fun.FunName == name {
+				return fun
+			}
+		}
+	}
+
+	return nil
+}
+
+// objOf returns the embedded ast.Obj of the given node. All ast nodes embed an Obj.
+func objOf(n ast.Node) *ast.Obj {
+	return reflect.ValueOf(n).Elem().FieldByName("Obj").Addr().Interface().(*ast.Obj)
+}
+
+// normalizeValue converts the generic json numbers back into ints, if possible.
+func normalizeValue(v interface{}) interface{} {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < math.MaxInt32 {
+		return int(f)
+	}
+
+	return v
+}
+
+func posOf(n *node) string {
+	if n == nil || n.Pos == nil {
+		return "<unknown>"
+	}
+
+	return ast.Pos{File: n.Pos.File, Line: n.Pos.Line, Col: n.Pos.Col}.String()
+}
@@ -0,0 +1,6 @@
+// Package encoding provides a stable and versioned JSON and YAML representation of an ast.Prj, so that
+// non-Go tools can produce and consume project models. Macros cannot be serialized as functions, instead they
+// are represented by the kind and the parameters they have been registered with (see ast.RegisterMacro). Decoding
+// recreates them using the registered factories, so packages like stdlib/lang must be imported. The format is
+// described by the JSON Schema returned by Schema.
+package encoding
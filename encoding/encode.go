@@ -0,0 +1,767 @@
+package encoding
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"reflect"
+	"sort"
+	"unicode/utf8"
+)
+
+// encoder converts an ast tree into the serialization model.
+type encoder struct {
+}
+
+func (e *encoder) nodes(n []ast.Node) ([]*node, error) {
+	var res []*node
+	for _, child := range n {
+		c, err := e.node(child)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+func (e *encoder) exprs(n []ast.Expr) ([]*node, error) {
+	return e.nodes(ast.ExprNodes(n...))
+}
+
+func (e *encoder) optNode(n ast.Node) (*node, error) {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return nil, nil
+	}
+
+	return e.node(n)
+}
+
+// node converts the given ast.Node into its serialized form. Unknown types or macros without a registered kind
+// cannot be converted.
+func (e *encoder) node(n ast.Node) (*node, error) {
+	res, err := e.encode(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if p := n.Pos(); p != (ast.Pos{}) {
+		res.Pos = &pos{File: p.File, Line: p.Line, Col: p.Col}
+	}
+
+	if p := n.End(); p != (ast.Pos{}) {
+		res.End = &pos{File: p.File, Line: p.Line, Col: p.Col}
+	}
+
+	if c := n.Comment(); c != nil {
+		text := c.Text
+		res.Comment = &text
+	}
+
+	return res, nil
+}
+
+func (e *encoder) encode(n ast.Node) (*node, error) {
+	var err error
+	switch t := n.(type) {
+	case *ast.Prj:
+		res := &node{Kind: kindPrj, Name: t.Name}
+		for _, mod := range t.Mods {
+			m, err := e.node(mod)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode module '%s': %w", mod.Name, err)
+			}
+
+			res.Mods = append(res.Mods, m)
+		}
+
+		return res, nil
+	case *ast.Mod:
+		res := &node{Kind: kindMod, Name: t.Name, Target: &target{
+			Out:            t.Target.Out,
+			Arch:           string(t.Target.Arch),
+			Os:             string(t.Target.Os),
+			Lang:           string(t.Target.Lang),
+			MinLangVersion: string(t.Target.MinLangVersion),
+			MaxLangVersion: string(t.Target.MaxLangVersion),
+			Framework:      string(t.Target.Framework),
+			RequireGoMod:   t.Target.Require.GoMod,
+			RequireMaven:   t.Target.Require.Maven,
+			RequireCargo:   t.Target.Require.Cargo,
+		}}
+
+		for _, pkg := range t.Pkgs {
+			p, err := e.node(pkg)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode package '%s': %w", pkg.Path, err)
+			}
+
+			res.Pkgs = append(res.Pkgs, p)
+		}
+
+		return res, nil
+	case *ast.Pkg:
+		res := &node{Kind: kindPkg, Name: t.Name, Path: t.Path, Preamble: commentText(t.Preamble)}
+		for _, file := range t.PkgFiles {
+			f, err := e.node(file)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode file '%s': %w", file.Name, err)
+			}
+
+			res.Files = append(res.Files, f)
+		}
+
+		for _, file := range t.RawFiles {
+			f, err := e.node(file)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode raw file '%s': %w", file.Name, err)
+			}
+
+			res.RawFiles = append(res.RawFiles, f)
+		}
+
+		return res, nil
+	case *ast.File:
+		res := &node{Kind: kindFile, Name: t.Name, Preamble: commentText(t.Preamble)}
+		if res.Nodes, err = e.nodes(t.Nodes); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.RawFile:
+		res := &node{Kind: kindRawFile, Name: t.Name, MimeType: t.MimeType}
+		if t.Tpl != nil {
+			if res.Template, err = e.node(t.Tpl); err != nil {
+				return nil, err
+			}
+
+			return res, nil
+		}
+
+		buf, err := t.Data(t)
+		if err != nil {
+			return nil, fmt.Errorf("cannot evaluate raw file data: %w", err)
+		}
+
+		if utf8.Valid(buf) {
+			text := string(buf)
+			res.Text = &text
+		} else {
+			res.Base64 = base64.StdEncoding.EncodeToString(buf)
+		}
+
+		return res, nil
+	case *ast.Import:
+		return &node{Kind: kindImport, Ident: t.Ident, Name: string(t.Name), Static: t.Static}, nil
+	case *ast.Struct:
+		res := &node{
+			Kind:           kindStruct,
+			Name:           t.TypeName,
+			Visibility:     encodeVisibility(t.TypeVisibility),
+			Static:         t.TypeStatic,
+			Final:          t.TypeFinal,
+			Record:         t.TypeRecord,
+			Implements:     names(t.Implements),
+			Extends:        string(t.Extends),
+			DefaultRecName: t.DefaultRecName,
+			Deprecated:     encodeDeprecation(t.TypeDeprecated),
+		}
+
+		for _, ref := range t.FactoryRefs {
+			res.FactoryRefs = append(res.FactoryRefs, ref.FunName)
+		}
+
+		if res.Annotations, err = e.annotations(t.TypeAnnotations); err != nil {
+			return nil, err
+		}
+
+		for _, field := range t.TypeFields {
+			f, err := e.node(field)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode field '%s': %w", field.FieldName, err)
+			}
+
+			res.Fields = append(res.Fields, f)
+		}
+
+		if res.Methods, err = e.funcs(t.TypeMethods); err != nil {
+			return nil, err
+		}
+
+		if res.Types, err = e.namedTypes(t.Types); err != nil {
+			return nil, err
+		}
+
+		if res.Embedded, err = e.typeDecls(t.Embedded); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Interface:
+		res := &node{
+			Kind:       kindInterface,
+			Name:       t.TypeName,
+			Visibility: encodeVisibility(t.TypeVisibility),
+			Permits:    names(t.Permits),
+			Deprecated: encodeDeprecation(t.TypeDeprecated),
+		}
+
+		if res.Annotations, err = e.annotations(t.TypeAnnotations); err != nil {
+			return nil, err
+		}
+
+		if res.Methods, err = e.funcs(t.TypeMethods); err != nil {
+			return nil, err
+		}
+
+		if res.Types, err = e.namedTypes(t.Types); err != nil {
+			return nil, err
+		}
+
+		if res.Embedded, err = e.typeDecls(t.Embedded); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Enum:
+		res := &node{Kind: kindEnum, Name: t.TypeName, BaseType: string(t.BaseType), Implements: names(t.Implements)}
+		for _, enumCase := range t.Cases {
+			c, err := e.node(enumCase)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode enum case '%s': %w", enumCase.TypeName, err)
+			}
+
+			res.Cases = append(res.Cases, c)
+		}
+
+		return res, nil
+	case *ast.EnumCase:
+		res := &node{Kind: kindEnumCase, Name: t.TypeName}
+		if res.Value, err = e.optNode(t.EnumValue); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Func:
+		res := &node{
+			Kind:        kindFunc,
+			Name:        t.FunName,
+			Static:      t.FunStatic,
+			Visibility:  encodeVisibility(t.FunVisibility),
+			RecName:     t.FunReceiverName,
+			PtrReceiver: t.FunPtrReceiver,
+			Variadic:    t.FunVariadic,
+			Deprecated:  encodeDeprecation(t.FunDeprecated),
+		}
+
+		for _, ref := range t.ErrorHintRefs {
+			res.ErrorRefs = append(res.ErrorRefs, errorRef{Name: ref.Name(), Comment: ref.GetComment()})
+		}
+
+		if res.Annotations, err = e.annotations(t.FunAnnotations); err != nil {
+			return nil, err
+		}
+
+		if res.Params, err = e.params(t.FunParams); err != nil {
+			return nil, err
+		}
+
+		if res.Results, err = e.params(t.FunResults); err != nil {
+			return nil, err
+		}
+
+		if res.Body, err = e.optNode(t.FunBody); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Field:
+		res := &node{
+			Kind:       kindField,
+			Name:       t.FieldName,
+			Visibility: encodeVisibility(t.FieldVisibility),
+			Deprecated: encodeDeprecation(t.FieldDeprecated),
+		}
+
+		if res.Type, err = e.node(t.FieldType); err != nil {
+			return nil, err
+		}
+
+		if res.Annotations, err = e.annotations(t.FieldAnnotations); err != nil {
+			return nil, err
+		}
+
+		if res.Default, err = e.optNode(t.FieldDefault); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Param:
+		res := &node{Kind: kindParam, Name: t.ParamName}
+		if res.Type, err = e.node(t.ParamTypeDecl); err != nil {
+			return nil, err
+		}
+
+		if res.Annotations, err = e.annotations(t.ParamAnnotations); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Property:
+		res := &node{
+			Kind:  kindProperty,
+			Name:  t.FieldName,
+			Read:  &accessor{Enabled: t.Read.Enabled, Visibility: encodeVisibility(t.Read.Visibility)},
+			Write: &accessor{Enabled: t.Write.Enabled, Visibility: encodeVisibility(t.Write.Visibility)},
+		}
+
+		if res.Type, err = e.node(t.FieldType); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Annotation:
+		return &node{Kind: kindAnnotation, Name: string(t.AnnotationName), Attributes: t.Values}, nil
+	case *ast.ConstDecl:
+		res := &node{Kind: kindConstDecl}
+		for _, assign := range t.Assignments {
+			a, err := e.node(assign)
+			if err != nil {
+				return nil, err
+			}
+
+			res.Nodes = append(res.Nodes, a)
+		}
+
+		return res, nil
+	case *ast.VarDecl:
+		res := &node{Kind: kindVarDecl}
+		if res.Nodes, err = e.nodes(t.Decl); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.SimpleTypeDecl:
+		return &node{Kind: kindSimpleTypeDecl, Name: string(t.SimpleName)}, nil
+	case *ast.TypeDeclPtr:
+		res := &node{Kind: kindTypeDeclPtr}
+		if res.Type, err = e.node(t.Decl); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.GenericTypeDecl:
+		res := &node{Kind: kindGenericTypeDecl}
+		if res.Type, err = e.node(t.TypeDecl); err != nil {
+			return nil, err
+		}
+
+		if res.TypeParams, err = e.typeDecls(t.TypeParams); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.NamedTypeDecl:
+		res := &node{Kind: kindNamedTypeDecl, Name: t.TypeName, Bound: string(t.TypeBound)}
+		if res.Type, err = e.node(t.TypeDecl); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.SliceTypeDecl:
+		res := &node{Kind: kindSliceTypeDecl}
+		if res.Type, err = e.node(t.TypeDecl); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.ArrayTypeDecl:
+		res := &node{Kind: kindArrayTypeDecl, Len: t.ArrayLen}
+		if res.Type, err = e.node(t.ArrayTypeDecl); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.ChanTypeDecl:
+		res := &node{Kind: kindChanTypeDecl, Dir: string(t.ChanDir)}
+		if res.Type, err = e.node(t.ChanTypeDecl); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.FuncTypeDecl:
+		res := &node{Kind: kindFuncTypeDecl}
+		if res.Params, err = e.params(t.In); err != nil {
+			return nil, err
+		}
+
+		if res.Results, err = e.params(t.Out); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Block:
+		res := &node{Kind: kindBlock}
+		if res.Nodes, err = e.nodes(t.Nodes); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Macro:
+		if t.Kind == "" {
+			return nil, fmt.Errorf("macro '%s' at %s has no registered kind and cannot be serialized", t.ID, t.Pos())
+		}
+
+		res := &node{Kind: kindMacro, ID: t.ID, Macro: t.Kind}
+		if res.MacroParams, err = e.macroParams(t.Params); err != nil {
+			return nil, fmt.Errorf("cannot encode parameters of macro '%s': %w", t.Kind, err)
+		}
+
+		return res, nil
+	case *ast.Tpl:
+		res := &node{Kind: kindTpl}
+		text := t.Template
+		res.Text = &text
+		for k, v := range t.Values {
+			switch v.(type) {
+			case string, bool, int, int64, float64:
+			default:
+				return nil, fmt.Errorf("template value '%s' has unsupported type %T", k, v)
+			}
+		}
+
+		res.Values = t.Values
+
+		return res, nil
+	case *ast.Sym:
+		res := &node{Kind: kindSym}
+		if res.Sym, err = encodeSymKind(t.Kind); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Ident:
+		return &node{Kind: kindIdent, Name: t.Name}, nil
+	case *ast.QualIdent:
+		return &node{Kind: kindQualIdent, Qualifier: t.Qualifier}, nil
+	case *ast.SelExpr:
+		res := &node{Kind: kindSelExpr}
+		if res.X, err = e.node(t.X); err != nil {
+			return nil, err
+		}
+
+		if res.Sel, err = e.node(t.Sel); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.CallExpr:
+		res := &node{Kind: kindCallExpr, Ellipsis: t.Ellipsis}
+		if res.Fun, err = e.node(t.Fun); err != nil {
+			return nil, err
+		}
+
+		if res.Args, err = e.exprs(t.Args); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.BasicLit:
+		res := &node{Kind: kindBasicLit, Lit: t.Val}
+		if res.Tok, err = encodeTokenKind(t.Kind); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.CompLit:
+		res := &node{Kind: kindCompLit}
+		if res.Type, err = e.optNode(t.Type); err != nil {
+			return nil, err
+		}
+
+		if res.Elements, err = e.exprs(t.Elements); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.UnaryExpr:
+		res := &node{Kind: kindUnaryExpr}
+		if res.Op, err = encodeOperator(t.Op); err != nil {
+			return nil, err
+		}
+
+		if res.X, err = e.node(t.X); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.BinaryExpr:
+		res := &node{Kind: kindBinaryExpr}
+		if res.Op, err = encodeOperator(t.Op); err != nil {
+			return nil, err
+		}
+
+		if res.X, err = e.node(t.X); err != nil {
+			return nil, err
+		}
+
+		if res.Y, err = e.node(t.Y); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.Assign:
+		res := &node{Kind: kindAssign}
+		if res.Op, err = encodeAssignKind(t.Kind); err != nil {
+			return nil, err
+		}
+
+		if res.Lhs, err = e.exprs(t.Lhs); err != nil {
+			return nil, err
+		}
+
+		if res.Rhs, err = e.exprs(t.Rhs); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.IfStmt:
+		res := &node{Kind: kindIfStmt}
+		if res.Init, err = e.optNode(t.Init); err != nil {
+			return nil, err
+		}
+
+		if res.Cond, err = e.node(t.Cond); err != nil {
+			return nil, err
+		}
+
+		if res.Body, err = e.optNode(t.Body); err != nil {
+			return nil, err
+		}
+
+		if res.Else, err = e.optNode(t.Else); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.ForStmt:
+		res := &node{Kind: kindForStmt}
+		if res.Init, err = e.optNode(t.Init); err != nil {
+			return nil, err
+		}
+
+		if res.Cond, err = e.optNode(t.Cond); err != nil {
+			return nil, err
+		}
+
+		if res.Post, err = e.optNode(t.Post); err != nil {
+			return nil, err
+		}
+
+		if res.Body, err = e.optNode(t.Body); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.RangeStmt:
+		res := &node{Kind: kindRangeStmt}
+		if res.Key, err = e.optNode(t.Key); err != nil {
+			return nil, err
+		}
+
+		if res.Val, err = e.optNode(t.Val); err != nil {
+			return nil, err
+		}
+
+		if res.X, err = e.optNode(t.X); err != nil {
+			return nil, err
+		}
+
+		if res.Body, err = e.optNode(t.Body); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.ReturnStmt:
+		res := &node{Kind: kindReturnStmt}
+		if res.Results, err = e.exprs(t.Results); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	case *ast.DeferStmt:
+		res := &node{Kind: kindDeferStmt}
+		if res.X, err = e.node(t.CallExpr); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unsupported node type: %s", reflect.TypeOf(n).String())
+	}
+}
+
+func (e *encoder) annotations(a []*ast.Annotation) ([]*node, error) {
+	var res []*node
+	for _, annotation := range a {
+		n, err := e.node(annotation)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (e *encoder) funcs(f []*ast.Func) ([]*node, error) {
+	var res []*node
+	for _, fun := range f {
+		n, err := e.node(fun)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode func '%s': %w", fun.FunName, err)
+		}
+
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (e *encoder) params(p []*ast.Param) ([]*node, error) {
+	var res []*node
+	for _, param := range p {
+		n, err := e.node(param)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (e *encoder) namedTypes(t []ast.NamedType) ([]*node, error) {
+	var res []*node
+	for _, namedType := range t {
+		n, err := e.node(namedType)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode type '%s': %w", namedType.Identifier(), err)
+		}
+
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (e *encoder) typeDecls(t []ast.TypeDecl) ([]*node, error) {
+	var res []*node
+	for _, decl := range t {
+		n, err := e.node(decl)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (e *encoder) macroParams(p ast.MacroParams) (map[string]*param, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+
+	// sort for a stable error behavior
+	var keys []string
+	for k := range p {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	res := map[string]*param{}
+	for _, k := range keys {
+		v := p[k]
+		if v == nil {
+			continue
+		}
+
+		var err error
+		switch t := v.(type) {
+		case string:
+			res[k] = &param{Type: paramString, String: t}
+		case bool:
+			res[k] = &param{Type: paramBool, Bool: t}
+		case int:
+			res[k] = &param{Type: paramInt, Int: t}
+		case []string:
+			res[k] = &param{Type: paramStrings, Strings: t}
+		case ast.Node:
+			if reflect.ValueOf(t).IsNil() {
+				continue
+			}
+
+			n, err := e.node(t)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode parameter '%s': %w", k, err)
+			}
+
+			res[k] = &param{Type: paramNode, Node: n}
+		case []ast.Node:
+			pr := &param{Type: paramNodes}
+			if pr.Nodes, err = e.nodes(t); err != nil {
+				return nil, fmt.Errorf("cannot encode parameter '%s': %w", k, err)
+			}
+
+			res[k] = pr
+		case ast.MacroParams:
+			pr := &param{Type: paramParams}
+			if pr.Params, err = e.macroParams(t); err != nil {
+				return nil, fmt.Errorf("cannot encode parameter '%s': %w", k, err)
+			}
+
+			res[k] = pr
+		case []ast.MacroParams:
+			pr := &param{Type: paramParamsList}
+			for _, params := range t {
+				m, err := e.macroParams(params)
+				if err != nil {
+					return nil, fmt.Errorf("cannot encode parameter '%s': %w", k, err)
+				}
+
+				pr.ParamsList = append(pr.ParamsList, m)
+			}
+
+			res[k] = pr
+		default:
+			return nil, fmt.Errorf("parameter '%s' has unsupported type %T", k, v)
+		}
+	}
+
+	return res, nil
+}
+
+func commentText(c *ast.Comment) *string {
+	if c == nil {
+		return nil
+	}
+
+	text := c.Text
+	return &text
+}
+
+func names(n []ast.Name) []string {
+	var res []string
+	for _, name := range n {
+		res = append(res, string(name))
+	}
+
+	return res
+}
@@ -0,0 +1,115 @@
+package encoding
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schema.json
+var schema []byte
+
+// Schema returns the JSON Schema (draft-07) of the serialization format. The YAML representation follows the
+// same structure.
+func Schema() []byte {
+	return append([]byte(nil), schema...)
+}
+
+// MarshalJSON encodes the given project into the versioned JSON format. Macros must have been created by a
+// registered factory (see ast.RegisterMacro), otherwise the project cannot be encoded.
+func MarshalJSON(prj *ast.Prj) ([]byte, error) {
+	doc, err := newDocument(prj)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// UnmarshalJSON decodes a project from the versioned JSON format. Macros are recreated by their registered
+// factories, so the according packages (e.g. stdlib/lang) must have been imported.
+func UnmarshalJSON(buf []byte) (*ast.Prj, error) {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+
+	doc := &document{}
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("cannot decode json: %w", err)
+	}
+
+	return doc.project()
+}
+
+// MarshalYAML encodes the given project into the versioned YAML format. See also MarshalJSON.
+func MarshalYAML(prj *ast.Prj) ([]byte, error) {
+	doc, err := newDocument(prj)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("cannot encode yaml: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("cannot encode yaml: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalYAML decodes a project from the versioned YAML format. See also UnmarshalJSON.
+func UnmarshalYAML(buf []byte) (*ast.Prj, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(buf))
+	dec.KnownFields(true)
+
+	doc := &document{}
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("cannot decode yaml: %w", err)
+	}
+
+	return doc.project()
+}
+
+func newDocument(prj *ast.Prj) (*document, error) {
+	e := &encoder{}
+	n, err := e.node(prj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document{Version: Version, Project: n}, nil
+}
+
+func (doc *document) project() (*ast.Prj, error) {
+	if doc.Version != Version {
+		return nil, fmt.Errorf("unsupported format version %d, expected %d", doc.Version, Version)
+	}
+
+	if doc.Project == nil {
+		return nil, fmt.Errorf("document contains no project")
+	}
+
+	d := &decoder{}
+	n, err := d.node(doc.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	prj, ok := n.(*ast.Prj)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected kind '%s' but found '%s'", posOf(doc.Project), kindPrj, doc.Project.Kind)
+	}
+
+	if err := d.resolveFactoryRefs(); err != nil {
+		return nil, err
+	}
+
+	return prj, nil
+}
@@ -0,0 +1,288 @@
+package encoding_test
+
+import (
+	"bytes"
+	"encoding/json"
+	. "github.com/golangee/src/ast"
+	"github.com/golangee/src/encoding"
+	"github.com/golangee/src/golang"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"github.com/golangee/src/stdlib/strings"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	testRoundTrip(t, encoding.MarshalJSON, encoding.UnmarshalJSON)
+}
+
+func TestYAML(t *testing.T) {
+	testRoundTrip(t, encoding.MarshalYAML, encoding.UnmarshalYAML)
+}
+
+func testRoundTrip(t *testing.T, marshal func(prj *Prj) ([]byte, error), unmarshal func(buf []byte) (*Prj, error)) {
+	t.Helper()
+
+	buf, err := marshal(newProject())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prj, err := unmarshal(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf2, err := marshal(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf, buf2) {
+		t.Fatalf("expected identical encoding after round trip:\n%s\n\nbut got\n%s", string(buf), string(buf2))
+	}
+
+	for i, mod := range newProject().Mods {
+		if !reflect.DeepEqual(mod.Target, prj.Mods[i].Target) {
+			t.Fatalf("expected target %+v after round trip but got %+v", mod.Target, prj.Mods[i].Target)
+		}
+	}
+
+	expected := renderProject(t, newProject())
+	actual := renderProject(t, prj)
+	if expected != actual {
+		t.Fatalf("expected identical source after round trip:\n%s\n\nbut got\n%s", expected, actual)
+	}
+}
+
+func TestUnmarshalVersion(t *testing.T) {
+	if _, err := encoding.UnmarshalJSON([]byte(`{"version":0,"project":{"kind":"Prj"}}`)); err == nil {
+		t.Fatal("expected unsupported version error")
+	}
+
+	if _, err := encoding.UnmarshalJSON([]byte(`{"version":1,"project":{"kind":"Prj","unknown":1}}`)); err == nil {
+		t.Fatal("expected unknown field error")
+	}
+}
+
+func TestMarshalUnregisteredMacro(t *testing.T) {
+	prj := NewPrj("prj").AddModules(NewMod("mod").AddPackages(NewPkg("mod").AddFiles(
+		NewFile("a.go").AddFuncs(NewFunc("A").SetBody(NewBlock(NewMacro()))),
+	)))
+
+	if _, err := encoding.MarshalJSON(prj); err == nil {
+		t.Fatal("expected error for macro without kind")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	var schema struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+		} `json:"definitions"`
+	}
+
+	if err := json.Unmarshal(encoding.Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	nodeDef, ok := schema.Definitions["node"]
+	if !ok {
+		t.Fatal("schema has no node definition")
+	}
+
+	kinds := append([]string(nil), nodeDef.Properties["kind"].Enum...)
+	expected := append([]string(nil), encoding.Kinds...)
+	sort.Strings(kinds)
+	sort.Strings(expected)
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("expected kinds %v but got %v", expected, kinds)
+	}
+
+	// all properties used by a full project must be declared
+	buf, err := encoding.MarshalJSON(newProject())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var check func(obj map[string]interface{})
+	check = func(obj map[string]interface{}) {
+		if _, isNode := obj["kind"]; isNode {
+			for key, val := range obj {
+				if _, ok := nodeDef.Properties[key]; !ok {
+					t.Fatalf("schema does not declare node property '%s'", key)
+				}
+
+				if key == "values" || key == "attributes" {
+					continue
+				}
+
+				walk(val, check)
+			}
+
+			return
+		}
+
+		for _, val := range obj {
+			walk(val, check)
+		}
+	}
+
+	walk(doc, check)
+}
+
+func walk(v interface{}, f func(obj map[string]interface{})) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		f(t)
+	case []interface{}:
+		for _, e := range t {
+			walk(e, f)
+		}
+	}
+}
+
+func renderProject(t *testing.T, prj *Prj) string {
+	t.Helper()
+
+	artifact, err := golang.NewRenderer(golang.Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, ok := artifact.(*render.Dir)
+	if !ok {
+		t.Fatalf("expected a directory but got %T", artifact)
+	}
+
+	return dir.String()
+}
+
+func newProject() *Prj {
+	notFound := lang.NewErrorCase("NotFound").
+		SetComment("...describes a missing entity.")
+
+	alreadyDeclared := lang.NewErrorCase("AlreadyDeclared").
+		SetComment("...describes a duplicate entity.").
+		AddProperty("id", NewSimpleTypeDecl(stdlib.UUID), "...is the affected id.")
+
+	myErr := lang.NewError("Entity").
+		SetComment("...is the sum type of all entity errors.").
+		AddCase(notFound).
+		AddCase(alreadyDeclared)
+
+	newHello := NewFunc("NewHello").
+		SetComment("...creates a new Hello.").
+		AddResults(NewParam("", NewTypeDeclPtr(NewSimpleTypeDecl("Hello")))).
+		SetBody(NewBlock(NewReturnStmt(NewUnaryExpr(lang.CreateLiteral("Hello"), OpAnd))))
+
+	return NewPrj("prj").
+		AddModules(
+			NewMod("example.com/mod").
+				SetLang(LangGo).
+				SetOutputDirectory("mod").
+				SetLangVersion(LangVersionGo16).
+				Require("github.com/golangee/sql v0.0.0-20210531101020-33021aed64c2").
+				AddPackages(
+					NewPkg("example.com/mod").
+						AddRawFiles(
+							NewRawFile("data.bin", "application/octet-stream", []byte{0xff, 0x00, 0xfe}),
+							NewRawTpl("makefile", "text/x-makefile", NewTpl("VERSION = '{{.Get \"v\"}}'\n").Put("v", 3)),
+						),
+					NewPkg("example.com/mod/api").
+						SetPreamble("Code generated. DO NOT EDIT.").
+						SetComment("...is the api package.").
+						AddFiles(
+							NewFile("api.go").
+								SetComment("...contains the api.").
+								AddNodes(NewImport("_", "github.com/go-sql-driver/mysql").SetComment("imported for side effects")).
+								AddTypes(
+									NewInterface("Greeter").
+										SetComment("...greets.").
+										AddPermits("Hello").
+										AddMethods(NewFunc("Greet").AddParams(NewParam("name", NewSimpleTypeDecl(stdlib.String)))),
+									NewStruct("Hello").
+										SetComment("...is a struct.").
+										SetDefaultRecName("h").
+										SetFinal(true).
+										SetRecord(true).
+										SetExtends("java.lang.Object").
+										AddFactoryRefs(newHello).
+										AddEmbedded(NewSimpleTypeDecl("sync.Mutex")).
+										AddFields(
+											NewField("Name", NewSimpleTypeDecl(stdlib.String)).
+												AddAnnotations(NewAnnotation("json").SetDefault("name")),
+											NewField("tags", NewMapDecl(NewSimpleTypeDecl(stdlib.String), NewSliceTypeDecl(NewSimpleTypeDecl(stdlib.Int)))).
+												SetVisibility(PackagePrivate),
+											NewField("done", NewChanTypeDecl(NewArrayTypeDecl(2, NewSimpleTypeDecl(stdlib.Bool)))).
+												SetVisibility(PackagePrivate).
+												SetDeprecated("closed channels are not reusable", "Hello.Name"),
+										).
+										AddMethods(
+											NewFunc("Greet").
+												SetRecName("h").
+												SetPtrReceiver(true).
+												AddParams(NewParam("names", NewSliceTypeDecl(NewSimpleTypeDecl(stdlib.String)))).
+												SetVariadic(true).
+												AddResults(NewParam("", NewSimpleTypeDecl(stdlib.Error))).
+												AddErrorCaseRefs(notFound).
+												SetBody(NewBlock(
+													lang.TryDefine(NewIdent("db"), lang.CallStatic("database/sql.Open", NewStrLit("mysql"), NewStrLit("")), "cannot open"),
+													NewDeferStmt(lang.CallIdent("db", "Close")),
+													lang.Term(),
+													NewForStmt(
+														NewSimpleAssign(NewIdent("i"), AssignDefine, NewIntLit(0)),
+														NewBinaryExpr(NewIdent("i"), OpLess, NewIntLit(10)),
+														NewUnaryExpr(NewIdent("i"), OpInc),
+														NewBlock(),
+													),
+													lang.Term(),
+													NewRangeStmt(nil, NewIdent("name"), NewIdent("names"), NewBlock(
+														NewIfStmt(NewBinaryExpr(NewIdent("name"), OpEqual, NewStrLit("")), NewBlock(
+															NewReturnStmt(notFound.Make()),
+														)),
+														lang.Term(),
+														strings.NewStrBuilder("sb", NewStrLit("hello "), NewIdent("name"), lang.Itoa(NewIntLit(1))),
+														lang.Call("println", lang.CallIdent("sb", "String")),
+														lang.Term(),
+													)),
+													lang.Term(),
+													NewTpl("fmt.Println({{.Get \"msg\"}})\n").Put("msg", `"done"`),
+													NewReturnStmt(alreadyDeclared.Make(NewIdent("nil"))),
+												)),
+										),
+								).
+								AddFuncs(
+									newHello,
+									NewFunc("check").
+										SetVisibility(PackagePrivate).
+										AddParams(NewParam("err", NewSimpleTypeDecl(stdlib.Error))).
+										SetBody(NewBlock(
+											alreadyDeclared.Check(lang.CheckCaseBehavior, "err", "matched", NewBlock(
+												lang.Panic("unexpected"),
+											)),
+										)),
+								).
+								AddNodes(
+									NewConstDecl(NewSimpleAssign(NewIdent("X"), AssignSimple, NewBasicLit(TokenString, "`x`")).SetComment("...is a constant.")),
+									NewVarDecl(NewAssign([]Expr{NewIdent("v")}, AssignSimple, []Expr{NewCompLit(NewSimpleTypeDecl("Hello"))})),
+								),
+							NewFile("errors.go").AddNodes(myErr.TypeDecl()),
+						),
+				),
+			NewMod("example.com/crate").
+				SetLang(LangRust).
+				SetOutputDirectory("crate").
+				Require(`serde = { version = "1", features = ["derive"] }`),
+		)
+}
@@ -0,0 +1,223 @@
+package encoding
+
+// Version denotes the current version of the serialization format. It is incremented on each incompatible change.
+const Version = 1
+
+// document is the root object of the serialization format.
+type document struct {
+	Version int   `json:"version" yaml:"version"`
+	Project *node `json:"project" yaml:"project"`
+}
+
+// pos is the serialized form of an ast.Pos.
+type pos struct {
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+	Line int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Col  int    `json:"col,omitempty" yaml:"col,omitempty"`
+}
+
+// target is the serialized form of an ast.Target.
+type target struct {
+	Out            string   `json:"out,omitempty" yaml:"out,omitempty"`
+	Arch           string   `json:"arch,omitempty" yaml:"arch,omitempty"`
+	Os             string   `json:"os,omitempty" yaml:"os,omitempty"`
+	Lang           string   `json:"lang,omitempty" yaml:"lang,omitempty"`
+	MinLangVersion string   `json:"minLangVersion,omitempty" yaml:"minLangVersion,omitempty"`
+	MaxLangVersion string   `json:"maxLangVersion,omitempty" yaml:"maxLangVersion,omitempty"`
+	Framework      string   `json:"framework,omitempty" yaml:"framework,omitempty"`
+	RequireGoMod   []string `json:"requireGoMod,omitempty" yaml:"requireGoMod,omitempty"`
+	RequireMaven   []string `json:"requireMaven,omitempty" yaml:"requireMaven,omitempty"`
+	RequireCargo   []string `json:"requireCargo,omitempty" yaml:"requireCargo,omitempty"`
+}
+
+// accessor is the serialized form of the read or write configuration of an ast.Property.
+type accessor struct {
+	Enabled    bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Visibility string `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+}
+
+// errorRef is the serialized form of an ast.ErrorRef.
+type errorRef struct {
+	Name    string `json:"name" yaml:"name"`
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+// deprecation is the serialized form of an ast.Deprecation.
+type deprecation struct {
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Replacement string `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// param is the serialized form of a single ast.MacroParams value. Type discriminates which field is used.
+type param struct {
+	Type       string              `json:"type" yaml:"type"`
+	String     string              `json:"string,omitempty" yaml:"string,omitempty"`
+	Bool       bool                `json:"bool,omitempty" yaml:"bool,omitempty"`
+	Int        int                 `json:"int,omitempty" yaml:"int,omitempty"`
+	Node       *node               `json:"node,omitempty" yaml:"node,omitempty"`
+	Nodes      []*node             `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Strings    []string            `json:"strings,omitempty" yaml:"strings,omitempty"`
+	Params     map[string]*param   `json:"params,omitempty" yaml:"params,omitempty"`
+	ParamsList []map[string]*param `json:"paramsList,omitempty" yaml:"paramsList,omitempty"`
+}
+
+// the discriminators of param.Type.
+const (
+	paramString     = "string"
+	paramBool       = "bool"
+	paramInt        = "int"
+	paramNode       = "node"
+	paramNodes      = "nodes"
+	paramStrings    = "strings"
+	paramParams     = "params"
+	paramParamsList = "paramsList"
+)
+
+// node is the serialized form of any ast.Node. Kind discriminates the actual type and which fields are used.
+// Fields are shared between kinds, if the semantic is similar, e.g. Name is used for all kinds of identifiers.
+type node struct {
+	Kind     string  `json:"kind" yaml:"kind"`
+	Pos      *pos    `json:"pos,omitempty" yaml:"pos,omitempty"`
+	End      *pos    `json:"end,omitempty" yaml:"end,omitempty"`
+	Comment  *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Preamble *string `json:"preamble,omitempty" yaml:"preamble,omitempty"`
+
+	Name       string  `json:"name,omitempty" yaml:"name,omitempty"`
+	Ident      string  `json:"ident,omitempty" yaml:"ident,omitempty"`
+	Path       string  `json:"path,omitempty" yaml:"path,omitempty"`
+	Qualifier  string  `json:"qualifier,omitempty" yaml:"qualifier,omitempty"`
+	Target     *target `json:"target,omitempty" yaml:"target,omitempty"`
+	Visibility string  `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+
+	Static      bool   `json:"static,omitempty" yaml:"static,omitempty"`
+	Final       bool   `json:"final,omitempty" yaml:"final,omitempty"`
+	Record      bool   `json:"record,omitempty" yaml:"record,omitempty"`
+	Variadic    bool   `json:"variadic,omitempty" yaml:"variadic,omitempty"`
+	PtrReceiver bool   `json:"ptrReceiver,omitempty" yaml:"ptrReceiver,omitempty"`
+	Ellipsis    bool   `json:"ellipsis,omitempty" yaml:"ellipsis,omitempty"`
+	RecName     string `json:"recName,omitempty" yaml:"recName,omitempty"`
+
+	DefaultRecName string   `json:"defaultRecName,omitempty" yaml:"defaultRecName,omitempty"`
+	Implements     []string `json:"implements,omitempty" yaml:"implements,omitempty"`
+	Extends        string   `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Permits        []string `json:"permits,omitempty" yaml:"permits,omitempty"`
+	FactoryRefs    []string `json:"factoryRefs,omitempty" yaml:"factoryRefs,omitempty"`
+	BaseType       string   `json:"baseType,omitempty" yaml:"baseType,omitempty"`
+
+	MimeType string                 `json:"mimeType,omitempty" yaml:"mimeType,omitempty"`
+	Text     *string                `json:"text,omitempty" yaml:"text,omitempty"`
+	Base64   string                 `json:"base64,omitempty" yaml:"base64,omitempty"`
+	Template *node                  `json:"template,omitempty" yaml:"template,omitempty"`
+	Values   map[string]interface{} `json:"values,omitempty" yaml:"values,omitempty"`
+
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+
+	Mods        []*node    `json:"mods,omitempty" yaml:"mods,omitempty"`
+	Pkgs        []*node    `json:"pkgs,omitempty" yaml:"pkgs,omitempty"`
+	Files       []*node    `json:"files,omitempty" yaml:"files,omitempty"`
+	RawFiles    []*node    `json:"rawFiles,omitempty" yaml:"rawFiles,omitempty"`
+	Nodes       []*node    `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Annotations []*node    `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Fields      []*node    `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Methods     []*node    `json:"methods,omitempty" yaml:"methods,omitempty"`
+	Types       []*node    `json:"types,omitempty" yaml:"types,omitempty"`
+	Embedded    []*node    `json:"embedded,omitempty" yaml:"embedded,omitempty"`
+	Params      []*node    `json:"params,omitempty" yaml:"params,omitempty"`
+	Results     []*node    `json:"results,omitempty" yaml:"results,omitempty"`
+	Cases       []*node    `json:"cases,omitempty" yaml:"cases,omitempty"`
+	ErrorRefs   []errorRef `json:"errorRefs,omitempty" yaml:"errorRefs,omitempty"`
+
+	Deprecated *deprecation `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+
+	Type       *node   `json:"type,omitempty" yaml:"type,omitempty"`
+	TypeParams []*node `json:"typeParams,omitempty" yaml:"typeParams,omitempty"`
+	Len        int     `json:"len,omitempty" yaml:"len,omitempty"`
+	Dir        string  `json:"dir,omitempty" yaml:"dir,omitempty"`
+	Bound      string  `json:"bound,omitempty" yaml:"bound,omitempty"`
+	Default    *node   `json:"default,omitempty" yaml:"default,omitempty"`
+	Value      *node   `json:"value,omitempty" yaml:"value,omitempty"`
+
+	Read  *accessor `json:"read,omitempty" yaml:"read,omitempty"`
+	Write *accessor `json:"write,omitempty" yaml:"write,omitempty"`
+
+	Body     *node   `json:"body,omitempty" yaml:"body,omitempty"`
+	X        *node   `json:"x,omitempty" yaml:"x,omitempty"`
+	Y        *node   `json:"y,omitempty" yaml:"y,omitempty"`
+	Sel      *node   `json:"sel,omitempty" yaml:"sel,omitempty"`
+	Fun      *node   `json:"fun,omitempty" yaml:"fun,omitempty"`
+	Args     []*node `json:"args,omitempty" yaml:"args,omitempty"`
+	Elements []*node `json:"elements,omitempty" yaml:"elements,omitempty"`
+	Lhs      []*node `json:"lhs,omitempty" yaml:"lhs,omitempty"`
+	Rhs      []*node `json:"rhs,omitempty" yaml:"rhs,omitempty"`
+	Op       string  `json:"op,omitempty" yaml:"op,omitempty"`
+	Tok      string  `json:"tok,omitempty" yaml:"tok,omitempty"`
+	Lit      string  `json:"lit,omitempty" yaml:"lit,omitempty"`
+	Sym      string  `json:"sym,omitempty" yaml:"sym,omitempty"`
+	Init     *node   `json:"init,omitempty" yaml:"init,omitempty"`
+	Cond     *node   `json:"cond,omitempty" yaml:"cond,omitempty"`
+	Post     *node   `json:"post,omitempty" yaml:"post,omitempty"`
+	Else     *node   `json:"else,omitempty" yaml:"else,omitempty"`
+	Key      *node   `json:"key,omitempty" yaml:"key,omitempty"`
+	Val      *node   `json:"val,omitempty" yaml:"val,omitempty"`
+
+	ID          string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Macro       string            `json:"macro,omitempty" yaml:"macro,omitempty"`
+	MacroParams map[string]*param `json:"macroParams,omitempty" yaml:"macroParams,omitempty"`
+}
+
+// the discriminators of node.Kind.
+const (
+	kindPrj             = "Prj"
+	kindMod             = "Mod"
+	kindPkg             = "Pkg"
+	kindFile            = "File"
+	kindRawFile         = "RawFile"
+	kindImport          = "Import"
+	kindStruct          = "Struct"
+	kindInterface       = "Interface"
+	kindEnum            = "Enum"
+	kindEnumCase        = "EnumCase"
+	kindFunc            = "Func"
+	kindField           = "Field"
+	kindParam           = "Param"
+	kindProperty        = "Property"
+	kindAnnotation      = "Annotation"
+	kindConstDecl       = "ConstDecl"
+	kindVarDecl         = "VarDecl"
+	kindSimpleTypeDecl  = "SimpleTypeDecl"
+	kindTypeDeclPtr     = "TypeDeclPtr"
+	kindGenericTypeDecl = "GenericTypeDecl"
+	kindNamedTypeDecl   = "NamedTypeDecl"
+	kindSliceTypeDecl   = "SliceTypeDecl"
+	kindArrayTypeDecl   = "ArrayTypeDecl"
+	kindChanTypeDecl    = "ChanTypeDecl"
+	kindFuncTypeDecl    = "FuncTypeDecl"
+	kindBlock           = "Block"
+	kindMacro           = "Macro"
+	kindTpl             = "Tpl"
+	kindSym             = "Sym"
+	kindIdent           = "Ident"
+	kindQualIdent       = "QualIdent"
+	kindSelExpr         = "SelExpr"
+	kindCallExpr        = "CallExpr"
+	kindBasicLit        = "BasicLit"
+	kindCompLit         = "CompLit"
+	kindUnaryExpr       = "UnaryExpr"
+	kindBinaryExpr      = "BinaryExpr"
+	kindAssign          = "Assign"
+	kindIfStmt          = "IfStmt"
+	kindForStmt         = "ForStmt"
+	kindRangeStmt       = "RangeStmt"
+	kindReturnStmt      = "ReturnStmt"
+	kindDeferStmt       = "DeferStmt"
+)
+
+// Kinds contains all node kinds of the serialization format.
+var Kinds = []string{
+	kindPrj, kindMod, kindPkg, kindFile, kindRawFile, kindImport, kindStruct, kindInterface, kindEnum, kindEnumCase,
+	kindFunc, kindField, kindParam, kindProperty, kindAnnotation, kindConstDecl, kindVarDecl, kindSimpleTypeDecl,
+	kindTypeDeclPtr, kindGenericTypeDecl, kindNamedTypeDecl, kindSliceTypeDecl, kindArrayTypeDecl, kindChanTypeDecl,
+	kindFuncTypeDecl, kindBlock, kindMacro, kindTpl, kindSym, kindIdent, kindQualIdent, kindSelExpr, kindCallExpr,
+	kindBasicLit, kindCompLit, kindUnaryExpr, kindBinaryExpr, kindAssign, kindIfStmt, kindForStmt, kindRangeStmt,
+	kindReturnStmt, kindDeferStmt,
+}
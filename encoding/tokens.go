@@ -0,0 +1,154 @@
+package encoding
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"go/token"
+)
+
+// operators contains all supported ast.Operator values. They are serialized using their go token representation.
+var operators = []ast.Operator{
+	ast.OpAdd, ast.OpSub, ast.OpMul, ast.OpQuo, ast.OpREM,
+	ast.OpAnd, ast.OpOr, ast.OpXOR, ast.OpShl, ast.OpShr, ast.OpAndNot, ast.OpNot,
+	ast.OpLAnd, ast.OpLOr, ast.OpArrow, ast.OpEqual, ast.OpLess, ast.OpGreater,
+	ast.OpInc, ast.OpDec,
+	ast.OpNotEqual, ast.OpLessEqual, ast.OpGreaterEqual,
+	ast.OpColon,
+}
+
+// assignKinds contains all supported ast.AssignKind values. They are serialized using their go token representation.
+var assignKinds = []ast.AssignKind{
+	ast.AssignSimple, ast.AssignDefine, ast.AssignAdd, ast.AssignSub, ast.AssignMul, ast.AssignRem,
+}
+
+// tokenKinds contains all supported ast.TokenKind values. They are serialized using their go token representation.
+var tokenKinds = []ast.TokenKind{
+	ast.TokenInt, ast.TokenFloat, ast.TokenImag, ast.TokenChar, ast.TokenString, ast.TokenIdent,
+}
+
+const (
+	symTermStmt = "term"
+	symNewline  = "newline"
+)
+
+func encodeOperator(op ast.Operator) (string, error) {
+	for _, o := range operators {
+		if o == op {
+			return token.Token(op).String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported operator: %d", op)
+}
+
+func decodeOperator(s string) (ast.Operator, error) {
+	for _, o := range operators {
+		if token.Token(o).String() == s {
+			return o, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported operator: '%s'", s)
+}
+
+func encodeAssignKind(k ast.AssignKind) (string, error) {
+	for _, o := range assignKinds {
+		if o == k {
+			return token.Token(k).String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported assignment: %d", k)
+}
+
+func decodeAssignKind(s string) (ast.AssignKind, error) {
+	for _, o := range assignKinds {
+		if token.Token(o).String() == s {
+			return o, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported assignment: '%s'", s)
+}
+
+func encodeTokenKind(k ast.TokenKind) (string, error) {
+	for _, o := range tokenKinds {
+		if o == k {
+			return token.Token(k).String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported literal kind: %d", k)
+}
+
+func decodeTokenKind(s string) (ast.TokenKind, error) {
+	for _, o := range tokenKinds {
+		if token.Token(o).String() == s {
+			return o, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported literal kind: '%s'", s)
+}
+
+func encodeSymKind(k ast.SymKind) (string, error) {
+	switch k {
+	case ast.SymTermStmt:
+		return symTermStmt, nil
+	case ast.SymNewline:
+		return symNewline, nil
+	default:
+		return "", fmt.Errorf("unsupported symbol: %d", k)
+	}
+}
+
+func decodeSymKind(s string) (ast.SymKind, error) {
+	switch s {
+	case symTermStmt:
+		return ast.SymTermStmt, nil
+	case symNewline:
+		return ast.SymNewline, nil
+	default:
+		return 0, fmt.Errorf("unsupported symbol: '%s'", s)
+	}
+}
+
+// encodeVisibility returns the empty string for the default visibility (public).
+func encodeVisibility(v ast.Visibility) string {
+	if v == ast.Public {
+		return ""
+	}
+
+	return v.String()
+}
+
+func decodeVisibility(s string) (ast.Visibility, error) {
+	if s == "" {
+		return ast.Public, nil
+	}
+
+	for _, v := range ast.Visibilities {
+		if v.String() == s {
+			return v, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported visibility: '%s'", s)
+}
+
+// encodeDeprecation returns nil, if the declaration is not deprecated.
+func encodeDeprecation(d *ast.Deprecation) *deprecation {
+	if d == nil {
+		return nil
+	}
+
+	return &deprecation{Reason: d.Reason, Replacement: string(d.Replacement)}
+}
+
+func decodeDeprecation(d *deprecation) *ast.Deprecation {
+	if d == nil {
+		return nil
+	}
+
+	return ast.NewDeprecation(d.Reason, ast.Name(d.Replacement))
+}
@@ -0,0 +1,166 @@
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"io"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// cacheFormat is a part of each cache key and must be incremented whenever the layout of the key changes.
+const cacheFormat = 6
+
+// develVersion is the module version of development builds, which does not identify the generator.
+const develVersion = "(devel)"
+
+// modulePath is used to detect the version of this generator from the build info.
+const modulePath = "github.com/golangee/src"
+
+var (
+	generatorVersionOnce sync.Once
+	generatorVersionStr  string
+)
+
+// generatorVersion returns the version and checksum of this module, as recorded by the go tool into the binary.
+// A development build, a replaced module without a version or a binary without build info has no meaningful
+// version, so the content hash of the executable is used instead, which changes with the renderer's own code.
+func generatorVersion() string {
+	generatorVersionOnce.Do(func() {
+		version, sum := moduleVersion()
+		if version == "" || version == develVersion || sum == "" {
+			version, sum = develVersion, executableHash()
+		}
+
+		generatorVersionStr = version + " " + sum
+	})
+
+	return generatorVersionStr
+}
+
+// moduleVersion returns the version and checksum of this module from the build info or empty strings.
+func moduleVersion() (version, sum string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+
+	if info.Main.Path == modulePath {
+		return info.Main.Version, info.Main.Sum
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+
+		return dep.Version, dep.Sum
+	}
+
+	return "", ""
+}
+
+// executableHash returns the hex encoded content hash of the running binary or "unknown".
+func executableHash() string {
+	fname, err := os.Executable()
+	if err != nil {
+		return "unknown"
+	}
+
+	file, err := os.Open(fname)
+	if err != nil {
+		return "unknown"
+	}
+
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheKey calculates the hex encoded content hash of the file, its package and module context, the names declared
+// by the package, the renderer options and the generator version. Resolving the key evaluates all macros of the file.
+func (r *Renderer) cacheKey(file *ast.File) string {
+	h := sha256.New()
+	pkg := file.Pkg()
+	fmt.Fprintf(h, "golang %d %q\n", cacheFormat, generatorVersion())
+
+	// the amount of workers and the cache location itself have no influence on the output
+	fmt.Fprintf(h, "%q %q\n", pkg.Path, pkg.Name)
+	mod := &ast.Mod{}
+	if ok := ast.ParentAs(file, &mod); ok {
+		fmt.Fprintf(h, "%q %+v\n", mod.Name, mod.Target)
+	}
+
+	// the imports depend on the names, which are declared by the sibling files
+	scope := declaredNames(pkg)
+	names := make([]string, 0, len(scope))
+	for name := range scope {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	fmt.Fprintf(h, "%q\n", names)
+
+	sum := ast.Hash(file)
+	h.Write(sum[:])
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// renderFileCached returns the formatted file from the cache or renders and stores it, if a cache is configured.
+// The source map, if enabled, is cached as a separate entry.
+func (r *Renderer) renderFileCached(file *ast.File) ([]byte, *SourceMap, error) {
+	if r.cache == nil {
+		return r.renderFile(file)
+	}
+
+	key := r.cacheKey(file)
+	if buf, ok := r.cache.Get(key); ok {
+		if r.marks == nil {
+			return buf, nil, nil
+		}
+
+		if mapBuf, ok := r.cache.Get(key + SourceMapSuffix); ok {
+			m := &SourceMap{}
+			if err := json.Unmarshal(mapBuf, m); err == nil {
+				return buf, m, nil
+			}
+		}
+	}
+
+	buf, m, err := r.renderFile(file)
+	if err != nil {
+		return buf, m, err
+	}
+
+	if err := r.cache.Put(key, buf); err != nil {
+		return buf, m, fmt.Errorf("cannot cache rendered file: %w", err)
+	}
+
+	if m != nil {
+		mapBuf, err := json.Marshal(m)
+		if err != nil {
+			return buf, m, fmt.Errorf("cannot encode source map: %w", err)
+		}
+
+		if err := r.cache.Put(key+SourceMapSuffix, mapBuf); err != nil {
+			return buf, m, fmt.Errorf("cannot cache source map: %w", err)
+		}
+	}
+
+	return buf, m, nil
+}
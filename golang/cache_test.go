@@ -0,0 +1,20 @@
+package golang
+
+import "testing"
+
+func TestGeneratorVersion(t *testing.T) {
+	version, _ := moduleVersion()
+	if version != "" && version != develVersion {
+		t.Skipf("not a development build: %s", version)
+	}
+
+	// a test binary is always a development build, so the key must depend on the renderer's own code
+	hash := executableHash()
+	if hash == "unknown" || len(hash) != 64 {
+		t.Fatalf("expected a sha256 of the executable but got %q", hash)
+	}
+
+	if got, want := generatorVersion(), develVersion+" "+hash; got != want {
+		t.Fatalf("generatorVersion() = %q, want %q", got, want)
+	}
+}
@@ -1,36 +1,77 @@
 package golang
 
 import (
+	"fmt"
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/render"
 	"strings"
 )
 
-// formatComment replaces a '...' prefix with the ellipsisName and prefixes all lines
-// with a '// '. If doc is empty, the empty string is returned.
+// docWidth is the width at which the lines of a doc comment are wrapped, including the comment prefix.
+const docWidth = 80
+
+// formatComment replaces a '...' prefix with the ellipsisName and emits the structured content of the doc as a
+// godoc comment, whose lines are prefixed with a '// '. Paragraphs keep their line breaks, but lines which are
+// longer than docWidth are wrapped. If doc is empty, the empty string is returned.
 func formatComment(ellipsisName, doc string) string {
-	doc = strings.TrimSpace(doc)
-	if len(doc) > 0 {
-		tmp := &strings.Builder{}
-		if strings.HasPrefix(doc, "...") {
-			tmp.WriteString(ellipsisName)
-			tmp.WriteString(" ")
-			tmp.WriteString(strings.TrimSpace(doc[3:]))
-		} else {
-			tmp.WriteString(doc)
-		}
-		str := tmp.String()
-		tmp.Reset()
-		for _, line := range strings.Split(str, "\n") {
-			tmp.WriteString("// ")
-			tmp.WriteString(line)
-			tmp.WriteString("\n")
+	doc = strings.TrimLeft(strings.TrimRight(doc, " \t\n"), "\n")
+	if strings.TrimSpace(doc) == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(doc), "...") {
+		doc = strings.TrimSpace(ellipsisName + " " + strings.TrimSpace(strings.TrimSpace(doc)[3:]))
+	}
+
+	tmp := &strings.Builder{}
+	for i, block := range ast.ParseDoc(doc) {
+		if i > 0 {
+			tmp.WriteString("//\n")
 		}
 
-		return tmp.String()
+		switch block.Kind {
+		case ast.DocParagraph:
+			writeDocLines(tmp, "", "", block.Text)
+		case ast.DocDeprecated:
+			writeDocLines(tmp, "", "", ast.DeprecatedPrefix+" "+block.Text)
+		case ast.DocList:
+			for j, item := range block.Items {
+				marker := "  - "
+				if block.Numbered {
+					marker = fmt.Sprintf(" %d. ", j+1)
+				}
+
+				writeDocLines(tmp, marker, "    ", item)
+			}
+		case ast.DocCode:
+			for _, line := range strings.Split(block.Text, "\n") {
+				if line == "" {
+					tmp.WriteString("//\n")
+					continue
+				}
+
+				tmp.WriteString("//\t")
+				tmp.WriteString(line)
+				tmp.WriteString("\n")
+			}
+		}
 	}
 
-	return ""
+	return tmp.String()
+}
+
+// writeDocLines wraps each line of text and emits it with the first prefix, all following lines are indented.
+func writeDocLines(w *strings.Builder, first, indent, text string) {
+	prefix := first
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range render.WrapLine(line, docWidth-len("// ")-len(prefix)) {
+			w.WriteString("// ")
+			w.WriteString(prefix)
+			w.WriteString(wrapped)
+			w.WriteString("\n")
+			prefix = indent
+		}
+	}
 }
 
 // DeEllipsis replaces a ... with the according text.
@@ -55,6 +96,16 @@ func (r *Renderer) writeCommentNode(w *render.BufferedWriter, isPkg bool, name s
 	r.writeComment(w, isPkg, name, comment.Text)
 }
 
+// writeDeclComment emits the comment of a declaration together with its deprecation notice.
+func (r *Renderer) writeDeclComment(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation) {
+	text := ""
+	if comment != nil {
+		text = comment.Text
+	}
+
+	r.writeComment(w, false, name, ast.DocWithDeprecation(text, deprecation))
+}
+
 func (r *Renderer) writeComment(w *render.BufferedWriter, isPkg bool, name, doc string) {
 	if isPkg {
 		name = "Package " + name
@@ -0,0 +1,57 @@
+package golang
+
+import (
+	"github.com/golangee/src/ast"
+	"testing"
+)
+
+func TestFormatComment(t *testing.T) {
+	doc := ast.NewComment("...greets the world and is a very long sentence, which must be wrapped at the end of the line.").
+		AddParagraph("The steps are:").
+		AddNumberedList("say hello", "wave").
+		AddParagraph("Links:").
+		AddList("see also "+ast.DocLink("fmt.Println"), "and [io.Writer]").
+		AddParagraph("Example:").
+		AddCode("func main() {\n\tHello()\n}").
+		AddDeprecated("use [Greet] instead.")
+
+	want := `// Hello greets the world and is a very long sentence, which must be wrapped at
+// the end of the line.
+//
+// The steps are:
+//
+//  1. say hello
+//  2. wave
+//
+// Links:
+//
+//   - see also [fmt.Println]
+//   - and [io.Writer]
+//
+// Example:
+//
+//	func main() {
+//		Hello()
+//	}
+//
+// Deprecated: use [Greet] instead.
+`
+
+	if got := formatComment("Hello", doc.Text); got != want {
+		t.Fatalf("formatComment() = \n%s\nwant\n%s", got, want)
+	}
+
+	// the output must already be in the canonical gofmt doc comment form
+	src := want + "func Hello() {}\n"
+	if formatted, err := Format([]byte(src)); err != nil || string(formatted) != src {
+		t.Fatalf("expected canonical doc comment but got %v\n%s", err, formatted)
+	}
+
+	if got := formatComment("x", "...is a constant."); got != "// x is a constant.\n" {
+		t.Fatalf("unexpected ellipsis: %q", got)
+	}
+
+	if got := formatComment("", "  \n"); got != "" {
+		t.Fatalf("expected empty comment but got %q", got)
+	}
+}
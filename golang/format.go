@@ -1,6 +1,7 @@
 package golang
 
 import (
+	"github.com/golangee/src/naming"
 	"github.com/golangee/src/render"
 	"go/format"
 	"strings"
@@ -18,36 +19,20 @@ func Format(source []byte) ([]byte, error) {
 	return buf, nil
 }
 
-// MakePrivate converts ABc to aBc.
+// MakePrivate converts ABc to aBc and fixes the case of initialisms, see naming.Go.
 // Special cases:
 //  * ID becomes id
+//  * URLPath becomes urlPath
 func MakePrivate(str string) string {
-	if len(str) == 0 {
-		return str
-	}
-
-	switch str {
-	case "ID":
-		return "id"
-	default:
-		return string(unicode.ToLower(rune(str[0]))) + str[1:]
-	}
+	return naming.Go.Private(str)
 }
 
-// MakePublic converts aBc to ABc.
+// MakePublic converts aBc to ABc and fixes the case of initialisms, see naming.Go.
 // Special cases:
 //  * id becomes ID
+//  * httpClient becomes HTTPClient
 func MakePublic(str string) string {
-	if len(str) == 0 {
-		return str
-	}
-
-	switch str {
-	case "id":
-		return "ID"
-	default:
-		return string(unicode.ToUpper(rune(str[0]))) + str[1:]
-	}
+	return naming.Go.Public(str)
 }
 
 // MakeIdentifier creates a public name out of the given string. If it just contains rubbish, at worst the empty
@@ -5,19 +5,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"sync/atomic"
 )
 
-// An importerKey declares a new type to secure private map access.
-type importerKey int32
-
-// lastImporterKey is a global thread-safe counter.
-var lastImporterKey int32
-
-func nextImporterKey() importerKey {
-	return importerKey(atomic.AddInt32(&lastImporterKey, 1))
-}
-
 // importer manages the rendered import section at the files top.
 type importer struct {
 	selfImportPath string
@@ -32,13 +21,15 @@ func newImporter(selfImportPath string) *importer {
 	}
 }
 
-// installImporter installs a new importer instance into every ast.SrcFileNode.
+// installImporter allocates a new importer instance for every ast.File. The importers are owned by the renderer
+// and never attached to the ast, so that files can be rendered concurrently and the ast is not modified.
+// The map is not modified while rendering, so concurrent lookups are safe.
 func installImporter(r *Renderer) error {
-	r.importerId = nextImporterKey()
+	r.importers = map[*ast.File]*importer{}
 	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
 		for _, pkg := range mod.Pkgs {
 			for _, file := range pkg.PkgFiles {
-				file.PutValue(r.importerId, newImporter(pkg.Path))
+				r.importers[file] = newImporter(pkg.Path)
 			}
 		}
 
@@ -46,21 +37,20 @@ func installImporter(r *Renderer) error {
 	})
 }
 
-// installImporter overwrites all registered importers with a nil value.
+// uninstallImporter releases all importers.
 func uninstallImporter(r *Renderer) error {
-	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
-		mod.PutValue(r.importerId, nil)
-
-		return nil
-	})
+	r.importers = nil
+	return nil
 }
 
-// importerFromTree walks up the tree until it finds the first importer from any ast.Node.Value.
+// importerFromTree walks up the tree until it finds the first file with an importer.
 func importerFromTree(r *Renderer, n ast.Node) *importer {
 	root := n
 	for root != nil {
-		if imp, ok := root.Value(r.importerId).(*importer); ok {
-			return imp
+		if file, ok := root.(*ast.File); ok {
+			if imp, ok := r.importers[file]; ok {
+				return imp
+			}
 		}
 
 		newRoot := root.Parent()
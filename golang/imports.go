@@ -0,0 +1,412 @@
+package golang
+
+import (
+	"bytes"
+	"github.com/golangee/src/ast"
+	goast "go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fixImports reconciles the import declarations of the (unformatted) source with the actually used qualifiers,
+// in the spirit of goimports but offline, only based on the local GOROOT:
+//   - imports which are not referenced anymore are dropped, except blank, dot and cgo imports. Only imports with a
+//     known name, i.e. GOROOT packages or explicitly named imports, are dropped, because the name of any other
+//     package may differ from its path, e.g. gopkg.in/yaml.v3 or a go-foo path which declares package foo.
+//   - unresolved qualifiers like fmt in fmt.Println are imported, if exactly one GOROOT package with that name
+//     exports all referenced identifiers. Ambiguous candidates are resolved by preferring the shortest path.
+//
+// Qualifiers which are declared by the package itself (pkgScope) are never imported. If the source cannot be
+// parsed, it is returned as is, so that the formatter can report the error.
+func fixImports(src []byte, pkgScope map[string]bool) []byte {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return src
+	}
+
+	refs := qualifierRefs(file)
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+
+	var edits []edit
+	imported := map[string]bool{}
+	var lastImportDecl *goast.GenDecl
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*goast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		lastImportDecl = gen
+		var unused []*goast.ImportSpec
+		for _, spec := range gen.Specs {
+			imp := spec.(*goast.ImportSpec)
+			name, known := importName(imp)
+			imported[name] = true
+			if !known || name == "_" || name == "." || name == "C" || refs[name] != nil {
+				continue
+			}
+
+			unused = append(unused, imp)
+		}
+
+		if len(unused) == 0 {
+			continue
+		}
+
+		if len(unused) == len(gen.Specs) {
+			edits = append(edits, edit{start: fset.Position(gen.Pos()).Offset, end: lineEnd(src, fset.Position(gen.End()).Offset)})
+			if lastImportDecl == gen {
+				lastImportDecl = nil
+			}
+
+			continue
+		}
+
+		for _, imp := range unused {
+			start, end := imp.Pos(), imp.End()
+			if imp.Doc != nil {
+				start = imp.Doc.Pos()
+			}
+
+			if imp.Comment != nil {
+				end = imp.Comment.End()
+			}
+
+			edits = append(edits, edit{start: fset.Position(start).Offset, end: lineEnd(src, fset.Position(end).Offset)})
+		}
+	}
+
+	var missing []string
+	for name, selectors := range refs {
+		if imported[name] || pkgScope[name] {
+			continue
+		}
+
+		if importPath := stdIndex().lookup(name, selectors); importPath != "" {
+			missing = append(missing, importPath)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		sb := &strings.Builder{}
+		for _, importPath := range missing {
+			sb.WriteString(strconv.Quote(importPath))
+			sb.WriteString("\n")
+		}
+
+		if lastImportDecl != nil && lastImportDecl.Rparen.IsValid() {
+			pos := fset.Position(lastImportDecl.Rparen).Offset
+			edits = append(edits, edit{start: pos, end: pos, text: sb.String()})
+		} else {
+			pos := fset.Position(file.Name.End()).Offset
+			edits = append(edits, edit{start: pos, end: pos, text: "\nimport (\n" + sb.String() + ")\n"})
+		}
+	}
+
+	if len(edits) == 0 {
+		return src
+	}
+
+	// apply from back to front, so that the offsets stay valid
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+
+	res := append([]byte{}, src...)
+	for _, e := range edits {
+		res = append(res[:e.start], append([]byte(e.text), res[e.end:]...)...)
+	}
+
+	return res
+}
+
+// qualifierRefs returns all identifiers which are used as qualifier of a selector expression and are not
+// resolved within the file, together with the selected identifiers, e.g. fmt => [Println, Sprintf].
+func qualifierRefs(file *goast.File) map[string][]string {
+	refs := map[string][]string{}
+	goast.Inspect(file, func(node goast.Node) bool {
+		sel, ok := node.(*goast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if x, ok := sel.X.(*goast.Ident); ok && x.Obj == nil && x.Name != "_" {
+			refs[x.Name] = append(refs[x.Name], sel.Sel.Name)
+		}
+
+		return true
+	})
+
+	return refs
+}
+
+// importName returns the explicit name or the package name of a GOROOT import, which are known. Otherwise, the
+// assumed package name is returned, which is just a guess.
+func importName(imp *goast.ImportSpec) (string, bool) {
+	if imp.Name != nil {
+		return imp.Name.Name, true
+	}
+
+	importPath, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		return "", false
+	}
+
+	if name := stdIndex().name(importPath); name != "" {
+		return name, true
+	}
+
+	return assumedPackageName(importPath), false
+}
+
+// assumedPackageName guesses the package name from the import path, just like goimports does, e.g.
+// github.com/go-sql-driver/mysql => mysql, gopkg.in/yaml.v3 => yaml or example.com/api/v2 => api.
+func assumedPackageName(importPath string) string {
+	segments := strings.Split(importPath, "/")
+	name := segments[len(segments)-1]
+	if len(segments) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = segments[len(segments)-2]
+	}
+
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexAny(name, ".-"); i > 0 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// lineEnd returns the offset after the line break, if only white space follows offset on its line.
+func lineEnd(src []byte, offset int) int {
+	rest := src[offset:]
+	if i := bytes.IndexByte(rest, '\n'); i >= 0 && len(bytes.TrimSpace(rest[:i])) == 0 {
+		return offset + i + 1
+	}
+
+	return offset
+}
+
+// stdPkgIndex knows the names of all GOROOT packages and loads their exported identifiers on demand.
+// It is safe for concurrent use.
+type stdPkgIndex struct {
+	root    string
+	byName  map[string][]string // package name => import paths
+	names   map[string]string   // import path => package name
+	mutex   sync.Mutex
+	exports map[string]map[string]bool // import path => exported identifiers
+}
+
+var (
+	stdIndexOnce sync.Once
+	stdIndexInst *stdPkgIndex
+)
+
+// stdIndex returns the lazily created index of the local GOROOT.
+func stdIndex() *stdPkgIndex {
+	stdIndexOnce.Do(func() {
+		stdIndexInst = newStdPkgIndex(filepath.Join(build.Default.GOROOT, "src"))
+	})
+
+	return stdIndexInst
+}
+
+func newStdPkgIndex(root string) *stdPkgIndex {
+	idx := &stdPkgIndex{
+		root:    root,
+		byName:  map[string][]string{},
+		names:   map[string]string{},
+		exports: map[string]map[string]bool{},
+	}
+
+	_ = filepath.WalkDir(root, func(fname string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, fname)
+		if err != nil || rel == "." {
+			return nil
+		}
+
+		importPath := filepath.ToSlash(rel)
+		switch d.Name() {
+		case "testdata", "vendor", "internal":
+			return filepath.SkipDir
+		}
+
+		if importPath == "cmd" || strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_") {
+			return filepath.SkipDir
+		}
+
+		if name := packageName(fname); name != "" && name != "main" {
+			idx.names[importPath] = name
+			idx.byName[name] = append(idx.byName[name], importPath)
+		}
+
+		return nil
+	})
+
+	for _, paths := range idx.byName {
+		sort.Slice(paths, func(i, j int) bool {
+			if len(paths[i]) != len(paths[j]) {
+				return len(paths[i]) < len(paths[j])
+			}
+
+			return paths[i] < paths[j]
+		})
+	}
+
+	return idx
+}
+
+// name returns the package name of the GOROOT import path or the empty string.
+func (p *stdPkgIndex) name(importPath string) string {
+	return p.names[importPath]
+}
+
+// lookup returns the shortest import path of a package with the given name, which exports all selectors.
+func (p *stdPkgIndex) lookup(name string, selectors []string) string {
+	for _, importPath := range p.byName[name] {
+		exports := p.exportsOf(importPath)
+		found := true
+		for _, sel := range selectors {
+			if !exports[sel] {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			return importPath
+		}
+	}
+
+	return ""
+}
+
+func (p *stdPkgIndex) exportsOf(importPath string) map[string]bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if exports, ok := p.exports[importPath]; ok {
+		return exports
+	}
+
+	exports := map[string]bool{}
+	dir := filepath.Join(p.root, filepath.FromSlash(importPath))
+	for _, fname := range goFiles(dir) {
+		file, err := parser.ParseFile(token.NewFileSet(), fname, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *goast.FuncDecl:
+				if d.Recv == nil && d.Name.IsExported() {
+					exports[d.Name.Name] = true
+				}
+			case *goast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *goast.TypeSpec:
+						if s.Name.IsExported() {
+							exports[s.Name.Name] = true
+						}
+					case *goast.ValueSpec:
+						for _, n := range s.Names {
+							if n.IsExported() {
+								exports[n.Name] = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	p.exports[importPath] = exports
+
+	return exports
+}
+
+// packageName returns the package name of the first non-test go file within dir.
+func packageName(dir string) string {
+	for _, fname := range goFiles(dir) {
+		file, err := parser.ParseFile(token.NewFileSet(), fname, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return file.Name.Name
+		}
+	}
+
+	return ""
+}
+
+// goFiles returns the non-test go files of the directory.
+func goFiles(dir string) []string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var res []string
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || path.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		res = append(res, filepath.Join(dir, name))
+	}
+
+	return res
+}
+
+// declaredNames returns the identifiers of all top level declarations of the package, including the nodes which
+// are produced by macros. Those names are resolved by the compiler across files and are never import qualifiers.
+func declaredNames(pkg *ast.Pkg) map[string]bool {
+	res := map[string]bool{}
+	for _, file := range pkg.PkgFiles {
+		collectNames(res, file.Nodes)
+	}
+
+	return res
+}
+
+func collectNames(dst map[string]bool, nodes []ast.Node) {
+	for _, node := range nodes {
+		if named, ok := node.(interface{ Identifier() string }); ok {
+			dst[named.Identifier()] = true
+		}
+
+		switch t := node.(type) {
+		case *ast.Macro:
+			collectNames(dst, t.Children())
+		case *ast.ConstDecl:
+			collectNames(dst, t.Children())
+		case *ast.VarDecl:
+			collectNames(dst, t.Children())
+		case *ast.Assign:
+			for _, lhs := range t.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					dst[ident.Name] = true
+				}
+			}
+		}
+	}
+}
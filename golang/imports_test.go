@@ -0,0 +1,91 @@
+package golang
+
+import (
+	"go/format"
+	"testing"
+)
+
+func TestFixImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		declared map[string]bool
+		want     string
+	}{
+		{
+			name: "add missing",
+			src:  "package a\nfunc f() { fmt.Println(strings.ToUpper(\"x\")) }",
+			want: "package a\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\nfunc f() { fmt.Println(strings.ToUpper(\"x\")) }\n",
+		},
+		{
+			name: "drop unused",
+			src:  "package a\nimport (\n\t\"fmt\"\n\t\"strings\" // unused\n)\nfunc f() { fmt.Println() }",
+			want: "package a\n\nimport (\n\t\"fmt\"\n)\n\nfunc f() { fmt.Println() }\n",
+		},
+		{
+			name: "drop entire decl",
+			src:  "package a\nimport \"fmt\"\nfunc f() {}",
+			want: "package a\n\nfunc f() {}\n",
+		},
+		{
+			name: "keep side effects and named",
+			src:  "package a\nimport (\n\t_ \"embed\"\n\tfoo \"strings\"\n\tyaml \"gopkg.in/yaml.v3\"\n)\nfunc f() { foo.ToUpper(yaml.X) }",
+			want: "package a\n\nimport (\n\t_ \"embed\"\n\tyaml \"gopkg.in/yaml.v3\"\n\tfoo \"strings\"\n)\n\nfunc f() { foo.ToUpper(yaml.X) }\n",
+		},
+		{
+			name: "keep unknown package names",
+			src:  "package a\nimport (\n\t\"fmt\"\n\t\"gopkg.in/yaml.v3\"\n\t\"example.com/go-foo\"\n\tbar \"example.com/bar\"\n)\nfunc f() { yaml.X(); foolib.Y() }",
+			want: "package a\n\nimport (\n\t\"example.com/go-foo\"\n\t\"gopkg.in/yaml.v3\"\n)\n\nfunc f() { yaml.X(); foolib.Y() }\n",
+		},
+		{
+			name: "prefer exporting package",
+			src:  "package a\nimport (\n)\nfunc f() { rand.Int(rand.Reader, nil) }",
+			want: "package a\n\nimport (\n\t\"crypto/rand\"\n)\n\nfunc f() { rand.Int(rand.Reader, nil) }\n",
+		},
+		{
+			name:     "ignore locals and package scope",
+			src:      "package a\nfunc f(fmt x) { fmt.Println(); sort.X(); bytes.Buffer() }",
+			declared: map[string]bool{"bytes": true},
+			want:     "package a\n\nfunc f(fmt x) { fmt.Println(); sort.X(); bytes.Buffer() }\n",
+		},
+		{
+			name: "syntax error",
+			src:  "package a\nfunc f() { fmt.Println( }",
+			want: "package a\nfunc f() { fmt.Println( }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fixImports([]byte(tt.src), tt.declared)
+			if formatted, err := format.Source(got); err == nil {
+				got = formatted
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("fixImports() = \n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssumedPackageName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"github.com/go-sql-driver/mysql", "mysql"},
+		{"gopkg.in/yaml.v3", "yaml"},
+		{"example.com/api/v2", "api"},
+		{"github.com/mattn/go-sqlite3", "sqlite3"},
+		{"github.com/golangee/src", "src"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := assumedPackageName(tt.path); got != tt.want {
+				t.Errorf("assumedPackageName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/render"
+	"runtime"
 )
 
 const (
@@ -18,23 +19,62 @@ const (
 
 // Options for the renderer.
 type Options struct {
+	// Workers is the amount of packages which are rendered concurrently. If zero or negative,
+	// runtime.GOMAXPROCS is used. Set it to 1 to render strictly sequentially.
+	Workers int
+
+	// CacheDir enables the reuse of rendered and formatted files from previous runs, if not empty. Each file is
+	// addressed by a content hash of the file, its context, the options and the generator version.
+	CacheDir string
+
+	// TypeCheck enables a type check of all rendered packages with go/types. Imports are resolved from the
+	// rendered packages and the local GOROOT, other packages are not available offline and are not checked.
+	// Problems are returned as TypeErrors, which refer to the originating model nodes.
+	TypeCheck bool
+
+	// SourceMap enables a source map for each rendered Go file, which is emitted next to it with the
+	// SourceMapSuffix. It maps the generated ranges to the model nodes and can be used with ResolvePosition to
+	// translate compiler errors or stack traces into model positions.
+	SourceMap bool
 }
 
 // Renderer provides a go renderer.
 type Renderer struct {
-	opts       Options
-	root       ast.Node
-	importerId importerKey // track our unique key, to perform cleanup
+	opts      Options
+	root      ast.Node
+	importers map[*ast.File]*importer // each file has its own importer, which is only used by a single worker
+	cache     *render.Cache           // nil, if disabled
+	rendered  []*renderedPkg          // all rendered packages of the current Render call, in declaration order
+	marks     *sourceMarks            // nil, if source maps are disabled
 }
 
 // NewRenderer creates a new Renderer instance.
 func NewRenderer(opts Options) *Renderer {
-	return &Renderer{opts: opts}
+	r := &Renderer{opts: opts}
+	if opts.CacheDir != "" {
+		r.cache = render.NewCache(opts.CacheDir)
+	}
+
+	return r
+}
+
+// workers returns the effective amount of concurrent package renderers.
+func (r *Renderer) workers() int {
+	if r.opts.Workers > 0 {
+		return r.opts.Workers
+	}
+
+	return runtime.GOMAXPROCS(0)
 }
 
 // tearUp prepares the ast to be used for source generation.
 func (r *Renderer) tearUp(node ast.Node) error {
 	r.root = ast.Root(node)
+	r.rendered = nil
+	r.marks = nil
+	if r.opts.SourceMap {
+		r.marks = &sourceMarks{}
+	}
 
 	if err := installImporter(r); err != nil {
 		return fmt.Errorf("unable to install importer: %w", err)
@@ -49,6 +89,9 @@ func (r *Renderer) tearDown() error {
 		return fmt.Errorf("unable to uninstall importer: %w", err)
 	}
 
+	r.rendered = nil
+	r.marks = nil
+
 	return nil
 }
 
@@ -57,8 +100,20 @@ func (r *Renderer) importer(n ast.Node) *importer {
 	return importerFromTree(r, n)
 }
 
+func init() {
+	render.Register(ast.LangGo, ast.FrameworkSDK, func() render.Renderer {
+		return NewRenderer(Options{})
+	})
+}
+
 // Render converts the given node into a render.Artifact. A partial result is returned if an error is detected.
+// If node is an *ast.Mod, only that module is rendered and it must target ast.LangGo. Otherwise all Go modules
+// of the project are rendered and other modules are ignored. Use render.Project to render mixed projects.
 func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
+	if mod, ok := node.(*ast.Mod); ok && mod.Target.Lang != ast.LangGo {
+		return nil, fmt.Errorf("cannot render module '%s': expected language '%s' but got '%s'", mod.Name, ast.LangGo, mod.Target.Lang)
+	}
+
 	if err := r.tearUp(node); err != nil {
 		return nil, fmt.Errorf("unable to tearUp: %w", err)
 	}
@@ -70,6 +125,18 @@ func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
 	}()
 
 	root := &render.Dir{}
+	if mod, ok := node.(*ast.Mod); ok {
+		if _, err = r.renderMod(mod, root); err != nil {
+			return root, err
+		}
+
+		if r.opts.TypeCheck {
+			err = r.typeCheck()
+		}
+
+		return root, err
+	}
+
 	err = ast.ForEachMod(node, func(mod *ast.Mod) error {
 		if mod.Target.Lang == ast.LangGo {
 			_, err := r.renderMod(mod, root)
@@ -86,5 +153,11 @@ func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
 		return root, fmt.Errorf("cannot render project: %w", err)
 	}
 
+	if r.opts.TypeCheck {
+		if err := r.typeCheck(); err != nil {
+			return root, err
+		}
+	}
+
 	return root, nil
 }
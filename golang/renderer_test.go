@@ -4,10 +4,17 @@ import (
 	"fmt"
 	. "github.com/golangee/src/ast"
 	"github.com/golangee/src/golang"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
 	"github.com/golangee/src/stdlib"
 	fmt2 "github.com/golangee/src/stdlib/fmt"
 	"github.com/golangee/src/stdlib/lang"
 	"github.com/golangee/src/stdlib/strings"
+	"go/token"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	strings2 "strings"
 	"testing"
 )
 
@@ -20,7 +27,7 @@ func TestRenderer_Render(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	fmt.Println(artifact)
+	srctest.Compare(t, srctest.DefaultGoldenDir, artifact)
 }
 
 func testError() *File {
@@ -254,3 +261,616 @@ VERSION = '{{.Get "check"}}'
 
 		)
 }
+
+// newLargeProject creates a synthetic project with the given amount of packages and files per package. Each
+// package also declares an error macro, so that macro evaluation is covered.
+func newLargeProject(pkgCount, filesPerPkg int) *Prj {
+	mod := NewMod("github.com/myproject/large").
+		SetLang(LangGo).
+		SetOutputDirectory("large").
+		SetLangVersion(LangVersionGo16)
+
+	for p := 0; p < pkgCount; p++ {
+		pkg := NewPkg(fmt.Sprintf("github.com/myproject/large/pkg%d", p))
+		for f := 0; f < filesPerPkg; f++ {
+			pkg.AddFiles(
+				NewFile(fmt.Sprintf("file%d.go", f)).
+					AddTypes(
+						NewStruct(fmt.Sprintf("Entity%d", f)).
+							SetComment("...is a generated entity.").
+							AddFields(
+								NewField("ID", NewSimpleTypeDecl(stdlib.UUID)),
+								NewField("Name", NewSimpleTypeDecl(stdlib.String)),
+								NewField("Created", NewSimpleTypeDecl(stdlib.Time)),
+							).
+							AddMethods(
+								NewFunc("String").
+									AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String))).
+									SetBody(NewBlock(NewReturnStmt(lang.CallStatic("fmt.Sprint", NewIdent("e"))))).
+									SetRecName("e"),
+							),
+					),
+			)
+		}
+
+		myErr := lang.NewError("Failure").AddCase(lang.NewErrorCase("NotFound"))
+		pkg.AddFiles(NewFile("errors.go").AddNodes(myErr.TypeDecl()))
+		mod.AddPackages(pkg)
+	}
+
+	return NewPrj("large").AddModules(mod)
+}
+
+func TestRenderer_RenderConcurrent(t *testing.T) {
+	sequential, err := golang.NewRenderer(golang.Options{Workers: 1}).Render(newLargeProject(8, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		concurrent, err := golang.NewRenderer(golang.Options{Workers: 4}).Render(newLargeProject(8, 3))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sequential.(*render.Dir).String() != concurrent.(*render.Dir).String() {
+			t.Fatalf("expected deterministic output but got\n%s\n\nvs\n\n%s", sequential, concurrent)
+		}
+	}
+}
+
+func benchmarkRender(b *testing.B, workers int) {
+	prj := newLargeProject(50, 10)
+	renderer := golang.NewRenderer(golang.Options{Workers: workers})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := renderer.Render(prj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRenderer_RenderSequential(b *testing.B) {
+	benchmarkRender(b, 1)
+}
+
+func BenchmarkRenderer_RenderConcurrent(b *testing.B) {
+	benchmarkRender(b, 0)
+}
+
+func TestRenderer_RenderCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	opts := golang.Options{CacheDir: cacheDir}
+	uncached, err := golang.NewRenderer(golang.Options{}).Render(newLargeProject(2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := golang.NewRenderer(opts).Render(newLargeProject(2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uncached.(*render.Dir).String() != cached.(*render.Dir).String() {
+		t.Fatalf("cache must not change the output")
+	}
+
+	// tamper each entry, so that we can proof that the cache is actually used
+	var entries []string
+	err = filepath.WalkDir(cacheDir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			entries = append(entries, path)
+			err = ioutil.WriteFile(path, []byte("// from cache\n"), 0600)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 6 {
+		t.Fatalf("expected an entry per file but got %v", entries)
+	}
+
+	prj := newLargeProject(2, 2)
+	artifact, err := golang.NewRenderer(opts).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := artifact.(*render.Dir).Directory("large").Directory("pkg0")
+	if string(dir.File("file0.go").Buf) != "// from cache\n" {
+		t.Fatalf("expected cached file but got\n%s", dir)
+	}
+
+	// any change in the model must invalidate the entry
+	prj = newLargeProject(2, 2)
+	prj.Mods[0].Pkgs[0].PkgFiles[0].SetComment("...has changed.")
+	artifact, err = golang.NewRenderer(opts).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir = artifact.(*render.Dir).Directory("large").Directory("pkg0")
+	if string(dir.File("file0.go").Buf) == "// from cache\n" || string(dir.File("file1.go").Buf) != "// from cache\n" {
+		t.Fatalf("expected invalidated file0.go but got\n%s", dir)
+	}
+}
+
+// newSiblingProject returns a package with a file, which uses fmt without importing it, and the given sibling.
+func newSiblingProject(sibling *Struct) *Prj {
+	return NewPrj("siblings").AddModules(
+		NewMod("example.com/siblings").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("siblings").
+			AddPackages(
+				NewPkg("example.com/siblings/hello").AddFiles(
+					NewFile("hello.go").
+						AddFuncs(NewFunc("Hello").SetVisibility(Public).SetBody(NewBlock(NewTpl(`fmt.Println("hello")`)))),
+					NewFile("sibling.go").AddTypes(sibling),
+				),
+			),
+	)
+}
+
+func TestRenderer_RenderCacheSiblings(t *testing.T) {
+	opts := golang.Options{CacheDir: t.TempDir()}
+	if _, err := golang.NewRenderer(opts).Render(newSiblingProject(NewStruct("Other"))); err != nil {
+		t.Fatal(err)
+	}
+
+	// a sibling declaration shadows the import, which changes the file without touching it
+	uncached, err := golang.NewRenderer(golang.Options{}).Render(newSiblingProject(NewStruct("fmt").SetVisibility(PackagePrivate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := golang.NewRenderer(opts).Render(newSiblingProject(NewStruct("fmt").SetVisibility(PackagePrivate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uncached.(*render.Dir).String() != cached.(*render.Dir).String() {
+		t.Fatalf("expected\n%s\n\nbut got stale cache entry\n%s", uncached, cached)
+	}
+
+	buf, err := fs.ReadFile(cached.(*render.Dir), "siblings/hello/hello.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings2.Contains(string(buf), `"fmt"`) {
+		t.Fatalf("expected no fmt import but got\n%s", buf)
+	}
+}
+
+// newTypeCheckProject returns a module with a single struct, which declares the given method.
+func newTypeCheckProject(fun *Func) *Prj {
+	return NewPrj("typecheck").AddModules(
+		NewMod("example.com/app").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("app").
+			AddPackages(
+				NewPkg("example.com/app/domain").AddFiles(
+					NewFile("user.go").AddTypes(
+						NewStruct("User").
+							AddFields(NewField("Name", NewSimpleTypeDecl(stdlib.String))).
+							AddMethods(
+								NewFunc("Greet").
+									SetRecName("u").
+									AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String))).
+									SetBody(NewBlock(NewReturnStmt(lang.CallStatic("strings.ToUpper", NewSelExpr(NewIdent("u"), NewIdent("Name")))))),
+								fun,
+							),
+					),
+				),
+			),
+	)
+}
+
+func TestRenderer_RenderTypeCheck(t *testing.T) {
+	broken := NewFunc("Broken").
+		SetRecName("u").
+		AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String))).
+		SetBody(NewBlock(NewReturnStmt(NewSelExpr(NewIdent("u"), NewIdent("Missing")))))
+	broken.ObjPos = Pos{File: "model.src", Line: 7, Col: 3}
+
+	_, err := golang.NewRenderer(golang.Options{TypeCheck: true}).Render(newTypeCheckProject(broken))
+	typeErrs, ok := err.(golang.TypeErrors)
+	if !ok || len(typeErrs) != 1 {
+		t.Fatalf("expected a single type error but got %v", err)
+	}
+
+	if typeErrs[0].Node != broken || typeErrs[0].Pos() != broken.ObjPos {
+		t.Fatalf("expected error to refer to the broken func but got %v", typeErrs[0])
+	}
+
+	if typeErrs[0].Generated.Filename != "app/domain/user.go" || !strings2.Contains(typeErrs[0].Msg, "Missing") {
+		t.Fatalf("unexpected error: %v", typeErrs[0])
+	}
+
+	valid := NewFunc("Valid").SetRecName("u").SetBody(NewBlock())
+	if _, err := golang.NewRenderer(golang.Options{TypeCheck: true}).Render(newTypeCheckProject(valid)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenderer_RenderReconcileImports(t *testing.T) {
+	prj := NewPrj("imports").AddModules(
+		NewMod("example.com/tpl").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("tpl").
+			AddPackages(
+				NewPkg("example.com/tpl/hello").AddFiles(
+					NewFile("hello.go").
+						AddNodes(NewImport("", "strings")).
+						AddFuncs(NewFunc("Hello").SetVisibility(Public).SetBody(NewBlock(NewTpl(`fmt.Println("hello")`)))),
+				),
+			),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{TypeCheck: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "tpl/hello/hello.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(buf)
+	if !strings2.Contains(src, `"fmt"`) || strings2.Contains(src, `"strings"`) {
+		t.Fatalf("expected fmt import only but got\n%s", src)
+	}
+}
+
+func TestRenderer_RenderSourceMap(t *testing.T) {
+	broken := NewFunc("Broken").
+		SetRecName("u").
+		AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String))).
+		SetBody(NewBlock(NewReturnStmt(NewSelExpr(NewIdent("u"), NewIdent("Missing")))))
+	broken.ObjPos = Pos{File: "model.src", Line: 7, Col: 3}
+
+	prj := newTypeCheckProject(broken)
+	artifact, err := golang.NewRenderer(golang.Options{SourceMap: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := artifact.(*render.Dir)
+	buf, err := fs.ReadFile(dir, "app/domain/user.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// locate the broken selector like a compiler error would do
+	var pos token.Position
+	for i, line := range strings2.Split(string(buf), "\n") {
+		if col := strings2.Index(line, "Missing"); col >= 0 {
+			pos = token.Position{Filename: "app/domain/user.go", Line: i + 1, Column: col + 1}
+		}
+	}
+
+	origin, err := golang.ResolvePosition(dir, pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if origin != broken.ObjPos {
+		t.Fatalf("expected %v but got %v", broken.ObjPos, origin)
+	}
+
+	srcMap, err := golang.ReadSourceMap(dir, "app/domain/user.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mappings := srcMap.Lookup(pos.Line, pos.Column)
+	if len(mappings) == 0 || mappings[0].Node != "SelExpr" || mappings[len(mappings)-1].Path != "example.com/app/domain.User" {
+		t.Fatalf("unexpected mappings: %+v", mappings)
+	}
+
+	if mappings[0].Path != "example.com/app/domain.User.Broken" {
+		t.Fatalf("unexpected identifier path: %s", mappings[0].Path)
+	}
+
+	// the source map must not change the rendered code
+	plain, err := golang.NewRenderer(golang.Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plainBuf, err := fs.ReadFile(plain.(*render.Dir), "app/domain/user.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(plainBuf) != string(buf) {
+		t.Fatalf("expected equal output but got\n%s\n\nvs\n\n%s", plainBuf, buf)
+	}
+}
+
+func TestRenderer_RenderDeprecation(t *testing.T) {
+	prj := NewPrj("deprecation").AddModules(
+		NewMod("example.com/old").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("old").
+			AddPackages(
+				NewPkg("example.com/old/api").AddFiles(
+					NewFile("api.go").
+						AddTypes(
+							NewStruct("User").
+								SetComment("...is a user.").
+								SetDeprecated("users are accounts now", "Account").
+								AddFields(NewField("Nick", NewSimpleTypeDecl(stdlib.String)).SetDeprecated("", "User.Name")),
+							NewStruct("Account"),
+						).
+						AddFuncs(NewFunc("Hello").SetDeprecated("hello is impolite", "")),
+				),
+			),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "old/api/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(buf)
+	for _, want := range []string{
+		"// User is a user.\n//\n// Deprecated: users are accounts now. Use [Account] instead.\ntype User struct",
+		"\t// Deprecated: Use [User.Name] instead.\n\tNick string",
+		"// Deprecated: hello is impolite\nfunc Hello()",
+	} {
+		if !strings2.Contains(src, want) {
+			t.Fatalf("expected %q but got\n%s", want, src)
+		}
+	}
+}
+
+func TestRenderer_RenderEnum(t *testing.T) {
+	prj := NewPrj("enum").AddModules(
+		NewMod("example.com/enum").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("enum").
+			AddPackages(
+				NewPkg("example.com/enum/api").AddFiles(
+					NewFile("api.go").
+						AddTypes(
+							NewEnum("Color", stdlib.String).AddCases(
+								NewEnumCase("darkRed"),
+								NewEnumCase("blue").SetValue(NewStrLit("#0000ff")),
+								NewEnumCase("green"),
+							),
+							NewEnum("Level", "").AddCases(
+								NewEnumCase("low"),
+								NewEnumCase("mid"),
+								NewEnumCase("high").SetValue(NewIntLit(10)),
+								NewEnumCase("max"),
+								NewEnumCase("overflow"),
+							),
+						),
+				),
+			),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{TypeCheck: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "enum/api/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(buf)
+	for _, want := range []string{
+		"type Color string\n\nconst (\n\tColorDarkRed Color = \"darkRed\"\n\tColorBlue    Color = \"#0000ff\"\n\tColorGreen   Color = \"green\"\n)",
+		"type Level int\n\nconst (\n\tLevelLow Level = iota\n\tLevelMid\n\tLevelHigh Level = 10\n\tLevelMax  Level = iota\n\tLevelOverflow\n)",
+	} {
+		if !strings2.Contains(src, want) {
+			t.Fatalf("expected %q but got\n%s", want, src)
+		}
+	}
+}
+
+func TestRenderer_RenderEscapeKeywords(t *testing.T) {
+	prj := NewPrj("keywords").AddModules(
+		NewMod("example.com/kw").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("kw").
+			AddPackages(
+				NewPkg("example.com/kw/api").AddFiles(
+					NewFile("api.go").
+						AddFuncs(
+							NewFunc("Kind").
+								AddParams(NewParam("type", NewSimpleTypeDecl(stdlib.String))).
+								AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String))).
+								SetBody(NewBlock(NewReturnStmt(NewIdent("type")))),
+						).
+						AddTypes(
+							NewStruct("Token").
+								AddFields(
+									NewField("type", NewSimpleTypeDecl(stdlib.String)).
+										SetVisibility(PackagePrivate).
+										SetComment("...is the kind of the token."),
+								).
+								AddMethods(
+									NewFunc("Kind").
+										SetRecName("t").
+										AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String))).
+										SetBody(NewBlock(NewReturnStmt(NewSelExpr(NewIdent("t"), NewIdent("type"))))),
+								),
+						),
+				),
+			),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{TypeCheck: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "kw/api/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(buf)
+	for _, want := range []string{
+		"func Kind(type_ string) string {\n\treturn type_\n}",
+		"type Token struct {\n\t// type_ is the kind of the token.\n\ttype_ string\n}",
+		"func (t Token) Kind() string {\n\treturn t.type_\n}",
+	} {
+		if !strings2.Contains(src, want) {
+			t.Fatalf("expected %q but got\n%s", want, src)
+		}
+	}
+}
+
+func TestRenderer_RenderFreshIdent(t *testing.T) {
+	prj := NewPrj("fresh").AddModules(
+		NewMod("example.com/fresh").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("fresh").
+			AddPackages(
+				NewPkg("example.com/fresh/api").AddFiles(
+					NewFile("api.go").
+						AddFuncs(
+							NewFunc("Greet").
+								AddParams(NewParam("err", NewSimpleTypeDecl(stdlib.String))).
+								AddResults(NewParam("", NewSimpleTypeDecl(stdlib.String)), NewParam("", NewSimpleTypeDecl(stdlib.Error))).
+								SetBody(NewBlock(
+									strings.NewStrBuilder("sb", NewIdent("err")),
+									strings.NewStrBuilder("", NewStrLit("!")),
+									lang.TryDefine(NewIdent("a"), lang.CallStatic("strconv.Atoi", NewIdent("err")), "cannot parse"),
+									lang.TryDefine(NewIdent("b"), lang.CallStatic("strconv.Atoi", NewStrLit("2")), "cannot parse"),
+									NewReturnStmt(
+										lang.CallIdent("sb", "String"),
+										NewIdent("nil"),
+									),
+								)),
+						),
+				),
+			),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "fresh/api/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(buf)
+	for _, want := range []string{
+		"sb := &strings.Builder{}\n\tsb.WriteString(err)",
+		"sb2 := &strings.Builder{}\n\tsb2.WriteString(\"!\")",
+		"a, err2 := strconv.Atoi(err)",
+		"b, err2 := strconv.Atoi(\"2\")",
+		"return \"\", fmt.Errorf(\"cannot parse: %w\", err2)",
+	} {
+		if !strings2.Contains(src, want) {
+			t.Fatalf("expected %q but got\n%s", want, src)
+		}
+	}
+}
+
+func TestRenderer_RenderFreshIdentIgnoresMessages(t *testing.T) {
+	prj := NewPrj("fresh").AddModules(
+		NewMod("example.com/fresh").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("fresh").
+			AddPackages(
+				NewPkg("example.com/fresh/api").AddFiles(
+					NewFile("api.go").
+						AddFuncs(
+							NewFunc("Parse").
+								AddParams(NewParam("text", NewSimpleTypeDecl(stdlib.String))).
+								AddResults(NewParam("", NewSimpleTypeDecl(stdlib.Int)), NewParam("", NewSimpleTypeDecl(stdlib.Error))).
+								SetBody(NewBlock(
+									lang.TryDefine(NewIdent("n"), lang.CallStatic("strconv.Atoi", NewIdent("text")), "cannot parse text, err"),
+									NewReturnStmt(NewIdent("n"), NewIdent("nil")),
+								)),
+						),
+				),
+			),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "fresh/api/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if src := string(buf); !strings2.Contains(src, "n, err := strconv.Atoi(text)") {
+		t.Fatalf("expected the message to be ignored but got\n%s", src)
+	}
+}
+
+func TestRenderer_RenderCheckKeepsModel(t *testing.T) {
+	notFound := lang.NewErrorCase("NotFound")
+	myErr := lang.NewError("Ticket").AddCase(notFound)
+	matched := NewIdent("matched")
+
+	file := NewFile("api.go").AddNodes(myErr.TypeDecl())
+	file.AddFuncs(
+		NewFunc("Find").
+			AddParams(NewParam("matched", NewSimpleTypeDecl(stdlib.String)), NewParam("err", NewSimpleTypeDecl(stdlib.Error))).
+			AddResults(NewParam("", NewSimpleTypeDecl(stdlib.Error))).
+			SetBody(NewBlock(
+				notFound.Check(lang.CheckCaseBehavior, "err", "matched", NewBlock(NewReturnStmt(matched))),
+				NewReturnStmt(NewIdent("nil")),
+			)),
+	)
+
+	prj := NewPrj("check").AddModules(
+		NewMod("example.com/check").
+			SetLang(LangGo).
+			SetLangVersion(LangVersionGo16).
+			SetOutputDirectory("check").
+			AddPackages(NewPkg("example.com/check/api").AddFiles(file)),
+	)
+
+	dir, err := golang.NewRenderer(golang.Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir.(*render.Dir), "check/api/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if src := string(buf); !strings2.Contains(src, "return matched2\n") {
+		t.Fatalf("expected the renamed variable but got\n%s", src)
+	}
+
+	if matched.Name != "matched" {
+		t.Fatalf("expected the model to be unchanged but got %s", matched.Name)
+	}
+}
@@ -0,0 +1,52 @@
+package golang
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"strconv"
+)
+
+// renderEnum emits a named base type and a const block containing each case. Cases without a value get their
+// name, if the base type is a string, otherwise they are enumerated by iota, which only makes sense for number
+// base types. Just like in Java, such a case evaluates to its index.
+func (r *Renderer) renderEnum(node *ast.Enum, w *render.BufferedWriter) error {
+	r.writeCommentNode(w, false, node.Identifier(), node.Comment())
+
+	baseType := node.BaseType
+	if baseType == "" {
+		baseType = stdlib.Int
+	}
+
+	w.Printf("type %s %s\n", node.Identifier(), r.importer(node).shortify(fromStdlib(baseType)))
+
+	if len(node.Cases) == 0 {
+		return nil
+	}
+
+	w.Printf("const (\n")
+	for i, enumCase := range node.Cases {
+		name := node.Identifier() + MakePublic(enumCase.Name())
+		r.writeCommentNode(w, false, name, enumCase.Comment())
+		switch {
+		case enumCase.EnumValue != nil:
+			w.Printf("%s %s = ", name, node.Identifier())
+			if err := r.renderBasicLit(enumCase.EnumValue, w); err != nil {
+				return err
+			}
+		case baseType == stdlib.String:
+			w.Printf("%s %s = %s", name, node.Identifier(), strconv.Quote(enumCase.Name()))
+		case i == 0 || node.Cases[i-1].EnumValue != nil:
+			w.Printf("%s %s = iota", name, node.Identifier())
+		default:
+			// repeats the previous expression, which is iota
+			w.Printf("%s", name)
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf(")\n")
+
+	return nil
+}
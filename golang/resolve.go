@@ -0,0 +1,198 @@
+package golang
+
+import (
+	"github.com/golangee/src/ast"
+	goast "go/ast"
+	"go/token"
+)
+
+// resolveNode returns the innermost model node which has produced the Go declaration at pos within the rendered
+// gofile. The resolution works at the declaration level, by matching the declared identifiers with the model: a
+// position within a function body resolves to the ast.Func, a position within a struct field resolves to the
+// ast.Field and so on. Declarations which cannot be matched resolve to the file itself.
+func resolveNode(file *ast.File, gofile *goast.File, pos token.Pos) ast.Node {
+	for _, decl := range gofile.Decls {
+		if !contains(decl, pos) {
+			continue
+		}
+
+		switch d := decl.(type) {
+		case *goast.FuncDecl:
+			return resolveFuncDecl(file, d, pos)
+		case *goast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*goast.TypeSpec); ok && contains(ts, pos) {
+					return resolveTypeSpec(file, ts, pos)
+				}
+			}
+		}
+	}
+
+	return file
+}
+
+func resolveFuncDecl(file *ast.File, decl *goast.FuncDecl, pos token.Pos) ast.Node {
+	var fun *ast.Func
+	if decl.Recv != nil && len(decl.Recv.List) > 0 {
+		typ := findNamed(file.Nodes, recvTypeName(decl.Recv.List[0].Type))
+		if typ == nil {
+			return file
+		}
+
+		fun = findMethod(typ, decl.Name.Name)
+		if fun == nil {
+			return typ
+		}
+	} else {
+		f, ok := findNamed(file.Nodes, decl.Name.Name).(*ast.Func)
+		if !ok {
+			return file
+		}
+
+		fun = f
+	}
+
+	if param := findParam(fun.Params(), decl.Type.Params, pos); param != nil {
+		return param
+	}
+
+	if param := findParam(fun.Results(), decl.Type.Results, pos); param != nil {
+		return param
+	}
+
+	return fun
+}
+
+func resolveTypeSpec(file *ast.File, spec *goast.TypeSpec, pos token.Pos) ast.Node {
+	typ := findNamed(file.Nodes, spec.Name.Name)
+	if typ == nil {
+		return file
+	}
+
+	switch t := spec.Type.(type) {
+	case *goast.StructType:
+		s, ok := typ.(*ast.Struct)
+		if !ok {
+			return typ
+		}
+
+		for _, field := range t.Fields.List {
+			if !contains(field, pos) || len(field.Names) == 0 {
+				continue
+			}
+
+			for _, f := range s.Fields() {
+				if f.Identifier() == field.Names[0].Name {
+					return f
+				}
+			}
+		}
+	case *goast.InterfaceType:
+		for _, method := range t.Methods.List {
+			if !contains(method, pos) || len(method.Names) == 0 {
+				continue
+			}
+
+			if fun := findMethod(typ, method.Names[0].Name); fun != nil {
+				return fun
+			}
+		}
+	}
+
+	return typ
+}
+
+// findNamed returns the first node with the given identifier. Macros are evaluated and searched recursively.
+func findNamed(nodes []ast.Node, name string) ast.Node {
+	for _, node := range nodes {
+		if named, ok := node.(interface{ Identifier() string }); ok && named.Identifier() == name {
+			return node
+		}
+
+		if macro, ok := node.(*ast.Macro); ok {
+			if n := findNamed(macro.Children(), name); n != nil {
+				return n
+			}
+		}
+	}
+
+	return nil
+}
+
+func findMethod(typ ast.Node, name string) *ast.Func {
+	var methods []*ast.Func
+	switch t := typ.(type) {
+	case *ast.Struct:
+		methods = t.Methods()
+	case *ast.Interface:
+		methods = t.Methods()
+	}
+
+	for _, method := range methods {
+		if method.Identifier() == name {
+			return method
+		}
+	}
+
+	return nil
+}
+
+// findParam returns the parameter at pos. Unnamed parameters are matched by their index.
+func findParam(params []*ast.Param, fields *goast.FieldList, pos token.Pos) *ast.Param {
+	if fields == nil {
+		return nil
+	}
+
+	idx := 0
+	for _, field := range fields.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+
+		if contains(field, pos) {
+			for i := 0; i < count; i++ {
+				if idx+i < len(params) && (len(field.Names) == 0 || params[idx+i].Identifier() == field.Names[i].Name) {
+					return params[idx+i]
+				}
+			}
+
+			return nil
+		}
+
+		idx += count
+	}
+
+	return nil
+}
+
+// recvTypeName returns the name of the receiver type, e.g. T for *T.
+func recvTypeName(expr goast.Expr) string {
+	switch t := expr.(type) {
+	case *goast.StarExpr:
+		return recvTypeName(t.X)
+	case *goast.Ident:
+		return t.Name
+	case *goast.IndexExpr:
+		return recvTypeName(t.X)
+	default:
+		return ""
+	}
+}
+
+func contains(n goast.Node, pos token.Pos) bool {
+	return n.Pos() <= pos && pos < n.End()
+}
+
+// modelPos returns the first defined position of the node or its parents.
+func modelPos(node ast.Node) ast.Pos {
+	for node != nil {
+		if node.Pos().Line > 0 {
+			return node.Pos()
+		}
+
+		node = node.Parent()
+	}
+
+	return ast.Pos{}
+}
@@ -4,18 +4,22 @@ import (
 	"fmt"
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/golang/validate"
+	"github.com/golangee/src/naming"
 	"github.com/golangee/src/render"
 	"strconv"
 )
 
 func (r *Renderer) renderField(node *ast.Field, w *render.BufferedWriter) error {
-	r.writeCommentNode(w, false, node.Identifier(), node.Comment())
+	defer r.mark(node, w)()
 
-	if err := validate.ExportedIdentifier(node.Visibility(), node.Identifier()); err != nil {
+	name := naming.Go.Escape(node.Identifier())
+	r.writeDeclComment(w, name, node.Comment(), node.Deprecated())
+
+	if err := validate.ExportedIdentifier(node.Visibility(), name); err != nil {
 		return err
 	}
 
-	w.Printf(node.FieldName)
+	w.Printf(name)
 	w.Printf(" ")
 	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
 		return err
@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-// renderFile generates the code for the entire file.
-func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
+// renderFile generates the code for the entire file. The source map is nil, if disabled.
+func (r *Renderer) renderFile(file *ast.File) ([]byte, *SourceMap, error) {
 	w := &render.BufferedWriter{}
 
 	// file license or whatever
@@ -30,12 +30,12 @@ func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
 		switch t := node.(type) {
 		case *ast.Func:
 			if err := r.renderFunc(t, tmp); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 		default:
 			if err := r.renderNode(t, tmp); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
@@ -67,5 +67,12 @@ func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
 
 	w.Printf(tmp.String())
 
-	return Format(w.Bytes())
+	src, ranges := r.stripMarkers(w.Bytes())
+	src = fixImports(src, declaredNames(file.Pkg()))
+	buf, err := Format(src)
+	if err != nil || r.marks == nil {
+		return buf, nil, err
+	}
+
+	return buf, newSourceMap(file.Name, src, buf, ranges), nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/golang/validate"
+	"github.com/golangee/src/naming"
 	"github.com/golangee/src/render"
 	"strings"
 )
@@ -12,6 +13,8 @@ import (
 // on the actual parent, which is either an ast.File, ast.Struct or ast.Interface. The keyword func is not
 // rendered here, because we renderFunc also for type declarations.
 func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
+	defer r.mark(node, w)()
+
 	funComment := r.renderFuncComment(node)
 	if funComment != "" {
 		r.writeComment(w, false, node.Identifier(), funComment)
@@ -44,8 +47,8 @@ func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
 	w.Printf(node.Identifier())
 	w.Printf("(")
 	for i, parameterNode := range node.FunParams {
-
-		w.Printf(parameterNode.Identifier())
+		endMark := r.mark(parameterNode, w)
+		w.Printf(naming.Go.Escape(parameterNode.Identifier()))
 
 		if i == len(node.FunParams)-1 && node.Variadic() {
 			w.Printf("...")
@@ -57,6 +60,8 @@ func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
 			return fmt.Errorf("unable to render input parameter TypeDecl: %w", err)
 		}
 
+		endMark()
+
 		if i < len(node.FunParams)-1 {
 			w.Printf(", ")
 		}
@@ -67,13 +72,16 @@ func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
 		w.Printf("(")
 	}
 	for i, parameterNode := range node.FunResults {
-		w.Printf(parameterNode.Identifier())
+		endMark := r.mark(parameterNode, w)
+		w.Printf(naming.Go.Escape(parameterNode.Identifier()))
 		w.Printf(" ")
 
 		if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
 			return fmt.Errorf("unable to render ouput parameter TypeDecl: %w", err)
 		}
 
+		endMark()
+
 		if i < len(node.FunResults)-1 {
 			w.Printf(", ")
 		}
@@ -128,7 +136,7 @@ func (r *Renderer) renderFuncComment(node *ast.Func) string {
 		}
 
 		comment.WriteString("The parameter ")
-		name := parameterNode.Identifier()
+		name := naming.Go.Escape(parameterNode.Identifier())
 		if name == "" {
 			name = fromStdlib(ast.Name(parameterNode.TypeDecl().String())).Identifier()
 		}
@@ -143,7 +151,7 @@ func (r *Renderer) renderFuncComment(node *ast.Func) string {
 		}
 
 		comment.WriteString("The result ")
-		name := parameterNode.Identifier()
+		name := naming.Go.Escape(parameterNode.Identifier())
 		if name == "" {
 			name = fromStdlib(ast.Name(parameterNode.TypeDecl().String())).Identifier()
 		}
@@ -162,5 +170,5 @@ func (r *Renderer) renderFuncComment(node *ast.Func) string {
 		comment.WriteString("\n")
 	}
 
-	return comment.String()
+	return ast.DocWithDeprecation(comment.String(), node.Deprecated())
 }
@@ -2,12 +2,13 @@ package golang
 
 import (
 	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
 	"github.com/golangee/src/render"
 )
 
-// renderIdent emits an identifiers name.
+// renderIdent emits an identifiers name. Names which collide with a keyword are escaped, see naming.Go.
 func (r *Renderer) renderIdent(node *ast.Ident, w *render.BufferedWriter) error {
-	w.Printf(node.Name)
+	w.Printf(naming.Go.Escape(node.Name))
 
 	return nil
 }
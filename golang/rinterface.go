@@ -9,7 +9,7 @@ import (
 
 // renderStruct emits a struct type.
 func (r *Renderer) renderInterface(node *ast.Interface, w *render.BufferedWriter) error {
-	r.writeCommentNode(w, false, node.Identifier(), node.Comment())
+	r.writeDeclComment(w, node.Identifier(), node.Comment(), node.Deprecated())
 
 	if node.TypeName == "" {
 		w.Printf(" %s interface {\n", node.Identifier())
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/render"
+	"path"
 	"strings"
+	"sync"
 )
 
 func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, error) {
@@ -17,31 +19,100 @@ func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, err
 		Buf:      []byte(createGoModFile(mod)),
 	})
 
-	var firstErr error
-
+	// the directory tree is created sequentially and in declaration order, to keep the output deterministic
+	pkgDirs := make([]*render.Dir, 0, len(mod.Pkgs))
 	for _, pkg := range mod.Pkgs {
 		// we cannot use name here, because in go the name and the import path may be different
 		if !strings.HasPrefix(pkg.Path, mod.Name) {
 			return nil, fmt.Errorf("declared package '%s' must be prefixed by module path '%s'", pkg.Path, mod.Name)
 		}
 		var pkgDir *render.Dir
+		dirPath := mod.Target.Out
 		if pkg.Path == mod.Name {
 			pkgDir = modDir
 		} else {
 			pkgDir = r.ensurePkgDir(pkg.Path[len(mod.Name)+1:], modDir)
+			dirPath = path.Join(dirPath, pkg.Path[len(mod.Name)+1:])
 		}
 
-		files, err := r.renderPkg(pkg)
-		if firstErr == nil && err != nil {
-			firstErr = fmt.Errorf("cannot render package '%s': %w", pkg.Path, err)
+		pkgDirs = append(pkgDirs, pkgDir)
+		r.rendered = append(r.rendered, &renderedPkg{pkg: pkg, dir: pkgDir, path: dirPath})
+	}
+
+	files, errs := r.renderPkgs(mod.Pkgs)
+
+	var firstErr error
+	for i, pkg := range mod.Pkgs {
+		if firstErr == nil && errs[i] != nil {
+			firstErr = fmt.Errorf("cannot render package '%s': %w", pkg.Path, errs[i])
 		}
 
-		pkgDir.Files = append(pkgDir.Files, files...)
+		pkgDirs[i].Files = append(pkgDirs[i].Files, files[i]...)
 	}
 
 	return modDir, firstErr
 }
 
+// renderPkgs renders the given packages concurrently, using the configured amount of workers. The files of a
+// single package are rendered sequentially by the same worker, because macros may inspect and cache nodes of
+// their package. The results have the same order as pkgs. A panic of a worker is propagated to the caller.
+func (r *Renderer) renderPkgs(pkgs []*ast.Pkg) ([][]*render.File, []error) {
+	files := make([][]*render.File, len(pkgs))
+	errs := make([]error, len(pkgs))
+
+	workers := r.workers()
+	if workers > len(pkgs) {
+		workers = len(pkgs)
+	}
+
+	if workers <= 1 {
+		for i, pkg := range pkgs {
+			files[i], errs[i] = r.renderPkg(pkg)
+		}
+
+		return files, errs
+	}
+
+	var (
+		wg        sync.WaitGroup
+		panicOnce sync.Once
+		panicVal  interface{}
+	)
+
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if v := recover(); v != nil {
+					panicOnce.Do(func() { panicVal = v })
+					for range jobs {
+						// drain, so that the producer is not blocked forever
+					}
+				}
+			}()
+
+			for i := range jobs {
+				files[i], errs[i] = r.renderPkg(pkgs[i])
+			}
+		}()
+	}
+
+	for i := range pkgs {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if panicVal != nil {
+		panic(panicVal)
+	}
+
+	return files, errs
+}
+
 func createGoModFile(mod *ast.Mod) string {
 	var tmp strings.Builder
 
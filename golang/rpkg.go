@@ -1,6 +1,7 @@
 package golang
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/render"
@@ -36,7 +37,7 @@ func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
 	}
 
 	for _, file := range pkg.PkgFiles {
-		buf, err := r.renderFile(file)
+		buf, srcMap, err := r.renderFileCached(file)
 
 		f := &render.File{
 			FileName: file.Name,
@@ -50,6 +51,19 @@ func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
 		}
 
 		res = append(res, f)
+
+		if srcMap != nil {
+			mapBuf, err := json.MarshalIndent(srcMap, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode source map: %w", err)
+			}
+
+			res = append(res, &render.File{
+				FileName: file.Name + SourceMapSuffix,
+				MimeType: MimeTypeSourceMap,
+				Buf:      mapBuf,
+			})
+		}
 	}
 
 	for _, file := range pkg.RawFiles {
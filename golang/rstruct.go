@@ -9,7 +9,7 @@ import (
 
 // renderStruct emits a struct type.
 func (r *Renderer) renderStruct(node *ast.Struct, w *render.BufferedWriter) error {
-	r.writeCommentNode(w, false, node.Identifier(), node.Comment())
+	r.writeDeclComment(w, node.Identifier(), node.Comment(), node.Deprecated())
 
 	if err := validate.ExportedIdentifier(node.Visibility(), node.Identifier()); err != nil {
 		return err
@@ -9,6 +9,11 @@ import (
 
 // renderType inspects and emits the actual type.
 func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
+	// functions are also rendered directly and mark themselves
+	if _, ok := node.(*ast.Func); !ok {
+		defer r.mark(node, w)()
+	}
+
 	switch n := node.(type) {
 	case *ast.Struct:
 		if err := r.renderStruct(n, w); err != nil {
@@ -87,6 +92,10 @@ func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
 		if err := r.renderInterface(n, w); err != nil {
 			return fmt.Errorf("cannot render Interface: %w", err)
 		}
+	case *ast.Enum:
+		if err := r.renderEnum(n, w); err != nil {
+			return fmt.Errorf("cannot render Enum: %w", err)
+		}
 
 	case *ast.Import:
 	// handled by Renderer.renderFile
@@ -0,0 +1,364 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"go/scanner"
+	"go/token"
+	"io/fs"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SourceMapSuffix is appended to the name of a rendered Go file to name its source map.
+const SourceMapSuffix = ".map"
+
+// MimeTypeSourceMap denotes a rendered SourceMap.
+const MimeTypeSourceMap = "application/x-golangee-sourcemap+json"
+
+// A SourceMap connects the ranges of a rendered Go file with the model nodes which have produced them.
+type SourceMap struct {
+	File     string    `json:"file"`     // File is the name of the rendered file.
+	Mappings []Mapping `json:"mappings"` // Mappings are ordered by their start and outer ranges come first.
+}
+
+// A Mapping describes the generated range of a single model node. Lines and columns are one-based and the end
+// is exclusive.
+type Mapping struct {
+	Line    int     `json:"line"`
+	Col     int     `json:"col"`
+	EndLine int     `json:"endLine"`
+	EndCol  int     `json:"endCol"`
+	Node    string  `json:"node"` // Node is the type name of the model node, e.g. Func or IfStmt.
+	Path    string  `json:"path"` // Path identifies the nearest named node, e.g. example.com/app/domain.User.Greet.
+	Pos     ast.Pos `json:"pos"`  // Pos is the position of the model node itself and may be undefined.
+}
+
+func (m Mapping) contains(line, col int) bool {
+	if line < m.Line || line == m.Line && col < m.Col {
+		return false
+	}
+
+	return line < m.EndLine || line == m.EndLine && col < m.EndCol
+}
+
+// Lookup returns all mappings which contain the given generated position, the innermost first.
+func (m *SourceMap) Lookup(line, col int) []Mapping {
+	var res []Mapping
+	for i := len(m.Mappings) - 1; i >= 0; i-- {
+		if m.Mappings[i].contains(line, col) {
+			res = append(res, m.Mappings[i])
+		}
+	}
+
+	return res
+}
+
+// Origin returns the first defined model position of the innermost mapping and its enclosing mappings.
+func (m *SourceMap) Origin(line, col int) (ast.Pos, bool) {
+	for _, mapping := range m.Lookup(line, col) {
+		if mapping.Pos.Line > 0 {
+			return mapping.Pos, true
+		}
+	}
+
+	return ast.Pos{}, false
+}
+
+// ReadSourceMap loads the source map of the given rendered Go file from fsys.
+func ReadSourceMap(fsys fs.FS, name string) (*SourceMap, error) {
+	buf, err := fs.ReadFile(fsys, name+SourceMapSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read source map: %w", err)
+	}
+
+	m := &SourceMap{}
+	if err := json.Unmarshal(buf, m); err != nil {
+		return nil, fmt.Errorf("cannot parse source map: %w", err)
+	}
+
+	return m, nil
+}
+
+// ResolvePosition translates a position within a rendered Go file, as reported by the compiler or a stack
+// trace, into the position of the originating model node. The filename must be slash separated and relative
+// to fsys, which is usually the rendered artifact (see render.Dir) or os.DirFS of the output directory. The
+// artifact must have been rendered with Options.SourceMap.
+func ResolvePosition(fsys fs.FS, pos token.Position) (ast.Pos, error) {
+	m, err := ReadSourceMap(fsys, path.Clean(strings.TrimPrefix(pos.Filename, "./")))
+	if err != nil {
+		return ast.Pos{}, err
+	}
+
+	origin, ok := m.Origin(pos.Line, pos.Column)
+	if !ok {
+		return ast.Pos{}, fmt.Errorf("no model position available for %s", pos)
+	}
+
+	return origin, nil
+}
+
+// srcMarker matches the comments which are written around each node before formatting.
+var srcMarker = regexp.MustCompile(`/\*@@src(begin|end) \d+\*/`)
+
+// sourceMarks collects the nodes which have been marked during rendering. It is shared by all workers.
+type sourceMarks struct {
+	mutex sync.Mutex
+	nodes []ast.Node
+}
+
+// mark writes a begin marker for the node and returns a function which writes the according end marker. This
+// is a no-op, if source maps are disabled. Markers are comments, which do not change the meaning of the code
+// and which are removed again, before the file is formatted.
+func (r *Renderer) mark(node ast.Node, w *render.BufferedWriter) func() {
+	if r.marks == nil {
+		return func() {}
+	}
+
+	r.marks.mutex.Lock()
+	id := len(r.marks.nodes)
+	r.marks.nodes = append(r.marks.nodes, node)
+	r.marks.mutex.Unlock()
+
+	w.Printf("/*@@srcbegin %d*/", id)
+
+	return func() {
+		w.Printf("/*@@srcend %d*/", id)
+	}
+}
+
+// markedRange denotes the token range of a marked node, by counting the tokens from the end of the file. The
+// counting from the end is stable, because import reconciliation and formatting only change tokens in front
+// of the marked declarations.
+type markedRange struct {
+	node       ast.Node
+	begin, end int
+}
+
+// stripMarkers removes all markers from the rendered source and returns the marked token ranges.
+func (r *Renderer) stripMarkers(src []byte) ([]byte, []markedRange) {
+	if r.marks == nil {
+		return src, nil
+	}
+
+	var s scanner.Scanner
+	file := token.NewFileSet().AddFile("", -1, len(src))
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	begins := map[int]int{}
+	var ranges []markedRange
+	count := 0
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		if tok == token.SEMICOLON {
+			continue
+		}
+
+		if tok != token.COMMENT {
+			count++
+			continue
+		}
+
+		m := srcMarker.FindStringSubmatch(lit)
+		if m == nil || m[0] != lit {
+			continue
+		}
+
+		id, _ := strconv.Atoi(lit[len("/*@@src"+m[1]+" ") : len(lit)-2])
+		if m[1] == "begin" {
+			begins[id] = count
+			continue
+		}
+
+		if begin, ok := begins[id]; ok && begin < count {
+			r.marks.mutex.Lock()
+			node := r.marks.nodes[id]
+			r.marks.mutex.Unlock()
+			ranges = append(ranges, markedRange{node: node, begin: begin, end: count})
+		}
+	}
+
+	for i := range ranges {
+		ranges[i].begin = count - ranges[i].begin
+		ranges[i].end = count - ranges[i].end
+	}
+
+	return srcMarker.ReplaceAll(src, nil), ranges
+}
+
+// A srcToken is a scanned token without semicolons, which may be inserted or removed by the formatter.
+type srcToken struct {
+	tok        token.Token
+	lit        string
+	begin, end token.Position
+}
+
+func scanTokens(name string, src []byte) []srcToken {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile(name, -1, len(src))
+	s.Init(file, src, nil, 0)
+
+	var res []srcToken
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return res
+		}
+
+		if tok == token.SEMICOLON {
+			continue
+		}
+
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+
+		res = append(res, srcToken{
+			tok:   tok,
+			lit:   lit,
+			begin: fset.Position(pos),
+			end:   fset.Position(pos + token.Pos(len(text))),
+		})
+	}
+}
+
+// newSourceMap resolves the marked ranges of the unformatted source within the formatted source. The
+// formatter never inserts tokens, but it removes some, e.g. trailing commas or the parentheses of a single
+// result. So the formatted tokens are a subsequence of the unformatted tokens and are matched greedily.
+func newSourceMap(name string, unformatted, formatted []byte, ranges []markedRange) *SourceMap {
+	in := scanTokens(name, unformatted)
+	out := scanTokens(name, formatted)
+
+	// matched contains for each unformatted token the index of the formatted token or -1, if removed. The
+	// imports are sorted by the formatter and never contain marked nodes, so they are skipped.
+	matched := make([]int, len(in))
+	i0, j := skipImports(in), skipImports(out)
+	for i, t := range in {
+		matched[i] = -1
+		if i < i0 {
+			continue
+		}
+
+		if j < len(out) && out[j].tok == t.tok && out[j].lit == t.lit {
+			matched[i] = j
+			j++
+		}
+	}
+
+	m := &SourceMap{File: name}
+	for _, rng := range ranges {
+		begin, end := -1, -1
+		for i := len(in) - rng.begin; i < len(in)-rng.end; i++ {
+			if i < 0 || matched[i] < 0 {
+				continue
+			}
+
+			if begin < 0 {
+				begin = matched[i]
+			}
+
+			end = matched[i]
+		}
+
+		if begin < 0 {
+			continue
+		}
+
+		m.Mappings = append(m.Mappings, Mapping{
+			Line:    out[begin].begin.Line,
+			Col:     out[begin].begin.Column,
+			EndLine: out[end].end.Line,
+			EndCol:  out[end].end.Column,
+			Node:    nodeTypeName(rng.node),
+			Path:    identifierPath(rng.node),
+			Pos:     rng.node.Pos(),
+		})
+	}
+
+	// the end markers are found from the inside out, but outer nodes must come first
+	sortMappings(m.Mappings)
+
+	return m
+}
+
+// skipImports returns the index of the first token after the package clause and the import declarations.
+func skipImports(tokens []srcToken) int {
+	i := 0
+	if i < len(tokens) && tokens[i].tok == token.PACKAGE {
+		i += 2
+	}
+
+	for i < len(tokens) && tokens[i].tok == token.IMPORT {
+		i++
+		if i < len(tokens) && tokens[i].tok == token.LPAREN {
+			for i < len(tokens) && tokens[i].tok != token.RPAREN {
+				i++
+			}
+		} else {
+			// optional name and the path
+			for i < len(tokens) && tokens[i].tok != token.STRING {
+				i++
+			}
+		}
+
+		i++
+	}
+
+	return i
+}
+
+func sortMappings(mappings []Mapping) {
+	sort.SliceStable(mappings, func(i, j int) bool {
+		a, b := mappings[i], mappings[j]
+		if a.Line != b.Line || a.Col != b.Col {
+			return a.Line < b.Line || a.Line == b.Line && a.Col < b.Col
+		}
+
+		// same start, the larger range is the outer one
+		return a.EndLine > b.EndLine || a.EndLine == b.EndLine && a.EndCol > b.EndCol
+	})
+}
+
+// nodeTypeName returns the type name of the node without package and pointer, e.g. Func.
+func nodeTypeName(node ast.Node) string {
+	t := reflect.TypeOf(node)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Name()
+}
+
+// identifierPath returns the package path and the identifiers of all named parents, separated by dots.
+func identifierPath(node ast.Node) string {
+	var names []string
+	for n := node; n != nil; n = n.Parent() {
+		if pkg, ok := n.(*ast.Pkg); ok {
+			names = append(names, pkg.Path)
+			break
+		}
+
+		if named, ok := n.(interface{ Identifier() string }); ok && named.Identifier() != "" {
+			names = append(names, named.Identifier())
+		}
+	}
+
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+
+	return strings.Join(names, ".")
+}
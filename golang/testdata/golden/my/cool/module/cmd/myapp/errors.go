@@ -0,0 +1,185 @@
+package main
+
+import (
+	errors "errors"
+	fmt "fmt"
+	uuid "github.com/golangee/uuid"
+)
+
+// TicketError represents the sum type behavior of all Ticket errors.
+type TicketError interface {
+	// Ticket returns true, if the error belongs to the sum type of Ticket.
+	Ticket() bool
+
+	// Unwrap unpacks the cause or returns nil.
+	Unwrap() error
+
+	// Error returns the conventional description of this error.
+	Error() string
+}
+
+// AsTicketError finds the first error in err's chain that matches any
+// TicketError behavior.
+// Returns nil if no such error is found.
+func AsTicketError(err error) TicketError {
+	var match TicketError
+	if errors.As(err, &match) && match.Ticket() {
+		return match
+	}
+
+	return nil
+}
+
+// TicketNotFoundError describes that a domain entity has not been found where
+// one has been expected.
+type TicketNotFoundError interface {
+	// NotFound returns true, if it represents a NotFound case.
+	NotFound() bool
+
+	TicketError
+}
+
+// AsTicketNotFoundError finds the first error in err's chain that matches
+// anyTicketNotFoundError behavior.
+// Returns nil if no such error is found.
+func AsTicketNotFoundError(err error) TicketNotFoundError {
+	var match TicketNotFoundError
+	if errors.As(err, &match) && match.Ticket() && match.NotFound() {
+		return match
+	}
+
+	return nil
+}
+
+// ticketNotFoundError describes that a domain entity has not been found where
+// one has been expected.
+// ticketNotFoundError is also a TicketError.
+type ticketNotFoundError struct {
+	// cause refers to a causing error or nil.
+	cause error
+}
+
+// Ticket returns true, if the error belongs to the sum type of Ticket.
+// This implementation always returns true.
+func (_ ticketNotFoundError) Ticket() bool {
+	return true
+}
+
+// NotFound returns true, if it represents a NotFound case.
+// This implementation always returns true.
+func (_ ticketNotFoundError) NotFound() bool {
+	return true
+}
+
+// Unwrap unpacks the cause or returns nil.
+func (e ticketNotFoundError) Unwrap() error {
+	return e.cause
+}
+
+// Error returns the conventional description of this error.
+func (e ticketNotFoundError) Error() string {
+	return "NotFound"
+}
+
+// TicketAlreadyDeclaredError describes a situation where a domain entity has
+// been found but that was unexpected.
+type TicketAlreadyDeclaredError interface {
+	// ID returns the value of id.
+	// ID is the affected id.
+	ID() uuid.UUID
+
+	// Status returns the value of status.
+	// Status is some secret status code.
+	Status() int
+
+	// AlreadyDeclared returns true, if it represents an AlreadyDeclaredError case.
+	AlreadyDeclared() bool
+
+	TicketError
+}
+
+// AsTicketAlreadyDeclaredError finds the first error in err's chain that
+// matches anyTicketAlreadyDeclaredError behavior.
+// Returns nil if no such error is found.
+func AsTicketAlreadyDeclaredError(err error) TicketAlreadyDeclaredError {
+	var match TicketAlreadyDeclaredError
+	if errors.As(err, &match) && match.Ticket() && match.AlreadyDeclared() {
+		return match
+	}
+
+	return nil
+}
+
+// ticketAlreadyDeclaredError describes a situation where a domain entity has
+// been found but that was unexpected.
+// ticketAlreadyDeclaredError is also a TicketError.
+type ticketAlreadyDeclaredError struct {
+	// id is the affected id.
+	id uuid.UUID
+
+	// status is some secret status code.
+	status int
+
+	// cause refers to a causing error or nil.
+	cause error
+}
+
+// ID returns the value of id.
+// ID is the affected id.
+func (e ticketAlreadyDeclaredError) ID() uuid.UUID {
+	return e.id
+}
+
+// Status returns the value of status.
+// Status is some secret status code.
+func (e ticketAlreadyDeclaredError) Status() int {
+	return e.status
+}
+
+// Ticket returns true, if the error belongs to the sum type of Ticket.
+// This implementation always returns true.
+func (_ ticketAlreadyDeclaredError) Ticket() bool {
+	return true
+}
+
+// AlreadyDeclared returns true, if it represents an AlreadyDeclaredError case.
+// This implementation always returns true.
+func (_ ticketAlreadyDeclaredError) AlreadyDeclared() bool {
+	return true
+}
+
+// Unwrap unpacks the cause or returns nil.
+func (e ticketAlreadyDeclaredError) Unwrap() error {
+	return e.cause
+}
+
+// Error returns the conventional description of this error.
+func (e ticketAlreadyDeclaredError) Error() string {
+	return fmt.Sprintf("AlreadyDeclaredError: id=%v, status=%v", e.id, e.status)
+}
+
+func TestError() error {
+	var matchedErr interface {
+		Ticket() bool
+		AlreadyDeclared() bool
+		ID() uuid.UUID
+		Status() int
+	}
+
+	if errors.As(err, &matchedErr) && matchedErr.Ticket() && matchedErr.AlreadyDeclared() {
+	}
+	var matchedErr2 interface {
+		Ticket() bool
+	}
+
+	if errors.As(err, &matchedErr2) && matchedErr2.Ticket() {
+	}
+	var matchedErr3 interface {
+		AlreadyDeclared() bool
+	}
+
+	if errors.As(err, &matchedErr3) && matchedErr3.AlreadyDeclared() {
+	}
+	return ticketNotFoundError{}
+	return ticketAlreadyDeclaredError{id: nil, status: 42}
+}
@@ -0,0 +1,7 @@
+module github.com/myproject/mymodule
+
+go 1.16
+
+require (
+	github.com/golangee/sql v0.0.0-20210531101020-33021aed64c2
+)
@@ -0,0 +1,212 @@
+package golang
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	goast "go/ast"
+	"go/build"
+	goimporter "go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// A TypeError describes a problem of the rendered Go source, which has been detected by the type checker.
+type TypeError struct {
+	Generated token.Position // Generated is the position within the rendered file, relative to the artifact root.
+	Node      ast.Node       // Node is the innermost model node which produced the declaration. May be nil.
+	Msg       string
+}
+
+// Pos returns the first defined position of the originating model node or its parents.
+func (e TypeError) Pos() ast.Pos {
+	return modelPos(e.Node)
+}
+
+func (e TypeError) Error() string {
+	if pos := e.Pos(); pos.Line > 0 {
+		return fmt.Sprintf("%s: %s (generated %s)", pos, e.Msg, e.Generated)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Generated, e.Msg)
+}
+
+// TypeErrors contains all errors of a type check in the order of their detection.
+type TypeErrors []TypeError
+
+func (e TypeErrors) Error() string {
+	tmp := make([]string, 0, len(e))
+	for _, err := range e {
+		tmp = append(tmp, err.Error())
+	}
+
+	return fmt.Sprintf("%d type errors:\n%s", len(e), strings.Join(tmp, "\n"))
+}
+
+// renderedPkg connects a model package with its rendered directory.
+type renderedPkg struct {
+	pkg  *ast.Pkg
+	dir  *render.Dir
+	path string // the slash separated directory, relative to the artifact root
+}
+
+// typeChecker implements types.ImporterFrom and type checks the rendered packages on demand. Packages of the
+// local GOROOT are type checked from source. Any other package cannot be resolved offline and is replaced by
+// a fake package, for which go/types reports no follow-up errors.
+type typeChecker struct {
+	fset        *token.FileSet
+	rendered    map[string]*renderedPkg // by import path
+	checked     map[string]*types.Package
+	inProgress  map[string]bool
+	std         types.ImporterFrom
+	unavailable map[string]bool
+	errs        TypeErrors
+}
+
+func newTypeChecker(pkgs []*renderedPkg) *typeChecker {
+	fset := token.NewFileSet()
+	c := &typeChecker{
+		fset:        fset,
+		rendered:    map[string]*renderedPkg{},
+		checked:     map[string]*types.Package{},
+		inProgress:  map[string]bool{},
+		std:         goimporter.ForCompiler(fset, "source", nil).(types.ImporterFrom),
+		unavailable: map[string]bool{},
+	}
+
+	for _, p := range pkgs {
+		c.rendered[p.pkg.Path] = p
+	}
+
+	return c
+}
+
+// typeCheck checks all rendered packages and returns TypeErrors if anything is wrong.
+func (r *Renderer) typeCheck() error {
+	c := newTypeChecker(r.rendered)
+	for _, p := range r.rendered {
+		if _, err := c.Import(p.pkg.Path); err != nil {
+			return fmt.Errorf("cannot type check package '%s': %w", p.pkg.Path, err)
+		}
+	}
+
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+
+	return nil
+}
+
+func (c *typeChecker) Import(path string) (*types.Package, error) {
+	return c.ImportFrom(path, "", 0)
+}
+
+func (c *typeChecker) ImportFrom(importPath, dir string, mode types.ImportMode) (*types.Package, error) {
+	if pkg, ok := c.checked[importPath]; ok {
+		return pkg, nil
+	}
+
+	if p, ok := c.rendered[importPath]; ok {
+		if c.inProgress[importPath] {
+			return nil, fmt.Errorf("import cycle via '%s'", importPath)
+		}
+
+		c.inProgress[importPath] = true
+		pkg := c.check(p)
+		c.checked[importPath] = pkg
+
+		return pkg, nil
+	}
+
+	if isStdPkg(importPath) {
+		pkg, err := c.std.ImportFrom(importPath, dir, mode)
+		if err != nil {
+			return nil, err
+		}
+
+		c.checked[importPath] = pkg
+
+		return pkg, nil
+	}
+
+	c.unavailable[importPath] = true
+
+	return nil, fmt.Errorf("package '%s' is not available offline", importPath)
+}
+
+// check parses and type checks the rendered files of the package.
+func (c *typeChecker) check(p *renderedPkg) *types.Package {
+	var files []*goast.File
+	byName := map[string]*ast.File{}
+	parsed := map[string]*goast.File{}
+	for _, file := range p.pkg.PkgFiles {
+		byName[path.Join(p.path, file.Name)] = file
+	}
+
+	for _, file := range p.dir.Files {
+		if file.MimeType != MimeTypeGo {
+			continue
+		}
+
+		fname := path.Join(p.path, file.FileName)
+		f, err := parser.ParseFile(c.fset, fname, file.Buf, parser.ParseComments)
+		if err != nil {
+			c.errs = append(c.errs, TypeError{
+				Generated: token.Position{Filename: fname},
+				Node:      byName[fname],
+				Msg:       err.Error(),
+			})
+
+			continue
+		}
+
+		parsed[fname] = f
+		files = append(files, f)
+	}
+
+	conf := types.Config{
+		Importer: c,
+		Error: func(err error) {
+			terr, ok := err.(types.Error)
+			if !ok {
+				c.errs = append(c.errs, TypeError{Msg: err.Error()})
+				return
+			}
+
+			for path := range c.unavailable {
+				if strings.HasPrefix(terr.Msg, "could not import "+path+" ") {
+					return
+				}
+			}
+
+			pos := c.fset.Position(terr.Pos)
+			var node ast.Node
+			if file := byName[pos.Filename]; file != nil {
+				node = resolveNode(file, parsed[pos.Filename], terr.Pos)
+			}
+
+			c.errs = append(c.errs, TypeError{Generated: pos, Node: node, Msg: terr.Msg})
+		},
+	}
+
+	// errors are collected by the handler above
+	pkg, _ := conf.Check(p.pkg.Path, c.fset, files, nil)
+
+	return pkg
+}
+
+// isStdPkg returns true, if the import path denotes a package of the local GOROOT.
+func isStdPkg(importPath string) bool {
+	if importPath == "C" || strings.Contains(strings.Split(importPath, "/")[0], ".") {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join(build.Default.GOROOT, "src", filepath.FromSlash(importPath)))
+
+	return err == nil && info.IsDir()
+}
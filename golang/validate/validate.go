@@ -3,17 +3,13 @@ package validate
 import (
 	"fmt"
 	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
 	"unicode"
 )
 
 // Keywords contains all go keywords which may not be used as identifiers.
 // See https://golang.org/ref/spec#Keywords.
-var Keywords = []string{"break", "default", "func", "interface", "select",
-	"case", "defer", "go", "map", "struct",
-	"chan", "else", "goto", "package", "switch",
-	"const", "fallthrough", "if", "range", "type",
-	"continue", "for", "import", "return", "var",
-}
+var Keywords = naming.GoKeywords
 
 // Identifier asserts the given string is an identifier.
 // See https://golang.org/ref/spec#Identifiers.
@@ -0,0 +1,229 @@
+// Package diff provides a minimal line based unified diff, which is sufficient to show changes of generated files.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the amount of unchanged lines around each change.
+const context = 3
+
+// noNewline marks the last line of a text without a trailing line break, just like the diff tool.
+const noNewline = "\n\\ No newline at end of file"
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	a, b int // line indices into the old and new text
+}
+
+// Unified returns the differences between old and new in the unified diff format or the empty string, if both
+// are equal. The names are used for the file header lines.
+func Unified(oldName, newName string, old, new []byte) string {
+	if string(old) == string(new) {
+		return ""
+	}
+
+	a := splitLines(string(old))
+	b := splitLines(string(new))
+	ops := compute(a, b)
+
+	sb := &strings.Builder{}
+	sb.WriteString("--- " + oldName + "\n")
+	sb.WriteString("+++ " + newName + "\n")
+
+	for start := 0; start < len(ops); {
+		// find the next change
+		for start < len(ops) && ops[start].kind == opEqual {
+			start++
+		}
+
+		if start == len(ops) {
+			break
+		}
+
+		// extend the hunk as long as changes are close together
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != opEqual {
+				end = i + 1
+				continue
+			}
+
+			if i-end >= 2*context {
+				break
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context, len(ops))
+		writeHunk(sb, a, b, ops[from:to])
+		start = to
+	}
+
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, a, b []string, ops []op) {
+	aStart, bStart := -1, -1
+	aLen, bLen := 0, 0
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			aLen++
+			bLen++
+		case opDelete:
+			aLen++
+		case opInsert:
+			bLen++
+		}
+
+		if aStart < 0 && o.kind != opInsert {
+			aStart = o.a
+		}
+
+		if bStart < 0 && o.kind != opDelete {
+			bStart = o.b
+		}
+	}
+
+	// empty ranges refer to the line before, just like the diff tool
+	if aStart < 0 {
+		aStart = ops[0].a - 1
+	}
+
+	if bStart < 0 {
+		bStart = ops[0].b - 1
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart+1, aLen, bStart+1, bLen)
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			sb.WriteString(" " + a[o.a] + "\n")
+		case opDelete:
+			sb.WriteString("-" + a[o.a] + "\n")
+		case opInsert:
+			sb.WriteString("+" + b[o.b] + "\n")
+		}
+	}
+}
+
+// compute implements the greedy algorithm of Myers, see "An O(ND) Difference Algorithm and Its Variations".
+func compute(a, b []string) []op {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD
+	v := make([]int, 2*maxD+2)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, offset, n, m)
+			}
+		}
+	}
+
+	return nil
+}
+
+func backtrack(trace [][]int, offset, x, y int) []op {
+	var ops []op
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, op{kind: opEqual, a: x, b: y})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, op{kind: opInsert, a: x, b: y})
+			} else {
+				x--
+				ops = append(ops, op{kind: opDelete, a: x, b: y})
+			}
+		}
+	}
+
+	// reverse into natural order
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
+
+// splitLines returns the lines without their line breaks. A last line without a line break carries the noNewline
+// marker, so that it differs from the same line with a line break.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += noNewline
+
+	return lines
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -3,52 +3,216 @@ package java
 import (
 	"fmt"
 	"github.com/golangee/src/ast"
-	"path/filepath"
-	"github.com/golangee/src"
+	"github.com/golangee/src/render"
+	"strings"
 )
 
-// Render emits the declared module as a java project.
-func Render(mod *src.Module) ([]src.RenderedFile, error) {
-	var res []src.RenderedFile
-
-	tree := ast.NewModNode(mod)
-	installImporter(tree)
-
-	for _, p := range tree.Packages() {
-		if p.SrcPackage().Doc() != "" {
-			pDoc := src.NewSrcFile(packageJavaDocFile)
-			pDoc.SetDoc(p.SrcPackage().Doc())
-			pDoc.SetDocPreamble(p.SrcPackage().DocPreamble())
-			docNode := ast.NewSrcFileNode(p, pDoc)
-			docNode.SetValue(importerId, newImporter())
-			buf, err := renderFile(docNode)
-			if err != nil {
-				panic("illegal state: " + err.Error() + ": " + string(buf))
-			}
+const (
+	PackageJavaDocFile = "package-info.java"
+
+	MimeTypeJava     = "text/x-java-source"
+	MimeTypeDir      = "application/x-directory"
+	MimeTypeJavaPkg  = "application/x-directory-java-package"
+	MimeTypeJavaRoot = "application/x-directory-java-module"
+)
+
+// Options for the renderer.
+type Options struct {
+	// GoogleJavaFormatJar is the path to a local google-java-format jar (all-deps variant). If not empty, the
+	// rendered files are formatted by executing it with the java command instead of using the built-in Format.
+	GoogleJavaFormatJar string
+
+	// BuildFiles selects the emitted build files. If zero, a gradle build is emitted.
+	BuildFiles BuildFile
+
+	// SkipBuildFiles disables the build files, so that only the sources are emitted.
+	SkipBuildFiles bool
+}
+
+// Renderer provides a java renderer.
+type Renderer struct {
+	opts      Options
+	root      ast.Node
+	importers map[ast.Node]*importer // either an *ast.File or an *ast.Pkg
+	// functionals collects the synthesized functional interfaces per package, see renderFuncTypeDecl.
+	functionals map[*ast.Pkg]map[string]functionalInterface
+}
 
-			res = append(res, src.RenderedFile{
-				AbsoluteFileName: filepath.Join(p.SrcPackage().ImportPath(), docNode.SrcFile().Name()+".java"),
-				MimeType:         mimeTypeJava,
-				Buf:              buf,
-				Error:            err,
-			})
+// NewRenderer creates a new Renderer instance.
+func NewRenderer(opts Options) *Renderer {
+	return &Renderer{opts: opts}
+}
+
+func init() {
+	render.Register(ast.LangJava, ast.FrameworkSDK, func() render.Renderer {
+		return NewRenderer(Options{})
+	})
+}
+
+// tearUp prepares the ast to be used for source generation.
+func (r *Renderer) tearUp(node ast.Node) error {
+	r.root = ast.Root(node)
+	r.functionals = map[*ast.Pkg]map[string]functionalInterface{}
+
+	if err := installImporter(r); err != nil {
+		return fmt.Errorf("unable to install importer: %w", err)
+	}
+
+	return nil
+}
 
+// tearDown frees allocated resources.
+func (r *Renderer) tearDown() error {
+	r.functionals = nil
+	if err := uninstallImporter(r); err != nil {
+		return fmt.Errorf("unable to uninstall importer: %w", err)
+	}
+
+	return nil
+}
+
+// importer resolves the current importer from the parents file.
+func (r *Renderer) importer(n ast.Node) *importer {
+	return importerFromTree(r, n)
+}
+
+// Render converts the given node into a render.Artifact. A partial result is returned if an error is detected.
+// If node is an *ast.Mod, only that module is rendered and it must target ast.LangJava. Otherwise all Java modules
+// of the project are rendered and other modules are ignored. Use render.Project to render mixed projects.
+func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
+	if mod, ok := node.(*ast.Mod); ok && mod.Target.Lang != ast.LangJava {
+		return nil, fmt.Errorf("cannot render module '%s': expected language '%s' but got '%s'", mod.Name, ast.LangJava, mod.Target.Lang)
+	}
+
+	if err := r.tearUp(node); err != nil {
+		return nil, fmt.Errorf("unable to tearUp: %w", err)
+	}
+
+	defer func() {
+		if e := r.tearDown(); e != nil && err == nil {
+			err = e
 		}
+	}()
+
+	root := &render.Dir{}
+	if mod, ok := node.(*ast.Mod); ok {
+		_, err = r.renderMod(mod, root)
+		return root, err
+	}
 
-		for _, file := range p.Files() {
-			buf, err := renderFile(file)
-			res = append(res, src.RenderedFile{
-				AbsoluteFileName: filepath.Join(p.SrcPackage().ImportPath(), file.SrcFile().Name()+".java"),
-				MimeType:         mimeTypeJava,
-				Buf:              buf,
-				Error:            err,
-			})
-
-			if err != nil {
-				return res, fmt.Errorf("unable to render %s/%s: %w", p.SrcPackage().ImportPath(), file.SrcFile().Name(), err)
+	err = ast.ForEachMod(node, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangJava {
+			if _, err := r.renderMod(mod, root); err != nil {
+				return fmt.Errorf("cannot render module '%s': %w", mod.Name, err)
 			}
 		}
+
+		return nil
+	})
+
+	if err != nil {
+		return root, fmt.Errorf("cannot render project: %w", err)
+	}
+
+	return root, nil
+}
+
+// renderMod emits the build files and each package into a directory hierarchy according to its fully qualified
+// name, relative to the SourceDir of the modules output directory.
+func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, error) {
+	modDir := r.ensureDir(mod.Target.Out, parent)
+	modDir.MimeType = MimeTypeJavaRoot
+
+	var firstErr error
+	if err := r.renderBuild(mod, parent, modDir); err != nil {
+		firstErr = fmt.Errorf("cannot render build files: %w", err)
+	}
+
+	srcDir := r.ensureDir(SourceDir, modDir)
+	for _, pkg := range mod.Pkgs {
+		pkgDir := r.ensureDir(strings.ReplaceAll(pkg.Path, ".", "/"), srcDir)
+		pkgDir.MimeType = MimeTypeJavaPkg
+
+		files, err := r.renderPkg(pkg)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render package '%s': %w", pkg.Path, err)
+		}
+
+		pkgDir.Files = append(pkgDir.Files, files...)
+	}
+
+	return modDir, firstErr
+}
+
+func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
+	var res []*render.File
+	var firstErr error
+
+	if pkg.Preamble != nil || pkg.ObjComment != nil {
+		buf, err := r.renderPkgInfo(pkg)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render %s: %w", PackageJavaDocFile, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: PackageJavaDocFile,
+			MimeType: MimeTypeJava,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	for _, file := range pkg.PkgFiles {
+		buf, err := r.renderFile(file)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render file '%s': %w", file.Name, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: MimeTypeJava,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	functionals, err := r.renderFunctionalInterfaces(pkg)
+	if firstErr == nil && err != nil {
+		firstErr = err
+	}
+
+	res = append(res, functionals...)
+
+	for _, file := range pkg.RawFiles {
+		buf, err := file.Data(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render raw file: %w", err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: file.MimeType,
+			Buf:      buf,
+		})
+	}
+
+	return res, firstErr
+}
+
+// ensureDir appends for each path segment a directory, if required. Returns the directory denoting
+// the last segment.
+func (r *Renderer) ensureDir(restPath string, parent *render.Dir) *render.Dir {
+	names := strings.Split(restPath, "/")
+
+	dir := parent.Directory(names[0])
+	if dir == nil {
+		dir = &render.Dir{DirName: names[0], MimeType: MimeTypeDir}
+		parent.Dirs = append(parent.Dirs, dir)
+	}
+
+	if len(names) == 1 {
+		return dir
 	}
 
-	return res, nil
+	return r.ensureDir(strings.Join(names[1:], "/"), dir)
 }
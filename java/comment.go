@@ -1,34 +1,175 @@
 package java
 
-import "strings"
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"html"
+	"strings"
+	"unicode"
+)
 
-// formatComment replaces a '...' prefix with the ellipsisName and prefixes all lines
-// with a ' * '. Also a new first line (/**) and a new last line ( */) is added.
+// docWidth is the width at which the lines of a javadoc comment are wrapped, including the comment prefix.
+const docWidth = 100
+
+// formatComment replaces a '...' prefix with the ellipsisName and emits the structured content of the doc as
+// javadoc: paragraphs are separated by <p>, lists become <ul> or <ol>, code blocks become <pre> and doc links
+// become {@link}. A deprecation notice and all block tags, like @param, are emitted at the end. Lines are
+// prefixed with a ' * ' and wrapped at docWidth. Also a new first line (/**) and a new last line ( */) is added.
 func formatComment(ellipsisName, doc string) string {
-	doc = strings.TrimSpace(doc)
-	if len(doc) > 0 {
-		tmp := &strings.Builder{}
-		if strings.HasPrefix(doc, "...") {
-			tmp.WriteString(ellipsisName)
-			tmp.WriteString(" ")
-			tmp.WriteString(strings.TrimSpace(doc[3:]))
-		} else {
-			tmp.WriteString(doc)
-		}
-		str := tmp.String()
-		tmp.Reset()
-		tmp.WriteString("/**\n")
-		for _, line := range strings.Split(str, "\n") {
-			tmp.WriteString(" * ")
-			tmp.WriteString(line)
-			tmp.WriteString("\n")
-		}
-		tmp.WriteString(" */")
-
-		return tmp.String()
-	}
-
-	return ""
+	doc = strings.TrimLeft(strings.TrimRight(doc, " \t\n"), "\n")
+	if strings.TrimSpace(doc) == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(doc), "...") {
+		doc = strings.TrimSpace(ellipsisName + " " + strings.TrimSpace(strings.TrimSpace(doc)[3:]))
+	}
+
+	description, tags := splitTags(doc)
+
+	tmp := &strings.Builder{}
+	tmp.WriteString("/**\n")
+	first := true
+	for _, block := range ast.ParseDoc(description) {
+		if block.Kind == ast.DocDeprecated {
+			tags = append(tags, "@deprecated "+block.Text)
+			continue
+		}
+
+		if !first {
+			tmp.WriteString(" *\n")
+		}
+
+		switch block.Kind {
+		case ast.DocParagraph:
+			prefix := "<p>"
+			if first {
+				prefix = ""
+			}
+
+			writeDocLines(tmp, prefix, "", javadocText(block.Text))
+		case ast.DocList:
+			tag := "ul"
+			if block.Numbered {
+				tag = "ol"
+			}
+
+			tmp.WriteString(" * <" + tag + ">\n")
+			for _, item := range block.Items {
+				writeDocLines(tmp, "  <li>", "      ", javadocText(item))
+			}
+
+			tmp.WriteString(" * </" + tag + ">\n")
+		case ast.DocCode:
+			tmp.WriteString(" * <pre>\n")
+			for _, line := range strings.Split(block.Text, "\n") {
+				tmp.WriteString(strings.TrimRight(" * "+escapeJavadoc(strings.ReplaceAll(html.EscapeString(line), "@", "&#64;")), " "))
+				tmp.WriteString("\n")
+			}
+
+			tmp.WriteString(" * </pre>\n")
+		}
+
+		first = false
+	}
+
+	if len(tags) > 0 {
+		if !first {
+			tmp.WriteString(" *\n")
+		}
+
+		for _, tag := range tags {
+			writeDocLines(tmp, "", "    ", javadocText(tag))
+		}
+	}
+
+	tmp.WriteString(" */")
+
+	return tmp.String()
+}
+
+// splitTags separates the description from the block tags, which start at the first line beginning with an @.
+func splitTags(doc string) (string, []string) {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range lines[i:] {
+			switch {
+			case strings.TrimSpace(tag) == "":
+			case strings.HasPrefix(tag, ast.DeprecatedPrefix):
+				tags = append(tags, "@deprecated "+strings.TrimSpace(tag[len(ast.DeprecatedPrefix):]))
+			case strings.HasPrefix(tag, "@") || len(tags) == 0:
+				tags = append(tags, strings.TrimSpace(tag))
+			default:
+				tags[len(tags)-1] += "\n" + strings.TrimSpace(tag)
+			}
+		}
+
+		return strings.Join(lines[:i], "\n"), tags
+	}
+
+	return doc, nil
+}
+
+// writeDocLines wraps each line of text and emits it with the first prefix, all following lines are indented.
+func writeDocLines(w *strings.Builder, first, indent, text string) {
+	prefix := first
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range render.WrapLine(line, docWidth-len(" * ")-len(prefix)) {
+			w.WriteString(" * ")
+			w.WriteString(prefix)
+			w.WriteString(wrapped)
+			w.WriteString("\n")
+			prefix = indent
+		}
+	}
+}
+
+// javadocText replaces the doc links of the text with {@link} tags.
+func javadocText(text string) string {
+	sb := &strings.Builder{}
+	for _, span := range ast.DocSpans(text) {
+		if span.Link == "" {
+			sb.WriteString(escapeJavadoc(span.Text))
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("{@link %s}", javadocLink(span.Link)))
+	}
+
+	return sb.String()
+}
+
+// javadocLink converts a doc link into the javadoc reference syntax, e.g. java.util.List.add becomes
+// java.util.List#add. A member is detected by the capitalized name of its enclosing type.
+func javadocLink(name ast.Name) string {
+	qualifier := name.Qualifier()
+	if qualifier == "" {
+		return string(name)
+	}
+
+	typeName := qualifier[strings.LastIndex(qualifier, ".")+1:]
+	if typeName != "" && unicode.IsUpper([]rune(typeName)[0]) {
+		return qualifier + "#" + name.Identifier()
+	}
+
+	return string(name)
+}
+
+// isEmptyComment returns true, if there is no comment or if it contains only white space, which would result in an
+// empty block tag.
+func isEmptyComment(comment *ast.Comment) bool {
+	return comment == nil || strings.TrimSpace(comment.Text) == ""
+}
+
+// escapeJavadoc prevents that the text terminates the comment.
+func escapeJavadoc(text string) string {
+	return strings.ReplaceAll(text, "*/", "*&#47;")
 }
 
 func deEllipsis(ellipsisName, doc string) string {
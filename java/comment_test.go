@@ -0,0 +1,50 @@
+package java
+
+import (
+	"github.com/golangee/src/ast"
+	"testing"
+)
+
+func TestFormatComment(t *testing.T) {
+	doc := ast.NewComment("...greets the [java.util.List] and calls [java.util.List.add].").
+		AddList("first", "second").
+		AddCode("if (a < b) {\n    @Ignore\n}").
+		AddDeprecated("use [Greeter] instead.")
+
+	want := `/**
+ * Hello greets the {@link java.util.List} and calls {@link java.util.List#add}.
+ *
+ * <ul>
+ *   <li>first
+ *   <li>second
+ * </ul>
+ *
+ * <pre>
+ * if (a &lt; b) {
+ *     &#64;Ignore
+ * }
+ * </pre>
+ *
+ * @param a is the first
+ * @deprecated use {@link Greeter} instead.
+ */`
+
+	if got := formatComment("Hello", doc.Text+"\n\n@param a is the first"); got != want {
+		t.Fatalf("formatComment() = \n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatCommentDeprecation(t *testing.T) {
+	doc := ast.DocWithDeprecation("...greets.\n\n@param name is the greeted", ast.NewDeprecation("impolite", "Greeter.greet"))
+
+	want := `/**
+ * Hello greets.
+ *
+ * @param name is the greeted
+ * @deprecated impolite. Use {@link Greeter#greet} instead.
+ */`
+
+	if got := formatComment("Hello", doc); got != want {
+		t.Fatalf("formatComment() = \n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,73 +1,46 @@
 package java
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
+	"bytes"
 	"fmt"
-	"github.com/golangee/src"
-	"io/ioutil"
-	"net/http"
+	"github.com/golangee/src/render"
 	"os"
 	"os/exec"
-	"path/filepath"
 )
 
-const googleJavaFormatUrl = "https://github.com/google/google-java-format/releases/download/google-java-format-1.9/google-java-format-1.9-all-deps.jar"
-const googleJavaFormatShaSum = "3214b30794e15bf501ae66805ca40475de71e831"
-
-// Format tries to apply the google-java-format rules to the given text
-// (see https://google.github.io/styleguide/javaguide.html).
+// Format applies a built-in pretty printer to the given text, which is close to the google-java-format rules
+// (see https://google.github.io/styleguide/javaguide.html): blocks are indented by 2 spaces and use the
+// Kernighan & Ritchie style, imports are sorted into a static and a non-static group and lines are wrapped at
+// 100 columns, if possible. It only checks the lexical structure and the nesting of the source.
 // If it fails, the error is returned and the string contains the text with line enumeration.
-// The formatter is automatically downloaded into the tmp folder and executed from there.
 func Format(source []byte) ([]byte, error) {
-	dir := filepath.Join(os.TempDir(), "golangee", "google-java-format")
-	jarFile := filepath.Join(dir, "google-java-format")
-	if _, err := os.Stat(jarFile); err != nil {
-		fmt.Printf("downloading google-java-format...\n")
-		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-			return nil, fmt.Errorf("unable to create temporary folder: %w", err)
-		}
-
-		// download
-		res, err := http.Get(googleJavaFormatUrl)
-		if err != nil {
-			return nil, fmt.Errorf("cannot start download %s: %w", googleJavaFormatUrl, err)
-		}
-
-		defer res.Body.Close()
-
-		buf, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return nil, fmt.Errorf("cannot download %s: %w", googleJavaFormatUrl, err)
-		}
+	res, err := prettyPrint(string(source))
+	if err != nil {
+		return []byte(render.WithLineNumbers(string(source))), fmt.Errorf("cannot format: %w", err)
+	}
 
-		shaSum := sha1.Sum(buf)
-		if hex.EncodeToString(shaSum[:]) != googleJavaFormatShaSum {
-			return nil, fmt.Errorf("download corruption detected %s. Expected: %s", googleJavaFormatUrl, googleJavaFormatShaSum)
-		}
+	return []byte(res), nil
+}
 
-		if err := ioutil.WriteFile(jarFile, buf, os.ModePerm); err != nil {
-			return nil, fmt.Errorf("cannot save jar file %s: %w", jarFile, err)
-		}
+// FormatWithJar applies the google-java-format rules to the given text by executing the given local
+// google-java-format jar (all-deps variant) with the java command, which must be installed.
+// If it fails, the error is returned and the string contains the text with line enumeration.
+func FormatWithJar(jarFile string, source []byte) ([]byte, error) {
+	if _, err := os.Stat(jarFile); err != nil {
+		return []byte(render.WithLineNumbers(string(source))), fmt.Errorf("cannot find google-java-format: %w", err)
 	}
 
 	cmd := exec.Command("java", "-jar", jarFile, "-")
 	cmd.Env = os.Environ()
-	cmd.Dir = dir
-	w, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, fmt.Errorf("unable to open stdinPipe: %w", err)
-	}
-
-	if _, err := w.Write(source); err != nil {
-		return nil, fmt.Errorf("unable to pipe to stdin: %w", err)
-	}
+	cmd.Stdin = bytes.NewReader(source)
 
-	_ = w.Close()
+	// warnings are written to stderr, so they must not become a part of the source
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
 
-	res, err := cmd.CombinedOutput()
+	res, err := cmd.Output()
 	if err != nil {
-		return []byte(src.WithLineNumbers(string(source))), fmt.Errorf("cannot format: %s: %w", string(res), err)
+		return []byte(render.WithLineNumbers(string(source))), fmt.Errorf("cannot format: %s: %w", stderr.String(), err)
 	}
 
 	return res, nil
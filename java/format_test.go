@@ -1,13 +1,19 @@
 package java
 
 import (
-	"fmt"
+	"github.com/golangee/src/render"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 )
 
 func TestFormat(t *testing.T) {
 	src0 := `
 package myTest ;
+import java.util.Map;
+import static org.junit.Assert.assertEquals;
+import java.util.List;
 
 // bla
 public class App
@@ -18,9 +24,53 @@ another line
 
 another lang
 */
-public static void  main(args ...String)
+public static void  main(String... args)
 {
 }
+private Map<String,List<Integer>> m=new HashMap<>();
+int[] arr = {1,2,3};
+int foo(int a,int b) {
+for (int i=0;i<a;i++) { if (a>=b && a>>>2 > -1) { a--; } else { b++; } }
+switch (a) { case 1: return (int) (b); default: return someVeryLongMethodName(argumentNumberOne, argumentNumberTwo, argumentNumberThree, argumentNumberFour); }
+}
+}
+`
+
+	want := `package myTest;
+
+import static org.junit.Assert.assertEquals;
+
+import java.util.List;
+import java.util.Map;
+
+// bla
+public class App {
+  /**
+   * Another comment
+   * another line
+   *
+   * another lang
+   */
+  public static void main(String... args) {}
+
+  private Map<String, List<Integer>> m = new HashMap<>();
+  int[] arr = {1, 2, 3};
+  int foo(int a, int b) {
+    for (int i = 0; i < a; i++) {
+      if (a >= b && a >>> 2 > -1) {
+        a--;
+      } else {
+        b++;
+      }
+    }
+    switch (a) {
+      case 1:
+        return (int) (b);
+      default:
+        return someVeryLongMethodName(argumentNumberOne, argumentNumberTwo, argumentNumberThree,
+            argumentNumberFour);
+    }
+  }
 }
 `
 
@@ -28,11 +78,45 @@ public static void  main(args ...String)
 	if err != nil {
 		t.Fatal(err, string(src))
 	}
-	fmt.Println(string(src))
 
-	src1 := `invalid stuff`
-	if _, err := Format([]byte(src1)); err == nil {
+	if string(src) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(src))
+	}
+
+	for _, invalid := range []string{`invalid stuff`, `class A {`, `class A { void a() { a(; } }`, `class A { String s = "a; }`} {
+		if _, err := Format([]byte(invalid)); err == nil {
+			t.Fatalf("should have failed: %s", invalid)
+		}
+	}
+}
+
+func TestFormatWithJar(t *testing.T) {
+	jar := os.Getenv("GOOGLE_JAVA_FORMAT_JAR")
+	if jar == "" {
+		t.Skip("GOOGLE_JAVA_FORMAT_JAR is not set")
+	}
+
+	if _, err := exec.LookPath("java"); err != nil {
+		t.Skip("java is not installed")
+	}
+
+	if _, err := FormatWithJar(jar, []byte("package myTest; public class App {}")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FormatWithJar(jar, []byte(`invalid stuff`)); err == nil {
+		t.Fatal("should have failed")
+	}
+}
+
+func TestFormatWithJarMissing(t *testing.T) {
+	source := "package myTest; public class App {}"
+	res, err := FormatWithJar(filepath.Join(t.TempDir(), "missing.jar"), []byte(source))
+	if err == nil {
 		t.Fatal("should have failed")
 	}
 
+	if string(res) != render.WithLineNumbers(source) {
+		t.Fatalf("expected the enumerated source but got\n%s", res)
+	}
 }
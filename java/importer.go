@@ -1,40 +1,71 @@
 package java
 
 import (
-	"github.com/golangee/src"
 	"github.com/golangee/src/ast"
 	"sort"
 )
 
-type importerKey int
-
-const importerId importerKey = 1
-
-// importer manages the rendered import section at the files top.
+// importer manages the rendered import section at the files top. Types of java.lang and of the own package
+// are in scope without an import declaration. Simple names are unique in the scope, so a colliding name stays
+// fully qualified at the use site.
 type importer struct {
-	identifiersInScope map[string]src.Name
+	pkg                string              // the package of the scope, e.g. com.example
+	identifiersInScope map[string]ast.Name // simple type names and their qualified names
+	imported           map[ast.Name]bool   // the qualified names, which require an import declaration
+	staticInScope      map[string]ast.Name // simple member names and their qualified names
 }
 
-func newImporter() *importer {
-	return &importer{
-		identifiersInScope: map[string]src.Name{},
+// newImporter allocates an importer for a scope of the given package. The declared identifiers are the simple
+// names of the packages types, which always win against any import.
+func newImporter(pkg string, declared []string) *importer {
+	imp := &importer{
+		pkg:                pkg,
+		identifiersInScope: map[string]ast.Name{},
+		imported:           map[ast.Name]bool{},
+		staticInScope:      map[string]ast.Name{},
+	}
+
+	for _, id := range declared {
+		imp.identifiersInScope[id] = ast.Name(pkg + "." + id)
 	}
+
+	return imp
 }
 
-// installImporter installs a new importer instance into every ast.SrcFileNode.
-func installImporter(n *ast.ModNode) {
-	for _, node := range n.Packages() {
-		for _, fileNode := range node.Files() {
-			fileNode.SetValue(importerId, newImporter())
+// installImporter allocates a new importer instance for every ast.Pkg (used by the package-info.java file) and
+// every ast.File. The importers are owned by the renderer and never attached to the ast.
+func installImporter(r *Renderer) error {
+	r.importers = map[ast.Node]*importer{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		for _, pkg := range mod.Pkgs {
+			var declared []string
+			for _, file := range pkg.PkgFiles {
+				for _, namedType := range file.Types() {
+					declared = append(declared, namedType.Identifier())
+				}
+			}
+
+			r.importers[pkg] = newImporter(pkg.Path, declared)
+			for _, file := range pkg.PkgFiles {
+				r.importers[file] = newImporter(pkg.Path, declared)
+			}
 		}
-	}
+
+		return nil
+	})
+}
+
+// uninstallImporter releases all importers.
+func uninstallImporter(r *Renderer) error {
+	r.importers = nil
+	return nil
 }
 
-// importerFromTree walks up the tree until it finds the first importer from any ast.Node.Value.
-func importerFromTree(n ast.Node) *importer {
+// importerFromTree walks up the tree until it finds the first file or package with an importer.
+func importerFromTree(r *Renderer, n ast.Node) *importer {
 	root := n
 	for root != nil {
-		if imp, ok := root.Value(importerId).(*importer); ok {
+		if imp, ok := r.importers[root]; ok {
 			return imp
 		}
 
@@ -49,16 +80,23 @@ func importerFromTree(n ast.Node) *importer {
 	panic("invalid node")
 }
 
-// qualifiers returns the unique imported qualifiers.
+// qualifiers returns the sorted names, which require an import declaration.
 func (p *importer) qualifiers() []string {
-	tmp := map[string]string{}
-	for _, name := range p.identifiersInScope {
-		tmp[string(name)] = ""
+	var sorted []string
+	for name := range p.imported {
+		sorted = append(sorted, string(name))
 	}
 
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// staticQualifiers returns the sorted members, which require a static import declaration.
+func (p *importer) staticQualifiers() []string {
 	var sorted []string
-	for uniqueQualifier := range tmp {
-		sorted = append(sorted, uniqueQualifier)
+	for _, name := range p.staticInScope {
+		sorted = append(sorted, string(name))
 	}
 
 	sort.Strings(sorted)
@@ -66,10 +104,15 @@ func (p *importer) qualifiers() []string {
 	return sorted
 }
 
+// implicit returns true, if the qualifier is always in scope and needs no import, e.g. java.lang or the own package.
+func (p *importer) implicit(qualifier string) bool {
+	return qualifier == "java.lang" || qualifier == p.pkg
+}
+
 // shortify returns a qualified name, which is only valid in the importers scope. It may also decide to not import
 // the given name, e.g. if a collision has been detected. If the name is a universe type or not complete, the original
 // name is just returned.
-func (p *importer) shortify(name src.Name) src.Name {
+func (p *importer) shortify(name ast.Name) ast.Name {
 	qual := name.Qualifier()
 	id := name.Identifier()
 	if id == "" || qual == "" {
@@ -82,13 +125,36 @@ func (p *importer) shortify(name src.Name) src.Name {
 		// a.A => A
 		// a.B => B
 		if otherName == name {
-			return src.Name(id)
-		} else {
-			// name collision
-			return name
+			return ast.Name(id)
 		}
+
+		// name collision, e.g. java.util.Date and java.sql.Date
+		return name
 	}
 
 	p.identifiersInScope[id] = name
-	return src.Name(id)
+	if !p.implicit(qual) {
+		p.imported[name] = true
+	}
+
+	return ast.Name(id)
+}
+
+// shortifyStatic returns the simple name of a static member, like a constant, and registers a static import for it,
+// e.g. SECONDS for java.util.concurrent.TimeUnit.SECONDS. On a collision, the member is qualified by its type
+// instead, which in turn is shortified.
+func (p *importer) shortifyStatic(name ast.Name) ast.Name {
+	qual := name.Qualifier()
+	id := name.Identifier()
+	if id == "" || qual == "" {
+		return name
+	}
+
+	if otherName, inScope := p.staticInScope[id]; inScope && otherName != name {
+		return p.shortify(ast.Name(qual)) + "." + ast.Name(id)
+	}
+
+	p.staticInScope[id] = name
+
+	return ast.Name(id)
 }
@@ -0,0 +1,93 @@
+package java
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"reflect"
+	"testing"
+)
+
+func TestImporter_Shortify(t *testing.T) {
+	imp := newImporter("com.example", []string{"Date"})
+
+	tests := []struct {
+		name ast.Name
+		want ast.Name
+	}{
+		{"java.lang.String", "String"},
+		{"java.util.List", "List"},
+		{"java.util.List", "List"},
+		{"java.awt.List", "java.awt.List"},
+		{"java.util.Date", "java.util.Date"},
+		{"com.example.Date", "Date"},
+		{"com.example.Ticket", "Ticket"},
+		{"int", "int"},
+	}
+
+	for _, tt := range tests {
+		if got := imp.shortify(tt.name); got != tt.want {
+			t.Errorf("shortify(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if got := imp.shortifyStatic("java.util.concurrent.TimeUnit.SECONDS"); got != "SECONDS" {
+		t.Errorf("shortifyStatic() = %s", got)
+	}
+
+	if got := imp.shortifyStatic("com.other.Units.SECONDS"); got != "Units.SECONDS" {
+		t.Errorf("shortifyStatic() = %s", got)
+	}
+
+	if got, want := imp.qualifiers(), []string{"com.other.Units", "java.util.List"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("qualifiers() = %v, want %v", got, want)
+	}
+
+	if got, want := imp.staticQualifiers(), []string{"java.util.concurrent.TimeUnit.SECONDS"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("staticQualifiers() = %v, want %v", got, want)
+	}
+}
+
+func TestRenderer_RenderImports(t *testing.T) {
+	prj := ast.NewPrj("Imports").AddModules(
+		ast.NewMod("example.com/imports").
+			SetLang(ast.LangJava).
+			SetLangVersion("11").
+			SetOutputDirectory("imports").
+			AddPackages(
+				ast.NewPkg("com.example.imports").AddFiles(
+					ast.NewFile("Event.java").
+						AddNodes(ast.NewImport("", "org.junit.Assert.assertTrue").SetStatic(true)).
+						AddTypes(
+							ast.NewStruct("Event").SetVisibility(ast.Public).
+								AddFields(
+									ast.NewField("created", ast.NewSimpleTypeDecl("java.util.Date")),
+									ast.NewField("stored", ast.NewSimpleTypeDecl("java.sql.Date")),
+									ast.NewField("name", ast.NewSimpleTypeDecl("java.lang.String")),
+									ast.NewField("owner", ast.NewSimpleTypeDecl("com.example.imports.Owner")),
+								).
+								AddMethods(ast.NewFunc("timeout").SetBody(ast.NewBlock(
+									ast.NewAssign(ast.Exprs(ast.NewIdent("unit")), ast.AssignDefine,
+										ast.Exprs(ast.NewQualIdent("java.util.concurrent.TimeUnit.SECONDS"))),
+								))),
+						),
+					ast.NewFile("Owner.java").AddTypes(ast.NewStruct("Owner").SetVisibility(ast.Public)),
+				),
+			),
+	)
+
+	a, err := NewRenderer(Options{SkipBuildFiles: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := srctest.ReadFile(t, a.(*render.Dir), "imports/src/main/java/com/example/imports/Event.java")
+	srctest.AssertContains(t, src,
+		"import static java.util.concurrent.TimeUnit.SECONDS;\nimport static org.junit.Assert.assertTrue;\n\nimport java.util.Date;\n\n",
+		"public Date created;\n",
+		"public java.sql.Date stored;\n",
+		"public String name;\n",
+		"public Owner owner;\n",
+		"var unit = SECONDS;\n",
+	)
+}
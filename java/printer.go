@@ -0,0 +1,711 @@
+package java
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxLineWidth is the column limit of the Google Java Style.
+	maxLineWidth = 100
+
+	// blockIndent is the indentation of a block level.
+	blockIndent = "  "
+
+	// continuationIndent is the additional indentation of a wrapped line.
+	continuationIndent = "    "
+)
+
+type blockKind int
+
+const (
+	blockStatements blockKind = iota
+	blockType
+	blockEnum
+	blockSwitch
+	blockInline // array initializers and annotation element arrays
+)
+
+type block struct {
+	kind   blockKind
+	parens int  // the paren depth of the enclosing line, which is restored when the block is closed
+	inCase bool // true, if the statements of a switch case label are indented
+	do     bool // true, if the block is the body of a do-while loop
+}
+
+// piece is a token of the current line.
+type piece struct {
+	text  string
+	space bool // a space is required in front of the piece
+	depth int  // the paren depth at which the piece starts
+	wrap  bool // the line may be wrapped in front of the piece
+}
+
+// printer implements a pretty printer, which emits Java source code close to the Google Java Style.
+type printer struct {
+	tokens []token
+	pos    int
+	out    strings.Builder
+	blocks []block
+	line   []piece
+	indent string // the indentation of the current line
+	parens int    // the paren depth of the current statement
+	cont   bool
+
+	imports      []string
+	pendingBlank bool   // a blank line is emitted in front of the next line
+	lastLine     string // the last emitted line without indentation
+	closedMember bool   // the last emitted line closed a member or type declaration
+	lineStart    int    // the token index at which the current line begins
+	annotation   int    // the index of the last token of a declaration annotation, which is followed by a line break
+}
+
+// prettyPrint formats the Java source.
+func prettyPrint(src string) (string, error) {
+	tokens, err := scanJava(src)
+	if err != nil {
+		return "", err
+	}
+
+	p := &printer{tokens: tokens, annotation: -1}
+	if err := p.print(); err != nil {
+		return "", err
+	}
+
+	return p.out.String(), nil
+}
+
+func (p *printer) print() error {
+	for p.pos = 0; p.pos < len(p.tokens); p.pos++ {
+		tok := p.tokens[p.pos]
+		if len(p.line) == 0 {
+			if err := p.beginLine(tok); err != nil {
+				return err
+			}
+
+			if p.pos >= len(p.tokens) {
+				break
+			}
+
+			tok = p.tokens[p.pos]
+		}
+
+		if err := p.printToken(tok); err != nil {
+			return err
+		}
+
+		if p.pos == p.annotation {
+			p.endLine()
+		}
+	}
+
+	if len(p.line) > 0 || len(p.blocks) > 0 || p.parens != 0 {
+		return fmt.Errorf("unexpected end of file")
+	}
+
+	p.printImports()
+
+	return nil
+}
+
+// beginLine handles the tokens, which begin a new line or declaration, like the imports.
+func (p *printer) beginLine(tok token) error {
+	if len(p.blocks) == 0 && !tok.isComment() && !p.cont {
+		// the import declarations are collected, sorted and emitted at once
+		for p.pos < len(p.tokens) && p.tokens[p.pos].is("import") {
+			if err := p.collectImport(); err != nil {
+				return err
+			}
+		}
+
+		if p.pos >= len(p.tokens) {
+			return nil
+		}
+
+		tok = p.tokens[p.pos]
+		p.printImports()
+		if !tok.isComment() && !isDeclarationStart(tok) {
+			return fmt.Errorf("line %d: unexpected '%s', expected a declaration", tok.line, tok.text)
+		}
+	}
+
+	p.lineStart = p.pos
+	if tok.is("}") {
+		return nil
+	}
+
+	if tok.newline > 1 && p.lastLine != "" && !strings.HasSuffix(p.lastLine, "{") {
+		p.pendingBlank = true
+	}
+
+	if b := p.block(); b != nil {
+		switch b.kind {
+		case blockType, blockEnum:
+			if p.closedMember || (tok.kind == tokBlockComment && strings.HasPrefix(tok.text, "/**")) {
+				p.pendingBlank = p.pendingBlank || !strings.HasSuffix(p.lastLine, "{")
+			}
+		case blockSwitch:
+			if tok.is("case") || tok.is("default") {
+				b.inCase = false
+			}
+		}
+	} else if p.closedMember || strings.HasPrefix(p.lastLine, "package ") || (tok.kind == tokBlockComment && p.lastLine != "") {
+		p.pendingBlank = true
+	}
+
+	if tok.is("@") && p.pos+1 < len(p.tokens) && !p.tokens[p.pos+1].is("interface") {
+		if b := p.block(); b == nil || b.kind == blockType || b.kind == blockEnum {
+			p.annotation = p.annotationEnd(p.pos)
+		}
+	}
+
+	return nil
+}
+
+// isDeclarationStart returns true, if the token may start a top level declaration.
+func isDeclarationStart(tok token) bool {
+	switch tok.text {
+	case "package", "import", "@", ";", "public", "protected", "private", "abstract", "static", "final",
+		"strictfp", "class", "interface", "enum", "record", "sealed", "non", "module", "open":
+		return tok.kind != tokString && tok.kind != tokChar
+	default:
+		return false
+	}
+}
+
+// annotationEnd returns the index of the last token of the annotation, which starts at i.
+func (p *printer) annotationEnd(i int) int {
+	i++ // @
+	for i+2 < len(p.tokens) && p.tokens[i+1].is(".") {
+		i += 2
+	}
+
+	if i+1 < len(p.tokens) && p.tokens[i+1].is("(") {
+		depth := 0
+		for j := i + 1; j < len(p.tokens); j++ {
+			switch {
+			case p.tokens[j].is("("):
+				depth++
+			case p.tokens[j].is(")"):
+				depth--
+				if depth == 0 {
+					return j
+				}
+			}
+		}
+	}
+
+	return i
+}
+
+// collectImport consumes an import declaration.
+func (p *printer) collectImport() error {
+	sb := &strings.Builder{}
+	for ; p.pos < len(p.tokens); p.pos++ {
+		tok := p.tokens[p.pos]
+		switch {
+		case tok.isComment():
+			continue
+		case tok.is(";"):
+			p.imports = append(p.imports, sb.String()+";")
+			p.pos++
+
+			return nil
+		case tok.is("import"), tok.is("static"):
+			sb.WriteString(tok.text + " ")
+		default:
+			sb.WriteString(tok.text)
+		}
+	}
+
+	return fmt.Errorf("unterminated import declaration")
+}
+
+// printImports emits the collected static imports and the other imports as two ASCII sorted groups.
+func (p *printer) printImports() {
+	if len(p.imports) == 0 {
+		return
+	}
+
+	var static, regular []string
+	unique := map[string]bool{}
+	for _, imp := range p.imports {
+		if unique[imp] {
+			continue
+		}
+
+		unique[imp] = true
+		if strings.HasPrefix(imp, "import static ") {
+			static = append(static, imp)
+		} else {
+			regular = append(regular, imp)
+		}
+	}
+
+	p.imports = nil
+	sort.Strings(static)
+	sort.Strings(regular)
+	for _, group := range [][]string{static, regular} {
+		if len(group) == 0 {
+			continue
+		}
+
+		p.pendingBlank = p.lastLine != ""
+		for _, imp := range group {
+			p.writeLine(imp)
+		}
+	}
+
+	p.pendingBlank = true
+}
+
+// block returns the innermost block or nil.
+func (p *printer) block() *block {
+	if len(p.blocks) == 0 {
+		return nil
+	}
+
+	return &p.blocks[len(p.blocks)-1]
+}
+
+func (p *printer) prevToken() token {
+	if len(p.line) == 0 || p.pos == 0 {
+		return token{}
+	}
+
+	for i := p.pos - 1; i >= 0; i-- {
+		if !p.tokens[i].isComment() {
+			return p.tokens[i]
+		}
+	}
+
+	return token{}
+}
+
+func (p *printer) nextToken() token {
+	if p.pos+1 < len(p.tokens) {
+		return p.tokens[p.pos+1]
+	}
+
+	return token{}
+}
+
+// printToken appends the token to the current line and breaks the line at the end of statements and blocks.
+func (p *printer) printToken(tok token) error {
+	prev := p.prevToken()
+	switch {
+	case tok.kind == tokLineComment:
+		if len(p.line) > 0 && tok.newline == 0 {
+			p.append(tok.text, true, false)
+		} else {
+			p.flush()
+			p.append(tok.text, false, false)
+		}
+
+		p.flush()
+		p.cont = p.inStatement()
+
+		return nil
+	case tok.kind == tokBlockComment:
+		if len(p.line) > 0 {
+			p.append(tok.text, true, false)
+			return nil
+		}
+
+		p.writeBlockComment(tok.text)
+
+		return nil
+	case tok.is("{"):
+		return p.openBrace(prev)
+	case tok.is("}"):
+		return p.closeBrace()
+	case tok.is("(") || tok.is("["):
+		p.append(tok.text, spaceBefore(prev, tok), canWrap(prev, tok))
+		p.parens++
+	case tok.is(")") || tok.is("]"):
+		if p.parens == 0 {
+			return fmt.Errorf("line %d: unbalanced '%s'", tok.line, tok.text)
+		}
+
+		p.parens--
+		p.append(tok.text, false, false)
+	case tok.is(";"):
+		p.append(tok.text, false, false)
+		if p.parens == 0 {
+			if b := p.block(); b != nil && b.kind == blockEnum {
+				b.kind = blockType // the enum constants are terminated, the members follow
+			}
+
+			p.endLine()
+		}
+	case tok.is(","):
+		p.append(tok.text, false, false)
+		if b := p.block(); b != nil && b.kind == blockEnum && p.parens == 0 {
+			p.endLine()
+		}
+	case tok.is(":") && p.isCaseLabel():
+		p.append(tok.text, false, false)
+		p.block().inCase = true
+		p.endLine()
+	default:
+		p.append(tok.text, spaceBefore(prev, tok), canWrap(prev, tok))
+	}
+
+	return nil
+}
+
+// inStatement returns true, if the current statement has not been terminated yet.
+func (p *printer) inStatement() bool {
+	for i := p.pos - 1; i >= 0; i-- {
+		if !p.tokens[i].isComment() {
+			return !(p.tokens[i].is(";") || p.tokens[i].is("{") || p.tokens[i].is("}"))
+		}
+	}
+
+	return false
+}
+
+// isCaseLabel returns true, if the current line is a case or default label of a switch.
+func (p *printer) isCaseLabel() bool {
+	b := p.block()
+	if b == nil || b.kind != blockSwitch || p.parens != 0 {
+		return false
+	}
+
+	first := p.tokens[p.lineStart]
+
+	return first.is("case") || first.is("default")
+}
+
+// openBrace emits a { and starts a new block.
+func (p *printer) openBrace(prev token) error {
+	inline := prev.is("]") || prev.is("=") || prev.is("(") || prev.is(",") || (prev.is("{") && len(p.line) > 0)
+	if b := p.block(); b != nil && b.kind == blockInline {
+		inline = true
+	}
+
+	if inline {
+		p.append("{", !prev.is("(") && !prev.is("{"), false)
+		p.blocks = append(p.blocks, block{kind: blockInline, parens: p.parens})
+		p.parens = 0
+
+		return nil
+	}
+
+	kind := blockStatements
+	for i, pc := range p.line {
+		if i > 0 && p.line[i-1].text == "." {
+			continue // e.g. Foo.class
+		}
+
+		switch pc.text {
+		case "class", "interface", "record", "new":
+			kind = blockType
+		case "enum":
+			kind = blockEnum
+		case "switch":
+			kind = blockSwitch
+		case "->", "=":
+			kind = blockStatements
+		}
+	}
+
+	isDo := len(p.line) > 0 && p.line[0].text == "do"
+	p.append("{", true, false)
+	p.blocks = append(p.blocks, block{kind: kind, parens: p.parens, do: isDo})
+	p.parens = 0
+	if next := p.nextToken(); next.is("}") {
+		return nil
+	}
+
+	p.endLine()
+
+	return nil
+}
+
+// closeBrace emits a } and closes the current block.
+func (p *printer) closeBrace() error {
+	if len(p.blocks) == 0 || p.parens != 0 {
+		return fmt.Errorf("line %d: unbalanced '}'", p.tokens[p.pos].line)
+	}
+
+	b := p.blocks[len(p.blocks)-1]
+	p.blocks = p.blocks[:len(p.blocks)-1]
+	if b.kind == blockInline {
+		p.append("}", false, false)
+		p.parens = b.parens
+
+		return nil
+	}
+
+	if len(p.line) > 0 && !(len(p.line) > 0 && p.line[len(p.line)-1].text == "{") {
+		p.flush()
+	}
+
+	p.parens = b.parens
+	p.append("}", false, false)
+
+	next := p.nextToken()
+	switch {
+	case next.is("else"), next.is("catch"), next.is("finally"), next.is("while") && b.do:
+		return nil
+	case p.parens > 0, next.is(")"), next.is(","), next.is(";"), next.is("."):
+		return nil
+	}
+
+	outer := p.block()
+	p.endLine()
+	p.closedMember = outer == nil || outer.kind == blockType || outer.kind == blockEnum
+
+	return nil
+}
+
+// append adds a piece to the current line.
+func (p *printer) append(text string, space bool, wrap bool) {
+	if len(p.line) == 0 {
+		space = false
+		p.indent = p.currentIndent()
+	}
+
+	depth := p.parens
+	for _, b := range p.blocks {
+		if b.kind == blockInline {
+			depth += b.parens + 1
+		}
+	}
+
+	p.line = append(p.line, piece{text: text, space: space, depth: depth, wrap: wrap})
+}
+
+// endLine flushes the current line, but keeps a trailing line comment.
+func (p *printer) endLine() {
+	if next := p.nextToken(); next.kind == tokLineComment && next.newline == 0 {
+		p.pos++
+		p.append(next.text, true, false)
+	}
+
+	p.flush()
+	p.cont = false
+}
+
+// currentIndent returns the indentation of a new line.
+func (p *printer) currentIndent() string {
+	sb := &strings.Builder{}
+	for _, b := range p.blocks {
+		if b.kind == blockInline {
+			continue
+		}
+
+		sb.WriteString(blockIndent)
+		if b.inCase {
+			sb.WriteString(blockIndent)
+		}
+	}
+
+	if p.cont {
+		sb.WriteString(continuationIndent)
+	}
+
+	return sb.String()
+}
+
+// flush emits the current line and wraps it, if it is too long.
+func (p *printer) flush() {
+	if len(p.line) == 0 {
+		return
+	}
+
+	indent := p.indent
+	for i, text := range wrapLine(p.line, len(indent)) {
+		if i == 0 {
+			p.writeIndented(indent, text)
+		} else {
+			p.writeIndented(indent+continuationIndent, text)
+		}
+	}
+
+	p.line = p.line[:0]
+	p.closedMember = false
+}
+
+func (p *printer) writeLine(text string) {
+	p.writeIndented(p.currentIndent(), text)
+}
+
+func (p *printer) writeIndented(indent, text string) {
+	if p.pendingBlank && p.lastLine != "" {
+		p.out.WriteString("\n")
+	}
+
+	p.pendingBlank = false
+	p.out.WriteString(strings.TrimRight(indent+text, " "))
+	p.out.WriteString("\n")
+	p.lastLine = text
+}
+
+// writeBlockComment emits a comment on its own lines and aligns the asterisks of a javadoc comment.
+func (p *printer) writeBlockComment(text string) {
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		p.writeLine(text)
+		p.closedMember = false
+
+		return
+	}
+
+	p.writeLine(strings.TrimRight(lines[0], " \t"))
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "*") {
+			line = "* " + line
+		}
+
+		p.writeLine(" " + line)
+	}
+
+	p.closedMember = false
+}
+
+// spaceBefore returns true, if the token requires a space after the previous one.
+func spaceBefore(prev, tok token) bool {
+	switch {
+	case prev.kind == 0:
+		return false
+	case tok.is(";"), tok.is(","), tok.is(")"), tok.is("]"), tok.is("."), tok.is("::"), tok.is("..."):
+		return false
+	case prev.is("("), prev.is("["), prev.is("{"), prev.is("."), prev.is("@"), prev.is("::"):
+		return false
+	case prev.unary:
+		return false
+	case tok.generic && tok.text == "<":
+		return prev.kind == tokKeyword && !prev.is("this") && !prev.is("super")
+	case tok.generic:
+		return false
+	case prev.generic && prev.text == "<":
+		return false
+	case prev.generic:
+		return !(tok.is("(") || tok.is("["))
+	case tok.is("("):
+		return (prev.kind == tokKeyword && !prev.is("this") && !prev.is("super")) || prev.is(")") ||
+			(prev.kind == tokOperator && !prev.is("]") && !prev.is("++") && !prev.is("--"))
+	case tok.is("["):
+		return false
+	case tok.is("++"), tok.is("--"):
+		return tok.unary
+	}
+
+	return true
+}
+
+// markUnary detects the prefix operators.
+func markUnary(tokens []token) {
+	var prev token
+	for i, tok := range tokens {
+		if tok.isComment() {
+			continue
+		}
+
+		switch {
+		case tok.is("!"), tok.is("~"):
+			tokens[i].unary = true
+		case tok.is("+"), tok.is("-"), tok.is("++"), tok.is("--"):
+			tokens[i].unary = isUnaryContext(prev)
+		}
+
+		prev = tokens[i]
+	}
+}
+
+// isUnaryContext returns true, if an operator after prev is a prefix operator.
+func isUnaryContext(prev token) bool {
+	switch prev.kind {
+	case 0:
+		return true
+	case tokOperator:
+		return !(prev.is(")") || prev.is("]") || prev.generic || ((prev.is("++") || prev.is("--")) && !prev.unary))
+	case tokKeyword:
+		return !(prev.is("this") || prev.is("super") || prev.is("true") || prev.is("false") || prev.is("null"))
+	default:
+		return false
+	}
+}
+
+// canWrap returns true, if the line may be wrapped in front of the token, which is after an opening paren, a
+// comma or an assignment, in front of a binary operator or in front of a chained method call.
+func canWrap(prev, tok token) bool {
+	switch {
+	case prev.is(","), prev.is("("), prev.is("="), prev.is("->"):
+		return true
+	case tok.is("."):
+		return prev.is(")")
+	default:
+		return tok.kind == tokOperator && isBinaryOperator(tok.text) && !tok.unary && !tok.generic
+	}
+}
+
+func isBinaryOperator(op string) bool {
+	switch op {
+	case "+", "-", "*", "/", "%", "&&", "||", "&", "|", "^", "==", "!=", "<", ">", "<=", ">=", "<<", ">>", ">>>",
+		"?", ":", "instanceof":
+		return true
+	default:
+		return false
+	}
+}
+
+// wrapLine splits the pieces into lines which fit into the maxLineWidth, if possible. It breaks at the lowest
+// paren depth first and fills each line as far as possible. The continuation lines are indented once.
+func wrapLine(line []piece, indent int) []string {
+	var res []string
+	start := 0
+	width := maxLineWidth - indent
+	for start < len(line) {
+		overflow := -1
+		w := 0
+		for k := start; k < len(line); k++ {
+			if line[k].space && k > start {
+				w++
+			}
+
+			w += len(line[k].text)
+			if w > width || strings.Contains(line[k].text, "\n") {
+				overflow = k
+				break
+			}
+		}
+
+		best := -1
+		if overflow >= 0 {
+			for k := start + 1; k < len(line) && (k <= overflow || best < 0); k++ {
+				if line[k].wrap && (best < 0 || line[k].depth <= line[best].depth) {
+					best = k
+				}
+			}
+		}
+
+		if best < 0 {
+			res = append(res, joinPieces(line[start:]))
+			break
+		}
+
+		res = append(res, joinPieces(line[start:best]))
+		start = best
+		width = maxLineWidth - indent - len(continuationIndent)
+	}
+
+	return res
+}
+
+func joinPieces(pieces []piece) string {
+	sb := &strings.Builder{}
+	for i, pc := range pieces {
+		if pc.space && i > 0 {
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(pc.text)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,727 @@
+package java
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// writeLineComment emits the comment as a // line comment, which is used within method bodies.
+func writeLineComment(w *render.BufferedWriter, comment *ast.Comment) {
+	if comment == nil || strings.TrimSpace(comment.Text) == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(comment.Text), "\n") {
+		w.Printf("// " + strings.TrimRight(line, " \t") + "\n")
+	}
+}
+
+// renderBlock emits a block and all contained statements.
+func (r *Renderer) renderBlock(node *ast.Block, w *render.BufferedWriter) error {
+	writeLineComment(w, node.ObjComment)
+	w.Printf("{\n")
+	for _, n := range node.Nodes {
+		if err := r.renderStmt(n, w); err != nil {
+			return fmt.Errorf("unable to render node in block: %w", err)
+		}
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderStmt emits a node in statement position. Simple statements are terminated by a semicolon, compound
+// statements are not and macros are expanded into statements.
+func (r *Renderer) renderStmt(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Macro:
+		writeLineComment(w, n.Comment())
+		for _, child := range n.Children() {
+			if err := r.renderStmt(child, w); err != nil {
+				return fmt.Errorf("unable to render dynamic macro node: %w", err)
+			}
+		}
+
+		return nil
+	case *ast.Sym:
+		// each statement is terminated anyway
+		if n.Kind == ast.SymTermStmt {
+			return nil
+		}
+
+		return r.renderNode(n, w)
+	case *ast.Block, *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.ReturnStmt:
+		return r.renderNode(n, w)
+	case *ast.Tpl:
+		// a template is terminated, if it does not end with a terminator or a block itself
+		tmp := &render.BufferedWriter{}
+		if err := r.renderTpl(n, tmp); err != nil {
+			return err
+		}
+
+		text := strings.TrimSpace(tmp.String())
+		w.Printf(text)
+		if text != "" && !strings.HasSuffix(text, ";") && !strings.HasSuffix(text, "}") {
+			w.Printf(";")
+		}
+
+		w.Printf("\n")
+
+		return nil
+	case *ast.Assign:
+		writeLineComment(w, n.ObjComment)
+	}
+
+	if err := r.renderNode(node, w); err != nil {
+		return err
+	}
+
+	w.Printf(";\n")
+
+	return nil
+}
+
+// renderAssign emits an assignment. A definition is declared as a local variable, which has the type of a
+// constructor call or otherwise a local type, see renderLocalType.
+func (r *Renderer) renderAssign(node *ast.Assign, w *render.BufferedWriter) error {
+	if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+		return fmt.Errorf("java does not support multiple assignments")
+	}
+
+	if node.Kind == ast.AssignDefine {
+		if lit := compLitOf(node.Rhs[0]); lit != nil && lit.Type != nil {
+			if err := r.renderCompLitType(lit, w); err != nil {
+				return err
+			}
+
+			w.Printf(" ")
+		} else {
+			if err := r.renderLocalType(node, node.Rhs[0], false, w); err != nil {
+				return err
+			}
+
+			w.Printf(" ")
+		}
+	}
+
+	if err := r.renderNode(node.Lhs[0], w); err != nil {
+		return fmt.Errorf("unable to render lhs: %w", err)
+	}
+
+	switch node.Kind {
+	case ast.AssignSimple, ast.AssignDefine:
+		w.Printf(" = ")
+	case ast.AssignAdd:
+		w.Printf(" += ")
+	case ast.AssignSub:
+		w.Printf(" -= ")
+	case ast.AssignMul:
+		w.Printf(" *= ")
+	case ast.AssignRem:
+		w.Print(" %= ")
+	default:
+		return fmt.Errorf("assignment not implemented: %d", node.Kind)
+	}
+
+	if err := r.renderNode(node.Rhs[0], w); err != nil {
+		return fmt.Errorf("unable to render rhs: %w", err)
+	}
+
+	return nil
+}
+
+// renderIfStmt emits an if statement. Java has no init statement, so it is declared in front and both are
+// wrapped into a block, to keep the scope.
+func (r *Renderer) renderIfStmt(node *ast.IfStmt, w *render.BufferedWriter) error {
+	if node.Init != nil {
+		w.Printf("{\n")
+		if err := r.renderStmt(node.Init, w); err != nil {
+			return fmt.Errorf("unable to render init: %w", err)
+		}
+	}
+
+	w.Printf("if (")
+	if err := r.renderNode(node.Cond, w); err != nil {
+		return fmt.Errorf("unable to render cond: %w", err)
+	}
+
+	w.Printf(") ")
+	if err := r.renderNode(node.Body, w); err != nil {
+		return fmt.Errorf("unable to render body: %w", err)
+	}
+
+	if node.Else != nil {
+		w.Printf("else ")
+		if err := r.renderNode(node.Else, w); err != nil {
+			return fmt.Errorf("unable to render else: %w", err)
+		}
+	}
+
+	if node.Init != nil {
+		w.Printf("}\n")
+	}
+
+	return nil
+}
+
+// renderForStmt emits a for statement or a while loop, if there is neither an init nor a post statement.
+func (r *Renderer) renderForStmt(node *ast.ForStmt, w *render.BufferedWriter) error {
+	if node.Init == nil && node.Post == nil {
+		w.Printf("while (")
+		if node.Cond == nil {
+			w.Printf("true")
+		} else if err := r.renderNode(node.Cond, w); err != nil {
+			return fmt.Errorf("unable to render cond: %w", err)
+		}
+
+		w.Printf(") ")
+
+		return r.renderNode(node.Body, w)
+	}
+
+	w.Printf("for (")
+	if node.Init != nil {
+		if err := r.renderNode(node.Init, w); err != nil {
+			return fmt.Errorf("unable to render init: %w", err)
+		}
+	}
+
+	w.Printf("; ")
+	if node.Cond != nil {
+		if err := r.renderNode(node.Cond, w); err != nil {
+			return fmt.Errorf("unable to render cond: %w", err)
+		}
+	}
+
+	w.Printf("; ")
+	if node.Post != nil {
+		if err := r.renderNode(node.Post, w); err != nil {
+			return fmt.Errorf("unable to render post: %w", err)
+		}
+	}
+
+	w.Printf(") ")
+
+	return r.renderNode(node.Body, w)
+}
+
+// renderRangeStmt emits an enhanced for loop, whose value has the element type of the range target, see
+// renderLocalType. Java cannot iterate over keys and values at once, so only the value is supported.
+func (r *Renderer) renderRangeStmt(node *ast.RangeStmt, w *render.BufferedWriter) error {
+	if node.Key != nil {
+		return fmt.Errorf("java cannot range over keys, declare the value only")
+	}
+
+	w.Printf("for (")
+	if err := r.renderLocalType(node, node.X, true, w); err != nil {
+		return err
+	}
+
+	w.Printf(" ")
+	if node.Val != nil {
+		if err := r.renderNode(node.Val, w); err != nil {
+			return fmt.Errorf("unable to render val: %w", err)
+		}
+	} else {
+		w.Printf(ast.FreshIdent(node, "ignored"))
+	}
+
+	w.Printf(" : ")
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render range target: %w", err)
+	}
+
+	w.Printf(") ")
+
+	return r.renderNode(node.Body, w)
+}
+
+// renderReturnStmt emits a return statement. Java methods cannot return multiple values, because the additional
+// results are thrown exceptions, so trailing nil results are omitted.
+func (r *Renderer) renderReturnStmt(node *ast.ReturnStmt, w *render.BufferedWriter) error {
+	results := node.Results
+	for len(results) > 1 {
+		if ident, ok := results[len(results)-1].(*ast.Ident); !ok || ident.Name != "nil" {
+			return fmt.Errorf("java cannot return multiple values, throw an exception instead")
+		}
+
+		results = results[:len(results)-1]
+	}
+
+	w.Printf("return")
+	if len(results) == 1 {
+		w.Printf(" ")
+		if err := r.renderNode(results[0], w); err != nil {
+			return fmt.Errorf("unable to render result: %w", err)
+		}
+	}
+
+	w.Printf(";\n")
+
+	return nil
+}
+
+// renderCallExpr emits a method call. A variadic call is the same, because Java accepts arrays as varargs.
+func (r *Renderer) renderCallExpr(node *ast.CallExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.Fun, w); err != nil {
+		return fmt.Errorf("cannot render function expression: %w", err)
+	}
+
+	w.Printf("(")
+	for i, n := range node.Args {
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render argument: %w", err)
+		}
+
+		if i < len(node.Args)-1 {
+			w.Printf(", ")
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// renderSelExpr emits a X.Sel expression.
+func (r *Renderer) renderSelExpr(node *ast.SelExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render selector target: %w", err)
+	}
+
+	w.Printf(".")
+
+	return r.renderIdent(node.Sel, w)
+}
+
+// renderIdent emits an identifier. The Go nil becomes null and other keywords are escaped, except those which
+// are valid expressions.
+func (r *Renderer) renderIdent(node *ast.Ident, w *render.BufferedWriter) error {
+	switch node.Name {
+	case "nil":
+		w.Printf("null")
+	case "this", "super", "true", "false", "null":
+		w.Printf(node.Name)
+	default:
+		w.Printf(naming.Java.Escape(node.Name))
+	}
+
+	return nil
+}
+
+// renderQualIdent emits an imported class, e.g. Objects for java.util.Objects. A constant of a class, like
+// java.util.concurrent.TimeUnit.SECONDS, is imported statically.
+func (r *Renderer) renderQualIdent(node *ast.QualIdent, w *render.BufferedWriter) error {
+	name := ast.Name(node.Qualifier)
+	if isConstantName(name) {
+		w.Printf(string(r.importer(node).shortifyStatic(name)))
+		return nil
+	}
+
+	w.Printf(string(r.importer(node).shortify(name)))
+
+	return nil
+}
+
+// isConstantName returns true, if the name denotes a member in screaming snake case of a type, whose
+// name starts with an upper case letter, e.g. java.util.concurrent.TimeUnit.SECONDS.
+func isConstantName(name ast.Name) bool {
+	owner := ast.Name(name.Qualifier()).Identifier()
+	if owner == "" || !unicode.IsUpper(rune(owner[0])) {
+		return false
+	}
+
+	id := name.Identifier()
+	for _, r := range id {
+		if !unicode.IsUpper(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+
+	return id != "" && unicode.IsUpper(rune(id[0]))
+}
+
+// renderBasicLit emits a literal. Go string literals are converted into Java string literals.
+func (r *Renderer) renderBasicLit(node *ast.BasicLit, w *render.BufferedWriter) error {
+	if strings.HasPrefix(node.Val, `"`) || strings.HasPrefix(node.Val, "`") {
+		s, err := strconv.Unquote(node.Val)
+		if err != nil {
+			return fmt.Errorf("invalid string literal %s: %w", node.Val, err)
+		}
+
+		w.Printf(javaQuote(s))
+
+		return nil
+	}
+
+	w.Printf(node.Val)
+
+	return nil
+}
+
+// javaQuote returns a double quoted Java string literal.
+func javaQuote(s string) string {
+	sb := &strings.Builder{}
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// renderCompLit emits a constructor call. Named elements (key: value) are passed by their value.
+func (r *Renderer) renderCompLit(node *ast.CompLit, w *render.BufferedWriter) error {
+	if node.Type == nil {
+		return fmt.Errorf("java cannot create anonymous literals")
+	}
+
+	w.Printf("new ")
+	if err := r.renderCompLitType(node, w); err != nil {
+		return err
+	}
+
+	w.Printf("(")
+	for i, element := range node.Elements {
+		if kv, ok := element.(*ast.BinaryExpr); ok && kv.Op == ast.OpColon {
+			element = kv.Y
+		}
+
+		if err := r.renderNode(element, w); err != nil {
+			return fmt.Errorf("unable to render composite elem: %w", err)
+		}
+
+		if i < len(node.Elements)-1 {
+			w.Printf(", ")
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// renderLocalType emits the type of a local variable, which is initialized with the expression or which iterates
+// over its elements. Since Java 10 the type is inferred by var. Before, the type must be declared, which is only
+// known for literals and the parameters of the enclosing method.
+func (r *Renderer) renderLocalType(scope, expr ast.Node, elem bool, w *render.BufferedWriter) error {
+	if featureVersion(scope) >= 10 {
+		w.Printf("var")
+		return nil
+	}
+
+	if macro, ok := expr.(*ast.Macro); ok {
+		if children := macro.Children(); len(children) == 1 {
+			return r.renderLocalType(scope, children[0], elem, w)
+		}
+	}
+
+	var name ast.Name
+	switch t := expr.(type) {
+	case *ast.BasicLit:
+		// the kind is not reliable, e.g. ast.NewIntLit declares a string token
+		switch {
+		case strings.HasPrefix(t.Val, `"`) || strings.HasPrefix(t.Val, "`"):
+			name = stdlib.String
+		case strings.HasPrefix(t.Val, "'"):
+			name = stdlib.Rune
+		case strings.ContainsAny(t.Val, ".eE") && !strings.HasPrefix(t.Val, "0x"):
+			name = stdlib.Float64
+		default:
+			name = stdlib.Int
+		}
+	case *ast.Ident:
+		if t.Name == "true" || t.Name == "false" {
+			name = stdlib.Bool
+			break
+		}
+
+		if decl := paramTypeDecl(scope, t.Name); decl != nil {
+			if !elem {
+				return r.renderTypeDecl(decl, w)
+			}
+
+			if decl = elementTypeDecl(decl); decl != nil {
+				return r.renderTypeDecl(decl, w)
+			}
+		}
+	}
+
+	if name != "" && !elem {
+		w.Print(string(r.importer(scope).shortify(fromStdlib(name))))
+		return nil
+	}
+
+	return fmt.Errorf("cannot declare the type of a local variable in Java %d, which requires at least Java 10 to infer it", featureVersion(scope))
+}
+
+// paramTypeDecl returns the type declaration of the named parameter of the enclosing method or nil.
+func paramTypeDecl(scope ast.Node, name string) ast.TypeDecl {
+	fun := &ast.Func{}
+	if !ast.ParentAs(scope, &fun) {
+		return nil
+	}
+
+	for _, param := range fun.Params() {
+		if param.Identifier() == name {
+			return param.TypeDecl()
+		}
+	}
+
+	return nil
+}
+
+// elementTypeDecl returns the type declaration of the elements of an array or a list or nil.
+func elementTypeDecl(decl ast.TypeDecl) ast.TypeDecl {
+	switch t := decl.(type) {
+	case *ast.SliceTypeDecl:
+		return t.TypeDecl
+	case *ast.ArrayTypeDecl:
+		return t.TypeDecl()
+	case *ast.GenericTypeDecl:
+		if simple, ok := t.TypeDecl.(*ast.SimpleTypeDecl); ok && simple.Name() == stdlib.List && len(t.Params()) == 1 {
+			return t.Params()[0]
+		}
+	}
+
+	return nil
+}
+
+// compLitOf returns the constructor call of the expression, which may be also the only node of a macro.
+func compLitOf(node ast.Node) *ast.CompLit {
+	switch t := node.(type) {
+	case *ast.CompLit:
+		return t
+	case *ast.Macro:
+		if children := t.Children(); len(children) == 1 {
+			return compLitOf(children[0])
+		}
+	}
+
+	return nil
+}
+
+// renderCompLitType emits the type of the constructor call.
+func (r *Renderer) renderCompLitType(node *ast.CompLit, w *render.BufferedWriter) error {
+	if decl, ok := node.Type.(ast.TypeDecl); ok {
+		return r.renderTypeDecl(decl, w)
+	}
+
+	if err := r.renderNode(node.Type, w); err != nil {
+		return fmt.Errorf("unable to render type: %w", err)
+	}
+
+	return nil
+}
+
+// javaBinaryOperators maps the operators, which exist in Java with the same meaning.
+var javaBinaryOperators = map[ast.Operator]string{
+	ast.OpAdd: "+", ast.OpSub: "-", ast.OpMul: "*", ast.OpQuo: "/", ast.OpREM: "%",
+	ast.OpAnd: "&", ast.OpOr: "|", ast.OpXOR: "^", ast.OpShl: "<<", ast.OpShr: ">>",
+	ast.OpLAnd: "&&", ast.OpLOr: "||", ast.OpEqual: "==", ast.OpLess: "<", ast.OpGreater: ">",
+	ast.OpNotEqual: "!=", ast.OpLessEqual: "<=", ast.OpGreaterEqual: ">=",
+}
+
+// renderBinaryExpr emits a binary expression. The Go and not operator x &^ y becomes x & ~(y).
+func (r *Renderer) renderBinaryExpr(node *ast.BinaryExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render x: %w", err)
+	}
+
+	if node.Op == ast.OpAndNot {
+		w.Printf(" & ~(")
+		if err := r.renderNode(node.Y, w); err != nil {
+			return fmt.Errorf("unable to render y: %w", err)
+		}
+
+		w.Printf(")")
+
+		return nil
+	}
+
+	op, ok := javaBinaryOperators[node.Op]
+	if !ok {
+		return fmt.Errorf("operator not supported by java: %d", node.Op)
+	}
+
+	w.Printf(" " + op + " ")
+	if err := r.renderNode(node.Y, w); err != nil {
+		return fmt.Errorf("unable to render y: %w", err)
+	}
+
+	return nil
+}
+
+// renderUnaryExpr emits a unary expression. Java has no pointers, so taking the address just refers to the
+// object itself.
+func (r *Renderer) renderUnaryExpr(node *ast.UnaryExpr, w *render.BufferedWriter) error {
+	switch node.Op {
+	case ast.OpAdd:
+		w.Printf("+")
+	case ast.OpSub:
+		w.Printf("-")
+	case ast.OpNot:
+		w.Printf("!")
+	case ast.OpXOR:
+		w.Printf("~")
+	case ast.OpAnd, ast.OpInc, ast.OpDec:
+	default:
+		return fmt.Errorf("operator not supported by java: %d", node.Op)
+	}
+
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render x: %w", err)
+	}
+
+	switch node.Op {
+	case ast.OpInc:
+		w.Printf("++")
+	case ast.OpDec:
+		w.Printf("--")
+	}
+
+	return nil
+}
+
+// renderMacro emits the evaluated nodes of a macro in expression position.
+func (r *Renderer) renderMacro(node *ast.Macro, w *render.BufferedWriter) error {
+	for _, n := range node.Children() {
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render dynamic macro node: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderSym emits a terminator or a line break.
+func (r *Renderer) renderSym(node *ast.Sym, w *render.BufferedWriter) error {
+	switch node.Kind {
+	case ast.SymTermStmt:
+		w.Printf(";")
+	case ast.SymNewline:
+		w.Printf("\n")
+	default:
+		return fmt.Errorf("unknown sym: %d", node.Kind)
+	}
+
+	return nil
+}
+
+// renderTpl executes and emits the template text.
+func (r *Renderer) renderTpl(node *ast.Tpl, w *render.BufferedWriter) error {
+	tmpl, err := template.New(node.ObjPos.String()).Parse(node.Template)
+	if err != nil {
+		return fmt.Errorf("cannot parse template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, &tplRenderContext{importer: r.importer(node), tpl: node}); err != nil {
+		return fmt.Errorf("cannot execute template: %w", err)
+	}
+
+	w.Printf(buf.String())
+
+	return nil
+}
+
+// ensure that we always implement the full contract
+var _ ast.TplContext = (*tplRenderContext)(nil)
+
+type tplRenderContext struct {
+	importer *importer
+	tpl      *ast.Tpl
+}
+
+func (t *tplRenderContext) Get(key string) interface{} {
+	return t.tpl.Values[key]
+}
+
+func (t *tplRenderContext) Use(name string) string {
+	return string(t.importer.shortify(fromStdlib(ast.Name(name))))
+}
+
+func (t *tplRenderContext) Self() *ast.Tpl {
+	return t.tpl
+}
+
+// renderExpr dispatches the statements and expressions of method bodies. It returns false, if the node is not
+// a statement or an expression.
+func (r *Renderer) renderExpr(node ast.Node, w *render.BufferedWriter) (bool, error) {
+	var err error
+	switch n := node.(type) {
+	case *ast.Block:
+		err = r.renderBlock(n, w)
+	case *ast.Assign:
+		err = r.renderAssign(n, w)
+	case *ast.IfStmt:
+		err = r.renderIfStmt(n, w)
+	case *ast.ForStmt:
+		err = r.renderForStmt(n, w)
+	case *ast.RangeStmt:
+		err = r.renderRangeStmt(n, w)
+	case *ast.ReturnStmt:
+		err = r.renderReturnStmt(n, w)
+	case *ast.CallExpr:
+		err = r.renderCallExpr(n, w)
+	case *ast.SelExpr:
+		err = r.renderSelExpr(n, w)
+	case *ast.Ident:
+		err = r.renderIdent(n, w)
+	case *ast.QualIdent:
+		err = r.renderQualIdent(n, w)
+	case *ast.BasicLit:
+		err = r.renderBasicLit(n, w)
+	case *ast.CompLit:
+		err = r.renderCompLit(n, w)
+	case *ast.BinaryExpr:
+		err = r.renderBinaryExpr(n, w)
+	case *ast.UnaryExpr:
+		err = r.renderUnaryExpr(n, w)
+	case *ast.Macro:
+		err = r.renderMacro(n, w)
+	case *ast.Sym:
+		err = r.renderSym(n, w)
+	case *ast.Tpl:
+		err = r.renderTpl(n, w)
+	case ast.TypeDecl:
+		err = r.renderTypeDecl(n, w)
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("cannot render %s: %w", reflect.TypeOf(node).Elem().Name(), err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,122 @@
+package java
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"github.com/golangee/src/stdlib/strings"
+	strings2 "strings"
+	"testing"
+)
+
+// newGreeter creates the method of a class in a module, which requires the given Java version.
+func newGreeter(version ast.LangVersion, stmts ...ast.Node) (*ast.Prj, *ast.Func) {
+	fun := ast.NewFunc("greet").
+		SetVisibility(ast.Public).
+		AddParams(ast.NewParam("names", ast.NewSliceTypeDecl(ast.NewSimpleTypeDecl(stdlib.String)))).
+		AddResults(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.String))).
+		SetBody(ast.NewBlock(stmts...))
+
+	prj := ast.NewPrj("body").AddModules(
+		ast.NewMod("example.com/body").
+			SetLang(ast.LangJava).
+			SetLangVersion(version).
+			AddPackages(
+				ast.NewPkg("com.example.body").AddFiles(
+					ast.NewFile("Greeter.java").AddTypes(
+						ast.NewStruct("Greeter").SetVisibility(ast.Public).AddMethods(fun),
+					),
+				),
+			),
+	)
+
+	return prj, fun
+}
+
+// newGreeterBody creates statements, which declare local variables.
+func newGreeterBody() []ast.Node {
+	return []ast.Node{
+		strings.NewStrBuilder("sb", ast.NewStrLit("hello\t\"%s\"")),
+		ast.NewRangeStmt(nil, ast.NewIdent("name"), ast.NewIdent("names"), ast.NewBlock(
+			ast.NewIfStmt(
+				ast.NewBinaryExpr(ast.NewIdent("name"), ast.OpEqual, ast.NewIdent("nil")),
+				ast.NewBlock(lang.Panic("no name")),
+			),
+			lang.CallIdent("sb", "append", ast.NewIdent("name")),
+		)),
+		ast.NewAssign(ast.Exprs(ast.NewIdent("i")), ast.AssignDefine, ast.Exprs(ast.NewIntLit(0))),
+		ast.NewForStmt(nil, ast.NewBinaryExpr(ast.NewIdent("i"), ast.OpLess, ast.NewIntLit(3)), nil, ast.NewBlock(
+			ast.NewUnaryExpr(ast.NewIdent("i"), ast.OpInc),
+		)),
+		ast.NewReturnStmt(lang.CallIdent("sb", "toString")),
+	}
+}
+
+func renderGreeter(t *testing.T, prj *ast.Prj, fun *ast.Func) (string, error) {
+	t.Helper()
+
+	r := NewRenderer(Options{})
+	if err := r.tearUp(prj); err != nil {
+		t.Fatal(err)
+	}
+
+	defer r.tearDown()
+
+	w := &render.BufferedWriter{}
+	err := r.renderFunc(fun, w)
+
+	return w.String(), err
+}
+
+func TestRenderer_RenderBody(t *testing.T) {
+	prj, fun := newGreeter(ast.LangVersionJava8, newGreeterBody()...)
+	src, err := renderGreeter(t, prj, fun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"public String greet(String[] names) {\n",
+		"StringBuilder sb = new StringBuilder();\nsb.append(\"hello\\t\\\"%s\\\"\");\n",
+		"for (String name : names) {\nif (name == null) {\nthrow new IllegalStateException(\"no name\");\n}\n",
+		"Integer i = 0;\nwhile (i < 3) {\ni++;\n}\n",
+		"return sb.toString();\n}\n",
+	} {
+		if !strings2.Contains(src, want) {
+			t.Fatalf("expected %q but got\n%s", want, src)
+		}
+	}
+
+	if strings2.Contains(src, "var ") {
+		t.Fatalf("expected no local variable type inference in Java 8 but got\n%s", src)
+	}
+}
+
+func TestRenderer_RenderBodyInferred(t *testing.T) {
+	prj, fun := newGreeter("11", newGreeterBody()...)
+	src, err := renderGreeter(t, prj, fun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"for (var name : names) {\n",
+		"var i = 0;\n",
+	} {
+		if !strings2.Contains(src, want) {
+			t.Fatalf("expected %q but got\n%s", want, src)
+		}
+	}
+}
+
+func TestRenderer_RenderBodyUnknownLocalType(t *testing.T) {
+	prj, fun := newGreeter(ast.LangVersionJava8,
+		ast.NewAssign(ast.Exprs(ast.NewIdent("n")), ast.AssignDefine, ast.Exprs(lang.CallIdent("names", "length"))),
+		ast.NewReturnStmt(ast.NewStrLit("")),
+	)
+
+	if _, err := renderGreeter(t, prj, fun); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,388 @@
+package java
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"html"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// BuildFile selects the build files, which are emitted for each module. The values can be combined.
+type BuildFile int
+
+const (
+	BuildGradle       BuildFile = 1 << iota // build.gradle and settings.gradle
+	BuildGradleKotlin                       // build.gradle.kts and settings.gradle.kts
+	BuildMaven                              // pom.xml
+)
+
+const (
+	// SourceDir is the standard directory of the Java sources, relative to the module.
+	SourceDir = "src/main/java"
+
+	// defaultVersion is the version of a module, which has no version in its name.
+	defaultVersion = "0.0.0-SNAPSHOT"
+
+	MimeTypeGradle = "text/x-gradle"
+	MimeTypeXML    = "application/xml"
+)
+
+// coordinates identify a maven artifact.
+type coordinates struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// parseCoordinates parses groupId:artifactId[:version].
+func parseCoordinates(s string) (coordinates, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return coordinates{}, fmt.Errorf("invalid maven coordinates '%s': expected groupId:artifactId:version", s)
+	}
+
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" || strings.ContainsAny(part, " \t\n'\"") {
+			return coordinates{}, fmt.Errorf("invalid maven coordinates '%s'", s)
+		}
+	}
+
+	c := coordinates{GroupID: parts[0], ArtifactID: parts[1]}
+	if len(parts) == 3 {
+		c.Version = parts[2]
+	}
+
+	return c, nil
+}
+
+// modCoordinates derives the coordinates of the module from its name, which is either groupId:artifactId[:version],
+// a package like name (com.example.app) or a module path (example.com/app, the host is reversed).
+func modCoordinates(mod *ast.Mod) (coordinates, error) {
+	var c coordinates
+	switch {
+	case strings.Contains(mod.Name, ":"):
+		var err error
+		if c, err = parseCoordinates(mod.Name); err != nil {
+			return c, err
+		}
+	case strings.Contains(mod.Name, "/"):
+		segments := strings.Split(mod.Name, "/")
+		host := strings.Split(segments[0], ".")
+		for i, j := 0, len(host)-1; i < j; i, j = i+1, j-1 {
+			host[i], host[j] = host[j], host[i]
+		}
+
+		group := append(host, segments[1:len(segments)-1]...)
+		c = coordinates{GroupID: strings.Join(group, "."), ArtifactID: segments[len(segments)-1]}
+	default:
+		idx := strings.LastIndex(mod.Name, ".")
+		if idx < 0 {
+			return c, fmt.Errorf("cannot derive maven coordinates from module name '%s'", mod.Name)
+		}
+
+		c = coordinates{GroupID: mod.Name[:idx], ArtifactID: mod.Name[idx+1:]}
+	}
+
+	if c.Version == "" {
+		c.Version = defaultVersion
+	}
+
+	return c, nil
+}
+
+// javaVersion returns the Target.MinLangVersion as Gradle JavaVersion constant and as maven compiler version,
+// e.g. VERSION_1_8 and 1.8 or VERSION_11 and 11.
+func javaVersion(mod *ast.Mod) (gradle, maven string) {
+	version := string(mod.Target.MinLangVersion)
+	if version == "" {
+		version = string(ast.LangVersionJava8)
+	}
+
+	return "VERSION_" + strings.ReplaceAll(version, ".", "_"), version
+}
+
+// featureVersion returns the major Java version of the module which contains the node, e.g. 8 for 1.8 or 17.
+// Language features like records or sealed types are only emitted, if the Target.MinLangVersion supports them.
+// Without a module or a parsable version, Java 8 is assumed.
+func featureVersion(node ast.Node) int {
+	mod := &ast.Mod{}
+	if !ast.ParentAs(node, &mod) {
+		return 8
+	}
+
+	version := strings.TrimPrefix(string(mod.Target.MinLangVersion), "1.")
+	if idx := strings.IndexByte(version, '.'); idx >= 0 {
+		version = version[:idx]
+	}
+
+	major, err := strconv.Atoi(version)
+	if err != nil {
+		return 8
+	}
+
+	return major
+}
+
+// dependencies parses the maven coordinates of all required dependencies, which must declare a version.
+func dependencies(mod *ast.Mod) ([]coordinates, error) {
+	var res []coordinates
+	for _, dep := range mod.Target.Require.Maven {
+		c, err := parseCoordinates(dep)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.Version == "" {
+			return nil, fmt.Errorf("invalid maven coordinates '%s': missing version", dep)
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+// buildFiles returns the configured build files.
+func (r *Renderer) buildFiles() BuildFile {
+	if r.opts.BuildFiles == 0 {
+		return BuildGradle
+	}
+
+	return r.opts.BuildFiles
+}
+
+// javaMods returns all Java modules of the rendered project.
+func (r *Renderer) javaMods() []*ast.Mod {
+	var res []*ast.Mod
+	_ = ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangJava {
+			res = append(res, mod)
+		}
+
+		return nil
+	})
+
+	return res
+}
+
+// renderBuild emits the build files of the module into modDir. If the project contains multiple Java modules,
+// the gradle settings or the maven aggregator project are emitted into the root directory, otherwise the
+// module is its own root project.
+func (r *Renderer) renderBuild(mod *ast.Mod, root, modDir *render.Dir) error {
+	if r.opts.SkipBuildFiles {
+		return nil
+	}
+
+	c, err := modCoordinates(mod)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies(mod)
+	if err != nil {
+		return err
+	}
+
+	mods := r.javaMods()
+	settingsDir, settingsName, groupID := modDir, c.ArtifactID, c.GroupID
+	var includes []string
+	if len(mods) > 1 {
+		// the project files must be equal for all modules
+		first, err := modCoordinates(mods[0])
+		if err != nil {
+			return err
+		}
+
+		settingsDir, groupID = root, first.GroupID
+		settingsName = groupID[strings.LastIndex(groupID, ".")+1:]
+		if prj, ok := r.root.(*ast.Prj); ok && prj.Name != "" {
+			settingsName = naming.Java.Kebab(prj.Name)
+		}
+
+		for _, m := range mods {
+			if m.Target.Out == "" {
+				return fmt.Errorf("module '%s' requires an output directory, because the project has multiple java modules", m.Name)
+			}
+
+			includes = append(includes, path.Clean(m.Target.Out))
+		}
+	}
+
+	build := r.buildFiles()
+	if build&BuildGradle != 0 {
+		modDir.Files = append(modDir.Files, gradleFile("build.gradle", createBuildGradle(c, mod, deps, false)))
+		addFile(settingsDir, gradleFile("settings.gradle", createSettingsGradle(settingsName, includes, false)))
+	}
+
+	if build&BuildGradleKotlin != 0 {
+		modDir.Files = append(modDir.Files, gradleFile("build.gradle.kts", createBuildGradle(c, mod, deps, true)))
+		addFile(settingsDir, gradleFile("settings.gradle.kts", createSettingsGradle(settingsName, includes, true)))
+	}
+
+	if build&BuildMaven != 0 {
+		modDir.Files = append(modDir.Files, &render.File{
+			FileName: "pom.xml",
+			MimeType: MimeTypeXML,
+			Buf:      []byte(createPom(c, mod, deps)),
+		})
+
+		if len(includes) > 0 {
+			addFile(root, &render.File{
+				FileName: "pom.xml",
+				MimeType: MimeTypeXML,
+				Buf:      []byte(createAggregatorPom(groupID, settingsName, includes)),
+			})
+		}
+	}
+
+	return nil
+}
+
+// addFile appends the file, if the directory does not contain it yet, because the project files are emitted
+// for each module.
+func addFile(dir *render.Dir, file *render.File) {
+	if dir.File(file.FileName) == nil {
+		dir.Files = append(dir.Files, file)
+	}
+}
+
+func gradleFile(name, text string) *render.File {
+	return &render.File{FileName: name, MimeType: MimeTypeGradle, Buf: []byte(text)}
+}
+
+// createBuildGradle emits a java-library build script in the groovy or kotlin dialect.
+func createBuildGradle(c coordinates, mod *ast.Mod, deps []coordinates, kotlin bool) string {
+	q := "'"
+	if kotlin {
+		q = `"`
+	}
+
+	gradleVersion, _ := javaVersion(mod)
+
+	var tmp strings.Builder
+	tmp.WriteString("plugins {\n")
+	if kotlin {
+		tmp.WriteString("    `java-library`\n")
+	} else {
+		tmp.WriteString("    id 'java-library'\n")
+	}
+
+	tmp.WriteString("}\n\n")
+	tmp.WriteString("group = " + q + c.GroupID + q + "\n")
+	tmp.WriteString("version = " + q + c.Version + q + "\n\n")
+	tmp.WriteString("java {\n")
+	tmp.WriteString("    sourceCompatibility = JavaVersion." + gradleVersion + "\n")
+	tmp.WriteString("    targetCompatibility = JavaVersion." + gradleVersion + "\n")
+	tmp.WriteString("}\n\n")
+	tmp.WriteString("repositories {\n    mavenCentral()\n}\n")
+
+	if len(deps) > 0 {
+		tmp.WriteString("\ndependencies {\n")
+		for _, dep := range deps {
+			coords := q + dep.GroupID + ":" + dep.ArtifactID + ":" + dep.Version + q
+			if kotlin {
+				tmp.WriteString("    implementation(" + coords + ")\n")
+			} else {
+				tmp.WriteString("    implementation " + coords + "\n")
+			}
+		}
+
+		tmp.WriteString("}\n")
+	}
+
+	return tmp.String()
+}
+
+// createSettingsGradle emits the settings of the root project, which includes the given module directories.
+func createSettingsGradle(name string, includes []string, kotlin bool) string {
+	q := "'"
+	if kotlin {
+		q = `"`
+	}
+
+	var tmp strings.Builder
+	tmp.WriteString("rootProject.name = " + q + name + q + "\n")
+	if len(includes) > 0 {
+		tmp.WriteString("\n")
+	}
+
+	for _, include := range includes {
+		project := q + strings.ReplaceAll(include, "/", ":") + q
+		if kotlin {
+			tmp.WriteString("include(" + project + ")\n")
+		} else {
+			tmp.WriteString("include " + project + "\n")
+		}
+	}
+
+	return tmp.String()
+}
+
+const pomHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+
+`
+
+// createPom emits a maven project with a jar packaging.
+func createPom(c coordinates, mod *ast.Mod, deps []coordinates) string {
+	_, version := javaVersion(mod)
+
+	var tmp strings.Builder
+	tmp.WriteString(pomHeader)
+	writeCoordinates(&tmp, "    ", c)
+	tmp.WriteString("    <packaging>jar</packaging>\n\n")
+	tmp.WriteString("    <properties>\n")
+	tmp.WriteString("        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>\n")
+	if strings.HasPrefix(version, "1.") {
+		tmp.WriteString("        <maven.compiler.source>" + html.EscapeString(version) + "</maven.compiler.source>\n")
+		tmp.WriteString("        <maven.compiler.target>" + html.EscapeString(version) + "</maven.compiler.target>\n")
+	} else {
+		tmp.WriteString("        <maven.compiler.release>" + html.EscapeString(version) + "</maven.compiler.release>\n")
+	}
+
+	tmp.WriteString("    </properties>\n")
+
+	if len(deps) > 0 {
+		tmp.WriteString("\n    <dependencies>\n")
+		for _, dep := range deps {
+			tmp.WriteString("        <dependency>\n")
+			writeCoordinates(&tmp, "            ", dep)
+			tmp.WriteString("        </dependency>\n")
+		}
+
+		tmp.WriteString("    </dependencies>\n")
+	}
+
+	tmp.WriteString("</project>\n")
+
+	return tmp.String()
+}
+
+// createAggregatorPom emits a maven project, which builds the given module directories.
+func createAggregatorPom(groupID, artifactID string, modules []string) string {
+	var tmp strings.Builder
+	tmp.WriteString(pomHeader)
+	writeCoordinates(&tmp, "    ", coordinates{GroupID: groupID, ArtifactID: artifactID, Version: defaultVersion})
+	tmp.WriteString("    <packaging>pom</packaging>\n\n")
+	tmp.WriteString("    <modules>\n")
+	for _, module := range modules {
+		tmp.WriteString("        <module>" + html.EscapeString(module) + "</module>\n")
+	}
+
+	tmp.WriteString("    </modules>\n")
+	tmp.WriteString("</project>\n")
+
+	return tmp.String()
+}
+
+func writeCoordinates(tmp *strings.Builder, indent string, c coordinates) {
+	tmp.WriteString(indent + "<groupId>" + html.EscapeString(c.GroupID) + "</groupId>\n")
+	tmp.WriteString(indent + "<artifactId>" + html.EscapeString(c.ArtifactID) + "</artifactId>\n")
+	tmp.WriteString(indent + "<version>" + html.EscapeString(c.Version) + "</version>\n")
+}
@@ -0,0 +1,79 @@
+package java
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"io/fs"
+	"testing"
+)
+
+func newBuildMod(name, out string) *ast.Mod {
+	return ast.NewMod(name).
+		SetLang(ast.LangJava).
+		SetLangVersion("11").
+		SetOutputDirectory(out).
+		AddPackages(
+			ast.NewPkg("com.example." + out).AddFiles(
+				ast.NewFile("App.java").AddTypes(ast.NewStruct("App").SetVisibility(ast.Public)),
+			),
+		)
+}
+
+func TestRenderer_RenderBuildSingleModule(t *testing.T) {
+	prj := ast.NewPrj("Shop").AddModules(
+		newBuildMod("example.com/shop/api", "api").Require("com.google.guava:guava:30.1-jre"),
+	)
+
+	a, err := NewRenderer(Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	srctest.ReadFile(t, dir, "api/src/main/java/com/example/api/App.java")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "api/settings.gradle"), "rootProject.name = 'api'\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "api/build.gradle"),
+		"id 'java-library'",
+		"group = 'com.example.shop'\n",
+		"sourceCompatibility = JavaVersion.VERSION_11\n",
+		"implementation 'com.google.guava:guava:30.1-jre'\n",
+	)
+
+	if _, err := fs.ReadFile(dir, "api/pom.xml"); err == nil {
+		t.Fatal("expected no pom.xml")
+	}
+}
+
+func TestRenderer_RenderBuildMultiModule(t *testing.T) {
+	prj := ast.NewPrj("Shop").AddModules(
+		newBuildMod("com.example:api:1.0.0", "api"),
+		newBuildMod("com.example:service", "service").Require("org.slf4j:slf4j-api:1.7.30"),
+	)
+
+	a, err := NewRenderer(Options{BuildFiles: BuildGradleKotlin | BuildMaven}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "settings.gradle.kts"),
+		"rootProject.name = \"shop\"\n", "include(\"api\")\ninclude(\"service\")\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "pom.xml"), "<packaging>pom</packaging>", "<module>api</module>")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "api/build.gradle.kts"), "`java-library`", "version = \"1.0.0\"\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "service/pom.xml"),
+		"<artifactId>service</artifactId>",
+		"<version>0.0.0-SNAPSHOT</version>",
+		"<maven.compiler.release>11</maven.compiler.release>",
+		"<artifactId>slf4j-api</artifactId>",
+	)
+
+	if _, err := fs.ReadFile(dir, "api/settings.gradle.kts"); err == nil {
+		t.Fatal("expected no module settings")
+	}
+
+	prj.Mods[1].Target.Require.Maven = append(prj.Mods[1].Target.Require.Maven, "org.slf4j:slf4j-simple")
+	if _, err := NewRenderer(Options{}).Render(prj); err == nil {
+		t.Fatal("expected a missing version error")
+	}
+}
@@ -0,0 +1,144 @@
+package java
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"regexp"
+	"testing"
+)
+
+// newDeclPrj creates a java project, which contains a record, an enum and an error group.
+func newDeclPrj(version ast.LangVersion) *ast.Prj {
+	notFound := lang.NewError("NotFound").AddCase(
+		lang.NewErrorCase("Ticket").SetComment("...is returned, if no such ticket exists.").
+			AddProperty("id", ast.NewSimpleTypeDecl(stdlib.String), "...is the unknown ticket."),
+	)
+
+	return ast.NewPrj("Decl").AddModules(
+		ast.NewMod("example.com/decl").
+			SetLang(ast.LangJava).
+			SetLangVersion(version).
+			SetOutputDirectory("decl").
+			AddPackages(
+				ast.NewPkg("com.example.decl").AddFiles(
+					ast.NewFile("Point.java").AddTypes(
+						ast.NewStruct("Point").
+							SetComment("...is a coordinate.").
+							SetVisibility(ast.Public).
+							SetRecord(true).
+							AddFields(
+								ast.NewField("x", ast.NewSimpleTypeDecl(stdlib.Int)).SetComment("...is the horizontal offset."),
+								ast.NewField("y", ast.NewSimpleTypeDecl(stdlib.Int)),
+							),
+					),
+					ast.NewFile("Color.java").AddTypes(
+						ast.NewEnum("Color", stdlib.String).AddCases(
+							ast.NewEnumCase("darkRed").SetComment("...is a dark red."),
+							ast.NewEnumCase("blue").SetValue(ast.NewStrLit("#0000ff")),
+						),
+					),
+					ast.NewFile("Level.java").AddTypes(
+						ast.NewEnum("Level", "").AddCases(ast.NewEnumCase("low"), ast.NewEnumCase("high")),
+					),
+					ast.NewFile("NotFoundError.java").AddNodes(notFound.TypeDecl()),
+					ast.NewFile("Tickets.java").AddTypes(
+						ast.NewStruct("Tickets").SetVisibility(ast.Public).AddMethods(
+							ast.NewFunc("find").
+								AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.String))).
+								SetBody(ast.NewBlock(
+									ast.NewAssign(ast.Exprs(ast.NewIdent("err")), ast.AssignDefine, ast.Exprs(notFound.Cases[0].Make(ast.NewIdent("id")))),
+									ast.NewTpl("throw err"),
+								)),
+						),
+					),
+				),
+			),
+	)
+}
+
+func TestRenderer_RenderDecl(t *testing.T) {
+	a, err := NewRenderer(Options{SkipBuildFiles: true}).Render(newDeclPrj("17"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	const pkgDir = "decl/src/main/java/com/example/decl/"
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Point.java"),
+		" * @param x is the horizontal offset.\n",
+		"public record Point(Integer x, Integer y) {}\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Color.java"),
+		"public enum Color {\n",
+		"  DARK_RED(\"darkRed\"),\n",
+		"  BLUE(\"#0000ff\");\n",
+		"  public static Color fromString(String text) {\n",
+		"      if (candidate.value.equals(text)) {\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Level.java"),
+		"  LOW(0),\n  HIGH(1);\n",
+		"      if (candidate.name().equals(text)) {\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"NotFoundError.java"),
+		"public sealed interface NotFoundError permits NotFoundError.Ticket {\n",
+		"  public static final class Ticket extends RuntimeException implements NotFoundError {\n",
+		"      super(String.format(\"Ticket: id=%s\", id), cause);\n",
+		"    public String id() {\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Tickets.java"), "NotFoundError.Ticket err = new NotFoundError.Ticket(id);\n")
+
+	a, err = NewRenderer(Options{SkipBuildFiles: true}).Render(newDeclPrj(ast.LangVersionJava8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir = a.(*render.Dir)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Point.java"), "public class Point {\n", "  public Integer x;\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"NotFoundError.java"), "public interface NotFoundError {\n")
+}
+
+func TestRenderer_RenderDeclWithoutParamDocs(t *testing.T) {
+	conflict := lang.NewError("Conflict").AddCase(
+		lang.NewErrorCase("Version").AddProperty("version", ast.NewSimpleTypeDecl(stdlib.Int), ""),
+	)
+
+	prj := ast.NewPrj("Decl").AddModules(
+		ast.NewMod("example.com/decl").
+			SetLang(ast.LangJava).
+			SetLangVersion("17").
+			SetOutputDirectory("decl").
+			AddPackages(
+				ast.NewPkg("com.example.decl").AddFiles(
+					ast.NewFile("Point.java").AddTypes(
+						ast.NewStruct("Point").
+							SetComment("...is a coordinate.").
+							SetRecord(true).
+							AddFields(ast.NewField("x", ast.NewSimpleTypeDecl(stdlib.Int)).SetComment(" ")),
+					),
+					ast.NewFile("ConflictError.java").AddNodes(conflict.TypeDecl()),
+				),
+			),
+	)
+
+	a, err := NewRenderer(Options{SkipBuildFiles: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	const pkgDir = "decl/src/main/java/com/example/decl/"
+	emptyTag := regexp.MustCompile(`@param[ \t]*\w*[ \t]*\n`)
+	for _, name := range []string{"Point.java", "ConflictError.java"} {
+		src := srctest.ReadFile(t, dir, pkgDir+name)
+		if emptyTag.MatchString(src) {
+			t.Fatalf("expected no empty @param tag in %s but got\n%s", name, src)
+		}
+	}
+
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"ConflictError.java"),
+		"     * @param cause refers to a causing error or null.\n",
+	)
+}
@@ -2,17 +2,15 @@ package java
 
 import (
 	"fmt"
-	"github.com/golangee/src"
 	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
 	"reflect"
 	"strconv"
 	"strings"
 )
 
-const mimeTypeJava = "text/x-java-source"
-const packageJavaDocFile = "package-info"
-
-func writeComment(w *src.BufferedWriter, name, doc string) {
+func writeComment(w *render.BufferedWriter, name, doc string) {
 	myDoc := formatComment(name, doc)
 	if doc != "" {
 		w.Printf(myDoc)
@@ -20,105 +18,221 @@ func writeComment(w *src.BufferedWriter, name, doc string) {
 	}
 }
 
+func writeCommentNode(w *render.BufferedWriter, name string, comment *ast.Comment) {
+	if comment == nil {
+		return
+	}
+
+	writeComment(w, name, comment.Text)
+}
+
+// writeDeclComment emits the comment of a declaration together with its deprecation notice.
+func writeDeclComment(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation) {
+	text := ""
+	if comment != nil {
+		text = comment.Text
+	}
+
+	writeComment(w, name, ast.DocWithDeprecation(text, deprecation))
+}
+
+// writeDeprecated emits the @Deprecated annotation, if required.
+func writeDeprecated(w *render.BufferedWriter, deprecation *ast.Deprecation) {
+	if deprecation != nil {
+		w.Printf("@Deprecated\n")
+	}
+}
+
+// format applies the configured formatter.
+func (r *Renderer) format(source []byte) ([]byte, error) {
+	if r.opts.GoogleJavaFormatJar != "" {
+		return FormatWithJar(r.opts.GoogleJavaFormatJar, source)
+	}
+
+	return Format(source)
+}
+
+// renderPkgInfo emits the package-info.java file, which carries the package documentation.
+func (r *Renderer) renderPkgInfo(pkg *ast.Pkg) ([]byte, error) {
+	w := &render.BufferedWriter{}
+	if pkg.Preamble != nil {
+		writeComment(w, pkg.Name, pkg.Preamble.Text)
+		w.Printf("\n\n") // double line break, otherwise the formatter will purge it
+	}
+
+	writeCommentNode(w, pkg.Name, pkg.ObjComment)
+	w.Printf("package %s;\n", pkg.Path)
+
+	return r.format(w.Bytes())
+}
+
 // renderFile tries to emit the file as java
-func renderFile(file *ast.SrcFileNode) ([]byte, error) {
-	w := &src.BufferedWriter{}
+func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
+	w := &render.BufferedWriter{}
 
-	writeComment(w, file.PkgNode().SrcPackage().PackageName(), file.SrcFile().DocPreamble())
-	w.Printf("\n\n") // double line break, otherwise the formatter will purge it
-	writeComment(w, file.PkgNode().SrcPackage().PackageName(), file.SrcFile().Doc())
+	if file.Preamble != nil {
+		writeComment(w, file.Pkg().Name, file.Preamble.Text)
+		w.Printf("\n\n") // double line break, otherwise the formatter will purge it
+	}
 
-	w.Printf("package %s;\n", file.PkgNode().SrcPackage().PackageName())
+	writeCommentNode(w, file.Pkg().Name, file.Comment())
+
+	w.Printf("package %s;\n", file.Pkg().Path)
+
+	// the explicit imports are registered first, so that they win against the generated ones
+	importer := r.importer(file)
+	var wildcards []string
+	for _, imp := range file.Imports() {
+		switch {
+		case imp.Name.Identifier() == "*" && imp.Static:
+			wildcards = append(wildcards, "import static "+string(imp.Name)+";\n")
+		case imp.Name.Identifier() == "*":
+			wildcards = append(wildcards, "import "+string(imp.Name)+";\n")
+		case imp.Static:
+			importer.shortifyStatic(imp.Name)
+		default:
+			importer.shortify(imp.Name)
+		}
+	}
 
 	// render everything into tmp first, the importer beautifies all required imports on-the-go
-	tmp := &src.BufferedWriter{}
-	for _, typ := range file.Types() {
-		if err := renderType(typ, tmp); err != nil {
-			return nil, err
+	tmp := &render.BufferedWriter{}
+	var funcs []*ast.Func
+	for _, node := range file.Nodes {
+		switch t := node.(type) {
+		case *ast.Func:
+			funcs = append(funcs, t)
+		case *ast.Import:
+			// handled below
+		default:
+			if err := r.renderNode(t, tmp); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	// ugly: we may have source file level functions, which are impossible in Java,
 	// so we create a new class named <filename>Functions use it to render the functions.
-	// This will be package-private only. An alternative would be to pull this up into
-	if len(file.SrcFile().Functions()) > 0 {
-		artificialHolder := src.NewStruct(file.SrcFile().Name() + "Functions").
-			SetDoc("...is introduced to hold static utility functions.").
-			SetVisibility(src.PackagePrivate)
-
-		// private constructor
-		artificialHolder.AddMethods(
-			src.NewFunc(artificialHolder.Name()).
-				SetVisibility(src.Private).
-				SetDoc("...is a private constructor because this class only contains static methods.").
-				SetBody(src.NewBlock()),
-		)
+	// This will be package-private only.
+	if len(funcs) > 0 {
+		holderName := strings.TrimSuffix(file.Name, ".java") + "Functions"
+		writeComment(tmp, holderName, "...is introduced to hold static utility functions.")
+		tmp.Printf("final class %s {\n", holderName)
+		writeComment(tmp, holderName, "...is a private constructor because this class only contains static methods.")
+		tmp.Printf("private %s() {\n}\n", holderName)
+
+		for _, fun := range funcs {
+			if err := r.renderFunc(fun, tmp); err != nil {
+				return nil, fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+			}
+		}
 
-		artificialHolder.AddMethods(file.SrcFile().Functions()...)
-		node := ast.NewTypeNode(file, artificialHolder)
+		tmp.Printf("}\n")
+	}
 
-		if err := renderType(node, tmp); err != nil {
-			return nil, err
-		}
+	// Google style: the static imports and the regular imports are ASCII sorted blocks
+	for _, qualifier := range importer.staticQualifiers() {
+		w.Printf("import static %s;\n", qualifier)
 	}
 
-	importer := importerFromTree(file)
 	for _, qualifier := range importer.qualifiers() {
 		w.Printf("import %s;\n", qualifier)
 	}
 
+	for _, wildcard := range wildcards {
+		w.Print(wildcard)
+	}
+
 	w.Printf(tmp.String())
 
-	return Format(w.Bytes())
+	return r.format(w.Bytes())
 }
 
-func renderTypePreamble(w *src.BufferedWriter, node interface {
-	Name() string
-	Doc() string
-	Annotations() []*ast.AnnotationNode
-}) error {
-	writeComment(w, node.Name(), node.Doc())
-
-	for _, annotation := range node.Annotations() {
-		if err := renderAnnotation(annotation, w); err != nil {
+// renderNode inspects and emits the actual type.
+func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Struct:
+		if err := r.renderStruct(n, w); err != nil {
+			return fmt.Errorf("cannot render struct '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Interface:
+		if err := r.renderInterface(n, w); err != nil {
+			return fmt.Errorf("cannot render interface '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Enum:
+		if err := r.renderEnum(n, w); err != nil {
+			return fmt.Errorf("cannot render enum '%s': %w", n.Identifier(), err)
+		}
+	default:
+		ok, err := r.renderExpr(n, w)
+		if err != nil {
 			return err
 		}
-		w.Printf("\n")
+
+		if !ok {
+			return fmt.Errorf("type not yet implemented: %s", reflect.TypeOf(n).String())
+		}
 	}
 
 	return nil
 }
 
-func renderType(t *ast.TypeNode, w *src.BufferedWriter) error {
+func (r *Renderer) renderTypePreamble(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation, annotations []*ast.Annotation) error {
+	writeDeclComment(w, name, comment, deprecation)
+	writeDeprecated(w, deprecation)
 
-	switch node := t.NamedNode().(type) {
-	case *ast.StructNode:
-		return renderStruct(node, w)
-	case *ast.InterfaceNode:
-		return renderInterface(node, w)
-	default:
-		panic("type not yet implemented: " + reflect.TypeOf(t).String())
+	for _, annotation := range annotations {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
 	}
+
+	return nil
 }
 
-func renderInterface(node *ast.InterfaceNode, w *src.BufferedWriter) error {
-	if err := renderTypePreamble(w, node); err != nil {
+func (r *Renderer) renderInterface(node *ast.Interface, w *render.BufferedWriter) error {
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), node.Annotations()); err != nil {
 		return err
 	}
 
-	w.Printf(visibilityAsKeyword(node.SrcInterface().Visibility()))
+	w.Printf(visibilityAsKeyword(node.Visibility()))
+
+	// sealed types require Java 17, otherwise we just emit an ordinary interface
+	sealed := len(node.Permits) > 0 && featureVersion(node) >= 17
+	if sealed {
+		w.Printf(" sealed")
+	}
 
-	w.Printf(" interface %s {\n", node.SrcInterface().Name())
+	w.Printf(" interface %s", node.Identifier())
 
-	for _, typeNode := range node.Types() {
-		if err := renderType(typeNode, w); err != nil {
+	if sealed {
+		w.Printf(" permits ")
+		for i, name := range node.Permits {
+			// nested types are not in scope of the permits clause and must be qualified by the interface
+			if name.Qualifier() == node.Identifier() {
+				w.Printf(string(name))
+			} else {
+				w.Printf(string(r.importer(node).shortify(name)))
+			}
+
+			if i < len(node.Permits)-1 {
+				w.Printf(", ")
+			}
+		}
+	}
+
+	w.Printf(" {\n")
+
+	for _, typeNode := range node.NamedTypes() {
+		if err := r.renderNode(typeNode, w); err != nil {
 			return err
 		}
 	}
 
 	for _, fun := range node.Methods() {
-		if err := renderFunc(fun, w); err != nil {
-			return fmt.Errorf("failed to render func %s: %w", fun.SrcFunc().Name(), err)
+		if err := r.renderFunc(fun, w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
 		}
 	}
 	w.Printf("}\n")
@@ -126,37 +240,77 @@ func renderInterface(node *ast.InterfaceNode, w *src.BufferedWriter) error {
 	return nil
 }
 
-func renderStruct(node *ast.StructNode, w *src.BufferedWriter) error {
-	if err := renderTypePreamble(w, node); err != nil {
-		return err
+// renderStruct emits a class or, if requested and supported by the target (Java 16+), a record.
+func (r *Renderer) renderStruct(node *ast.Struct, w *render.BufferedWriter) error {
+	record := node.Record() && featureVersion(node) >= 16
+	if record {
+		if node.Extends != "" {
+			return fmt.Errorf("a record cannot extend '%s'", node.Extends)
+		}
+
+		if err := r.renderTypePreamble(w, node.Identifier(), recordComment(node), node.Deprecated(), node.Annotations()); err != nil {
+			return err
+		}
+	} else {
+		if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), node.Annotations()); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(visibilityAsKeyword(node.Visibility()))
+
+	if node.Static() {
+		w.Printf(" static ")
 	}
 
-	w.Printf(visibilityAsKeyword(node.SrcStruct().Visibility()))
-	if node.SrcStruct().Final() {
+	if node.Final() && !record {
 		w.Printf(" final ")
 	}
 
-	if node.SrcStruct().Static() {
-		w.Printf(" static ")
+	if record {
+		w.Printf(" record %s(", node.Identifier())
+		if err := r.renderRecordComponents(node, w); err != nil {
+			return err
+		}
+
+		w.Printf(")")
+	} else {
+		w.Printf(" class %s", node.Identifier())
 	}
 
-	w.Printf(" class %s {\n", node.SrcStruct().Name())
+	if node.Extends != "" {
+		w.Printf(" extends %s", r.importer(node).shortify(node.Extends))
+	}
+
+	if len(node.Implements) > 0 {
+		w.Printf(" implements ")
+		for i, name := range node.Implements {
+			w.Printf(string(r.importer(node).shortify(name)))
+			if i < len(node.Implements)-1 {
+				w.Printf(", ")
+			}
+		}
+	}
 
-	for _, typeNode := range node.Types() {
-		if err := renderType(typeNode, w); err != nil {
+	w.Printf(" {\n")
+
+	for _, typeNode := range node.NamedTypes() {
+		if err := r.renderNode(typeNode, w); err != nil {
 			return err
 		}
 	}
 
-	for _, field := range node.Fields() {
-		if err := renderField(field, w); err != nil {
-			return fmt.Errorf("failed to render field %s: %w", field.SrcField().Name(), err)
+	if !record {
+		for _, field := range node.Fields() {
+			if err := r.renderField(field, w); err != nil {
+				return fmt.Errorf("failed to render field %s: %w", field.Identifier(), err)
+			}
 		}
 	}
 
 	for _, fun := range node.Methods() {
-		if err := renderFunc(fun, w); err != nil {
-			return fmt.Errorf("failed to render func %s: %w", fun.SrcFunc().Name(), err)
+		if err := r.renderFunc(fun, w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
 		}
 	}
 	w.Printf("}\n")
@@ -164,166 +318,230 @@ func renderStruct(node *ast.StructNode, w *src.BufferedWriter) error {
 	return nil
 }
 
-func renderFunc(node *ast.FuncNode, w *src.BufferedWriter) error {
+// recordComment returns the type comment, which also documents the record components as parameters.
+func recordComment(node *ast.Struct) *ast.Comment {
+	comment := &strings.Builder{}
+	if node.Comment() != nil {
+		comment.WriteString(node.Comment().Text)
+	}
+	comment.WriteString("\n\n")
+
+	for _, field := range node.Fields() {
+		if isEmptyComment(field.Comment()) {
+			continue
+		}
+
+		comment.WriteString("@param ")
+		name := naming.Java.Escape(field.Identifier())
+		comment.WriteString(deEllipsis(name, field.Comment().Text))
+		comment.WriteString("\n")
+	}
+
+	return ast.NewComment(comment.String())
+}
+
+// renderRecordComponents emits the fields as the comma separated components of a record header. The visibility
+// of fields is ignored, because the accessor methods of a record are always public.
+func (r *Renderer) renderRecordComponents(node *ast.Struct, w *render.BufferedWriter) error {
+	for i, field := range node.Fields() {
+		for _, annotation := range field.Annotations() {
+			if err := r.renderAnnotation(annotation, w); err != nil {
+				return err
+			}
+
+			w.Printf(" ")
+		}
+
+		if err := r.renderTypeDecl(field.TypeDecl(), w); err != nil {
+			return fmt.Errorf("failed to render component %s: %w", field.Identifier(), err)
+		}
+
+		w.Printf(" %s", naming.Java.Escape(field.Identifier()))
+		if i < len(node.Fields())-1 {
+			w.Printf(", ")
+		}
+	}
+
+	return nil
+}
+
+func (r *Renderer) renderFuncComment(node *ast.Func) string {
 	comment := &strings.Builder{}
-	comment.WriteString(node.SrcFunc().Doc())
+	if node.ObjComment != nil {
+		comment.WriteString(node.ObjComment.Text)
+	}
 	comment.WriteString("\n\n")
 
-	for _, parameterNode := range node.InputParams() {
-		if parameterNode.SrcParameter().Doc() == "" {
+	for _, parameterNode := range node.Params() {
+		if isEmptyComment(parameterNode.ObjComment) {
 			continue
 		}
 
 		comment.WriteString("@param ")
-		name := parameterNode.SrcParameter().Name()
+		name := naming.Java.Escape(parameterNode.Identifier())
 		if name == "" {
-			name = fromStdlib(src.Name(parameterNode.SrcParameter().TypeDecl().String())).Identifier()
+			name = fromStdlib(ast.Name(parameterNode.TypeDecl().String())).Identifier()
 		}
 
-		comment.WriteString(deEllipsis(name, parameterNode.SrcParameter().Doc()))
+		comment.WriteString(deEllipsis(name, parameterNode.ObjComment.Text))
 		comment.WriteString("\n")
 	}
 
-	for i, parameterNode := range node.OutputParams() {
-		if i == 0 || parameterNode.SrcParameter().Doc() == "" {
+	for i, parameterNode := range node.Results() {
+		if i == 0 || isEmptyComment(parameterNode.ObjComment) {
 			continue
 		}
 
 		comment.WriteString("@throws ")
-		name := parameterNode.SrcParameter().Name()
+		name := parameterNode.Identifier()
 		if name == "" {
-			name = fromStdlib(src.Name(parameterNode.SrcParameter().TypeDecl().String())).Identifier()
+			name = fromStdlib(ast.Name(parameterNode.TypeDecl().String())).Identifier()
 		}
 
-		comment.WriteString(deEllipsis(name, parameterNode.SrcParameter().Doc()))
+		comment.WriteString(deEllipsis(name, parameterNode.ObjComment.Text))
 		comment.WriteString("\n")
 	}
 
-	writeComment(w, node.SrcFunc().Name(), comment.String())
+	return ast.DocWithDeprecation(comment.String(), node.Deprecated())
+}
 
-	if _, ok := node.Parent().(*ast.InterfaceNode); ok {
-		// we ignore the visibility entirely, because in Java interfaces methods are always public
-	} else {
-		w.Printf(visibilityAsKeyword(node.SrcFunc().Visibility()))
-		w.Printf(" ")
-		if node.SrcFunc().Static() {
-			w.Printf("static ")
-		}
-	}
+// renderFunc emits a method. Depending on the parent, which is either an ast.Struct, an ast.Interface or an
+// ast.File, the function is rendered as a member, an interface method or a static utility method.
+func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
+	writeComment(w, node.Identifier(), r.renderFuncComment(node))
+	writeDeprecated(w, node.Deprecated())
 
 	for _, annotation := range node.Annotations() {
-		if err := renderAnnotation(annotation, w); err != nil {
+		if err := r.renderAnnotation(annotation, w); err != nil {
 			return err
 		}
 		w.Printf("\n")
 	}
 
-	if len(node.OutputParams()) == 0 {
-		parentType := ast.ParentTypeNode(node)
-		if node.SrcFunc().Name() == parentType.SrcNamedType().Name() {
-			// special case, if we are a constructor, we omit also the void
-		} else {
+	isConstructor := false
+	switch t := node.Parent().(type) {
+	case *ast.Interface:
+		// we ignore the visibility entirely, because in Java interfaces methods are always public
+	case *ast.File:
+		// a function without class is always a static utility method
+		w.Printf(visibilityAsKeyword(node.Visibility()))
+		w.Printf(" static ")
+	case *ast.Struct:
+		isConstructor = node.Identifier() == t.Identifier()
+		w.Printf(visibilityAsKeyword(node.Visibility()))
+		w.Printf(" ")
+		if node.Static() {
+			w.Printf("static ")
+		}
+	}
+
+	if len(node.Results()) == 0 {
+		if !isConstructor {
 			w.Printf("void ")
 		}
 	} else {
-		if err := renderTypeDecl(node.OutputParams()[0].TypeDecl(), w); err != nil {
+		if err := r.renderTypeDecl(node.Results()[0].TypeDecl(), w); err != nil {
 			return err
 		}
 		w.Printf(" ")
 	}
-	w.Printf(node.SrcFunc().Name())
+	w.Printf(naming.Java.Escape(node.Identifier()))
 	w.Printf("(")
-	for i, parameterNode := range node.InputParams() {
+	for i, parameterNode := range node.Params() {
 		for _, annotationNode := range parameterNode.Annotations() {
-			if err := renderAnnotation(annotationNode, w); err != nil {
+			if err := r.renderAnnotation(annotationNode, w); err != nil {
 				return err
 			}
 
 			w.Printf(" ")
 		}
 
-		if err := renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
+		if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
 			return err
 		}
 
-		if i == len(node.InputParams())-1 && node.SrcFunc().Variadic() {
+		if i == len(node.Params())-1 && node.Variadic() {
 			w.Printf("...")
 		} else {
 			w.Printf(" ")
 		}
 
-		w.Printf(parameterNode.SrcParameter().Name())
+		w.Printf(naming.Java.Escape(parameterNode.Identifier()))
 
-		if i < len(node.OutputParams())-1 {
+		if i < len(node.Params())-1 {
 			w.Printf(", ")
 		}
 	}
 	w.Printf(")")
 
 	// by convention this must be throwables in Java
-	if len(node.OutputParams()) > 1 {
-		w.Printf("throws ")
-		for i, parameterNode := range node.OutputParams() {
+	if len(node.Results()) > 1 {
+		w.Printf(" throws ")
+		for i, parameterNode := range node.Results() {
 			if i == 0 {
 				continue
 			}
 
-			if err := renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
+			if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
 				return err
 			}
 
-			if i < len(node.OutputParams())-1 {
+			if i < len(node.Results())-1 {
 				w.Printf(", ")
 			}
 		}
 	}
 
-	if node.SrcFunc().Body() == nil {
-		w.Printf(";")
+	if node.Body() == nil {
+		w.Printf(";\n")
 	} else {
-		w.Printf("{\n")
-		w.Printf("}\n")
+		w.Printf(" ")
+		if err := r.renderBlock(node.Body(), w); err != nil {
+			return fmt.Errorf("unable to render method body: %w", err)
+		}
 	}
 
 	return nil
 }
 
-func renderField(node *ast.FieldNode, w *src.BufferedWriter) error {
-	writeComment(w, node.SrcField().Name(), node.SrcField().Doc())
+func (r *Renderer) renderField(node *ast.Field, w *render.BufferedWriter) error {
+	writeDeclComment(w, node.Identifier(), node.Comment(), node.Deprecated())
+	writeDeprecated(w, node.Deprecated())
 	for _, annotation := range node.Annotations() {
-		if err := renderAnnotation(annotation, w); err != nil {
+		if err := r.renderAnnotation(annotation, w); err != nil {
 			return err
 		}
+		w.Printf("\n")
 	}
-	w.Printf(visibilityAsKeyword(node.SrcField().Visibility()))
+	w.Printf(visibilityAsKeyword(node.Visibility()))
 	w.Printf(" ")
-	if err := renderTypeDecl(node.TypeDecl(), w); err != nil {
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
 		return err
 	}
 	w.Printf(" ")
-	w.Printf(node.SrcField().Name())
+	w.Printf(naming.Java.Escape(node.Identifier()))
 	w.Printf(";\n")
 
 	return nil
 }
 
-func renderAnnotation(node *ast.AnnotationNode, w *src.BufferedWriter) error {
-	importer := importerFromTree(node)
+func (r *Renderer) renderAnnotation(node *ast.Annotation, w *render.BufferedWriter) error {
+	importer := r.importer(node)
 
 	w.Printf("@")
-	w.Printf(string(importer.shortify(node.SrcAnnotation().Name())))
-	attrs := node.SrcAnnotation().Attributes()
+	w.Printf(string(importer.shortify(node.Identifier())))
+	attrs := node.Attributes()
 	if len(attrs) > 0 {
 		w.Printf("(")
 		// the default case
 		if len(attrs) == 1 && attrs[0] == "" {
-			w.Printf(node.SrcAnnotation().Value(""))
+			w.Printf(node.GetLiteral(""))
 		} else {
 			// the named attribute cases
 			for i, attr := range attrs {
 				w.Printf(attr)
 				w.Printf(" = ")
-				w.Printf(node.SrcAnnotation().Value(attr))
+				w.Printf(node.GetLiteral(attr))
 				if i < len(attrs)-1 {
 					w.Printf(", ")
 				}
@@ -336,31 +554,31 @@ func renderAnnotation(node *ast.AnnotationNode, w *src.BufferedWriter) error {
 	return nil
 }
 
-func renderTypeDecl(node ast.TypeDeclNode, w *src.BufferedWriter) error {
-	importer := importerFromTree(node)
+func (r *Renderer) renderTypeDecl(node ast.TypeDecl, w *render.BufferedWriter) error {
+	importer := r.importer(node)
 
 	switch t := node.(type) {
-	case *ast.SimpleTypeDeclNode:
-		w.Printf(string(importer.shortify(fromStdlib(t.SrcSimpleTypeDecl().Name()))))
-	case *ast.TypeDeclPtrNode:
+	case *ast.SimpleTypeDecl:
+		w.Printf(string(importer.shortify(fromStdlib(t.Name()))))
+	case *ast.TypeDeclPtr:
 		atomicReference := importer.shortify("java.util.concurrent.atomic.AtomicReference")
 		w.Printf(string(atomicReference) + "<")
-		if err := renderTypeDecl(t.TypeDecl(), w); err != nil {
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
 			return err
 		}
 		w.Printf(">")
-	case *ast.SliceTypeDeclNode:
-		if err := renderTypeDecl(t.TypeDecl(), w); err != nil {
+	case *ast.SliceTypeDecl:
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
 			return err
 		}
 		w.Printf("[]")
-	case *ast.GenericTypeDeclNode:
-		if err := renderTypeDecl(t.TypeDecl(), w); err != nil {
+	case *ast.GenericTypeDecl:
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
 			return err
 		}
 		w.Printf("<")
 		for i, decl := range t.Params() {
-			if err := renderTypeDecl(decl, w); err != nil {
+			if err := r.renderTypeDecl(decl, w); err != nil {
 				return err
 			}
 			if i < len(t.Params())-1 {
@@ -368,42 +586,39 @@ func renderTypeDecl(node ast.TypeDeclNode, w *src.BufferedWriter) error {
 			}
 		}
 		w.Printf(">")
-	case *ast.ChanTypeDeclNode:
+	case *ast.ChanTypeDecl:
 		blockingQueue := importer.shortify("java.util.concurrent.BlockingQueue")
 		w.Printf(string(blockingQueue) + "<")
-		if err := renderTypeDecl(t.TypeDecl(), w); err != nil {
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
 			return err
 		}
 		w.Printf(">")
 
-	case *ast.ArrayTypeDeclNode:
+	case *ast.ArrayTypeDecl:
 		// in Java this is the same as a slice, we cannot have yet custom size value arrays. Perhaps
 		// valhalla may fix that
-		if err := renderTypeDecl(t.TypeDecl(), w); err != nil {
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
 			return err
 		}
 		w.Printf("[]")
-	case *ast.FuncTypeDeclNode:
-		// Java does not have it. We would need to create a functional interface for it, which is out of scope here.
-		writeComment(w, "", "inline function declarations are not supported by java:\n\n"+t.SrcFuncTypeDecl().String())
-		w.Printf("Object")
-		return nil
+	case *ast.FuncTypeDecl:
+		return r.renderFuncTypeDecl(t, w)
 	default:
-		panic("not yet implemented: " + reflect.TypeOf(t).String())
+		return fmt.Errorf("type declaration not yet implemented: %s", reflect.TypeOf(t).String())
 	}
 
 	return nil
 }
 
-func visibilityAsKeyword(v src.Visibility) string {
+func visibilityAsKeyword(v ast.Visibility) string {
 	switch v {
-	case src.Public:
+	case ast.Public:
 		return "public"
-	case src.PackagePrivate:
+	case ast.PackagePrivate:
 		return ""
-	case src.Private:
+	case ast.Private:
 		return "private"
-	case src.Protected:
+	case ast.Protected:
 		return "protected"
 	default:
 		panic("visibility not implemented: " + strconv.Itoa(int(v)))
@@ -0,0 +1,84 @@
+package java
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"strconv"
+)
+
+// renderEnum emits a Java enum. Each case is a constant in screaming snake case, which carries its value.
+// Cases without a value get their name, if the base type is a string, otherwise their index, just like iota
+// in Go. The value is returned by value() and fromString resolves a case either by its string value or by
+// its constant name.
+func (r *Renderer) renderEnum(node *ast.Enum, w *render.BufferedWriter) error {
+	writeCommentNode(w, node.Identifier(), node.Comment())
+
+	baseType := node.BaseType
+	if baseType == "" {
+		baseType = stdlib.Int
+	}
+
+	isString := baseType == stdlib.String
+	valueType := string(r.importer(node).shortify(fromStdlib(baseType)))
+
+	w.Printf("public enum %s", node.Identifier())
+	if len(node.Implements) > 0 {
+		w.Printf(" implements ")
+		for i, name := range node.Implements {
+			w.Printf(string(r.importer(node).shortify(name)))
+			if i < len(node.Implements)-1 {
+				w.Printf(", ")
+			}
+		}
+	}
+
+	w.Printf(" {\n")
+
+	for i, enumCase := range node.Cases {
+		name := naming.Java.ScreamingSnake(enumCase.Name())
+		writeCommentNode(w, name, enumCase.Comment())
+		w.Printf("%s(", name)
+		switch {
+		case enumCase.EnumValue != nil:
+			if err := r.renderBasicLit(enumCase.EnumValue, w); err != nil {
+				return fmt.Errorf("cannot render value of case '%s': %w", enumCase.Name(), err)
+			}
+		case isString:
+			w.Printf(javaQuote(enumCase.Name()))
+		default:
+			w.Printf(strconv.Itoa(i))
+		}
+
+		w.Printf(")")
+		if i < len(node.Cases)-1 {
+			w.Printf(",\n")
+		}
+	}
+
+	w.Printf(";\n\n")
+
+	w.Printf("private final %s value;\n\n", valueType)
+	w.Printf("%s(%s value) {\nthis.value = value;\n}\n", node.Identifier(), valueType)
+
+	writeComment(w, "value", "...returns the underlying value of this case.")
+	w.Printf("public %s value() {\nreturn value;\n}\n", valueType)
+
+	match := "candidate.name().equals(text)"
+	doc := "...returns the case, whose constant name is equal to the given text."
+	if isString {
+		match = "candidate.value.equals(text)"
+		doc = "...returns the case, whose value is equal to the given text."
+	}
+
+	writeComment(w, "fromString", doc+"\n\n@throws IllegalArgumentException if no such case exists")
+	w.Printf("public static %s fromString(String text) {\n", node.Identifier())
+	w.Printf("for (%s candidate : values()) {\nif (%s) {\nreturn candidate;\n}\n}\n", node.Identifier(), match)
+	w.Printf("throw new IllegalArgumentException(%s + text);\n}\n", javaQuote("unknown "+node.Identifier()+": "))
+
+	w.Printf("}\n")
+
+	return nil
+}
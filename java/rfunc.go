@@ -0,0 +1,283 @@
+package java
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// javaBoxes maps the primitive types to their boxed types, because type arguments must be reference types.
+var javaBoxes = map[string]string{
+	"boolean": "Boolean", "byte": "Byte", "char": "Character", "short": "Short",
+	"int": "Integer", "long": "Long", "float": "Float", "double": "Double", "void": "Void",
+}
+
+// functionalInterface describes a generic interface, which is synthesized for a function signature without a
+// counterpart in java.util.function, e.g. for more than 2 parameters or a trailing error result.
+type functionalInterface struct {
+	name   string // e.g. Function3 or ThrowingSupplier
+	params int    // amount of type parameters for the arguments
+	result bool   // true, if the method returns a value
+	throws bool   // true, if the method declares to throw a checked exception
+}
+
+// method returns the name of the single abstract method.
+func (f functionalInterface) method() string {
+	switch {
+	case f.result && f.params == 0:
+		return "get"
+	case f.result:
+		return "apply"
+	case f.params == 0:
+		return "run"
+	default:
+		return "accept"
+	}
+}
+
+// newFunctionalInterface derives the name from the shape, just like java.util.function does, e.g.
+// BiConsumer, Function3 or ThrowingRunnable.
+func newFunctionalInterface(params int, result, throws bool) functionalInterface {
+	name := ""
+	switch {
+	case result && params == 0:
+		name = "Supplier"
+	case result:
+		name = "Function"
+	case params == 0:
+		name = "Runnable"
+	default:
+		name = "Consumer"
+	}
+
+	switch {
+	case params == 2:
+		name = "Bi" + name
+	case params > 2:
+		name += strconv.Itoa(params)
+	}
+
+	if throws {
+		name = "Throwing" + name
+	}
+
+	return functionalInterface{name: name, params: params, result: result, throws: throws}
+}
+
+// renderFuncTypeDecl emits a functional interface for the function signature. The common shapes are mapped to
+// java.util.function (Runnable, Supplier, Consumer, Predicate, Function and BiFunction) and all others to a
+// generic interface, which is synthesized in the package of the node. A trailing error result becomes a
+// throws clause and primitive arguments are boxed.
+func (r *Renderer) renderFuncTypeDecl(node *ast.FuncTypeDecl, w *render.BufferedWriter) error {
+	var args []ast.TypeDecl
+	for _, param := range node.InputParams() {
+		args = append(args, param.TypeDecl())
+	}
+
+	var results []ast.TypeDecl
+	for _, param := range node.OutputParams() {
+		if simple, ok := param.TypeDecl().(*ast.SimpleTypeDecl); ok && simple.Name() == stdlib.Void {
+			continue
+		}
+
+		results = append(results, param.TypeDecl())
+	}
+
+	throws := false
+	if len(results) > 0 {
+		if simple, ok := results[len(results)-1].(*ast.SimpleTypeDecl); ok && simple.Name() == stdlib.Error {
+			throws = true
+			results = results[:len(results)-1]
+		}
+	}
+
+	if len(results) > 1 {
+		return fmt.Errorf("java cannot express multiple results: %s", node.String())
+	}
+
+	importer := r.importer(node)
+	name := ""
+	switch {
+	case throws || len(args) > 2:
+		f := newFunctionalInterface(len(args), len(results) == 1, throws)
+		if err := r.addFunctionalInterface(node, f); err != nil {
+			return err
+		}
+
+		name = f.name
+	case len(args) == 0 && len(results) == 0:
+		w.Printf("Runnable")
+		return nil
+	case len(args) == 0:
+		name = string(importer.shortify("java.util.function.Supplier"))
+	case len(args) == 1 && len(results) == 0:
+		name = string(importer.shortify("java.util.function.Consumer"))
+	case len(args) == 1 && isBool(results[0]):
+		name = string(importer.shortify("java.util.function.Predicate"))
+		results = nil
+	case len(args) == 1:
+		name = string(importer.shortify("java.util.function.Function"))
+	case len(results) == 0:
+		name = string(importer.shortify("java.util.function.BiConsumer"))
+	default:
+		name = string(importer.shortify("java.util.function.BiFunction"))
+	}
+
+	w.Printf(name)
+	w.Printf("<")
+	for i, decl := range append(args, results...) {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if err := r.renderBoxedTypeDecl(decl, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(">")
+
+	return nil
+}
+
+// isBool returns true, if the type declares the stdlib boolean.
+func isBool(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.Bool
+}
+
+// renderBoxedTypeDecl emits the type declaration but replaces a primitive by its boxed type.
+func (r *Renderer) renderBoxedTypeDecl(decl ast.TypeDecl, w *render.BufferedWriter) error {
+	tmp := &render.BufferedWriter{}
+	if err := r.renderTypeDecl(decl, tmp); err != nil {
+		return err
+	}
+
+	name := tmp.String()
+	if boxed, ok := javaBoxes[name]; ok {
+		name = boxed
+	}
+
+	w.Print(name)
+
+	return nil
+}
+
+// addFunctionalInterface registers the interface to be synthesized in the package of the node.
+func (r *Renderer) addFunctionalInterface(node ast.Node, f functionalInterface) error {
+	pkg := ast.PkgFrom(node)
+	if pkg == nil {
+		return fmt.Errorf("cannot synthesize functional interface '%s': node is not attached to a package", f.name)
+	}
+
+	for _, file := range pkg.PkgFiles {
+		if file.Name == f.name+".java" {
+			return fmt.Errorf("cannot synthesize functional interface '%s': file already declared", f.name)
+		}
+	}
+
+	if r.functionals[pkg] == nil {
+		r.functionals[pkg] = map[string]functionalInterface{}
+	}
+
+	r.functionals[pkg][f.name] = f
+
+	return nil
+}
+
+// renderFunctionalInterfaces emits a file for each functional interface, which has been synthesized while
+// rendering the files of the package.
+func (r *Renderer) renderFunctionalInterfaces(pkg *ast.Pkg) ([]*render.File, error) {
+	var names []string
+	for name := range r.functionals[pkg] {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var res []*render.File
+	var firstErr error
+	for _, name := range names {
+		buf, err := r.renderFunctionalInterface(pkg, r.functionals[pkg][name])
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render functional interface '%s': %w", name, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: name + ".java",
+			MimeType: MimeTypeJava,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	return res, firstErr
+}
+
+// renderFunctionalInterface emits the generic declaration, e.g.
+//
+//	@FunctionalInterface
+//	public interface Function3<T1, T2, T3, R> {
+//	  R apply(T1 t1, T2 t2, T3 t3);
+//	}
+func (r *Renderer) renderFunctionalInterface(pkg *ast.Pkg, f functionalInterface) ([]byte, error) {
+	w := &render.BufferedWriter{}
+	w.Printf("package %s;\n", pkg.Path)
+
+	var typeParams, params []string
+	arguments := strconv.Itoa(f.params) + " arguments"
+	switch f.params {
+	case 0:
+		arguments = "no arguments"
+	case 1:
+		arguments = "one argument"
+	}
+
+	doc := &strings.Builder{}
+	if f.result {
+		doc.WriteString("...represents a function, which accepts " + arguments + " and produces a result.")
+	} else {
+		doc.WriteString("...represents an operation, which accepts " + arguments + " and returns no result.")
+	}
+
+	doc.WriteString("\n\n")
+	for i := 1; i <= f.params; i++ {
+		typeParams = append(typeParams, "T"+strconv.Itoa(i))
+		params = append(params, "T"+strconv.Itoa(i)+" t"+strconv.Itoa(i))
+		doc.WriteString("@param <T" + strconv.Itoa(i) + "> the type of the argument t" + strconv.Itoa(i) + "\n")
+	}
+
+	result := "void"
+	if f.result {
+		result = "R"
+		typeParams = append(typeParams, result)
+		doc.WriteString("@param <R> the type of the result\n")
+	}
+
+	writeComment(w, f.name, doc.String())
+	w.Printf("@FunctionalInterface\n")
+	w.Printf("public interface %s", f.name)
+	if len(typeParams) > 0 {
+		w.Printf("<%s>", strings.Join(typeParams, ", "))
+	}
+
+	w.Printf(" {\n")
+	if f.throws {
+		writeComment(w, f.method(), "...performs this operation on the given arguments.\n\n@throws Exception if the operation fails")
+	} else {
+		writeComment(w, f.method(), "...performs this operation on the given arguments.")
+	}
+
+	w.Printf("%s %s(%s)", result, f.method(), strings.Join(params, ", "))
+	if f.throws {
+		w.Printf(" throws Exception")
+	}
+
+	w.Printf(";\n}\n")
+
+	return r.format(w.Bytes())
+}
@@ -0,0 +1,81 @@
+package java
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"github.com/golangee/src/stdlib"
+	"testing"
+)
+
+func newFuncType(in []ast.Name, out ...ast.Name) *ast.FuncTypeDecl {
+	decl := ast.NewFuncTypeDecl()
+	for _, name := range in {
+		decl.AddInputParams(ast.NewParam("", ast.NewSimpleTypeDecl(name)))
+	}
+
+	for _, name := range out {
+		decl.AddOutputParams(ast.NewParam("", ast.NewSimpleTypeDecl(name)))
+	}
+
+	return decl
+}
+
+func TestRenderer_RenderFuncTypeDecl(t *testing.T) {
+	prj := ast.NewPrj("Func").AddModules(
+		ast.NewMod("example.com/func").
+			SetLang(ast.LangJava).
+			SetOutputDirectory("func").
+			AddPackages(
+				ast.NewPkg("com.example.func").AddFiles(
+					ast.NewFile("Handlers.java").AddTypes(
+						ast.NewStruct("Handlers").SetVisibility(ast.Public).AddFields(
+							ast.NewField("run", newFuncType(nil)),
+							ast.NewField("supply", newFuncType(nil, stdlib.String)),
+							ast.NewField("consume", newFuncType([]ast.Name{stdlib.Rune})),
+							ast.NewField("test", newFuncType([]ast.Name{stdlib.String}, stdlib.Bool)),
+							ast.NewField("apply", newFuncType([]ast.Name{stdlib.Int}, stdlib.String)),
+							ast.NewField("combine", newFuncType([]ast.Name{stdlib.Int, stdlib.Int64}, stdlib.Float64)),
+							ast.NewField("load", newFuncType([]ast.Name{stdlib.String}, stdlib.Int, stdlib.Error)),
+							ast.NewField("merge", newFuncType([]ast.Name{stdlib.String, stdlib.Int, stdlib.Bool}, stdlib.String)),
+						),
+					),
+				),
+			),
+	)
+
+	a, err := NewRenderer(Options{SkipBuildFiles: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	const pkgDir = "func/src/main/java/com/example/func/"
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Handlers.java"),
+		"import java.util.function.BiFunction;\n",
+		"public Runnable run;\n",
+		"public Supplier<String> supply;\n",
+		"public Consumer<Integer> consume;\n",
+		"public Predicate<String> test;\n",
+		"public Function<Integer, String> apply;\n",
+		"public BiFunction<Integer, Long, Double> combine;\n",
+		"public ThrowingFunction<String, Integer> load;\n",
+		"public Function3<String, Integer, Boolean, String> merge;\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"Function3.java"),
+		"@FunctionalInterface\npublic interface Function3<T1, T2, T3, R> {\n",
+		"  R apply(T1 t1, T2 t2, T3 t3);\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, pkgDir+"ThrowingFunction.java"),
+		"public interface ThrowingFunction<T1, R> {\n",
+		"  R apply(T1 t1) throws Exception;\n",
+	)
+
+	prj.Mods[0].Pkgs[0].PkgFiles[0].AddTypes(
+		ast.NewStruct("Broken").AddFields(ast.NewField("multi", newFuncType(nil, stdlib.Int, stdlib.String))),
+	)
+
+	if _, err := NewRenderer(Options{SkipBuildFiles: true}).Render(prj); err == nil {
+		t.Fatal("expected a multiple results error")
+	}
+}
@@ -0,0 +1,283 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota + 1
+	tokKeyword
+	tokNumber
+	tokString
+	tokChar
+	tokOperator
+	tokLineComment
+	tokBlockComment
+)
+
+// token is a lexical element of a Java source.
+type token struct {
+	kind    tokenKind
+	text    string
+	line    int  // 1 based line of the first character
+	newline int  // amount of line breaks in the whitespace in front of the token
+	glued   bool // true, if there is no whitespace in front of the token
+	generic bool // true, if the token is a < or > of a type argument or parameter list
+	unary   bool // true, if the token is a prefix operator
+}
+
+func (t token) is(text string) bool {
+	return (t.kind == tokOperator || t.kind == tokKeyword) && t.text == text
+}
+
+func (t token) isComment() bool {
+	return t.kind == tokLineComment || t.kind == tokBlockComment
+}
+
+// javaReservedKeywords contains the reserved Java keywords and literals, which are not identifiers.
+var javaReservedKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`abstract assert boolean break byte case catch char class const continue default do
+		double else enum extends final finally float for goto if implements import instanceof int interface long native
+		new package private protected public return short static strictfp super switch synchronized this throw throws
+		transient try void volatile while true false null`) {
+		javaReservedKeywords[k] = true
+	}
+}
+
+// javaOperators contains all operators and separators, the longest first. The > is always scanned as a single
+// token and joined later, because it may close nested type argument lists.
+var javaOperators = []string{
+	"<<=", "...", "->", "::", "++", "--", "&&", "||", "==", "!=", "<=", "+=", "-=", "*=", "/=", "&=", "|=",
+	"^=", "%=", "<<", "(", ")", "{", "}", "[", "]", ";", ",", ".", "@", "=", ">", "<", "!", "~", "?", ":", "+",
+	"-", "*", "/", "&", "|", "^", "%",
+}
+
+// scanJava splits the source into tokens. Whitespace is not returned but recorded at the following token.
+func scanJava(src string) ([]token, error) {
+	var tokens []token
+	line := 1
+	newlines := 0
+	glued := true
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		if r == '\n' {
+			line++
+			newlines++
+			glued = false
+			i += size
+			continue
+		}
+
+		if unicode.IsSpace(r) {
+			glued = false
+			i += size
+			continue
+		}
+
+		tok := token{line: line, newline: newlines, glued: glued}
+		start := i
+		switch {
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+
+			tok.kind = tokLineComment
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+
+			tok.kind = tokBlockComment
+			i += end + 4
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(src[i+3:], `"""`)
+			for end >= 0 && isEscaped(src[i+3:], end) {
+				next := strings.Index(src[i+3+end+1:], `"""`)
+				if next < 0 {
+					end = -1
+					break
+				}
+
+				end += next + 1
+			}
+
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated text block", line)
+			}
+
+			tok.kind = tokString
+			i += end + 6
+		case r == '"' || r == '\'':
+			end := i + 1
+			for ; end < len(src) && src[end] != byte(r); end++ {
+				if src[end] == '\\' {
+					end++
+				} else if src[end] == '\n' {
+					break
+				}
+			}
+
+			if end >= len(src) || src[end] != byte(r) {
+				return nil, fmt.Errorf("line %d: unterminated literal", line)
+			}
+
+			tok.kind = tokString
+			if r == '\'' {
+				tok.kind = tokChar
+			}
+
+			i = end + 1
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9'):
+			tok.kind = tokNumber
+			i = scanNumber(src, i)
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			end := i
+			for end < len(src) {
+				c, s := utf8.DecodeRuneInString(src[end:])
+				if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '$' {
+					break
+				}
+
+				end += s
+			}
+
+			tok.kind = tokIdent
+			if javaReservedKeywords[src[i:end]] {
+				tok.kind = tokKeyword
+			}
+
+			i = end
+		default:
+			for _, op := range javaOperators {
+				if strings.HasPrefix(src[i:], op) {
+					tok.kind = tokOperator
+					i += len(op)
+					break
+				}
+			}
+
+			if tok.kind == 0 {
+				return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+			}
+		}
+
+		tok.text = src[start:i]
+		line += strings.Count(tok.text, "\n")
+		tokens = append(tokens, tok)
+		newlines = 0
+		glued = true
+	}
+
+	markGenerics(tokens)
+	tokens = joinGreater(tokens)
+	markUnary(tokens)
+
+	return tokens, nil
+}
+
+// isEscaped returns true, if the character at idx is preceded by an odd amount of backslashes.
+func isEscaped(s string, idx int) bool {
+	n := 0
+	for i := idx - 1; i >= 0 && s[i] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+// scanNumber returns the end of the numeric literal, which starts at i.
+func scanNumber(src string, i int) int {
+	hex := strings.HasPrefix(src[i:], "0x") || strings.HasPrefix(src[i:], "0X")
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case (c == 'e' || c == 'E') && !hex, c == 'p' || c == 'P':
+			i++
+			if i < len(src) && (src[i] == '+' || src[i] == '-') {
+				i++
+			}
+		case c == '.' || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			i++
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// markGenerics detects the angle brackets of type argument and type parameter lists, like List<Map<K, V>>.
+func markGenerics(tokens []token) {
+	for i, tok := range tokens {
+		if !tok.is("<") || tok.generic || (i > 0 && (tokens[i-1].kind == tokNumber || tokens[i-1].kind == tokString)) {
+			continue
+		}
+
+		depth := 0
+		var brackets []int
+	scan:
+		for j := i; j < len(tokens); j++ {
+			t := tokens[j]
+			switch {
+			case t.is("<"):
+				depth++
+				brackets = append(brackets, j)
+			case t.is(">"):
+				depth--
+				brackets = append(brackets, j)
+				if depth == 0 {
+					for _, b := range brackets {
+						tokens[b].generic = true
+					}
+
+					break scan
+				}
+			case t.kind == tokIdent, t.is("extends"), t.is("super"), t.is("."), t.is(","), t.is("?"), t.is("&"),
+				t.is("["), t.is("]"), t.is("@"), t.kind == tokKeyword && isPrimitive(t.text):
+			default:
+				break scan
+			}
+		}
+	}
+}
+
+func isPrimitive(name string) bool {
+	switch name {
+	case "boolean", "byte", "char", "short", "int", "long", "float", "double":
+		return true
+	default:
+		return false
+	}
+}
+
+// joinGreater joins the adjacent > tokens, which are not part of a type argument list, into the shift and
+// comparison operators.
+func joinGreater(tokens []token) []token {
+	res := tokens[:0]
+	for _, tok := range tokens {
+		if len(res) > 0 && tok.glued && !tok.generic {
+			last := &res[len(res)-1]
+			if last.kind == tokOperator && !last.generic && (last.text == ">" || last.text == ">>" || last.text == ">>>") {
+				if tok.is(">") || (tok.is("=") && last.text != ">>>=") || tok.is(">=") {
+					last.text += tok.text
+					continue
+				}
+			}
+		}
+
+		res = append(res, tok)
+	}
+
+	return res
+}
@@ -1,38 +1,42 @@
 package java
 
 import (
-	"github.com/golangee/src"
+	"github.com/golangee/src/ast"
 	"github.com/golangee/src/stdlib"
 	"strings"
 )
 
 // fromStdlib converts stdlib types (indicated by the macro ! sign at the end) and returns a Java name for it.
+// The names are always qualified, so that the importer can detect collisions with the implicit java.lang types.
 // Note that primitive types are always returned as their boxed types, because otherwise we would need to carry
 // a lot of context information for it. The Java/JVM model is more or less broken for generics and we just wait until
 // they fix it up (perhaps with valhalla value types). If you want a reasonable memory usage, you probably
 // want a different language anyway.
-func fromStdlib(name src.Name) src.Name {
+func fromStdlib(name ast.Name) ast.Name {
 	switch name {
+	case stdlib.Bool:
+		return "java.lang.Boolean"
+
 	case stdlib.Int:
-		return "Integer"
+		return "java.lang.Integer"
 
 	case stdlib.Byte:
-		return "Byte"
+		return "java.lang.Byte"
 
 	case stdlib.Int16:
-		return "Short"
+		return "java.lang.Short"
 
 	case stdlib.Int32:
-		return "Integer"
+		return "java.lang.Integer"
 
 	case stdlib.Int64:
-		return "Long"
+		return "java.lang.Long"
 
 	case stdlib.Float32:
-		return "Float"
+		return "java.lang.Float"
 
 	case stdlib.Float64:
-		return "Double"
+		return "java.lang.Double"
 
 	case stdlib.Map:
 		return "java.util.Map"
@@ -44,10 +48,10 @@ func fromStdlib(name src.Name) src.Name {
 		return "java.util.UUID"
 
 	case stdlib.String:
-		return "String"
+		return "java.lang.String"
 
 	case stdlib.Error:
-		return "Exception"
+		return "java.lang.Exception"
 
 	case stdlib.Time:
 		return "java.time.ZonedDateTime"
@@ -0,0 +1,186 @@
+package kotlin
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+const (
+	MimeTypeKotlin     = "text/x-kotlin"
+	MimeTypeDir        = "application/x-directory"
+	MimeTypeKotlinPkg  = "application/x-directory-kotlin-package"
+	MimeTypeKotlinRoot = "application/x-directory-kotlin-module"
+)
+
+// Options for the renderer.
+type Options struct {
+	// SkipBuildFiles disables the build files, so that only the sources are emitted.
+	SkipBuildFiles bool
+}
+
+// Renderer provides a kotlin renderer.
+type Renderer struct {
+	opts      Options
+	root      ast.Node
+	importers map[ast.Node]*importer // either an *ast.File or an *ast.Pkg
+}
+
+// NewRenderer creates a new Renderer instance.
+func NewRenderer(opts Options) *Renderer {
+	return &Renderer{opts: opts}
+}
+
+func init() {
+	render.Register(ast.LangKotlin, ast.FrameworkSDK, func() render.Renderer {
+		return NewRenderer(Options{})
+	})
+}
+
+// tearUp prepares the ast to be used for source generation.
+func (r *Renderer) tearUp(node ast.Node) error {
+	r.root = ast.Root(node)
+
+	if err := installImporter(r); err != nil {
+		return fmt.Errorf("unable to install importer: %w", err)
+	}
+
+	return nil
+}
+
+// tearDown frees allocated resources.
+func (r *Renderer) tearDown() error {
+	if err := uninstallImporter(r); err != nil {
+		return fmt.Errorf("unable to uninstall importer: %w", err)
+	}
+
+	return nil
+}
+
+// importer resolves the current importer from the parents file.
+func (r *Renderer) importer(n ast.Node) *importer {
+	return importerFromTree(r, n)
+}
+
+// Render converts the given node into a render.Artifact. A partial result is returned if an error is detected.
+// If node is an *ast.Mod, only that module is rendered and it must target ast.LangKotlin. Otherwise all Kotlin
+// modules of the project are rendered and other modules are ignored. Use render.Project to render mixed projects.
+func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
+	if mod, ok := node.(*ast.Mod); ok && mod.Target.Lang != ast.LangKotlin {
+		return nil, fmt.Errorf("cannot render module '%s': expected language '%s' but got '%s'", mod.Name, ast.LangKotlin, mod.Target.Lang)
+	}
+
+	if err := r.tearUp(node); err != nil {
+		return nil, fmt.Errorf("unable to tearUp: %w", err)
+	}
+
+	defer func() {
+		if e := r.tearDown(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	root := &render.Dir{}
+	if mod, ok := node.(*ast.Mod); ok {
+		_, err = r.renderMod(mod, root)
+		return root, err
+	}
+
+	err = ast.ForEachMod(node, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangKotlin {
+			if _, err := r.renderMod(mod, root); err != nil {
+				return fmt.Errorf("cannot render module '%s': %w", mod.Name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return root, fmt.Errorf("cannot render project: %w", err)
+	}
+
+	return root, nil
+}
+
+// renderMod emits the build files and each package into a directory hierarchy according to its fully qualified
+// name, relative to the SourceDir of the modules output directory.
+func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, error) {
+	modDir := r.ensureDir(mod.Target.Out, parent)
+	modDir.MimeType = MimeTypeKotlinRoot
+
+	var firstErr error
+	if err := r.renderBuild(mod, parent, modDir); err != nil {
+		firstErr = fmt.Errorf("cannot render build files: %w", err)
+	}
+
+	srcDir := r.ensureDir(SourceDir, modDir)
+	for _, pkg := range mod.Pkgs {
+		pkgDir := r.ensureDir(strings.ReplaceAll(pkg.Path, ".", "/"), srcDir)
+		pkgDir.MimeType = MimeTypeKotlinPkg
+
+		files, err := r.renderPkg(pkg)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render package '%s': %w", pkg.Path, err)
+		}
+
+		pkgDir.Files = append(pkgDir.Files, files...)
+	}
+
+	return modDir, firstErr
+}
+
+// renderPkg emits the files of the package. Kotlin has no package level documentation file, so the package
+// comment is not emitted.
+func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
+	var res []*render.File
+	var firstErr error
+
+	for _, file := range pkg.PkgFiles {
+		buf, err := r.renderFile(file)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render file '%s': %w", file.Name, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: MimeTypeKotlin,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	for _, file := range pkg.RawFiles {
+		buf, err := file.Data(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render raw file: %w", err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: file.MimeType,
+			Buf:      buf,
+		})
+	}
+
+	return res, firstErr
+}
+
+// ensureDir appends for each path segment a directory, if required. Returns the directory denoting
+// the last segment.
+func (r *Renderer) ensureDir(restPath string, parent *render.Dir) *render.Dir {
+	names := strings.Split(restPath, "/")
+
+	dir := parent.Directory(names[0])
+	if dir == nil {
+		dir = &render.Dir{DirName: names[0], MimeType: MimeTypeDir}
+		parent.Dirs = append(parent.Dirs, dir)
+	}
+
+	if len(names) == 1 {
+		return dir
+	}
+
+	return r.ensureDir(strings.Join(names[1:], "/"), dir)
+}
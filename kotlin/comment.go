@@ -0,0 +1,147 @@
+package kotlin
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"strconv"
+	"strings"
+)
+
+// docWidth is the width at which the lines of a KDoc comment are wrapped, including the comment prefix.
+const docWidth = 100
+
+// formatComment replaces a '...' prefix with the ellipsisName and emits the structured content of the doc as
+// KDoc, which uses markdown: paragraphs are separated by an empty line, lists and code blocks use the markdown
+// syntax and doc links are kept as [links]. All block tags, like @param, are emitted at the end. Lines are
+// prefixed with a ' * ' and wrapped at docWidth. Also a new first line (/**) and a new last line ( */) is added.
+func formatComment(ellipsisName, doc string) string {
+	doc = strings.TrimLeft(strings.TrimRight(doc, " \t\n"), "\n")
+	if strings.TrimSpace(doc) == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(doc), "...") {
+		doc = strings.TrimSpace(ellipsisName + " " + strings.TrimSpace(strings.TrimSpace(doc)[3:]))
+	}
+
+	description, tags := splitTags(doc)
+
+	tmp := &strings.Builder{}
+	tmp.WriteString("/**\n")
+	first := true
+	for _, block := range ast.ParseDoc(description) {
+		if !first {
+			tmp.WriteString(" *\n")
+		}
+
+		switch block.Kind {
+		case ast.DocParagraph:
+			writeDocLines(tmp, "", "", kdocText(block.Text))
+		case ast.DocDeprecated:
+			// the actual marker is the @Deprecated annotation
+			writeDocLines(tmp, "", "", ast.DeprecatedPrefix+" "+kdocText(block.Text))
+		case ast.DocList:
+			for i, item := range block.Items {
+				marker := "- "
+				if block.Numbered {
+					marker = strconv.Itoa(i+1) + ". "
+				}
+
+				writeDocLines(tmp, marker, strings.Repeat(" ", len(marker)), kdocText(item))
+			}
+		case ast.DocCode:
+			tmp.WriteString(" * ```\n")
+			for _, line := range strings.Split(block.Text, "\n") {
+				tmp.WriteString(strings.TrimRight(" * "+escapeKDoc(line), " "))
+				tmp.WriteString("\n")
+			}
+
+			tmp.WriteString(" * ```\n")
+		}
+
+		first = false
+	}
+
+	if len(tags) > 0 {
+		if !first {
+			tmp.WriteString(" *\n")
+		}
+
+		for _, tag := range tags {
+			writeDocLines(tmp, "", "    ", kdocText(tag))
+		}
+	}
+
+	tmp.WriteString(" */")
+
+	return tmp.String()
+}
+
+// splitTags separates the description from the block tags, which start at the first line beginning with an @.
+func splitTags(doc string) (string, []string) {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range lines[i:] {
+			switch {
+			case strings.TrimSpace(tag) == "":
+			case strings.HasPrefix(tag, "@") || len(tags) == 0:
+				tags = append(tags, strings.TrimSpace(tag))
+			default:
+				tags[len(tags)-1] += "\n" + strings.TrimSpace(tag)
+			}
+		}
+
+		return strings.Join(lines[:i], "\n"), tags
+	}
+
+	return doc, nil
+}
+
+// writeDocLines wraps each line of text and emits it with the first prefix, all following lines are indented.
+func writeDocLines(w *strings.Builder, first, indent, text string) {
+	prefix := first
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range render.WrapLine(line, docWidth-len(" * ")-len(prefix)) {
+			w.WriteString(" * ")
+			w.WriteString(prefix)
+			w.WriteString(wrapped)
+			w.WriteString("\n")
+			prefix = indent
+		}
+	}
+}
+
+// kdocText keeps the doc links of the text, because KDoc resolves [name] just like godoc.
+func kdocText(text string) string {
+	sb := &strings.Builder{}
+	for _, span := range ast.DocSpans(text) {
+		if span.Link == "" {
+			sb.WriteString(escapeKDoc(span.Text))
+			continue
+		}
+
+		sb.WriteString("[" + string(span.Link) + "]")
+	}
+
+	return sb.String()
+}
+
+// escapeKDoc prevents that the text terminates the comment or opens a nested one, because block comments nest
+// in Kotlin.
+func escapeKDoc(text string) string {
+	return strings.NewReplacer("*/", "*&#47;", "/*", "&#47;*").Replace(text)
+}
+
+// deEllipsis replaces a '...' prefix of the doc with the given name.
+func deEllipsis(ellipsisName, doc string) string {
+	if strings.HasPrefix(doc, "...") {
+		return ellipsisName + " " + strings.TrimSpace(doc[3:])
+	}
+
+	return doc
+}
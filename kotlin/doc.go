@@ -0,0 +1,4 @@
+// Package kotlin provides a renderer for Kotlin/JVM source code and gradle kotlin build scripts. Structs become
+// data classes, interfaces, enums and properties are mapped to their Kotlin counterparts and pointers become
+// nullable types.
+package kotlin
@@ -0,0 +1,34 @@
+package kotlin
+
+import "github.com/golangee/src/render"
+
+// format follows the Kotlin coding conventions, see https://kotlinlang.org/docs/coding-conventions.html#formatting.
+// Block comments are allowed to nest in Kotlin and a restricted setter is indented as a continuation of its
+// property.
+var format = render.BraceFormat{
+	Indent:         "    ",
+	Quote:          render.QuotedBy(`"'`),
+	NestedComments: true,
+	Level: func(line string) int {
+		if isSetter(line) {
+			return 1
+		}
+
+		return 0
+	},
+}
+
+// Format pretty prints Kotlin source, see render.BraceFormat.Format.
+func Format(source []byte) ([]byte, error) {
+	return format.Format(source)
+}
+
+// isSetter returns true, if the line declares a restricted setter of a property, e.g. private set.
+func isSetter(line string) bool {
+	switch line {
+	case "private set", "protected set", "internal set":
+		return true
+	default:
+		return false
+	}
+}
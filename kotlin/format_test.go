@@ -0,0 +1,54 @@
+package kotlin
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	src := `
+package com.example
+
+
+class App(
+val s: String = "{"
+) {
+
+var n: Int = 0
+private set
+/* outer /* nested } */
+* still comment
+*/
+fun run() {
+if (n > 0) {
+println("}${n}")
+}
+
+}
+}
+`
+
+	want := `package com.example
+
+class App(
+    val s: String = "{"
+) {
+    var n: Int = 0
+        private set
+    /* outer /* nested } */
+     * still comment
+     */
+    fun run() {
+        if (n > 0) {
+            println("}${n}")
+        }
+    }
+}
+`
+
+	buf, err := Format([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+}
@@ -0,0 +1,133 @@
+package kotlin
+
+import (
+	"github.com/golangee/src/ast"
+	"sort"
+)
+
+// implicitPackages are imported by default into every Kotlin/JVM file.
+// See https://kotlinlang.org/docs/packages.html#default-imports.
+var implicitPackages = map[string]bool{
+	"kotlin": true, "kotlin.annotation": true, "kotlin.collections": true, "kotlin.comparisons": true,
+	"kotlin.io": true, "kotlin.ranges": true, "kotlin.sequences": true, "kotlin.text": true, "kotlin.jvm": true,
+	"java.lang": true,
+}
+
+// importer manages the rendered import section at the files top. Types of the default imports and of the own
+// package are in scope without an import directive. Simple names are unique in the scope, so a colliding name
+// stays fully qualified at the use site.
+type importer struct {
+	pkg                string              // the package of the scope, e.g. com.example
+	identifiersInScope map[string]ast.Name // simple type names and their qualified names
+	imported           map[ast.Name]bool   // the qualified names, which require an import directive
+}
+
+// newImporter allocates an importer for a scope of the given package. The declared identifiers are the simple
+// names of the packages types, which always win against any import.
+func newImporter(pkg string, declared []string) *importer {
+	imp := &importer{
+		pkg:                pkg,
+		identifiersInScope: map[string]ast.Name{},
+		imported:           map[ast.Name]bool{},
+	}
+
+	for _, id := range declared {
+		imp.identifiersInScope[id] = ast.Name(pkg + "." + id)
+	}
+
+	return imp
+}
+
+// installImporter allocates a new importer instance for every ast.Pkg and every ast.File. The importers are owned
+// by the renderer and never attached to the ast.
+func installImporter(r *Renderer) error {
+	r.importers = map[ast.Node]*importer{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		for _, pkg := range mod.Pkgs {
+			var declared []string
+			for _, file := range pkg.PkgFiles {
+				for _, namedType := range file.Types() {
+					declared = append(declared, namedType.Identifier())
+				}
+			}
+
+			r.importers[pkg] = newImporter(pkg.Path, declared)
+			for _, file := range pkg.PkgFiles {
+				r.importers[file] = newImporter(pkg.Path, declared)
+			}
+		}
+
+		return nil
+	})
+}
+
+// uninstallImporter releases all importers.
+func uninstallImporter(r *Renderer) error {
+	r.importers = nil
+	return nil
+}
+
+// importerFromTree walks up the tree until it finds the first file or package with an importer.
+func importerFromTree(r *Renderer, n ast.Node) *importer {
+	root := n
+	for root != nil {
+		if imp, ok := r.importers[root]; ok {
+			return imp
+		}
+
+		newRoot := root.Parent()
+		if newRoot == nil {
+			panic("no attached importer found in ast scope")
+		}
+
+		root = newRoot
+	}
+
+	panic("invalid node")
+}
+
+// qualifiers returns the sorted names, which require an import directive.
+func (p *importer) qualifiers() []string {
+	var sorted []string
+	for name := range p.imported {
+		sorted = append(sorted, string(name))
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// implicit returns true, if the qualifier is always in scope and needs no import, e.g. kotlin.collections or the
+// own package.
+func (p *importer) implicit(qualifier string) bool {
+	return implicitPackages[qualifier] || qualifier == p.pkg
+}
+
+// shortify returns a qualified name, which is only valid in the importers scope. It may also decide to not import
+// the given name, e.g. if a collision has been detected. If the name is a universe type or not complete, the original
+// name is just returned.
+func (p *importer) shortify(name ast.Name) ast.Name {
+	qual := name.Qualifier()
+	id := name.Identifier()
+	if id == "" || qual == "" {
+		return name
+	}
+
+	otherName, inScope := p.identifiersInScope[id]
+	if inScope {
+		if otherName == name {
+			return ast.Name(id)
+		}
+
+		// name collision, e.g. java.util.Date and java.sql.Date
+		return name
+	}
+
+	p.identifiersInScope[id] = name
+	if !p.implicit(qual) {
+		p.imported[name] = true
+	}
+
+	return ast.Name(id)
+}
@@ -0,0 +1,222 @@
+package kotlin
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"path"
+	"strings"
+)
+
+const (
+	// SourceDir is the standard directory of the Kotlin sources, relative to the module.
+	SourceDir = "src/main/kotlin"
+
+	// PluginVersion is the version of the kotlin gradle plugin, which is applied by the build script.
+	PluginVersion = "2.0.21"
+
+	// defaultVersion is the version of a module, which has no version in its name.
+	defaultVersion = "0.0.0-SNAPSHOT"
+
+	MimeTypeGradle = "text/x-gradle"
+)
+
+// coordinates identify a maven artifact.
+type coordinates struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// parseCoordinates parses groupId:artifactId[:version].
+func parseCoordinates(s string) (coordinates, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return coordinates{}, fmt.Errorf("invalid maven coordinates '%s': expected groupId:artifactId:version", s)
+	}
+
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" || strings.ContainsAny(part, " \t\n'\"") {
+			return coordinates{}, fmt.Errorf("invalid maven coordinates '%s'", s)
+		}
+	}
+
+	c := coordinates{GroupID: parts[0], ArtifactID: parts[1]}
+	if len(parts) == 3 {
+		c.Version = parts[2]
+	}
+
+	return c, nil
+}
+
+// modCoordinates derives the coordinates of the module from its name, which is either groupId:artifactId[:version],
+// a package like name (com.example.app) or a module path (example.com/app, the host is reversed), just like
+// for Java modules.
+func modCoordinates(mod *ast.Mod) (coordinates, error) {
+	var c coordinates
+	switch {
+	case strings.Contains(mod.Name, ":"):
+		var err error
+		if c, err = parseCoordinates(mod.Name); err != nil {
+			return c, err
+		}
+	case strings.Contains(mod.Name, "/"):
+		segments := strings.Split(mod.Name, "/")
+		host := strings.Split(segments[0], ".")
+		for i, j := 0, len(host)-1; i < j; i, j = i+1, j-1 {
+			host[i], host[j] = host[j], host[i]
+		}
+
+		group := append(host, segments[1:len(segments)-1]...)
+		c = coordinates{GroupID: strings.Join(group, "."), ArtifactID: segments[len(segments)-1]}
+	default:
+		idx := strings.LastIndex(mod.Name, ".")
+		if idx < 0 {
+			return c, fmt.Errorf("cannot derive maven coordinates from module name '%s'", mod.Name)
+		}
+
+		c = coordinates{GroupID: mod.Name[:idx], ArtifactID: mod.Name[idx+1:]}
+	}
+
+	if c.Version == "" {
+		c.Version = defaultVersion
+	}
+
+	return c, nil
+}
+
+// dependencies parses the maven coordinates of all required dependencies, which must declare a version.
+func dependencies(mod *ast.Mod) ([]coordinates, error) {
+	var res []coordinates
+	for _, dep := range mod.Target.Require.Maven {
+		c, err := parseCoordinates(dep)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.Version == "" {
+			return nil, fmt.Errorf("invalid maven coordinates '%s': missing version", dep)
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+// kotlinMods returns all Kotlin modules of the rendered project.
+func (r *Renderer) kotlinMods() []*ast.Mod {
+	var res []*ast.Mod
+	_ = ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangKotlin {
+			res = append(res, mod)
+		}
+
+		return nil
+	})
+
+	return res
+}
+
+// renderBuild emits the gradle kotlin build script of the module into modDir. If the project contains multiple
+// Kotlin modules, the settings are emitted into the root directory, otherwise the module is its own root project.
+func (r *Renderer) renderBuild(mod *ast.Mod, root, modDir *render.Dir) error {
+	if r.opts.SkipBuildFiles {
+		return nil
+	}
+
+	c, err := modCoordinates(mod)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies(mod)
+	if err != nil {
+		return err
+	}
+
+	mods := r.kotlinMods()
+	settingsDir, settingsName := modDir, c.ArtifactID
+	var includes []string
+	if len(mods) > 1 {
+		// the settings must be equal for all modules
+		first, err := modCoordinates(mods[0])
+		if err != nil {
+			return err
+		}
+
+		settingsDir = root
+		settingsName = first.GroupID[strings.LastIndex(first.GroupID, ".")+1:]
+		if prj, ok := r.root.(*ast.Prj); ok && prj.Name != "" {
+			settingsName = naming.Kotlin.Kebab(prj.Name)
+		}
+
+		for _, m := range mods {
+			if m.Target.Out == "" {
+				return fmt.Errorf("module '%s' requires an output directory, because the project has multiple kotlin modules", m.Name)
+			}
+
+			includes = append(includes, path.Clean(m.Target.Out))
+		}
+	}
+
+	modDir.Files = append(modDir.Files, gradleFile("build.gradle.kts", createBuildGradle(c, mod, deps)))
+	if settingsDir.File("settings.gradle.kts") == nil {
+		settingsDir.Files = append(settingsDir.Files, gradleFile("settings.gradle.kts", createSettingsGradle(settingsName, includes)))
+	}
+
+	return nil
+}
+
+func gradleFile(name, text string) *render.File {
+	return &render.File{FileName: name, MimeType: MimeTypeGradle, Buf: []byte(text)}
+}
+
+// createBuildGradle emits a kotlin jvm build script. The Target.MinLangVersion, e.g. 1.9, restricts the language
+// and api version of the compiler.
+func createBuildGradle(c coordinates, mod *ast.Mod, deps []coordinates) string {
+	var tmp strings.Builder
+	tmp.WriteString("plugins {\n")
+	tmp.WriteString("    kotlin(\"jvm\") version \"" + PluginVersion + "\"\n")
+	tmp.WriteString("}\n\n")
+	tmp.WriteString("group = \"" + c.GroupID + "\"\n")
+	tmp.WriteString("version = \"" + c.Version + "\"\n\n")
+	tmp.WriteString("repositories {\n    mavenCentral()\n}\n")
+
+	if len(deps) > 0 {
+		tmp.WriteString("\ndependencies {\n")
+		for _, dep := range deps {
+			tmp.WriteString("    implementation(\"" + dep.GroupID + ":" + dep.ArtifactID + ":" + dep.Version + "\")\n")
+		}
+
+		tmp.WriteString("}\n")
+	}
+
+	if version := string(mod.Target.MinLangVersion); version != "" {
+		kotlinVersion := "org.jetbrains.kotlin.gradle.dsl.KotlinVersion.fromVersion(\"" + version + "\")"
+		tmp.WriteString("\nkotlin {\n")
+		tmp.WriteString("    compilerOptions {\n")
+		tmp.WriteString("        languageVersion.set(" + kotlinVersion + ")\n")
+		tmp.WriteString("        apiVersion.set(" + kotlinVersion + ")\n")
+		tmp.WriteString("    }\n")
+		tmp.WriteString("}\n")
+	}
+
+	return tmp.String()
+}
+
+// createSettingsGradle emits the settings of the root project, which includes the given module directories.
+func createSettingsGradle(name string, includes []string) string {
+	var tmp strings.Builder
+	tmp.WriteString("rootProject.name = \"" + name + "\"\n")
+	if len(includes) > 0 {
+		tmp.WriteString("\n")
+	}
+
+	for _, include := range includes {
+		tmp.WriteString("include(\"" + strings.ReplaceAll(include, "/", ":") + "\")\n")
+	}
+
+	return tmp.String()
+}
@@ -0,0 +1,65 @@
+package kotlin
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"io/fs"
+	"testing"
+)
+
+func newBuildMod(name, out string) *ast.Mod {
+	return ast.NewMod(name).
+		SetLang(ast.LangKotlin).
+		SetOutputDirectory(out).
+		AddPackages(
+			ast.NewPkg("com.example." + out).AddFiles(
+				ast.NewFile("App.kt").AddTypes(ast.NewStruct("App")),
+			),
+		)
+}
+
+func TestRenderer_RenderBuildSingleModule(t *testing.T) {
+	prj := ast.NewPrj("Shop").AddModules(
+		newBuildMod("example.com/shop/api", "api").SetLangVersion("1.9").Require("com.google.guava:guava:30.1-jre"),
+	)
+
+	a, err := NewRenderer(Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	srctest.ReadFile(t, dir, "api/src/main/kotlin/com/example/api/App.kt")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "api/settings.gradle.kts"), "rootProject.name = \"api\"\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "api/build.gradle.kts"),
+		"    kotlin(\"jvm\") version \""+PluginVersion+"\"\n",
+		"group = \"com.example.shop\"\n",
+		"    implementation(\"com.google.guava:guava:30.1-jre\")\n",
+		"        languageVersion.set(org.jetbrains.kotlin.gradle.dsl.KotlinVersion.fromVersion(\"1.9\"))\n",
+	)
+}
+
+func TestRenderer_RenderBuildMultiModule(t *testing.T) {
+	prj := ast.NewPrj("Shop").AddModules(
+		newBuildMod("com.example:api:1.0.0", "api"),
+		newBuildMod("com.example:service", "service"),
+	)
+
+	a, err := NewRenderer(Options{}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "settings.gradle.kts"),
+		"rootProject.name = \"shop\"\n",
+		"include(\"api\")\ninclude(\"service\")\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "api/build.gradle.kts"), "version = \"1.0.0\"\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "service/build.gradle.kts"), "version = \""+defaultVersion+"\"\n")
+
+	if _, err := fs.ReadFile(dir, "api/settings.gradle.kts"); err == nil {
+		t.Fatal("expected no module settings")
+	}
+}
@@ -0,0 +1,751 @@
+package kotlin
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func writeComment(w *render.BufferedWriter, name, doc string) {
+	myDoc := formatComment(name, doc)
+	if myDoc != "" {
+		w.Print(myDoc)
+		w.Printf("\n")
+	}
+}
+
+func writeCommentNode(w *render.BufferedWriter, name string, comment *ast.Comment) {
+	if comment == nil {
+		return
+	}
+
+	writeComment(w, name, comment.Text)
+}
+
+// writeDeclComment emits the comment of a declaration together with its deprecation notice.
+func writeDeclComment(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation) {
+	text := ""
+	if comment != nil {
+		text = comment.Text
+	}
+
+	writeComment(w, name, ast.DocWithDeprecation(text, deprecation))
+}
+
+// writeDeprecated emits the @Deprecated annotation in its own line, if required.
+func writeDeprecated(w *render.BufferedWriter, deprecation *ast.Deprecation) {
+	if deprecation != nil {
+		w.Print(deprecatedAnnotation(deprecation))
+		w.Printf("\n")
+	}
+}
+
+// deprecatedAnnotation returns the @Deprecated annotation, which requires a message in Kotlin. A replacement
+// becomes a ReplaceWith expression, so that the IDE can apply it.
+func deprecatedAnnotation(deprecation *ast.Deprecation) string {
+	reason := strings.TrimSpace(deprecation.Reason)
+	if reason == "" {
+		reason = "deprecated"
+	}
+
+	if deprecation.Replacement == "" {
+		return "@Deprecated(" + kotlinQuote(reason) + ")"
+	}
+
+	return "@Deprecated(" + kotlinQuote(reason) + ", ReplaceWith(" + kotlinQuote(string(deprecation.Replacement)) + "))"
+}
+
+// renderFile tries to emit the file as kotlin.
+func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
+	w := &render.BufferedWriter{}
+
+	if file.Preamble != nil {
+		writeComment(w, file.Pkg().Name, file.Preamble.Text)
+		w.Printf("\n")
+	}
+
+	writeCommentNode(w, file.Pkg().Name, file.Comment())
+
+	w.Printf("package %s\n\n", file.Pkg().Path)
+
+	// the explicit imports are registered first, so that they win against the generated ones
+	importer := r.importer(file)
+	var wildcards []string
+	for _, imp := range file.Imports() {
+		if imp.Name.Identifier() == "*" {
+			wildcards = append(wildcards, "import "+string(imp.Name)+"\n")
+			continue
+		}
+
+		// Kotlin imports members just like types, so static imports need no special treatment
+		importer.shortify(imp.Name)
+	}
+
+	// render everything into tmp first, the importer beautifies all required imports on-the-go
+	tmp := &render.BufferedWriter{}
+	for _, node := range file.Nodes {
+		if _, ok := node.(*ast.Import); ok {
+			continue
+		}
+
+		if err := r.renderNode(node, tmp); err != nil {
+			return nil, err
+		}
+
+		tmp.Printf("\n")
+	}
+
+	for _, qualifier := range importer.qualifiers() {
+		w.Printf("import %s\n", qualifier)
+	}
+
+	for _, wildcard := range wildcards {
+		w.Print(wildcard)
+	}
+
+	w.Printf("\n")
+	w.Print(tmp.String())
+
+	return Format(w.Bytes())
+}
+
+// renderNode inspects and emits the actual type.
+func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Struct:
+		if err := r.renderStruct(n, w); err != nil {
+			return fmt.Errorf("cannot render struct '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Interface:
+		if err := r.renderInterface(n, w); err != nil {
+			return fmt.Errorf("cannot render interface '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Enum:
+		if err := r.renderEnum(n, w); err != nil {
+			return fmt.Errorf("cannot render enum '%s': %w", n.Identifier(), err)
+		}
+	case *ast.ErrorType:
+		if err := r.renderErrorType(n, w); err != nil {
+			return fmt.Errorf("cannot render error type '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Func:
+		if err := r.renderFunc(n, w); err != nil {
+			return fmt.Errorf("cannot render func '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Property:
+		if err := r.renderProperty(n, w); err != nil {
+			return fmt.Errorf("cannot render property '%s': %w", n.Identifier(), err)
+		}
+	default:
+		ok, err := r.renderExpr(n, w)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("type not yet implemented: %s", reflect.TypeOf(n).String())
+		}
+	}
+
+	return nil
+}
+
+func (r *Renderer) renderTypePreamble(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation, annotations []*ast.Annotation) error {
+	writeDeclComment(w, name, comment, deprecation)
+	writeDeprecated(w, deprecation)
+
+	for _, annotation := range annotations {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
+	}
+
+	return nil
+}
+
+// renderInterface emits an interface, whose embedded types become super interfaces. If the interface permits
+// a set of implementations, it is sealed, which requires Kotlin 1.5. Methods with a body become default methods.
+func (r *Renderer) renderInterface(node *ast.Interface, w *render.BufferedWriter) error {
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), node.Annotations()); err != nil {
+		return err
+	}
+
+	w.Printf(visibilityAsModifier(node.Visibility(), isTopLevel(node)))
+	if len(node.Permits) > 0 {
+		w.Printf("sealed ")
+	}
+
+	w.Printf("interface %s", node.Identifier())
+
+	for i, decl := range node.Embedded {
+		if i == 0 {
+			w.Printf(" : ")
+		} else {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+	}
+
+	return r.renderMembers(node.NamedTypes(), node.Properties(), node.Methods(), w)
+}
+
+// renderStruct emits a data class, whose fields are the properties of the primary constructor. Fields are
+// mutable, unless the struct is declared as a record. Nullable fields and fields of the standard library types
+// default to their zero value. A struct without fields becomes an ordinary class, because a data class
+// requires at least one property. Classes are final by default in Kotlin.
+func (r *Renderer) renderStruct(node *ast.Struct, w *render.BufferedWriter) error {
+	if err := r.renderTypePreamble(w, node.Identifier(), structComment(node), node.Deprecated(), node.Annotations()); err != nil {
+		return err
+	}
+
+	w.Printf(visibilityAsModifier(node.Visibility(), isTopLevel(node)))
+
+	data := len(node.Fields()) > 0
+	if data {
+		w.Printf("data ")
+	}
+
+	w.Printf("class %s", node.Identifier())
+
+	if data {
+		w.Printf("(\n")
+		for i, field := range node.Fields() {
+			if err := r.renderConstructorProperty(field, node.Record(), w); err != nil {
+				return fmt.Errorf("failed to render field %s: %w", field.Identifier(), err)
+			}
+
+			if i < len(node.Fields())-1 {
+				w.Printf(",\n")
+			}
+		}
+
+		w.Printf("\n)")
+
+		for _, fun := range node.Methods() {
+			if fun.Identifier() == node.Identifier() {
+				return fmt.Errorf("a data class cannot declare constructor '%s', because it must delegate to the primary constructor", fun.Identifier())
+			}
+		}
+	}
+
+	var supertypes []string
+	if node.Extends != "" {
+		supertypes = append(supertypes, string(r.importer(node).shortify(node.Extends))+"()")
+	}
+
+	for _, name := range node.Implements {
+		supertypes = append(supertypes, string(r.importer(node).shortify(name)))
+	}
+
+	if len(supertypes) > 0 {
+		w.Printf(" : %s", strings.Join(supertypes, ", "))
+	}
+
+	return r.renderMembers(node.NamedTypes(), node.Properties(), node.Methods(), w)
+}
+
+// renderMembers emits the body of a class or an interface. Static methods are declared in the companion object.
+// The body is omitted, if there are no members at all.
+func (r *Renderer) renderMembers(types []ast.NamedType, properties []*ast.Property, methods []*ast.Func, w *render.BufferedWriter) error {
+	if len(types) == 0 && len(properties) == 0 && len(methods) == 0 {
+		w.Printf("\n")
+		return nil
+	}
+
+	w.Printf(" {\n")
+
+	for _, typeNode := range types {
+		if err := r.renderNode(typeNode, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	for _, property := range properties {
+		if err := r.renderProperty(property, w); err != nil {
+			return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	var statics []*ast.Func
+	for _, fun := range methods {
+		if fun.Static() {
+			statics = append(statics, fun)
+			continue
+		}
+
+		if err := r.renderFunc(fun, w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	if len(statics) > 0 {
+		w.Printf("companion object {\n")
+		for _, fun := range statics {
+			if err := r.renderFunc(fun, w); err != nil {
+				return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+			}
+
+			w.Printf("\n")
+		}
+
+		w.Printf("}\n")
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// structComment returns the type comment, which also documents the constructor properties. The deprecation
+// notice precedes the block tags, because KDoc would otherwise append it to the last tag.
+func structComment(node *ast.Struct) *ast.Comment {
+	comment := &strings.Builder{}
+	text := ""
+	if node.Comment() != nil {
+		text = node.Comment().Text
+	}
+	comment.WriteString(ast.DocWithDeprecation(text, node.Deprecated()))
+	comment.WriteString("\n\n")
+
+	for _, field := range node.Fields() {
+		if field.Comment() == nil {
+			continue
+		}
+
+		comment.WriteString("@property ")
+		name := naming.Kotlin.Escape(field.Identifier())
+		comment.WriteString(deEllipsis(name, field.Comment().Text))
+		comment.WriteString("\n")
+	}
+
+	return ast.NewComment(comment.String())
+}
+
+// renderConstructorProperty emits a field as a property of the primary constructor, e.g. var name: String = "".
+func (r *Renderer) renderConstructorProperty(node *ast.Field, readOnly bool, w *render.BufferedWriter) error {
+	if node.Deprecated() != nil {
+		w.Print(deprecatedAnnotation(node.Deprecated()) + " ")
+	}
+
+	for _, annotation := range node.Annotations() {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+
+		w.Printf(" ")
+	}
+
+	w.Printf(visibilityAsModifier(node.Visibility(), false))
+	if readOnly {
+		w.Printf("val ")
+	} else {
+		w.Printf("var ")
+	}
+
+	w.Printf("%s: ", naming.Kotlin.Escape(node.Identifier()))
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+		return err
+	}
+
+	if node.FieldDefault != nil {
+		w.Printf(" = ")
+		return r.renderBasicLit(node.FieldDefault, w)
+	}
+
+	if zero, ok := zeroValue(node.TypeDecl()); ok {
+		w.Printf(" = %s", zero)
+	}
+
+	return nil
+}
+
+// renderProperty emits a val, if the property has no writer, otherwise a var. A disabled reader makes the
+// property private and a writer with a more restrictive visibility restricts the setter. Properties of an
+// interface are abstract. All others are initialized with their zero value, or if there is none, they are
+// declared as lateinit, which requires a var.
+func (r *Renderer) renderProperty(node *ast.Property, w *render.BufferedWriter) error {
+	writeCommentNode(w, node.Identifier(), node.Comment())
+
+	name := naming.Kotlin.Escape(node.Identifier())
+	if _, ok := node.Parent().(*ast.Interface); ok {
+		if node.Write.Enabled {
+			w.Printf("var ")
+		} else {
+			w.Printf("val ")
+		}
+
+		w.Printf("%s: ", name)
+		if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+
+		return nil
+	}
+
+	visibility := ast.Private
+	if node.Read.Enabled {
+		visibility = node.Read.Visibility
+	}
+
+	setter := visibility
+	if node.Write.Enabled {
+		setter = node.Write.Visibility
+	}
+
+	zero, hasZero := zeroValue(node.TypeDecl())
+	mutable := node.Write.Enabled || !hasZero
+	if !node.Write.Enabled {
+		setter = ast.Private
+	}
+
+	w.Printf(visibilityAsModifier(visibility, isTopLevel(node)))
+	switch {
+	case !hasZero:
+		w.Printf("lateinit var ")
+	case mutable:
+		w.Printf("var ")
+	default:
+		w.Printf("val ")
+	}
+
+	w.Printf("%s: ", name)
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+		return err
+	}
+
+	if hasZero {
+		w.Printf(" = %s", zero)
+	}
+
+	w.Printf("\n")
+
+	// the visibility constants are ordered from public to private
+	if mutable && setter > visibility {
+		w.Printf("%sset\n", visibilityAsModifier(setter, false))
+	}
+
+	return nil
+}
+
+// renderFuncComment returns the comment of the func together with the @param and @throws tags. The deprecation
+// notice precedes the tags, because KDoc would otherwise append it to the last tag.
+func (r *Renderer) renderFuncComment(node *ast.Func) string {
+	comment := &strings.Builder{}
+	text := ""
+	if node.ObjComment != nil {
+		text = node.ObjComment.Text
+	}
+	comment.WriteString(ast.DocWithDeprecation(text, node.Deprecated()))
+	comment.WriteString("\n\n")
+
+	for _, parameterNode := range node.Params() {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		comment.WriteString("@param ")
+		comment.WriteString(deEllipsis(naming.Kotlin.Escape(parameterNode.Identifier()), parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	for i, parameterNode := range node.Results() {
+		if i == 0 || parameterNode.ObjComment == nil {
+			continue
+		}
+
+		comment.WriteString("@throws ")
+		name := parameterNode.Identifier()
+		if name == "" {
+			name = fromStdlib(ast.Name(parameterNode.TypeDecl().String())).Identifier()
+		}
+
+		comment.WriteString(deEllipsis(name, parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	return comment.String()
+}
+
+// renderFunc emits a function. Depending on the parent, which is either an ast.Struct, an ast.Interface or an
+// ast.File, the function is rendered as a member, an interface method or a top level function. A function
+// named like its struct becomes a secondary constructor. Just like in Java, all results except the first
+// one are exceptions. Kotlin has no checked exceptions, so they are declared by @Throws for Java callers.
+func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
+	writeComment(w, node.Identifier(), r.renderFuncComment(node))
+	writeDeprecated(w, node.Deprecated())
+
+	for _, annotation := range node.Annotations() {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
+	}
+
+	if len(node.Results()) > 1 {
+		w.Printf("@Throws(")
+		for i, parameterNode := range node.Results()[1:] {
+			if i > 0 {
+				w.Printf(", ")
+			}
+
+			if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
+				return err
+			}
+
+			w.Printf("::class")
+		}
+
+		w.Printf(")\n")
+	}
+
+	isConstructor := false
+	switch t := node.Parent().(type) {
+	case *ast.Interface:
+		// we ignore the visibility entirely, because interface members are always public
+	case *ast.File:
+		w.Printf(visibilityAsModifier(node.Visibility(), true))
+	case *ast.Struct:
+		isConstructor = node.Identifier() == t.Identifier()
+		w.Printf(visibilityAsModifier(node.Visibility(), false))
+	}
+
+	if isConstructor {
+		w.Printf("constructor(")
+	} else {
+		w.Printf("fun %s(", naming.Kotlin.Escape(node.Identifier()))
+	}
+
+	for i, parameterNode := range node.Params() {
+		for _, annotationNode := range parameterNode.Annotations() {
+			if err := r.renderAnnotation(annotationNode, w); err != nil {
+				return err
+			}
+
+			w.Printf(" ")
+		}
+
+		if i == len(node.Params())-1 && node.Variadic() {
+			w.Printf("vararg ")
+		}
+
+		w.Printf("%s: ", naming.Kotlin.Escape(parameterNode.Identifier()))
+		if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if i < len(node.Params())-1 {
+			w.Printf(", ")
+		}
+	}
+	w.Printf(")")
+
+	if len(node.Results()) > 0 && !isVoid(node.Results()[0].TypeDecl()) {
+		w.Printf(": ")
+		if err := r.renderTypeDecl(node.Results()[0].TypeDecl(), w); err != nil {
+			return err
+		}
+	}
+
+	if node.Body() == nil {
+		w.Printf("\n")
+		return nil
+	}
+
+	w.Printf(" ")
+	if err := r.renderBlock(node.Body(), w); err != nil {
+		return fmt.Errorf("unable to render method body: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Renderer) renderAnnotation(node *ast.Annotation, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+
+	w.Printf("@")
+	w.Printf(string(importer.shortify(node.Identifier())))
+	attrs := node.Attributes()
+	if len(attrs) > 0 {
+		w.Printf("(")
+		// the default case
+		if len(attrs) == 1 && attrs[0] == "" {
+			w.Print(node.GetLiteral(""))
+		} else {
+			// the named attribute cases
+			for i, attr := range attrs {
+				w.Print(attr)
+				w.Printf(" = ")
+				w.Print(node.GetLiteral(attr))
+				if i < len(attrs)-1 {
+					w.Printf(", ")
+				}
+			}
+		}
+
+		w.Printf(")")
+	}
+
+	return nil
+}
+
+// renderTypeDecl emits a type. Pointers become nullable types, slices become read-only lists and function
+// types become Kotlin function types.
+func (r *Renderer) renderTypeDecl(node ast.TypeDecl, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+
+	switch t := node.(type) {
+	case *ast.SimpleTypeDecl:
+		w.Printf(string(importer.shortify(fromStdlib(t.Name()))))
+	case *ast.TypeDeclPtr:
+		if _, ok := t.TypeDecl().(*ast.TypeDeclPtr); ok {
+			// a nullable type cannot be nullable again
+			return r.renderTypeDecl(t.TypeDecl(), w)
+		}
+
+		_, isFunc := t.TypeDecl().(*ast.FuncTypeDecl)
+		if isFunc {
+			w.Printf("(")
+		}
+
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if isFunc {
+			w.Printf(")")
+		}
+
+		w.Printf("?")
+	case *ast.SliceTypeDecl:
+		w.Printf(string(importer.shortify("kotlin.collections.List")) + "<")
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+		w.Printf(">")
+	case *ast.GenericTypeDecl:
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+		w.Printf("<")
+		for i, decl := range t.Params() {
+			if err := r.renderTypeDecl(decl, w); err != nil {
+				return err
+			}
+			if i < len(t.Params())-1 {
+				w.Printf(", ")
+			}
+		}
+		w.Printf(">")
+	case *ast.ChanTypeDecl:
+		blockingQueue := importer.shortify("java.util.concurrent.BlockingQueue")
+		w.Printf(string(blockingQueue) + "<")
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+		w.Printf(">")
+	case *ast.ArrayTypeDecl:
+		// the length is not part of the type
+		w.Printf("Array<")
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+		w.Printf(">")
+	case *ast.FuncTypeDecl:
+		return r.renderFuncTypeDecl(t, w)
+	default:
+		return fmt.Errorf("type declaration not yet implemented: %s", reflect.TypeOf(t).String())
+	}
+
+	return nil
+}
+
+// renderFuncTypeDecl emits a function type, e.g. (String, Int) -> Boolean. Void results are omitted and a
+// trailing error result is dropped, because Kotlin has no checked exceptions.
+func (r *Renderer) renderFuncTypeDecl(node *ast.FuncTypeDecl, w *render.BufferedWriter) error {
+	var results []ast.TypeDecl
+	for _, param := range node.OutputParams() {
+		if !isVoid(param.TypeDecl()) {
+			results = append(results, param.TypeDecl())
+		}
+	}
+
+	if len(results) > 0 {
+		if simple, ok := results[len(results)-1].(*ast.SimpleTypeDecl); ok && simple.Name() == stdlib.Error {
+			results = results[:len(results)-1]
+		}
+	}
+
+	if len(results) > 1 {
+		return fmt.Errorf("kotlin cannot express multiple results: %s", node.String())
+	}
+
+	w.Printf("(")
+	for i, param := range node.InputParams() {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(param.TypeDecl(), w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(") -> ")
+	if len(results) == 0 {
+		w.Printf("Unit")
+		return nil
+	}
+
+	return r.renderTypeDecl(results[0], w)
+}
+
+// isVoid returns true, if the type declares the stdlib void.
+func isVoid(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.Void
+}
+
+// isTopLevel returns true, if the node is declared directly in a file.
+func isTopLevel(node ast.Node) bool {
+	_, ok := node.Parent().(*ast.File)
+	return ok
+}
+
+// visibilityAsModifier returns the modifier including a trailing space. Public is the default and package
+// private becomes internal, which is visible in the entire module. Kotlin has no protected top level
+// declarations, so they become internal as well.
+func visibilityAsModifier(v ast.Visibility, topLevel bool) string {
+	switch v {
+	case ast.Public:
+		return ""
+	case ast.PackagePrivate:
+		return "internal "
+	case ast.Private:
+		return "private "
+	case ast.Protected:
+		if topLevel {
+			return "internal "
+		}
+
+		return "protected "
+	default:
+		panic("visibility not implemented: " + strconv.Itoa(int(v)))
+	}
+}
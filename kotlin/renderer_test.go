@@ -0,0 +1,164 @@
+package kotlin_test
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/kotlin"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"testing"
+)
+
+// newPrj creates a kotlin project, which contains a data class, an interface with properties, an enum and an
+// error group.
+func newPrj() *ast.Prj {
+	notFound := lang.NewError("NotFound").AddCase(
+		lang.NewErrorCase("Ticket").SetComment("...is returned, if no such ticket exists.").
+			AddProperty("id", ast.NewSimpleTypeDecl(stdlib.UUID), "...is the unknown ticket."),
+	)
+
+	memRepository := ast.NewStruct("MemRepository").
+		SetVisibility(ast.PackagePrivate).
+		AddProperties(
+			ast.NewProperty("size", ast.NewSimpleTypeDecl(stdlib.Int)).Reader(true, ast.Public).Writer(true, ast.Private),
+			ast.NewProperty("name", ast.NewSimpleTypeDecl(stdlib.String)).Reader(true, ast.Public).Writer(true, ast.Public),
+			ast.NewProperty("clock", ast.NewSimpleTypeDecl("java.time.Clock")).Reader(true, ast.Protected),
+		)
+	memRepository.Implements = append(memRepository.Implements, "Repository")
+
+	ifStmt := ast.NewIfStmt(ast.NewBinaryExpr(ast.NewIdent("n"), ast.OpLess, ast.NewIntLit(1)), ast.NewBlock(
+		ast.NewAssign(ast.Exprs(ast.NewIdent("n")), ast.AssignAdd, ast.Exprs(ast.NewIntLit(1))),
+	))
+	ifStmt.Else = ast.NewBlock(ast.NewTpl("println(n)"))
+	ifStmt.Else.(*ast.Block).SetParent(ifStmt)
+
+	return ast.NewPrj("Tickets").AddModules(
+		ast.NewMod("example.com/tickets").
+			SetLang(ast.LangKotlin).
+			SetOutputDirectory("tickets").
+			AddPackages(
+				ast.NewPkg("com.example.tickets").AddFiles(
+					ast.NewFile("Ticket.kt").AddTypes(
+						ast.NewStruct("Ticket").
+							SetComment("...is an issue.").
+							AddFields(
+								ast.NewField("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the unique id."),
+								ast.NewField("title", ast.NewSimpleTypeDecl(stdlib.String)),
+								ast.NewField("assignee", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl(stdlib.String))),
+								ast.NewField("tags", ast.NewSliceTypeDecl(ast.NewSimpleTypeDecl(stdlib.String))),
+							).
+							AddMethods(
+								ast.NewFunc("isAssigned").
+									SetComment("...returns true, if someone works on it.").
+									AddResults(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool))).
+									SetBody(ast.NewBlock(ast.NewReturnStmt(ast.NewBinaryExpr(ast.NewIdent("assignee"), ast.OpNotEqual, ast.NewIdent("nil"))))),
+								ast.NewFunc("parse").
+									SetStatic(true).
+									AddParams(ast.NewParam("text", ast.NewSimpleTypeDecl(stdlib.String))).
+									AddResults(ast.NewParam("", ast.NewSimpleTypeDecl("Ticket")), ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Error))).
+									SetBody(ast.NewBlock(ast.NewTpl(`throw {{.Use "kotlin.UnsupportedOperationException"}}(text)`))),
+							),
+					),
+					ast.NewFile("Point.kt").AddTypes(
+						ast.NewStruct("Point").
+							SetComment("...is a location.").
+							SetDeprecated("points are not precise", "Ticket").
+							SetRecord(true).
+							AddFields(
+								ast.NewField("x", ast.NewSimpleTypeDecl(stdlib.Int64)).SetComment("...is the horizontal position."),
+								ast.NewField("y", ast.NewSimpleTypeDecl(stdlib.Int64)).SetVisibility(ast.Private),
+							),
+					),
+					ast.NewFile("Repository.kt").AddTypes(
+						ast.NewInterface("Repository").
+							SetComment("...stores tickets.").
+							AddProperties(
+								ast.NewProperty("size", ast.NewSimpleTypeDecl(stdlib.Int)).Reader(true, ast.Public),
+								ast.NewProperty("name", ast.NewSimpleTypeDecl(stdlib.String)).Reader(true, ast.Public).Writer(true, ast.Public),
+							).
+							AddMethods(
+								ast.NewFunc("find").
+									AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the ticket id.")).
+									AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket")))),
+								ast.NewFunc("findSlow").
+									SetComment("...scans all tickets.").
+									SetDeprecated("too slow", "Repository.find").
+									AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the ticket id.")).
+									AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket")))),
+								ast.NewFunc("forEach").
+									AddParams(ast.NewParam("f", ast.NewFuncTypeDecl().
+										AddInputParams(ast.NewParam("", ast.NewSimpleTypeDecl("Ticket"))).
+										AddOutputParams(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool))))),
+							),
+						memRepository,
+					),
+					ast.NewFile("Status.kt").AddTypes(
+						ast.NewEnum("Status", stdlib.String).AddCases(
+							ast.NewEnumCase("inProgress").SetComment("...is the state of active work."),
+							ast.NewEnumCase("done").SetValue(ast.NewStrLit("$done")),
+						),
+					),
+					ast.NewFile("NotFoundError.kt").AddNodes(notFound.TypeDecl()),
+					ast.NewFile("Tickets.kt").AddNodes(
+						ast.NewFunc("find").
+							SetComment("...fails always.").
+							AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID))).
+							SetBody(ast.NewBlock(
+								ast.NewAssign(ast.Exprs(ast.NewIdent("err")), ast.AssignDefine, ast.Exprs(notFound.Cases[0].Make(ast.NewIdent("id")))),
+								ast.NewAssign(ast.Exprs(ast.NewIdent("n")), ast.AssignDefine, ast.Exprs(ast.NewIntLit(0))),
+								ifStmt,
+								ast.NewTpl("throw err"),
+							)),
+						ast.NewProperty("defaultTimeout", ast.NewSimpleTypeDecl(stdlib.Int64)).Reader(true, ast.PackagePrivate),
+					),
+				),
+			),
+	)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	a, err := kotlin.NewRenderer(kotlin.Options{SkipBuildFiles: true}).Render(newPrj())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srctest.Compare(t, srctest.DefaultGoldenDir, a)
+}
+
+func TestRenderer_RenderStatements(t *testing.T) {
+	fun := ast.NewFunc("sum").
+		AddParams(ast.NewParam("values", ast.NewSliceTypeDecl(ast.NewSimpleTypeDecl(stdlib.Int)))).
+		AddResults(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Int))).
+		SetBody(ast.NewBlock(
+			ast.NewAssign(ast.Exprs(ast.NewIdent("total")), ast.AssignDefine, ast.Exprs(ast.NewIntLit(0))),
+			ast.NewRangeStmt(nil, ast.NewIdent("v"), ast.NewIdent("values"), ast.NewBlock(
+				ast.NewAssign(ast.Exprs(ast.NewIdent("total")), ast.AssignAdd, ast.Exprs(ast.NewBinaryExpr(ast.NewIdent("v"), ast.OpAnd, ast.NewIntLit(0xff)))),
+			)),
+			ast.NewForStmt(
+				ast.NewAssign(ast.Exprs(ast.NewIdent("i")), ast.AssignDefine, ast.Exprs(ast.NewIntLit(0))),
+				ast.NewBinaryExpr(ast.NewIdent("i"), ast.OpLess, ast.NewIntLit(3)),
+				ast.NewUnaryExpr(ast.NewIdent("i"), ast.OpInc),
+				ast.NewBlock(ast.NewCallExpr(ast.NewIdent("println"), ast.NewStrLit("i=$i"))),
+			),
+			ast.NewReturnStmt(ast.NewIdent("total")),
+		))
+
+	prj := ast.NewPrj("Stmts").AddModules(
+		ast.NewMod("com.example.stmts").SetLang(ast.LangKotlin).SetOutputDirectory("stmts").AddPackages(
+			ast.NewPkg("com.example.stmts").AddFiles(ast.NewFile("Sum.kt").AddFuncs(fun)),
+		),
+	)
+
+	a, err := kotlin.NewRenderer(kotlin.Options{SkipBuildFiles: true}).Render(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srctest.AssertContains(t, srctest.ReadFile(t, a.(*render.Dir), "stmts/src/main/kotlin/com/example/stmts/Sum.kt"),
+		"fun sum(values: List<Int>): Int {\n",
+		"    var total = 0\n",
+		"    for (v in values) {\n        total += v and 255\n    }\n",
+		"    run {\n        var i = 0\n        while (i < 3) {\n            println(\"i=\\$i\")\n            i++\n        }\n    }\n",
+	)
+}
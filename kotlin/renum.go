@@ -0,0 +1,78 @@
+package kotlin
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"strconv"
+)
+
+// renderEnum emits an enum class. Each case is a constant in screaming snake case, which carries its value.
+// Cases without a value get their name, if the base type is a string, otherwise their index, just like iota
+// in Go. The companion object provides fromString, which resolves a case either by its string value or by its
+// constant name.
+func (r *Renderer) renderEnum(node *ast.Enum, w *render.BufferedWriter) error {
+	writeCommentNode(w, node.Identifier(), node.Comment())
+
+	baseType := node.BaseType
+	if baseType == "" {
+		baseType = stdlib.Int
+	}
+
+	isString := baseType == stdlib.String
+	valueType := string(r.importer(node).shortify(fromStdlib(baseType)))
+
+	w.Printf("enum class %s(val value: %s)", node.Identifier(), valueType)
+	for i, name := range node.Implements {
+		if i == 0 {
+			w.Printf(" : ")
+		} else {
+			w.Printf(", ")
+		}
+
+		w.Printf(string(r.importer(node).shortify(name)))
+	}
+
+	w.Printf(" {\n")
+
+	for i, enumCase := range node.Cases {
+		name := naming.Kotlin.ScreamingSnake(enumCase.Name())
+		writeCommentNode(w, name, enumCase.Comment())
+		w.Printf("%s(", name)
+		switch {
+		case enumCase.EnumValue != nil:
+			if err := r.renderBasicLit(enumCase.EnumValue, w); err != nil {
+				return fmt.Errorf("cannot render value of case '%s': %w", enumCase.Name(), err)
+			}
+		case isString:
+			w.Print(kotlinQuote(enumCase.Name()))
+		default:
+			w.Printf(strconv.Itoa(i))
+		}
+
+		w.Printf(")")
+		if i < len(node.Cases)-1 {
+			w.Printf(",\n")
+		}
+	}
+
+	w.Printf(";\n\n")
+
+	match := "candidate.name == text"
+	doc := "...returns the case, whose constant name is equal to the given text."
+	if isString {
+		match = "candidate.value == text"
+		doc = "...returns the case, whose value is equal to the given text."
+	}
+
+	w.Printf("companion object {\n")
+	writeComment(w, "fromString", doc+"\n\n@throws IllegalArgumentException if no such case exists")
+	w.Printf("fun fromString(text: String): %s {\n", node.Identifier())
+	w.Printf("for (candidate in values()) {\nif (%s) {\nreturn candidate\n}\n}\n\n", match)
+	w.Printf("throw IllegalArgumentException(%s + text)\n}\n", kotlinQuote("unknown "+node.Identifier()+": "))
+	w.Printf("}\n}\n")
+
+	return nil
+}
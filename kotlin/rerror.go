@@ -0,0 +1,67 @@
+package kotlin
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+// renderErrorType emits a sealed class, which extends RuntimeException and nests a class for each case. The
+// properties of a case become read-only properties of its primary constructor and are part of the message.
+func (r *Renderer) renderErrorType(node *ast.ErrorType, w *render.BufferedWriter) error {
+	groupType := ErrorTypeName(node.TypeName)
+	writeComment(w, groupType, node.Doc())
+	w.Printf("sealed class %s(message: String, cause: Throwable?) : RuntimeException(message, cause) {\n", groupType)
+
+	for _, errorCase := range node.Cases {
+		name := ErrorClassName(errorCase.Name())
+		doc := errorCase.Doc(name, groupType) + "\n\n"
+		for _, property := range errorCase.Properties {
+			if property.Comment() != nil {
+				doc += "@property " + deEllipsis(errorPropertyName(property), property.Comment().Text) + "\n"
+			}
+		}
+
+		doc += "@param cause refers to a causing error or null."
+
+		w.Printf("\n")
+		writeComment(w, name, doc)
+		w.Printf("class %s(\n", name)
+		for _, property := range errorCase.Properties {
+			w.Printf("val %s: ", errorPropertyName(property))
+			if err := r.renderTypeDecl(property.TypeDecl(), w); err != nil {
+				return err
+			}
+
+			w.Printf(",\n")
+		}
+
+		msg := errorCase.Message(func(property *ast.Field) string {
+			return "${" + errorPropertyName(property) + "}"
+		})
+
+		w.Printf("cause: Throwable? = null\n")
+		w.Printf(") : %s(\"%s\", cause)\n", groupType, msg)
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// ErrorTypeName returns the name of the sealed class of an error group, e.g. NotFoundError.
+func ErrorTypeName(groupName string) string {
+	const errStr = "Error"
+	return naming.Kotlin.Public(strings.TrimSuffix(groupName, errStr)) + errStr
+}
+
+// ErrorClassName returns the name of the nested class of an error case, e.g. Ticket.
+func ErrorClassName(caseName string) string {
+	return naming.Kotlin.Public(caseName)
+}
+
+// errorPropertyName is the name of the read-only property, e.g. userId.
+func errorPropertyName(property *ast.Field) string {
+	return naming.Kotlin.Escape(naming.Kotlin.Private(property.Identifier()))
+}
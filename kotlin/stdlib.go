@@ -0,0 +1,115 @@
+package kotlin
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/stdlib"
+	"strings"
+)
+
+// fromStdlib converts stdlib types (indicated by the macro ! sign at the end) and returns a Kotlin name for it.
+// The names are always qualified, so that the importer can detect collisions with the default imports. Kotlin
+// has no primitive types in its type system, so in contrast to Java, no boxing is involved. Types without a
+// counterpart in the Kotlin standard library are mapped to the same JVM classes as in Java.
+func fromStdlib(name ast.Name) ast.Name {
+	switch name {
+	case stdlib.Bool:
+		return "kotlin.Boolean"
+
+	case stdlib.Int, stdlib.Int32:
+		return "kotlin.Int"
+
+	case stdlib.Byte:
+		return "kotlin.Byte"
+
+	case stdlib.Int16:
+		return "kotlin.Short"
+
+	case stdlib.Int64:
+		return "kotlin.Long"
+
+	case stdlib.Float32:
+		return "kotlin.Float"
+
+	case stdlib.Float64:
+		return "kotlin.Double"
+
+	case stdlib.Map:
+		return "kotlin.collections.Map"
+
+	case stdlib.List:
+		return "kotlin.collections.List"
+
+	case stdlib.UUID:
+		return "java.util.UUID"
+
+	case stdlib.String:
+		return "kotlin.String"
+
+	case stdlib.Error:
+		return "kotlin.Exception"
+
+	case stdlib.Time:
+		return "java.time.ZonedDateTime"
+
+	case stdlib.Duration:
+		return "java.time.Duration"
+
+	case stdlib.URL:
+		return "java.net.URL"
+
+	case stdlib.Rune:
+		// just like in Java, a code point is an int, because a Char is only a UTF-16 code unit
+		return "kotlin.Int"
+
+	case stdlib.Void:
+		return "kotlin.Unit"
+
+	default:
+		if strings.HasSuffix(string(name), "!") {
+			panic("not a stdlib type: " + string(name))
+		}
+		return name
+	}
+}
+
+// zeroValue returns the Kotlin literal of the zero value of the given type, just like Go would initialize it.
+// Pointers are nullable and become null. Returns false, if the type has no reasonable zero value, e.g. for
+// any custom class.
+func zeroValue(decl ast.TypeDecl) (string, bool) {
+	switch t := decl.(type) {
+	case *ast.TypeDeclPtr:
+		return "null", true
+	case *ast.SliceTypeDecl:
+		return "emptyList()", true
+	case *ast.GenericTypeDecl:
+		if simple, ok := t.TypeDecl.(*ast.SimpleTypeDecl); ok {
+			switch simple.Name() {
+			case stdlib.List:
+				return "emptyList()", true
+			case stdlib.Map:
+				return "emptyMap()", true
+			}
+		}
+	case *ast.SimpleTypeDecl:
+		switch t.Name() {
+		case stdlib.Bool:
+			return "false", true
+		case stdlib.Int, stdlib.Int32, stdlib.Byte, stdlib.Int16, stdlib.Rune:
+			return "0", true
+		case stdlib.Int64:
+			return "0L", true
+		case stdlib.Float32:
+			return "0f", true
+		case stdlib.Float64:
+			return "0.0", true
+		case stdlib.String:
+			return `""`, true
+		case stdlib.List:
+			return "emptyList()", true
+		case stdlib.Map:
+			return "emptyMap()", true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,20 @@
+package com.example.tickets
+
+import java.util.UUID
+
+/**
+ * NotFoundError represents the sum type of all NotFound errors.
+ */
+sealed class NotFoundError(message: String, cause: Throwable?) : RuntimeException(message, cause) {
+    /**
+     * Ticket is returned, if no such ticket exists.
+     * Ticket is also a NotFoundError.
+     *
+     * @property id is the unknown ticket.
+     * @param cause refers to a causing error or null.
+     */
+    class Ticket(
+        val id: UUID,
+        cause: Throwable? = null
+    ) : NotFoundError("Ticket: id=${id}", cause)
+}
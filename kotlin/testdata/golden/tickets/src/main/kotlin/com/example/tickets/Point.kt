@@ -0,0 +1,14 @@
+package com.example.tickets
+
+/**
+ * Point is a location.
+ *
+ * Deprecated: points are not precise. Use [Ticket] instead.
+ *
+ * @property x is the horizontal position.
+ */
+@Deprecated("points are not precise", ReplaceWith("Ticket"))
+data class Point(
+    val x: Long = 0L,
+    private val y: Long = 0L
+)
@@ -0,0 +1,40 @@
+package com.example.tickets
+
+import java.time.Clock
+import java.util.UUID
+
+/**
+ * Repository stores tickets.
+ */
+interface Repository {
+    val size: Int
+
+    var name: String
+
+    /**
+     * @param id is the ticket id.
+     */
+    fun find(id: UUID): Ticket?
+
+    /**
+     * findSlow scans all tickets.
+     *
+     * Deprecated: too slow. Use [Repository.find] instead.
+     *
+     * @param id is the ticket id.
+     */
+    @Deprecated("too slow", ReplaceWith("Repository.find"))
+    fun findSlow(id: UUID): Ticket?
+
+    fun forEach(f: (Ticket) -> Boolean)
+}
+
+internal class MemRepository : Repository {
+    var size: Int = 0
+        private set
+
+    var name: String = ""
+
+    protected lateinit var clock: Clock
+        private set
+}
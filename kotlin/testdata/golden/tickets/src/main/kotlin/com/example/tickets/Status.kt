@@ -0,0 +1,26 @@
+package com.example.tickets
+
+enum class Status(val value: String) {
+    /**
+     * IN_PROGRESS is the state of active work.
+     */
+    IN_PROGRESS("inProgress"),
+    DONE("\$done");
+
+    companion object {
+        /**
+         * fromString returns the case, whose value is equal to the given text.
+         *
+         * @throws IllegalArgumentException if no such case exists
+         */
+        fun fromString(text: String): Status {
+            for (candidate in values()) {
+                if (candidate.value == text) {
+                    return candidate
+                }
+            }
+
+            throw IllegalArgumentException("unknown Status: " + text)
+        }
+    }
+}
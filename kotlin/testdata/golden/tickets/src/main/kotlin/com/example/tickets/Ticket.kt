@@ -0,0 +1,29 @@
+package com.example.tickets
+
+import java.util.UUID
+
+/**
+ * Ticket is an issue.
+ *
+ * @property id is the unique id.
+ */
+data class Ticket(
+    var id: UUID,
+    var title: String = "",
+    var assignee: String? = null,
+    var tags: List<String> = emptyList()
+) {
+    /**
+     * isAssigned returns true, if someone works on it.
+     */
+    fun isAssigned(): Boolean {
+        return assignee != null
+    }
+
+    companion object {
+        @Throws(Exception::class)
+        fun parse(text: String): Ticket {
+            throw UnsupportedOperationException(text)
+        }
+    }
+}
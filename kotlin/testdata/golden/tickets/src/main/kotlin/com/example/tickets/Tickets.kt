@@ -0,0 +1,19 @@
+package com.example.tickets
+
+import java.util.UUID
+
+/**
+ * find fails always.
+ */
+fun find(id: UUID) {
+    val err = NotFoundError.Ticket(id)
+    var n = 0
+    if (n < 1) {
+        n += 1
+    } else {
+        println(n)
+    }
+    throw err
+}
+
+internal val defaultTimeout: Long = 0L
@@ -0,0 +1,71 @@
+package naming
+
+// GoKeywords contains all Go keywords, which may not be used as identifiers.
+// See https://golang.org/ref/spec#Keywords.
+var GoKeywords = []string{
+	"break", "default", "func", "interface", "select",
+	"case", "defer", "go", "map", "struct",
+	"chan", "else", "goto", "package", "switch",
+	"const", "fallthrough", "if", "range", "type",
+	"continue", "for", "import", "return", "var",
+}
+
+// JavaKeywords contains all reserved Java keywords and the literals true, false and null, which may not be used
+// as identifiers. Contextual keywords like var or record are valid identifiers and not contained.
+// See https://docs.oracle.com/javase/specs/jls/se17/html/jls-3.html#jls-3.9.
+var JavaKeywords = []string{
+	"abstract", "continue", "for", "new", "switch",
+	"assert", "default", "if", "package", "synchronized",
+	"boolean", "do", "goto", "private", "this",
+	"break", "double", "implements", "protected", "throw",
+	"byte", "else", "import", "public", "throws",
+	"case", "enum", "instanceof", "return", "transient",
+	"catch", "extends", "int", "short", "try",
+	"char", "final", "interface", "static", "void",
+	"class", "finally", "long", "strictfp", "volatile",
+	"const", "float", "native", "super", "while",
+	"true", "false", "null",
+}
+
+// KotlinKeywords contains all hard Kotlin keywords, which may not be used as identifiers. Soft and modifier
+// keywords like data or value are valid identifiers and not contained.
+// See https://kotlinlang.org/docs/keyword-reference.html#hard-keywords.
+var KotlinKeywords = []string{
+	"as", "class", "false", "in", "object", "super", "throw", "typealias", "when",
+	"break", "continue", "for", "interface", "package", "this", "true", "typeof", "while",
+	"do", "else", "fun", "if", "is", "null", "return", "try", "val", "var",
+}
+
+// SwiftKeywords contains all Swift keywords, which are reserved in declarations, statements, expressions and
+// types. Keywords reserved only in particular contexts, like get or set, are valid identifiers and not contained.
+// See https://docs.swift.org/swift-book/ReferenceManual/LexicalStructure.html#ID413.
+var SwiftKeywords = []string{
+	"associatedtype", "class", "deinit", "enum", "extension", "fileprivate", "func", "import", "init", "inout",
+	"internal", "let", "open", "operator", "private", "precedencegroup", "protocol", "public", "rethrows", "static",
+	"struct", "subscript", "typealias", "var",
+	"break", "case", "catch", "continue", "default", "defer", "do", "else", "fallthrough", "for", "guard", "if",
+	"in", "repeat", "return", "throw", "switch", "where", "while",
+	"Any", "as", "await", "false", "is", "nil", "self", "Self", "super", "throws", "true", "try",
+}
+
+// RustKeywords contains all strict and reserved Rust keywords of the 2021 edition. Weak keywords like union are
+// valid identifiers and not contained.
+// See https://doc.rust-lang.org/reference/keywords.html.
+var RustKeywords = []string{
+	"as", "async", "await", "break", "const", "continue", "crate", "dyn", "else", "enum", "extern", "false", "fn",
+	"for", "if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub", "ref", "return", "self", "Self",
+	"static", "struct", "super", "trait", "true", "type", "unsafe", "use", "where", "while",
+	"abstract", "become", "box", "do", "final", "macro", "override", "priv", "try", "typeof", "unsized", "virtual",
+	"yield",
+}
+
+// TypeScriptKeywords contains all reserved words of ECMAScript, the strict mode reserved words and the reserved
+// type names of TypeScript. Contextual keywords like type or readonly are valid identifiers and not contained.
+// See https://github.com/microsoft/TypeScript/issues/2536.
+var TypeScriptKeywords = []string{
+	"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do", "else", "enum",
+	"export", "extends", "false", "finally", "for", "function", "if", "import", "in", "instanceof", "new", "null",
+	"return", "super", "switch", "this", "throw", "true", "try", "typeof", "var", "void", "while", "with",
+	"implements", "interface", "let", "package", "private", "protected", "public", "static", "yield",
+	"any", "boolean", "never", "number", "string", "symbol", "unknown",
+}
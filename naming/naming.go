@@ -0,0 +1,281 @@
+// Package naming converts identifiers between the naming conventions of the supported target languages. It knows
+// about initialisms like URL or ID, so that e.g. the Go convention turns httpClient into HTTPClient as golint
+// expects it, and it escapes identifiers which collide with a reserved keyword.
+package naming
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultInitialisms are the initialisms known by golint. See also
+// https://github.com/golang/lint/blob/master/lint.go.
+var DefaultInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "LHS",
+	"QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI",
+	"URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+var (
+	// Go is the convention of Go, using the DefaultInitialisms and the Go keywords.
+	Go = New(DefaultInitialisms, GoKeywords)
+
+	// Java is the convention of Java. Like in the Google Java Style, initialisms are treated as ordinary words,
+	// e.g. XmlHttpRequest, so no initialisms are configured.
+	Java = New(nil, JavaKeywords)
+
+	// Kotlin is the convention of Kotlin, which treats initialisms as ordinary words just like Java.
+	Kotlin = New(nil, KotlinKeywords)
+
+	// Swift is the convention of Swift. Like in Go, initialisms are uniformly upper or lower case, e.g. userID or
+	// urlPath, see https://swift.org/documentation/api-design-guidelines/#conventions.
+	Swift = New(DefaultInitialisms, SwiftKeywords)
+
+	// Rust is the convention of Rust, which treats initialisms as ordinary words, e.g. Uuid or http_server, see
+	// https://rust-lang.github.io/api-guidelines/naming.html.
+	Rust = New(nil, RustKeywords)
+
+	// TypeScript is the convention of TypeScript. Like in Go, initialisms are uniformly upper or lower case, e.g.
+	// userID or URLPath.
+	TypeScript = New(DefaultInitialisms, TypeScriptKeywords)
+)
+
+// A Convention describes the initialisms and the reserved keywords of a language. It is immutable and safe for
+// concurrent use.
+type Convention struct {
+	initialisms map[string]bool
+	keywords    map[string]bool
+}
+
+// New creates a Convention with the given (upper case) initialisms and reserved keywords.
+func New(initialisms, keywords []string) *Convention {
+	c := &Convention{initialisms: map[string]bool{}, keywords: map[string]bool{}}
+	for _, s := range initialisms {
+		c.initialisms[strings.ToUpper(s)] = true
+	}
+
+	for _, s := range keywords {
+		c.keywords[s] = true
+	}
+
+	return c
+}
+
+// WithInitialisms returns a copy of the convention, which knows the additional initialisms, e.g. GRPC.
+func (c *Convention) WithInitialisms(initialisms ...string) *Convention {
+	res := New(initialisms, nil)
+	for s := range c.initialisms {
+		res.initialisms[s] = true
+	}
+
+	for s := range c.keywords {
+		res.keywords[s] = true
+	}
+
+	return res
+}
+
+// IsInitialism returns true, if the word is a known initialism, independent of its case.
+func (c *Convention) IsInitialism(word string) bool {
+	_, ok := c.initialism(word)
+	return ok
+}
+
+// IsKeyword returns true, if the identifier is reserved and cannot be declared.
+func (c *Convention) IsKeyword(identifier string) bool {
+	return c.keywords[identifier]
+}
+
+// Escape appends an underscore to a reserved keyword, e.g. type becomes type_. Other identifiers are returned
+// as is.
+func (c *Convention) Escape(identifier string) string {
+	if c.IsKeyword(identifier) {
+		return identifier + "_"
+	}
+
+	return identifier
+}
+
+// Words splits the identifier at case changes and at all runes which are neither letters nor digits. Digits
+// belong to the preceding word and an initialism keeps its plural, e.g. user_ID, userId and UserIDs become
+// [user ID], [user Id] and [User IDs].
+func (c *Convention) Words(s string) []string {
+	var res []string
+	for _, seg := range c.segments(s) {
+		if seg.word {
+			res = append(res, seg.text)
+		}
+	}
+
+	return res
+}
+
+// Pascal joins the normalized words, e.g. http_server or HTTP-SERVER become HTTPServer in Go or HttpServer in
+// Java.
+func (c *Convention) Pascal(s string) string {
+	sb := &strings.Builder{}
+	for _, word := range c.Words(s) {
+		sb.WriteString(c.title(word))
+	}
+
+	return sb.String()
+}
+
+// Camel is like Pascal but the first word is lower case, e.g. HTTP_SERVER becomes httpServer.
+func (c *Convention) Camel(s string) string {
+	sb := &strings.Builder{}
+	for i, word := range c.Words(s) {
+		if i == 0 {
+			sb.WriteString(strings.ToLower(word))
+			continue
+		}
+
+		sb.WriteString(c.title(word))
+	}
+
+	return sb.String()
+}
+
+// Snake joins the lower case words with underscores, e.g. HTTPServer becomes http_server.
+func (c *Convention) Snake(s string) string {
+	return strings.ToLower(strings.Join(c.Words(s), "_"))
+}
+
+// ScreamingSnake joins the upper case words with underscores, e.g. httpServer becomes HTTP_SERVER.
+func (c *Convention) ScreamingSnake(s string) string {
+	return strings.ToUpper(strings.Join(c.Words(s), "_"))
+}
+
+// Kebab joins the lower case words with dashes, e.g. HTTPServer becomes http-server.
+func (c *Convention) Kebab(s string) string {
+	return strings.ToLower(strings.Join(c.Words(s), "-"))
+}
+
+// Public makes the identifier start with an upper case letter and fixes the case of initialisms, but keeps
+// everything else, e.g. jsonData becomes JSONData and userId becomes UserID in Go.
+func (c *Convention) Public(s string) string {
+	return c.visible(s, true)
+}
+
+// Private makes the identifier start with a lower case letter and fixes the case of initialisms, but keeps
+// everything else, e.g. ID becomes id and URLPath becomes urlPath in Go.
+func (c *Convention) Private(s string) string {
+	return c.visible(s, false)
+}
+
+func (c *Convention) visible(s string, public bool) string {
+	sb := &strings.Builder{}
+	first := true
+	for _, seg := range c.segments(s) {
+		text := seg.text
+		if seg.word {
+			if initialism, ok := c.initialism(text); ok {
+				text = initialism
+				if first && !public {
+					text = strings.ToLower(text)
+				}
+			} else if first {
+				r, size := utf8.DecodeRuneInString(text)
+				if public {
+					r = unicode.ToUpper(r)
+				} else {
+					r = unicode.ToLower(r)
+				}
+
+				text = string(r) + text[size:]
+			}
+
+			first = false
+		}
+
+		sb.WriteString(text)
+	}
+
+	return sb.String()
+}
+
+// title returns the canonical form of an initialism or the word in title case.
+func (c *Convention) title(word string) string {
+	if initialism, ok := c.initialism(word); ok {
+		return initialism
+	}
+
+	r, size := utf8.DecodeRuneInString(word)
+
+	return string(unicode.ToUpper(r)) + strings.ToLower(word[size:])
+}
+
+// initialism returns the upper case form of the word, if it is a known initialism. A trailing s is a plural and
+// stays lower case, e.g. ids becomes IDs.
+func (c *Convention) initialism(word string) (string, bool) {
+	upper := strings.ToUpper(word)
+	if c.initialisms[upper] {
+		return upper, true
+	}
+
+	if len(word) > 2 && word[len(word)-1] == 's' && c.initialisms[upper[:len(upper)-1]] {
+		return upper[:len(upper)-1] + "s", true
+	}
+
+	return "", false
+}
+
+// A segment is either a word or a run of separating runes.
+type segment struct {
+	text string
+	word bool
+}
+
+func (c *Convention) segments(s string) []segment {
+	var res []segment
+	runes := []rune(s)
+	start := 0
+	flush := func(end int, word bool) {
+		if end > start {
+			res = append(res, segment{text: string(runes[start:end]), word: word})
+		}
+
+		start = end
+	}
+
+	for i, r := range runes {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if i == start {
+			continue
+		}
+
+		prev := runes[i-1]
+		prevIsWordRune := unicode.IsLetter(prev) || unicode.IsDigit(prev)
+		switch {
+		case isWordRune != prevIsWordRune:
+			flush(i, prevIsWordRune)
+		case !isWordRune:
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// fooBar or utf8Encoder
+			flush(i, true)
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// HTTPServer splits before the S, but the plural in IDs does not split
+			if !c.isPlural(runes, start, i) {
+				flush(i, true)
+			}
+		}
+	}
+
+	if len(runes) > start {
+		last := runes[len(runes)-1]
+		flush(len(runes), unicode.IsLetter(last) || unicode.IsDigit(last))
+	}
+
+	return res
+}
+
+// isPlural returns true, if the upper case rune at i finishes an initialism, which is followed by a plural s at
+// the end of the word.
+func (c *Convention) isPlural(runes []rune, start, i int) bool {
+	if runes[i+1] != 's' || i+2 < len(runes) && (unicode.IsLower(runes[i+2]) || unicode.IsDigit(runes[i+2])) {
+		return false
+	}
+
+	return c.initialisms[string(runes[start:i+1])]
+}
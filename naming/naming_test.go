@@ -0,0 +1,128 @@
+package naming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvention_Words(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"httpClient", []string{"http", "Client"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"user_id", []string{"user", "id"}},
+		{"user-ID", []string{"user", "ID"}},
+		{"UserIDs", []string{"User", "IDs"}},
+		{"UserIDsByName", []string{"User", "IDs", "By", "Name"}},
+		{"Users", []string{"Users"}},
+		{"utf8Encoder", []string{"utf8", "Encoder"}},
+		{"  hello world ", []string{"hello", "world"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Go.Words(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvention_Conversions(t *testing.T) {
+	tests := []struct {
+		in                                  string
+		pascal, camel, snake, kebab, public string
+		private, javaPascal, javaCamel      string
+	}{
+		{"url", "URL", "url", "url", "url", "URL", "url", "Url", "url"},
+		{"httpClient", "HTTPClient", "httpClient", "http_client", "http-client", "HTTPClient", "httpClient", "HttpClient", "httpClient"},
+		{"jsonData", "JSONData", "jsonData", "json_data", "json-data", "JSONData", "jsonData", "JsonData", "jsonData"},
+		{"ID", "ID", "id", "id", "id", "ID", "id", "Id", "id"},
+		{"user_ids", "UserIDs", "userIDs", "user_ids", "user-ids", "User_IDs", "user_IDs", "UserIds", "userIds"},
+		{"URLPath", "URLPath", "urlPath", "url_path", "url-path", "URLPath", "urlPath", "UrlPath", "urlPath"},
+		{"RED", "Red", "red", "red", "red", "RED", "rED", "Red", "red"},
+		{"Hello", "Hello", "hello", "hello", "hello", "Hello", "hello", "Hello", "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := []string{
+				Go.Pascal(tt.in), Go.Camel(tt.in), Go.Snake(tt.in), Go.Kebab(tt.in), Go.Public(tt.in),
+				Go.Private(tt.in), Java.Pascal(tt.in), Java.Camel(tt.in),
+			}
+
+			want := []string{tt.pascal, tt.camel, tt.snake, tt.kebab, tt.public, tt.private, tt.javaPascal, tt.javaCamel}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestConvention_WithInitialisms(t *testing.T) {
+	c := Go.WithInitialisms("grpc")
+	if got := c.Public("grpcServer"); got != "GRPCServer" {
+		t.Errorf("Public() = %v", got)
+	}
+
+	if Go.IsInitialism("GRPC") {
+		t.Error("expected the default convention to be unchanged")
+	}
+
+	if got := c.Escape("type"); got != "type_" {
+		t.Errorf("Escape() = %v", got)
+	}
+}
+
+func TestConvention_Escape(t *testing.T) {
+	if got := Go.Escape("type"); got != "type_" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Go.Escape("class"); got != "class" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Java.Escape("class"); got != "class_" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Kotlin.Escape("val"); got != "val_" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Kotlin.Escape("data"); got != "data" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Swift.Escape("default"); got != "default_" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Swift.Private("URLPath"); got != "urlPath" {
+		t.Errorf("Private() = %v", got)
+	}
+
+	if got := Rust.Escape("type"); got != "type_" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := Rust.Snake("userID"); got != "user_id" {
+		t.Errorf("Snake() = %v", got)
+	}
+
+	if got := TypeScript.Escape("interface"); got != "interface_" {
+		t.Errorf("Escape() = %v", got)
+	}
+
+	if got := TypeScript.Kebab("NotFoundError"); got != "not-found-error" {
+		t.Errorf("Kebab() = %v", got)
+	}
+
+	if got := Java.ScreamingSnake("httpServer"); got != "HTTP_SERVER" {
+		t.Errorf("ScreamingSnake() = %v", got)
+	}
+}
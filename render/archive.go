@@ -0,0 +1,129 @@
+package render
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+)
+
+// archiveTime is used for all archive entries, so that equal artifacts result in equal archives.
+var archiveTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// WriteZip streams the given artifact as a zip archive into w. Just like Write, the name of a Dir is the first
+// path segment of each entry, unless it is empty. Entries are sorted and have a fixed modification time,
+// so the archive is reproducible.
+func WriteZip(w io.Writer, artifact Artifact) error {
+	zw := zip.NewWriter(w)
+	err := walkArtifact(artifact, func(name string, file *File) error {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: archiveTime}
+		if file == nil {
+			header.Name += "/"
+			header.Method = zip.Store
+			header.SetMode(fs.ModeDir | 0755)
+		} else {
+			header.SetMode(0644)
+		}
+
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("cannot create zip entry '%s': %w", name, err)
+		}
+
+		if file != nil {
+			if _, err := fw.Write(file.Buf); err != nil {
+				return fmt.Errorf("cannot write zip entry '%s': %w", name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("cannot finish zip archive: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTar streams the given artifact as an uncompressed tar archive into w. See also WriteZip.
+func WriteTar(w io.Writer, artifact Artifact) error {
+	tw := tar.NewWriter(w)
+	err := walkArtifact(artifact, func(name string, file *File) error {
+		header := &tar.Header{Name: name, ModTime: archiveTime, Format: tar.FormatPAX}
+		if file == nil {
+			header.Typeflag = tar.TypeDir
+			header.Name += "/"
+			header.Mode = 0755
+		} else {
+			header.Typeflag = tar.TypeReg
+			header.Mode = 0644
+			header.Size = int64(len(file.Buf))
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("cannot write tar header '%s': %w", name, err)
+		}
+
+		if file != nil {
+			if _, err := tw.Write(file.Buf); err != nil {
+				return fmt.Errorf("cannot write tar entry '%s': %w", name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("cannot finish tar archive: %w", err)
+	}
+
+	return nil
+}
+
+// walkArtifact invokes f in lexical order for each directory (with a nil file) and each file.
+func walkArtifact(artifact Artifact, f func(name string, file *File) error) error {
+	switch t := artifact.(type) {
+	case *File:
+		return f(t.FileName, t)
+	case *Dir:
+		return fs.WalkDir(t, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			name := path.Join(t.DirName, p)
+			if p == "." {
+				if t.DirName == "" {
+					return nil
+				}
+
+				return f(name, nil)
+			}
+
+			if d.IsDir() {
+				return f(name, nil)
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			return f(name, info.Sys().(*File))
+		})
+	default:
+		return fmt.Errorf("unsupported artifact type %T", artifact)
+	}
+}
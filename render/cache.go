@@ -0,0 +1,80 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// A Cache stores rendered file contents on disk, addressed by an arbitrary key which is usually a hex encoded
+// content hash. It is safe for concurrent use, also by multiple processes, because entries are replaced atomically.
+type Cache struct {
+	Dir string // Dir is the root directory of the cache and is created on demand.
+}
+
+// NewCache returns a Cache which stores its entries below the given directory.
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// path returns the location of the entry. Entries are spread across sub directories to avoid huge directories.
+func (c *Cache) path(key string) string {
+	if len(key) < 3 {
+		return filepath.Join(c.Dir, key)
+	}
+
+	return filepath.Join(c.Dir, key[:2], key)
+}
+
+// Get returns the cached content. A missing or unreadable entry is treated as a cache miss.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	buf, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	return buf, true
+}
+
+// Put replaces the content of the given key.
+func (c *Cache) Put(key string, buf []byte) error {
+	fname := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(fname), 0700); err != nil {
+		return fmt.Errorf("cannot create cache directory: %w", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(fname), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("cannot create cache entry: %w", err)
+	}
+
+	if _, err := tmp.Write(buf); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), fname); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("cannot commit cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes all cache entries.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.Dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("cannot clear cache: %w", err)
+	}
+
+	return nil
+}
@@ -24,6 +24,17 @@ func (n *Dir) Directory(name string) *Dir {
 	return nil
 }
 
+// File returns the file with the given name or nil.
+func (n *Dir) File(name string) *File {
+	for _, file := range n.Files {
+		if file.Name() == name {
+			return file
+		}
+	}
+
+	return nil
+}
+
 func (n *Dir) String() string {
 	return n.StringIndent(0)
 }
@@ -48,6 +48,22 @@ func Write(dir string, artifact Artifact) error {
 // Clean takes the given magic bytes and searches in the very first bytes of each file in dir recursively, if
 // it contains one of the magic sequences and deletes it. It ignores any hidden (prefixed with .) folders and files.
 func Clean(dir string, magic ...[]byte) error {
+	files, err := FindGenerated(dir, magic...)
+	if err != nil {
+		return err
+	}
+
+	for _, fname := range files {
+		if err := os.Remove(fname); err != nil {
+			return fmt.Errorf("unable to delete file: %s: %w", fname, err)
+		}
+	}
+
+	return nil
+}
+
+// FindGenerated returns all files which would be deleted by Clean.
+func FindGenerated(dir string, magic ...[]byte) ([]string, error) {
 	var files []string
 
 	err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
@@ -55,6 +71,10 @@ func Clean(dir string, magic ...[]byte) error {
 			return err
 		}
 
+		if path == dir {
+			return nil
+		}
+
 		if info.IsDir() && strings.HasPrefix(info.Name(), ".") {
 			return filepath.SkipDir
 		}
@@ -71,23 +91,22 @@ func Clean(dir string, magic ...[]byte) error {
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var res []string
 	for _, fname := range files {
 		ok, err := fileHasMagic(fname, magic...)
 		if err != nil {
-			return fmt.Errorf("unable to check %s for magic: %w", fname, err)
+			return nil, fmt.Errorf("unable to check %s for magic: %w", fname, err)
 		}
 
 		if ok {
-			if err := os.Remove(fname); err != nil {
-				return fmt.Errorf("unable to delete file: %s: %w", fname, err)
-			}
+			res = append(res, fname)
 		}
 	}
 
-	return nil
+	return res, nil
 }
 
 func fileHasMagic(fname string, magic ...[]byte) (bool, error) {
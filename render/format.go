@@ -0,0 +1,155 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BraceFormat is a built-in pretty printer for languages with a C-like block syntax. Each nesting level of braces,
+// parentheses and brackets is indented by Indent, trailing whitespace is removed and blank lines are collapsed.
+// Blank lines directly after an opening or before a closing brace are removed. Line breaks are never introduced,
+// so the renderer is responsible for them. It only checks the nesting of the source.
+type BraceFormat struct {
+	Indent string // Indent is the width of a nesting level, e.g. 4 spaces.
+	// Quote returns true, if the rune at index i of the line starts a string or character literal, which is
+	// terminated by the same rune. Brackets within literals are ignored. See also QuotedBy.
+	Quote func(line []rune, i int) bool
+	// NestedComments must be true, if block comments are allowed to nest, like in Kotlin, Swift or Rust.
+	NestedComments bool
+	// Level returns the optional adjustment of the nesting level of a line outside of a block comment, e.g. -1
+	// for the case label of a switch. Leading closing brackets are always outdented.
+	Level func(line string) int
+}
+
+// QuotedBy returns a BraceFormat.Quote function, which accepts each of the given runes as a quote.
+func QuotedBy(quotes string) func(line []rune, i int) bool {
+	return func(line []rune, i int) bool {
+		return strings.ContainsRune(quotes, line[i])
+	}
+}
+
+// Format applies the pretty printer to the given text, which keeps the line breaks and replaces the indentation
+// by the nesting level. If it fails, the error is returned and the text contains the source with line enumeration.
+func (f BraceFormat) Format(source []byte) ([]byte, error) {
+	res, err := f.reindent(string(source))
+	if err != nil {
+		return []byte(WithLineNumbers(string(source))), fmt.Errorf("cannot format: %w", err)
+	}
+
+	return []byte(res), nil
+}
+
+// reindent replaces the indentation of each line by the nesting level.
+func (f BraceFormat) reindent(source string) (string, error) {
+	sb := &strings.Builder{}
+	depth := 0
+	comment := 0 // the nesting level of block comments
+	blank := false
+	for i, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			blank = sb.Len() > 0
+			continue
+		}
+
+		level := depth
+		if comment > 0 {
+			if strings.HasPrefix(trimmed, "*") {
+				trimmed = " " + trimmed
+			}
+		} else {
+			level -= leadingClosers(trimmed)
+			if f.Level != nil {
+				level += f.Level(trimmed)
+			}
+		}
+
+		if blank && !strings.HasSuffix(strings.TrimRight(sb.String(), "\n"), "{") && level >= depth {
+			sb.WriteString("\n")
+		}
+
+		blank = false
+
+		var delta int
+		delta, comment = f.scanLine(trimmed, comment)
+		depth += delta
+		if depth < 0 || level < 0 {
+			return "", fmt.Errorf("line %d: unbalanced closing bracket", i+1)
+		}
+
+		sb.WriteString(strings.Repeat(f.Indent, level))
+		sb.WriteString(trimmed)
+		sb.WriteString("\n")
+	}
+
+	if depth != 0 {
+		return "", fmt.Errorf("unbalanced brackets: %d unclosed", depth)
+	}
+
+	if comment != 0 {
+		return "", fmt.Errorf("unterminated block comment")
+	}
+
+	return sb.String(), nil
+}
+
+// leadingClosers counts the closing brackets at the beginning of the line, which are outdented.
+func leadingClosers(line string) int {
+	n := 0
+	for _, r := range line {
+		switch r {
+		case '}', ')', ']':
+			n++
+		case ' ', '.', ',', ';':
+		default:
+			return n
+		}
+	}
+
+	return n
+}
+
+// scanLine returns the nesting difference of the line and the nesting level of block comments at its end.
+// Brackets within literals or comments are ignored.
+func (f BraceFormat) scanLine(line string, comment int) (int, int) {
+	delta := 0
+	var quote rune
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case comment > 0:
+			if r == '*' && next == '/' {
+				comment--
+				i++
+			} else if r == '/' && next == '*' && f.NestedComments {
+				comment++
+				i++
+			}
+		case quote != 0:
+			if r == '\\' {
+				i++
+			} else if r == quote {
+				quote = 0
+			}
+		case f.Quote != nil && f.Quote(runes, i):
+			quote = r
+		case r == '/' && next == '/':
+			return delta, comment
+		case r == '/' && next == '*':
+			comment++
+			i++
+		case r == '{' || r == '(' || r == '[':
+			delta++
+		case r == '}' || r == ')' || r == ']':
+			delta--
+		}
+	}
+
+	return delta, comment
+}
@@ -0,0 +1,86 @@
+package render
+
+import "testing"
+
+func TestBraceFormat_Format(t *testing.T) {
+	src := `
+
+class App {
+
+
+/* a { in a comment
+* continues
+*/
+fun run(s: String = "}") {
+run(')')
+
+
+}
+
+}
+`
+
+	want := `class App {
+  /* a { in a comment
+   * continues
+   */
+  fun run(s: String = "}") {
+    run(')')
+  }
+}
+`
+
+	format := BraceFormat{Indent: "  ", Quote: QuotedBy(`"'`)}
+	buf, err := format.Format([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+}
+
+func TestBraceFormat_FormatComments(t *testing.T) {
+	src := "/* outer /* nested */ still comment { */\nf {\n}\n"
+	if _, err := (BraceFormat{Indent: "  ", NestedComments: true}).Format([]byte(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	// without nesting, the first terminator ends the comment and leaves an unbalanced brace
+	if _, err := (BraceFormat{Indent: "  "}).Format([]byte(src)); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestBraceFormat_FormatLevel(t *testing.T) {
+	format := BraceFormat{Indent: "  ", Level: func(line string) int {
+		if line == "default:" {
+			return -1
+		}
+
+		return 0
+	}}
+
+	buf, err := format.Format([]byte("switch x {\ndefault:\nbreak\n}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "switch x {\ndefault:\n  break\n}\n"; string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+}
+
+func TestBraceFormat_FormatUnbalanced(t *testing.T) {
+	for _, src := range []string{"class App {\n", "}\n", "/* open\n"} {
+		buf, err := (BraceFormat{Indent: "  "}).Format([]byte(src))
+		if err == nil {
+			t.Fatalf("expected an error for %q", src)
+		}
+
+		if string(buf) != WithLineNumbers(src) {
+			t.Fatalf("expected the enumerated source but got\n%s", string(buf))
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	_ fs.FS        = (*Dir)(nil)
+	_ fs.ReadDirFS = (*Dir)(nil)
+)
+
+// Open implements fs.FS. The Dir itself is the root ".", so its own DirName is not a part of any path.
+// If a directory contains a file and a directory with the same name, the file wins. Opened files are read-only
+// snapshots of the current buffers.
+func (n *Dir) Open(name string) (fs.File, error) {
+	artifact, err := n.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := artifact.(type) {
+	case *File:
+		return &openFile{file: t, Reader: bytes.NewReader(t.Buf)}, nil
+	case *Dir:
+		return &openDir{dir: t, entries: t.entries()}, nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// ReadDir implements fs.ReadDirFS and returns the entries of the named directory sorted by name.
+func (n *Dir) ReadDir(name string) ([]fs.DirEntry, error) {
+	artifact, err := n.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, ok := artifact.(*Dir)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return dir.entries(), nil
+}
+
+// lookup resolves the slash separated path relative to this directory.
+func (n *Dir) lookup(op, name string) (Artifact, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." {
+		return n, nil
+	}
+
+	dir := n
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if file := dir.File(segment); file != nil {
+			if last {
+				return file, nil
+			}
+
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+
+		dir = dir.Directory(segment)
+		if dir == nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+	}
+
+	return dir, nil
+}
+
+// entries returns the sorted and unique directory entries.
+func (n *Dir) entries() []fs.DirEntry {
+	seen := map[string]bool{}
+	var res []fs.DirEntry
+	for _, file := range n.Files {
+		if !seen[file.FileName] {
+			seen[file.FileName] = true
+			res = append(res, dirEntry{fileInfoOf(file)})
+		}
+	}
+
+	for _, dir := range n.Dirs {
+		if !seen[dir.DirName] {
+			seen[dir.DirName] = true
+			res = append(res, dirEntry{fileInfoOf(dir)})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name() < res[j].Name()
+	})
+
+	return res
+}
+
+// fileInfo implements fs.FileInfo for a File or Dir.
+type fileInfo struct {
+	artifact Artifact
+}
+
+func fileInfoOf(a Artifact) fileInfo {
+	return fileInfo{artifact: a}
+}
+
+func (f fileInfo) Name() string {
+	return f.artifact.Name()
+}
+
+func (f fileInfo) Size() int64 {
+	if file, ok := f.artifact.(*File); ok {
+		return int64(len(file.Buf))
+	}
+
+	return 0
+}
+
+func (f fileInfo) Mode() fs.FileMode {
+	if f.IsDir() {
+		return fs.ModeDir | 0555
+	}
+
+	return 0444
+}
+
+// ModTime returns the zero time, because rendered artifacts have no modification time.
+func (f fileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (f fileInfo) IsDir() bool {
+	_, ok := f.artifact.(*Dir)
+	return ok
+}
+
+// Sys returns the underlying *File or *Dir.
+func (f fileInfo) Sys() interface{} {
+	return f.artifact
+}
+
+// dirEntry implements fs.DirEntry.
+type dirEntry struct {
+	info fileInfo
+}
+
+func (d dirEntry) Name() string {
+	return d.info.Name()
+}
+
+func (d dirEntry) IsDir() bool {
+	return d.info.IsDir()
+}
+
+func (d dirEntry) Type() fs.FileMode {
+	return d.info.Mode().Type()
+}
+
+func (d dirEntry) Info() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+// openFile implements fs.File, io.Seeker and io.ReaderAt.
+type openFile struct {
+	file *File
+	*bytes.Reader
+}
+
+func (f *openFile) Stat() (fs.FileInfo, error) {
+	return fileInfoOf(f.file), nil
+}
+
+func (f *openFile) Close() error {
+	return nil
+}
+
+// openDir implements fs.ReadDirFile.
+type openDir struct {
+	dir     *Dir
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *openDir) Stat() (fs.FileInfo, error) {
+	return fileInfoOf(d.dir), nil
+}
+
+func (d *openDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.dir.DirName, Err: fs.ErrInvalid}
+}
+
+func (d *openDir) Close() error {
+	return nil
+}
+
+func (d *openDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if count <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	if count > len(rest) {
+		count = len(rest)
+	}
+
+	d.offset += count
+
+	return rest[:count], nil
+}
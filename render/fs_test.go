@@ -0,0 +1,142 @@
+package render
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestDir() *Dir {
+	return &Dir{
+		DirName: "prj",
+		Files:   []*File{{FileName: "README.md", Buf: []byte("# readme\n")}},
+		Dirs: []*Dir{
+			{
+				DirName: "server",
+				Files: []*File{
+					{FileName: "main.go", Buf: []byte("package main\n")},
+					{FileName: "go.mod", Buf: []byte("module server\n")},
+				},
+				Dirs: []*Dir{{DirName: "empty"}},
+			},
+		},
+	}
+}
+
+func TestDir_FS(t *testing.T) {
+	dir := newTestDir()
+	if err := fstest.TestFS(dir, "README.md", "server/main.go", "server/go.mod", "server/empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := fs.ReadFile(dir, "server/main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != "package main\n" {
+		t.Fatalf("unexpected content: %s", buf)
+	}
+
+	if _, err := dir.Open("server/main.go/x"); err == nil {
+		t.Fatal("expected an error for a file used as directory")
+	}
+}
+
+var wantEntries = []string{
+	"prj/",
+	"prj/README.md",
+	"prj/server/",
+	"prj/server/empty/",
+	"prj/server/go.mod",
+	"prj/server/main.go",
+}
+
+func TestWriteZip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteZip(buf, newTestDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+
+	if !reflect.DeepEqual(names, wantEntries) {
+		t.Fatalf("unexpected entries: %v", names)
+	}
+
+	f, err := zr.Open("prj/server/go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "module server\n" {
+		t.Fatalf("unexpected content: %s", content)
+	}
+
+	// archives must be reproducible
+	other := &bytes.Buffer{}
+	if err := WriteZip(other, newTestDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), other.Bytes()) {
+		t.Fatal("expected equal archives")
+	}
+}
+
+func TestWriteTar(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteTar(buf, newTestDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(buf)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names = append(names, hdr.Name)
+		if hdr.Name == "prj/README.md" {
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(content) != "# readme\n" {
+				t.Fatalf("unexpected content: %s", content)
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(names, wantEntries) {
+		t.Fatalf("unexpected entries: %v", names)
+	}
+}
@@ -0,0 +1,186 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"path"
+	"strings"
+	"sync"
+)
+
+// DefaultRegistry is used by the package level functions Register, Project and Mods. Renderer packages register
+// themselves within their init function, so a blank import is sufficient to enable a language.
+var DefaultRegistry = NewRegistry()
+
+// A Factory allocates a new Renderer. Each module is rendered by its own instance, so that a Renderer
+// implementation does not need to be safe for concurrent use.
+type Factory func() Renderer
+
+type registryKey struct {
+	lang      ast.Lang
+	framework ast.Framework
+}
+
+// A Registry dispatches modules to the Renderer which has been registered for the modules target language
+// and framework. It is safe for concurrent use.
+type Registry struct {
+	mutex     sync.RWMutex
+	factories map[registryKey]Factory
+}
+
+// NewRegistry allocates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[registryKey]Factory{}}
+}
+
+// Register installs the factory for the given language and framework. Use ast.FrameworkSDK to register the
+// default renderer of a language, which is also used for all frameworks without a dedicated renderer.
+// Registering the same combination twice panics.
+func (r *Registry) Register(lang ast.Lang, framework ast.Framework, factory Factory) {
+	if factory == nil {
+		panic("render: factory for " + string(lang) + " must not be nil")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := registryKey{lang: lang, framework: framework}
+	if _, ok := r.factories[key]; ok {
+		panic("render: duplicate renderer for " + key.String())
+	}
+
+	r.factories[key] = factory
+}
+
+// Lookup returns the factory which is responsible for the given target. If no renderer for the exact framework
+// has been registered, the default renderer for the language is returned.
+func (r *Registry) Lookup(target ast.Target) (Factory, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if f, ok := r.factories[registryKey{lang: target.Lang, framework: target.Framework}]; ok {
+		return f, true
+	}
+
+	f, ok := r.factories[registryKey{lang: target.Lang, framework: ast.FrameworkSDK}]
+	return f, ok
+}
+
+// Project renders all modules of the given project. See also Mods.
+func (r *Registry) Project(prj *ast.Prj) (*Dir, error) {
+	return r.Mods(prj.Mods...)
+}
+
+// Mods renders each module with the Renderer registered for its target and merges the resulting trees into
+// a single unnamed root directory. Each module is expected to emit its files into its Target.Out directory,
+// relative to the returned root. Nothing is rendered, if any module has an unsupported target, in which case
+// an *UnsupportedError is returned. A partial result is returned if a module cannot be rendered.
+func (r *Registry) Mods(mods ...*ast.Mod) (*Dir, error) {
+	factories := make([]Factory, 0, len(mods))
+	unsupported := &UnsupportedError{}
+	for _, mod := range mods {
+		f, ok := r.Lookup(mod.Target)
+		if !ok {
+			unsupported.Mods = append(unsupported.Mods, mod)
+		}
+
+		factories = append(factories, f)
+	}
+
+	if len(unsupported.Mods) > 0 {
+		return nil, unsupported
+	}
+
+	root := &Dir{}
+	for i, mod := range mods {
+		artifact, err := factories[i]().Render(mod)
+		if dir, ok := artifact.(*Dir); ok {
+			if mergeErr := merge(root, dir, ""); mergeErr != nil && err == nil {
+				err = mergeErr
+			}
+		}
+
+		if err != nil {
+			return root, fmt.Errorf("cannot render module '%s': %w", mod.Name, err)
+		}
+
+		if _, ok := artifact.(*Dir); !ok {
+			return root, fmt.Errorf("cannot render module '%s': expected a root directory but got %T", mod.Name, artifact)
+		}
+	}
+
+	return root, nil
+}
+
+// merge copies all files and directories from src into dst. Files which have been rendered with the same name
+// and the same content multiple times (e.g. a shared build file) are tolerated.
+func merge(dst, src *Dir, parent string) error {
+	if dst.MimeType == "" {
+		dst.MimeType = src.MimeType
+	}
+
+	for _, file := range src.Files {
+		other := dst.File(file.FileName)
+		if other == nil {
+			dst.Files = append(dst.Files, file)
+			continue
+		}
+
+		if !bytes.Equal(other.Buf, file.Buf) {
+			return fmt.Errorf("file '%s' has been rendered multiple times with different content", path.Join(parent, file.FileName))
+		}
+	}
+
+	for _, dir := range src.Dirs {
+		other := dst.Directory(dir.DirName)
+		if other == nil {
+			dst.Dirs = append(dst.Dirs, dir)
+			continue
+		}
+
+		if err := merge(other, dir, path.Join(parent, dir.DirName)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k registryKey) String() string {
+	if k.framework == ast.FrameworkSDK {
+		return string(k.lang)
+	}
+
+	return string(k.lang) + "/" + string(k.framework)
+}
+
+// An UnsupportedError is returned if no Renderer has been registered for the target of a module.
+type UnsupportedError struct {
+	Mods []*ast.Mod
+}
+
+func (e *UnsupportedError) Error() string {
+	var tmp []string
+	for _, mod := range e.Mods {
+		key := registryKey{lang: mod.Target.Lang, framework: mod.Target.Framework}
+		tmp = append(tmp, fmt.Sprintf("module '%s' (%s)", mod.Name, key))
+	}
+
+	return "no renderer registered for " + strings.Join(tmp, ", ")
+}
+
+// Register installs the factory into the DefaultRegistry.
+func Register(lang ast.Lang, framework ast.Framework, factory Factory) {
+	DefaultRegistry.Register(lang, framework, factory)
+}
+
+// Project renders all modules of the project using the DefaultRegistry.
+func Project(prj *ast.Prj) (*Dir, error) {
+	return DefaultRegistry.Project(prj)
+}
+
+// Mods renders the given modules using the DefaultRegistry.
+func Mods(mods ...*ast.Mod) (*Dir, error) {
+	return DefaultRegistry.Mods(mods...)
+}
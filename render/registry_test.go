@@ -0,0 +1,92 @@
+package render
+
+import (
+	"errors"
+	"github.com/golangee/src/ast"
+	"strings"
+	"testing"
+)
+
+// fakeRenderer emits a single file into the modules output directory.
+type fakeRenderer struct {
+	fname string
+}
+
+func (f fakeRenderer) Render(node ast.Node) (Artifact, error) {
+	mod := node.(*ast.Mod)
+	return &Dir{Dirs: []*Dir{{
+		DirName: mod.Target.Out,
+		Files:   []*File{{FileName: f.fname, Buf: []byte(mod.Name)}},
+	}}}, nil
+}
+
+func fakeFactory(fname string) Factory {
+	return func() Renderer {
+		return fakeRenderer{fname: fname}
+	}
+}
+
+func TestRegistry_Project(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(ast.LangGo, ast.FrameworkSDK, fakeFactory("main.go"))
+	reg.Register(ast.LangJava, ast.FrameworkSDK, fakeFactory("Main.java"))
+	reg.Register(ast.LangJava, "spring", fakeFactory("Application.java"))
+
+	spring := ast.NewMod("spring").SetLang(ast.LangJava).SetOutputDirectory("java")
+	spring.Target.Framework = "spring"
+
+	prj := ast.NewPrj("test").AddModules(
+		ast.NewMod("go").SetLang(ast.LangGo).SetOutputDirectory("server"),
+		ast.NewMod("java").SetLang(ast.LangJava).SetOutputDirectory("java"),
+		spring,
+	)
+
+	root, err := reg.Project(prj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(root.Dirs) != 2 {
+		t.Fatalf("expected 2 merged directories but got\n%s", root)
+	}
+
+	java := root.Directory("java")
+	if java == nil || java.File("Main.java") == nil || java.File("Application.java") == nil {
+		t.Fatalf("expected merged java directory but got\n%s", root)
+	}
+}
+
+func TestRegistry_Unsupported(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(ast.LangGo, ast.FrameworkSDK, fakeFactory("main.go"))
+
+	prj := ast.NewPrj("test").AddModules(
+		ast.NewMod("go").SetLang(ast.LangGo).SetOutputDirectory("server"),
+		ast.NewMod("app").SetLang(ast.LangSwift).SetOutputDirectory("ios"),
+		ast.NewMod("lib").SetLang(ast.LangRust).SetOutputDirectory("lib"),
+	)
+
+	_, err := reg.Project(prj)
+	var unsupported *UnsupportedError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected UnsupportedError but got %v", err)
+	}
+
+	if len(unsupported.Mods) != 2 || !strings.Contains(err.Error(), "module 'app' (swift)") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRegistry_Conflict(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(ast.LangGo, ast.FrameworkSDK, fakeFactory("main.go"))
+
+	prj := ast.NewPrj("test").AddModules(
+		ast.NewMod("a").SetLang(ast.LangGo).SetOutputDirectory("server"),
+		ast.NewMod("b").SetLang(ast.LangGo).SetOutputDirectory("server"),
+	)
+
+	if _, err := reg.Project(prj); err == nil || !strings.Contains(err.Error(), "server/main.go") {
+		t.Fatalf("expected conflict error but got %v", err)
+	}
+}
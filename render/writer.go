@@ -59,3 +59,33 @@ func WithLineNumbers(text string) string {
 	}
 	return sb.String()
 }
+
+// WrapLine splits a line of text at white spaces, so that each resulting line is not longer than width runes.
+// Words which are longer than width are never broken.
+func WrapLine(line string, width int) []string {
+	var res []string
+	cur := &strings.Builder{}
+	curLen := 0
+	for _, word := range strings.Fields(line) {
+		wordLen := len([]rune(word))
+		if curLen > 0 && curLen+1+wordLen > width {
+			res = append(res, cur.String())
+			cur.Reset()
+			curLen = 0
+		}
+
+		if curLen > 0 {
+			cur.WriteString(" ")
+			curLen++
+		}
+
+		cur.WriteString(word)
+		curLen += wordLen
+	}
+
+	if curLen > 0 || len(res) == 0 {
+		res = append(res, cur.String())
+	}
+
+	return res
+}
@@ -0,0 +1,409 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"sort"
+	"strings"
+)
+
+const (
+	MimeTypeRust       = "text/x-rust"
+	MimeTypeDir        = "application/x-directory"
+	MimeTypeRustModule = "application/x-directory-rust-module"
+	MimeTypeRustCrate  = "application/x-directory-rust-crate"
+)
+
+// Options for the renderer.
+type Options struct {
+	// SkipBuildFiles disables the Cargo manifests, so that only the sources are emitted.
+	SkipBuildFiles bool
+}
+
+// Renderer provides a rust renderer.
+type Renderer struct {
+	opts      Options
+	root      ast.Node
+	modules   map[string]*module     // modules by package path of all Rust crates
+	importers map[ast.Node]*importer // each *ast.File
+}
+
+// A module is the Rust module of a package within its crate.
+type module struct {
+	crate string   // the crate name, e.g. tickets
+	path  []string // the module path within the crate, e.g. [domain], which is empty for the crate root
+	mod   *ast.Mod // the module, which declares the package and becomes the crate
+}
+
+// qualifier returns the absolute path of the module, as seen from the given crate, e.g. crate::domain.
+func (m *module) qualifier(fromCrate string) string {
+	root := m.crate
+	if root == fromCrate {
+		root = "crate"
+	}
+
+	return strings.Join(append([]string{root}, m.path...), "::")
+}
+
+// NewRenderer creates a new Renderer instance.
+func NewRenderer(opts Options) *Renderer {
+	return &Renderer{opts: opts}
+}
+
+func init() {
+	render.Register(ast.LangRust, ast.FrameworkSDK, func() render.Renderer {
+		return NewRenderer(Options{})
+	})
+}
+
+// tearUp prepares the ast to be used for source generation.
+func (r *Renderer) tearUp(node ast.Node) error {
+	r.root = ast.Root(node)
+
+	if err := r.installModules(); err != nil {
+		return err
+	}
+
+	if err := installImporter(r); err != nil {
+		return fmt.Errorf("unable to install importer: %w", err)
+	}
+
+	return nil
+}
+
+// tearDown frees allocated resources.
+func (r *Renderer) tearDown() error {
+	if err := uninstallImporter(r); err != nil {
+		return fmt.Errorf("unable to uninstall importer: %w", err)
+	}
+
+	r.modules = nil
+
+	return nil
+}
+
+// installModules declares a module for each package of all Rust crates. The crate names must be unique within the
+// project, because crates refer to each other by name, and the module paths must be unique within their crate.
+func (r *Renderer) installModules() error {
+	r.modules = map[string]*module{}
+	crates := map[string]string{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang != ast.LangRust {
+			return nil
+		}
+
+		name := crateName(mod)
+		switch name {
+		case "std", "core", "alloc", "proc_macro", "test":
+			return fmt.Errorf("module '%s' cannot declare the crate '%s' of the standard library", mod.Name, name)
+		}
+
+		if other, ok := crates[name]; ok {
+			return fmt.Errorf("modules '%s' and '%s' declare the same crate '%s'", other, mod.Name, name)
+		}
+
+		crates[name] = mod.Name
+		paths := map[string]string{}
+		for _, pkg := range mod.Pkgs {
+			path, err := modulePath(mod, pkg)
+			if err != nil {
+				return err
+			}
+
+			key := strings.Join(path, "::")
+			if other, ok := paths[key]; ok {
+				return fmt.Errorf("packages '%s' and '%s' of module '%s' declare the same module '%s'", other, pkg.Path, mod.Name, key)
+			}
+
+			paths[key] = pkg.Path
+			r.modules[pkg.Path] = &module{crate: name, path: path, mod: mod}
+		}
+
+		return nil
+	})
+}
+
+// crateName returns the name of the crate, which is the last segment of the module name, e.g. tickets for
+// example.com/tickets or com.example.tickets.
+func crateName(mod *ast.Mod) string {
+	return naming.Rust.Snake(mod.Name[strings.LastIndexAny(mod.Name, "./:")+1:])
+}
+
+// modulePath returns the module path of the package relative to its module, e.g. [domain] for the package
+// example.com/tickets/domain of the module example.com/tickets. The package of the module itself is the crate
+// root.
+func modulePath(mod *ast.Mod, pkg *ast.Pkg) ([]string, error) {
+	if pkg.Path == mod.Name {
+		return nil, nil
+	}
+
+	rest := strings.TrimPrefix(pkg.Path, mod.Name)
+	if rest == pkg.Path || rest[0] != '/' && rest[0] != '.' {
+		return nil, fmt.Errorf("package '%s' is not contained in module '%s'", pkg.Path, mod.Name)
+	}
+
+	var res []string
+	for _, segment := range strings.FieldsFunc(rest, func(r rune) bool { return r == '/' || r == '.' }) {
+		res = append(res, moduleName(segment))
+	}
+
+	return res, nil
+}
+
+// moduleName returns the escaped snake case name of a module, e.g. order_items.
+func moduleName(s string) string {
+	return naming.Rust.Escape(naming.Rust.Snake(s))
+}
+
+// fileModuleName returns the name of the module, which is declared by the file, e.g. ticket for Ticket.rs.
+func fileModuleName(file *ast.File) string {
+	return moduleName(strings.TrimSuffix(file.Name, ".rs"))
+}
+
+// importer resolves the current importer from the parents file.
+func (r *Renderer) importer(n ast.Node) *importer {
+	return importerFromTree(r, n)
+}
+
+// Render converts the given node into a render.Artifact. A partial result is returned if an error is detected.
+// If node is an *ast.Mod, only that module is rendered and it must target ast.LangRust. Otherwise all Rust
+// modules of the project are rendered and other modules are ignored. Use render.Project to render mixed projects.
+func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
+	if mod, ok := node.(*ast.Mod); ok && mod.Target.Lang != ast.LangRust {
+		return nil, fmt.Errorf("cannot render module '%s': expected language '%s' but got '%s'", mod.Name, ast.LangRust, mod.Target.Lang)
+	}
+
+	if err := r.tearUp(node); err != nil {
+		return nil, fmt.Errorf("unable to tearUp: %w", err)
+	}
+
+	defer func() {
+		if e := r.tearDown(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	root := &render.Dir{}
+	if mod, ok := node.(*ast.Mod); ok {
+		_, err = r.renderMod(mod, root)
+		return root, err
+	}
+
+	err = ast.ForEachMod(node, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangRust {
+			if _, err := r.renderMod(mod, root); err != nil {
+				return fmt.Errorf("cannot render module '%s': %w", mod.Name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return root, fmt.Errorf("cannot render project: %w", err)
+	}
+
+	return root, nil
+}
+
+// renderMod emits the module hierarchy of the crate into the SourceDir of the modules output directory. Each
+// package becomes a directory with a mod.rs, or the lib.rs for the crate root, which declares the child modules.
+// Each file becomes a private child module, whose items are re-exported, so that the items of a package are
+// referred to just like in Go, e.g. crate::domain::Ticket. The manifest is emitted last, because the dependencies
+// of the crate are only known after rendering the sources.
+func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, error) {
+	modDir := r.ensureDir(mod.Target.Out, parent)
+	modDir.MimeType = MimeTypeRustCrate
+
+	var firstErr error
+	srcDir := r.ensureDir(SourceDir, modDir)
+	tree := newModuleTree(mod, r.modules)
+	tree.walk(func(node *moduleNode) {
+		dir := srcDir
+		if len(node.path) > 0 {
+			dir = r.ensureDir(strings.Join(node.path, "/"), srcDir)
+			dir.MimeType = MimeTypeRustModule
+		}
+
+		files, err := r.renderModule(node)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render module '%s': %w", strings.Join(append([]string{"crate"}, node.path...), "::"), err)
+		}
+
+		dir.Files = append(dir.Files, files...)
+	})
+
+	if err := r.renderBuild(mod, parent, modDir); firstErr == nil && err != nil {
+		firstErr = fmt.Errorf("cannot render cargo manifest: %w", err)
+	}
+
+	return modDir, firstErr
+}
+
+// A moduleNode is a module of the hierarchy of a crate, which either belongs to a package or is just the parent
+// of other modules.
+type moduleNode struct {
+	path     []string
+	pkg      *ast.Pkg // the package or nil
+	children map[string]*moduleNode
+}
+
+// newModuleTree creates the module hierarchy of the packages of the given module.
+func newModuleTree(mod *ast.Mod, modules map[string]*module) *moduleNode {
+	root := &moduleNode{children: map[string]*moduleNode{}}
+	for _, pkg := range mod.Pkgs {
+		node := root
+		for i, name := range modules[pkg.Path].path {
+			child, ok := node.children[name]
+			if !ok {
+				child = &moduleNode{path: modules[pkg.Path].path[:i+1], children: map[string]*moduleNode{}}
+				node.children[name] = child
+			}
+
+			node = child
+		}
+
+		node.pkg = pkg
+	}
+
+	return root
+}
+
+// sortedChildren returns the names of the child modules in alphabetical order.
+func (n *moduleNode) sortedChildren() []string {
+	var res []string
+	for name := range n.children {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// walk visits the node and all its descendants in depth-first order.
+func (n *moduleNode) walk(f func(node *moduleNode)) {
+	f(n)
+	for _, name := range n.sortedChildren() {
+		n.children[name].walk(f)
+	}
+}
+
+// renderModule emits the module file, which is documented by the package comment and declares the child modules,
+// followed by the files of the package.
+func (r *Renderer) renderModule(node *moduleNode) ([]*render.File, error) {
+	fileName := "mod.rs"
+	if len(node.path) == 0 {
+		fileName = "lib.rs"
+	}
+
+	w := &render.BufferedWriter{}
+	if node.pkg != nil {
+		if node.pkg.Preamble != nil {
+			writeLineComment(w, node.pkg.Preamble)
+			w.Printf("\n")
+		}
+
+		if node.pkg.Comment() != nil {
+			writeInnerDoc(w, node.pkg.Comment().Text)
+			w.Printf("\n")
+		}
+	}
+
+	for _, name := range node.sortedChildren() {
+		w.Printf("pub mod %s;\n", name)
+	}
+
+	var res []*render.File
+	var firstErr error
+	if node.pkg != nil {
+		if len(node.children) > 0 && len(node.pkg.PkgFiles) > 0 {
+			w.Printf("\n")
+		}
+
+		declared := map[string]bool{}
+		for _, file := range node.pkg.PkgFiles {
+			name := fileModuleName(file)
+			if _, ok := node.children[name]; ok || declared[name] || name == "mod" || name == "lib" {
+				return nil, fmt.Errorf("file '%s' declares the module '%s', which is already declared", file.Name, name)
+			}
+
+			declared[name] = true
+			w.Printf("mod %s;\npub use self::%s::*;\n", name, name)
+		}
+
+		files, err := r.renderPkg(node.pkg)
+		if err != nil {
+			firstErr = err
+		}
+
+		res = files
+	}
+
+	buf, err := Format(w.Bytes())
+	if firstErr == nil && err != nil {
+		firstErr = fmt.Errorf("cannot render module file: %w", err)
+	}
+
+	return append([]*render.File{{
+		FileName: fileName,
+		MimeType: MimeTypeRust,
+		Buf:      buf,
+		Error:    err,
+	}}, res...), firstErr
+}
+
+// renderPkg emits the files of the package, each named after its module.
+func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
+	var res []*render.File
+	var firstErr error
+
+	for _, file := range pkg.PkgFiles {
+		buf, err := r.renderFile(file)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render file '%s': %w", file.Name, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: fileModuleName(file) + ".rs",
+			MimeType: MimeTypeRust,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	for _, file := range pkg.RawFiles {
+		buf, err := file.Data(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render raw file: %w", err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: file.MimeType,
+			Buf:      buf,
+		})
+	}
+
+	return res, firstErr
+}
+
+// ensureDir appends for each path segment a directory, if required. Returns the directory denoting
+// the last segment.
+func (r *Renderer) ensureDir(restPath string, parent *render.Dir) *render.Dir {
+	names := strings.Split(restPath, "/")
+
+	dir := parent.Directory(names[0])
+	if dir == nil {
+		dir = &render.Dir{DirName: names[0], MimeType: MimeTypeDir}
+		parent.Dirs = append(parent.Dirs, dir)
+	}
+
+	if len(names) == 1 {
+		return dir
+	}
+
+	return r.ensureDir(strings.Join(names[1:], "/"), dir)
+}
@@ -0,0 +1,206 @@
+package rust
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"strconv"
+	"strings"
+)
+
+// docWidth is the width at which the lines of a documentation comment are wrapped, including the comment prefix.
+const docWidth = 100
+
+const (
+	// outerDocPrefix starts each line of the documentation of the following item.
+	outerDocPrefix = "/// "
+
+	// innerDocPrefix starts each line of the documentation of the enclosing module.
+	innerDocPrefix = "//! "
+)
+
+// formatComment replaces a '...' prefix with the ellipsisName and emits the structured content of the doc as
+// rustdoc markdown: paragraphs are separated by an empty line, lists use the markdown syntax and doc links are
+// emitted as intra-doc links. Code blocks are marked as text, because rustdoc would compile them as doc tests
+// otherwise. The block tags @param, @return and @throws are emitted at the end in the conventional sections
+// Arguments, Returns and Errors. Lines are prefixed with a '/// ' and wrapped at docWidth.
+func formatComment(ellipsisName, doc string) string {
+	return formatDoc(outerDocPrefix, deEllipsis(ellipsisName, strings.TrimSpace(doc)))
+}
+
+// writeInnerDoc emits the documentation of the module, which is declared by the current file.
+func writeInnerDoc(w *render.BufferedWriter, doc string) {
+	if text := formatDoc(innerDocPrefix, strings.TrimSpace(doc)); text != "" {
+		w.Print(text)
+		w.Printf("\n")
+	}
+}
+
+// formatDoc emits the doc with the given line prefix.
+func formatDoc(prefix, doc string) string {
+	doc = strings.TrimLeft(strings.TrimRight(doc, " \t\n"), "\n")
+	if strings.TrimSpace(doc) == "" {
+		return ""
+	}
+
+	description, tags := splitTags(doc)
+	emptyLine := strings.TrimSpace(prefix) + "\n"
+
+	tmp := &strings.Builder{}
+	first := true
+	for _, block := range ast.ParseDoc(description) {
+		if !first {
+			tmp.WriteString(emptyLine)
+		}
+
+		switch block.Kind {
+		case ast.DocParagraph:
+			writeDocLines(tmp, prefix, "", "", markupText(block.Text))
+		case ast.DocDeprecated:
+			// the actual marker is the deprecated attribute
+			writeDocLines(tmp, prefix, "", "", ast.DeprecatedPrefix+" "+markupText(block.Text))
+		case ast.DocList:
+			for i, item := range block.Items {
+				marker := "* "
+				if block.Numbered {
+					marker = strconv.Itoa(i+1) + ". "
+				}
+
+				writeDocLines(tmp, prefix, marker, strings.Repeat(" ", len(marker)), markupText(item))
+			}
+		case ast.DocCode:
+			tmp.WriteString(prefix + "```text\n")
+			for _, line := range strings.Split(block.Text, "\n") {
+				tmp.WriteString(strings.TrimRight(prefix+line, " "))
+				tmp.WriteString("\n")
+			}
+
+			tmp.WriteString(prefix + "```\n")
+		}
+
+		first = false
+	}
+
+	for _, section := range sections(tags) {
+		if !first {
+			tmp.WriteString(emptyLine)
+		}
+
+		if section.title != "" {
+			tmp.WriteString(prefix + "# " + section.title + "\n")
+			tmp.WriteString(emptyLine)
+		}
+
+		for _, item := range section.items {
+			writeDocLines(tmp, prefix, "", "", markupText(item))
+		}
+
+		first = false
+	}
+
+	return strings.TrimRight(tmp.String(), "\n")
+}
+
+// splitTags separates the description from the block tags, which start at the first line beginning with an @.
+func splitTags(doc string) (string, []string) {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range lines[i:] {
+			switch {
+			case strings.TrimSpace(tag) == "":
+			case strings.HasPrefix(tag, "@") || len(tags) == 0:
+				tags = append(tags, strings.TrimSpace(tag))
+			default:
+				tags[len(tags)-1] += "\n" + strings.TrimSpace(tag)
+			}
+		}
+
+		return strings.Join(lines[:i], "\n"), tags
+	}
+
+	return doc, nil
+}
+
+// A docSection is a headed part of the documentation, e.g. # Arguments. A section without title has no heading.
+type docSection struct {
+	title string
+	items []string
+}
+
+// sections groups the block tags into the conventional sections. A parameter becomes a list item, e.g. @param id
+// is the key becomes * `id` - is the key. Unknown tags are kept in front of the sections without a heading.
+func sections(tags []string) []docSection {
+	var args, returns, errors, others []string
+	for _, tag := range tags {
+		name, text := tag, ""
+		if idx := strings.IndexAny(tag, " \n"); idx >= 0 {
+			name, text = tag[:idx], strings.TrimSpace(tag[idx+1:])
+		}
+
+		switch name {
+		case "@param":
+			param, rest := text, ""
+			if idx := strings.IndexAny(text, " \n"); idx >= 0 {
+				param, rest = text[:idx], strings.TrimSpace(text[idx+1:])
+			}
+
+			args = append(args, "* `"+param+"` - "+rest)
+		case "@return":
+			returns = append(returns, text)
+		case "@throws":
+			errors = append(errors, text)
+		default:
+			others = append(others, strings.TrimPrefix(tag, "@"))
+		}
+	}
+
+	var res []docSection
+	for _, section := range []docSection{{"", others}, {"Arguments", args}, {"Returns", returns}, {"Errors", errors}} {
+		if len(section.items) > 0 {
+			res = append(res, section)
+		}
+	}
+
+	return res
+}
+
+// writeDocLines wraps each line of text and emits it with the first marker, all following lines are indented.
+func writeDocLines(w *strings.Builder, prefix, first, indent, text string) {
+	marker := first
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range render.WrapLine(line, docWidth-len(prefix)-len(marker)) {
+			w.WriteString(strings.TrimRight(prefix+marker+wrapped, " "))
+			w.WriteString("\n")
+			marker = indent
+		}
+	}
+}
+
+// markupText emits the doc links of the text as intra-doc links, e.g. [`Ticket`], which are resolved by rustdoc
+// within the scope of the module, so a qualified link keeps only its identifier.
+func markupText(text string) string {
+	sb := &strings.Builder{}
+	for _, span := range ast.DocSpans(text) {
+		if span.Link == "" {
+			sb.WriteString(span.Text)
+			continue
+		}
+
+		sb.WriteString("[`" + span.Link.Identifier() + "`]")
+	}
+
+	return sb.String()
+}
+
+// deEllipsis replaces a '...' prefix of the doc with the given name.
+func deEllipsis(ellipsisName, doc string) string {
+	if strings.HasPrefix(doc, "...") {
+		return ellipsisName + " " + strings.TrimSpace(doc[3:])
+	}
+
+	return doc
+}
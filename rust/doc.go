@@ -0,0 +1,4 @@
+// Package rust provides a renderer for Rust source code and Cargo manifests. Structs become structs with derived
+// traits, interfaces become traits, pointers become options and functions which return an error return a Result.
+// Each module is a crate, whose packages become a module hierarchy.
+package rust
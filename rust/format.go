@@ -0,0 +1,28 @@
+package rust
+
+import "github.com/golangee/src/render"
+
+// format follows the indentation of rustfmt. Block comments are allowed to nest in Rust and a single quote, which
+// does not start a character literal, denotes a lifetime, e.g. 'static.
+var format = render.BraceFormat{
+	Indent:         "    ",
+	Quote:          isQuote,
+	NestedComments: true,
+}
+
+// Format pretty prints Rust source, see render.BraceFormat.Format.
+func Format(source []byte) ([]byte, error) {
+	return format.Format(source)
+}
+
+// isQuote returns true, if the rune at index i starts a string or a character literal.
+func isQuote(line []rune, i int) bool {
+	switch {
+	case line[i] == '"':
+		return true
+	case line[i] == '\'':
+		return i+1 < len(line) && line[i+1] == '\\' || i+2 < len(line) && line[i+2] == '\''
+	default:
+		return false
+	}
+}
@@ -0,0 +1,43 @@
+package rust
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	src := `
+use std::fmt;
+
+
+impl fmt::Display for Status {
+fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+match self {
+Status::Open => write!(f, "{{"),
+Status::Quote => write!(f, "{}", '{'),
+
+}
+}
+}
+`
+
+	want := `use std::fmt;
+
+impl fmt::Display for Status {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        match self {
+            Status::Open => write!(f, "{{"),
+            Status::Quote => write!(f, "{}", '{'),
+        }
+    }
+}
+`
+
+	buf, err := Format([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+}
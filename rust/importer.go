@@ -0,0 +1,178 @@
+package rust
+
+import (
+	"github.com/golangee/src/ast"
+	"sort"
+	"strings"
+)
+
+// importer manages the use declarations at the files top. The qualifier of a name is either the path of a
+// package, which is resolved to the path of its module, e.g. crate::domain, or a Rust path, like std::collections.
+// Primitive and prelude types, like String or Vec, have no qualifier and are always in scope. Each file is a child
+// module of its package, so the types of the other files are used from the parent module, e.g. super::Ticket.
+// Simple names are unique in the scope, so a colliding name is referred to by its absolute path at the use site.
+type importer struct {
+	pkg                string              // the package path of the scope
+	crate              string              // the crate of the scope, e.g. tickets
+	modules            map[string]*module  // the modules of all Rust packages
+	identifiersInScope map[string]ast.Name // simple type names and their qualified names
+	siblings           map[string]bool     // the simple type names, which are declared by the other files of the package
+	uses               map[string]bool     // the paths, which require a use declaration, e.g. std::fmt
+	crates             map[string]bool     // the referred crates, except the own and the standard library
+}
+
+// newImporter allocates an importer for a file of the given package. The declared identifiers are the simple
+// names of the files types, which always win against any use declaration. The siblings are the simple names of
+// the types of the other files, which are used from the parent module, if referred to without a qualifier.
+func newImporter(pkg string, modules map[string]*module, declared, siblings []string) *importer {
+	imp := &importer{
+		pkg:                pkg,
+		crate:              modules[pkg].crate,
+		modules:            modules,
+		identifiersInScope: map[string]ast.Name{},
+		siblings:           map[string]bool{},
+		uses:               map[string]bool{},
+		crates:             map[string]bool{},
+	}
+
+	for _, id := range preludeTypes {
+		imp.identifiersInScope[id] = ast.Name(id)
+	}
+
+	for _, id := range declared {
+		imp.identifiersInScope[id] = ast.Name(pkg + "." + id)
+	}
+
+	for _, id := range siblings {
+		imp.siblings[id] = true
+	}
+
+	return imp
+}
+
+// installImporter allocates a new importer instance for every ast.File of the Rust crates. The importers are owned
+// by the renderer and never attached to the ast.
+func installImporter(r *Renderer) error {
+	r.importers = map[ast.Node]*importer{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang != ast.LangRust {
+			return nil
+		}
+
+		for _, pkg := range mod.Pkgs {
+			declared := map[*ast.File][]string{}
+			for _, file := range pkg.PkgFiles {
+				for _, namedType := range file.Types() {
+					declared[file] = append(declared[file], namedType.Identifier())
+				}
+			}
+
+			for _, file := range pkg.PkgFiles {
+				var siblings []string
+				for _, other := range pkg.PkgFiles {
+					if other != file {
+						siblings = append(siblings, declared[other]...)
+					}
+				}
+
+				r.importers[file] = newImporter(pkg.Path, r.modules, declared[file], siblings)
+			}
+		}
+
+		return nil
+	})
+}
+
+// uninstallImporter releases all importers.
+func uninstallImporter(r *Renderer) error {
+	r.importers = nil
+	return nil
+}
+
+// importerFromTree walks up the tree until it finds the first file with an importer.
+func importerFromTree(r *Renderer, n ast.Node) *importer {
+	root := n
+	for root != nil {
+		if imp, ok := r.importers[root]; ok {
+			return imp
+		}
+
+		newRoot := root.Parent()
+		if newRoot == nil {
+			panic("no attached importer found in ast scope")
+		}
+
+		root = newRoot
+	}
+
+	panic("invalid node")
+}
+
+// sortedUses returns the paths of the use declarations in alphabetical order.
+func (p *importer) sortedUses() []string {
+	var sorted []string
+	for path := range p.uses {
+		sorted = append(sorted, path)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// pathOf returns the Rust path of the qualifier, which is either the module of a package or the qualifier itself.
+func (p *importer) pathOf(qualifier string) string {
+	if qualifier == p.pkg {
+		return "super"
+	}
+
+	if m, ok := p.modules[qualifier]; ok {
+		return m.qualifier(p.crate)
+	}
+
+	return qualifier
+}
+
+// refer records the crate of the path, if it is neither the own nor one of the standard library.
+func (p *importer) refer(path string) {
+	root := strings.Split(path, "::")[0]
+	switch root {
+	case "crate", "self", "super", "std", "core", "alloc":
+	default:
+		p.crates[root] = true
+	}
+}
+
+// shortify returns a name, which is only valid in the importers scope and declares its use. If a collision has
+// been detected, the absolute path is returned instead, e.g. std::fmt::Result. If the name is not complete, the
+// original name is just returned.
+func (p *importer) shortify(name ast.Name) ast.Name {
+	qual := name.Qualifier()
+	id := name.Identifier()
+	if qual == "" && p.siblings[id] {
+		qual = p.pkg
+		name = ast.Name(qual + "." + id)
+	}
+
+	if id == "" || qual == "" {
+		return name
+	}
+
+	path := p.pathOf(qual)
+	p.refer(path)
+
+	otherName, inScope := p.identifiersInScope[id]
+	if inScope {
+		if otherName == name {
+			return ast.Name(id)
+		}
+
+		// name collision, e.g. std::fmt::Result and the prelude Result
+		return ast.Name(path + "::" + id)
+	}
+
+	p.identifiersInScope[id] = name
+	p.uses[path+"::"+id] = true
+
+	return ast.Name(id)
+}
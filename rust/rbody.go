@@ -0,0 +1,867 @@
+package rust
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// writeLineComment emits the comment as a // line comment, which is used within function bodies.
+func writeLineComment(w *render.BufferedWriter, comment *ast.Comment) {
+	if comment == nil || strings.TrimSpace(comment.Text) == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(comment.Text), "\n") {
+		w.Print("// " + strings.TrimRight(line, " \t") + "\n")
+	}
+}
+
+// renderBlock emits a block and all contained statements.
+func (r *Renderer) renderBlock(node *ast.Block, w *render.BufferedWriter) error {
+	writeLineComment(w, node.ObjComment)
+	w.Printf("{\n")
+	if err := r.renderStmts(node.Nodes, w); err != nil {
+		return err
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderStmts emits each node as a statement.
+func (r *Renderer) renderStmts(nodes []ast.Node, w *render.BufferedWriter) error {
+	for _, n := range nodes {
+		if err := r.renderStmt(n, w); err != nil {
+			return fmt.Errorf("unable to render node in block: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderStmt emits a node in statement position. Expressions are terminated by a semicolon, but blocks and
+// control flow statements are not. Templates and macros are responsible for their own terminators.
+func (r *Renderer) renderStmt(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Macro:
+		writeLineComment(w, n.Comment())
+		for _, child := range n.Children() {
+			if err := r.renderStmt(child, w); err != nil {
+				return fmt.Errorf("unable to render dynamic macro node: %w", err)
+			}
+		}
+
+		return nil
+	case *ast.Sym:
+		// each statement is terminated anyway
+		if n.Kind == ast.SymTermStmt {
+			return nil
+		}
+
+		return r.renderNode(n, w)
+	case *ast.Block, *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.ReturnStmt:
+		return r.renderNode(n, w)
+	case *ast.Tpl:
+		tmp := &render.BufferedWriter{}
+		if err := r.renderTpl(n, tmp); err != nil {
+			return err
+		}
+
+		w.Print(strings.TrimSpace(tmp.String()))
+		w.Printf("\n")
+
+		return nil
+	case *ast.Assign:
+		writeLineComment(w, n.ObjComment)
+	}
+
+	if err := r.renderNode(node, w); err != nil {
+		return err
+	}
+
+	w.Printf(";\n")
+
+	return nil
+}
+
+// renderAssign emits an assignment. A definition declares an immutable binding, which becomes mutable, if it is
+// assigned again within the enclosing function. Multiple identifiers are destructured as tuple.
+func (r *Renderer) renderAssign(node *ast.Assign, w *render.BufferedWriter) error {
+	if node.Kind == ast.AssignDefine {
+		if isReassigned(node) {
+			w.Printf("let mut ")
+		} else {
+			w.Printf("let ")
+		}
+	}
+
+	if err := r.renderTupleExpr(node.Lhs, w); err != nil {
+		return fmt.Errorf("unable to render lhs: %w", err)
+	}
+
+	switch node.Kind {
+	case ast.AssignSimple, ast.AssignDefine:
+		w.Printf(" = ")
+	case ast.AssignAdd:
+		w.Printf(" += ")
+	case ast.AssignSub:
+		w.Printf(" -= ")
+	case ast.AssignMul:
+		w.Printf(" *= ")
+	case ast.AssignRem:
+		w.Print(" %= ")
+	default:
+		return fmt.Errorf("assignment not implemented: %d", node.Kind)
+	}
+
+	if err := r.renderTupleExpr(node.Rhs, w); err != nil {
+		return fmt.Errorf("unable to render rhs: %w", err)
+	}
+
+	return nil
+}
+
+// renderTupleExpr emits a single expression as is and all others as a tuple, e.g. (a, b).
+func (r *Renderer) renderTupleExpr(exprs []ast.Expr, w *render.BufferedWriter) error {
+	if len(exprs) == 1 {
+		return r.renderNode(exprs[0], w)
+	}
+
+	w.Printf("(")
+	for i, expr := range exprs {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if err := r.renderNode(expr, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// isReassigned returns true, if any identifier defined by the assignment is the target of another assignment
+// or of an increment or decrement within the enclosing function.
+func isReassigned(def *ast.Assign) bool {
+	names := map[string]bool{}
+	for _, lhs := range def.Lhs {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		names[ident.Name] = true
+	}
+
+	var scope ast.Node = def
+	fun := &ast.Func{}
+	if ast.ParentAs(def, &fun) {
+		scope = fun
+	}
+
+	found := false
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		switch t := node.(type) {
+		case *ast.Assign:
+			if t != def && t.Kind != ast.AssignDefine {
+				for _, lhs := range t.Lhs {
+					if other, ok := lhs.(*ast.Ident); ok && names[other.Name] {
+						found = true
+					}
+				}
+			}
+		case *ast.UnaryExpr:
+			if t.Op == ast.OpInc || t.Op == ast.OpDec {
+				if other, ok := t.X.(*ast.Ident); ok && names[other.Name] {
+					found = true
+				}
+			}
+		}
+
+		if p, ok := node.(ast.Parent); ok && !found {
+			for _, child := range p.Children() {
+				walk(child)
+			}
+		}
+	}
+
+	walk(scope)
+
+	return found
+}
+
+// renderIfStmt emits an if statement. Rust has no init statement, so it is declared in front and both are
+// wrapped into a block, to keep the scope.
+func (r *Renderer) renderIfStmt(node *ast.IfStmt, w *render.BufferedWriter) error {
+	if node.Init != nil {
+		w.Printf("{\n")
+		if err := r.renderStmt(node.Init, w); err != nil {
+			return fmt.Errorf("unable to render init: %w", err)
+		}
+	}
+
+	w.Printf("if ")
+	if err := r.renderNode(node.Cond, w); err != nil {
+		return fmt.Errorf("unable to render cond: %w", err)
+	}
+
+	w.Printf(" ")
+	body := &render.BufferedWriter{}
+	if err := r.renderNode(node.Body, body); err != nil {
+		return fmt.Errorf("unable to render body: %w", err)
+	}
+
+	if node.Else == nil {
+		w.Print(body.String())
+	} else {
+		// the else keyword continues the line of the closing brace
+		w.Print(strings.TrimRight(body.String(), "\n"))
+		w.Printf(" else ")
+		if err := r.renderNode(node.Else, w); err != nil {
+			return fmt.Errorf("unable to render else: %w", err)
+		}
+	}
+
+	if node.Init != nil {
+		w.Printf("}\n")
+	}
+
+	return nil
+}
+
+// renderForStmt emits a while loop or a loop without condition. Rust has no three-clause for loop, so the init
+// statement is declared in front of the loop and the post statement is appended to the body. Both are wrapped
+// into a block, to keep the scope.
+func (r *Renderer) renderForStmt(node *ast.ForStmt, w *render.BufferedWriter) error {
+	if node.Init != nil {
+		w.Printf("{\n")
+		if err := r.renderStmt(node.Init, w); err != nil {
+			return fmt.Errorf("unable to render init: %w", err)
+		}
+	}
+
+	if node.Cond == nil {
+		w.Printf("loop ")
+	} else {
+		w.Printf("while ")
+		if err := r.renderNode(node.Cond, w); err != nil {
+			return fmt.Errorf("unable to render cond: %w", err)
+		}
+
+		w.Printf(" ")
+	}
+
+	if node.Post == nil {
+		if err := r.renderNode(node.Body, w); err != nil {
+			return fmt.Errorf("unable to render body: %w", err)
+		}
+	} else {
+		writeLineComment(w, node.Body.ObjComment)
+		w.Printf("{\n")
+		if err := r.renderStmts(node.Body.Nodes, w); err != nil {
+			return fmt.Errorf("unable to render body: %w", err)
+		}
+
+		if err := r.renderStmt(node.Post, w); err != nil {
+			return fmt.Errorf("unable to render post: %w", err)
+		}
+
+		w.Printf("}\n")
+	}
+
+	if node.Init != nil {
+		w.Printf("}\n")
+	}
+
+	return nil
+}
+
+// renderRangeStmt emits a for loop over a borrowed collection. A key and a value are destructured, e.g. the
+// entries of a map.
+func (r *Renderer) renderRangeStmt(node *ast.RangeStmt, w *render.BufferedWriter) error {
+	w.Printf("for ")
+	switch {
+	case node.Key != nil && node.Val != nil:
+		w.Printf("(")
+		if err := r.renderNode(node.Key, w); err != nil {
+			return fmt.Errorf("unable to render key: %w", err)
+		}
+
+		w.Printf(", ")
+		if err := r.renderNode(node.Val, w); err != nil {
+			return fmt.Errorf("unable to render val: %w", err)
+		}
+
+		w.Printf(")")
+	case node.Key != nil:
+		return fmt.Errorf("rust cannot range over keys, declare the value or both")
+	case node.Val != nil:
+		if err := r.renderNode(node.Val, w); err != nil {
+			return fmt.Errorf("unable to render val: %w", err)
+		}
+	default:
+		w.Printf("_")
+	}
+
+	w.Printf(" in &")
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render range target: %w", err)
+	}
+
+	w.Printf(" ")
+
+	return r.renderNode(node.Body, w)
+}
+
+// renderReturnStmt emits a return statement. If the enclosing function returns a Result, the values are wrapped
+// into Ok, if the error is nil, otherwise the error is converted into the declared error type and wrapped into
+// Err.
+func (r *Renderer) renderReturnStmt(node *ast.ReturnStmt, w *render.BufferedWriter) error {
+	fun := &ast.Func{}
+	var errParam *ast.Param
+	if ast.ParentAs(node, &fun) {
+		_, errParam = splitResults(fun.Results())
+	}
+
+	w.Printf("return")
+	if errParam == nil || len(node.Results) == 0 {
+		if len(node.Results) > 0 {
+			w.Printf(" ")
+			if err := r.renderTupleExpr(node.Results, w); err != nil {
+				return fmt.Errorf("unable to render result: %w", err)
+			}
+		}
+
+		w.Printf(";\n")
+
+		return nil
+	}
+
+	values := node.Results[:len(node.Results)-1]
+	last := node.Results[len(node.Results)-1]
+	if ident, ok := last.(*ast.Ident); ok && ident.Name == "nil" {
+		w.Printf(" Ok(")
+		if len(values) == 0 {
+			w.Printf("()")
+		} else if err := r.renderTupleExpr(values, w); err != nil {
+			return fmt.Errorf("unable to render result: %w", err)
+		}
+	} else {
+		w.Printf(" Err(")
+		if err := r.renderNode(last, w); err != nil {
+			return fmt.Errorf("unable to render error: %w", err)
+		}
+
+		w.Printf(".into()")
+	}
+
+	w.Printf(");\n")
+
+	return nil
+}
+
+// renderCallExpr emits a function call. An array, which is passed as variadic arguments, is borrowed as slice.
+func (r *Renderer) renderCallExpr(node *ast.CallExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.Fun, w); err != nil {
+		return fmt.Errorf("cannot render function expression: %w", err)
+	}
+
+	w.Printf("(")
+	for i, n := range node.Args {
+		if node.Ellipsis && i == len(node.Args)-1 {
+			w.Printf("&")
+		}
+
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render argument: %w", err)
+		}
+
+		if i < len(node.Args)-1 {
+			w.Printf(", ")
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// renderSelExpr emits a X.Sel expression. By convention, a type is named in Pascal case, so the selector of a
+// type or of an imported name is a path, e.g. Status::Done, and everything else is a field or method access.
+func (r *Renderer) renderSelExpr(node *ast.SelExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render selector target: %w", err)
+	}
+
+	isPath := false
+	switch x := node.X.(type) {
+	case *ast.QualIdent:
+		isPath = true
+	case *ast.Ident:
+		isPath = x.Name != "" && unicode.IsUpper([]rune(x.Name)[0])
+	}
+
+	if isPath {
+		w.Printf("::")
+	} else {
+		w.Printf(".")
+	}
+
+	return r.renderIdent(node.Sel, w)
+}
+
+// renderIdent emits an identifier. The receiver this becomes self, nil becomes None and other keywords are
+// escaped, except those which are valid expressions.
+func (r *Renderer) renderIdent(node *ast.Ident, w *render.BufferedWriter) error {
+	switch node.Name {
+	case "this":
+		w.Printf("self")
+	case "nil":
+		w.Printf("None")
+	case "self", "Self", "super", "crate", "true", "false":
+		w.Printf(node.Name)
+	default:
+		if member := r.selfMember(node); member != "" {
+			w.Print(member)
+		} else {
+			w.Printf(naming.Rust.Escape(node.Name))
+		}
+	}
+
+	return nil
+}
+
+// selfMember returns the member expression of an identifier, which refers to a member of the enclosing struct or
+// trait, because Rust has no implicit self, e.g. self.size or self.size() for the property of a trait. Locals
+// and parameters shadow members, just like in Go. Otherwise, the empty string is returned.
+func (r *Renderer) selfMember(node *ast.Ident) string {
+	switch p := node.Parent().(type) {
+	case *ast.SelExpr:
+		if p.Sel == node {
+			return ""
+		}
+	case *ast.BinaryExpr:
+		if _, ok := p.Parent().(*ast.CompLit); ok && p.Op == ast.OpColon && p.X == node {
+			return ""
+		}
+	}
+
+	fun := &ast.Func{}
+	if !ast.ParentAs(node, &fun) || fun.Static() || isLocal(fun, node.Name) {
+		return ""
+	}
+
+	var fields, properties []string
+	var methods []*ast.Func
+	switch owner := fun.Parent().(type) {
+	case *ast.Struct:
+		for _, field := range owner.Fields() {
+			fields = append(fields, field.Identifier())
+		}
+
+		for _, property := range owner.Properties() {
+			fields = append(fields, property.Identifier())
+		}
+
+		methods = owner.Methods()
+	case *ast.Interface:
+		for _, property := range owner.Properties() {
+			properties = append(properties, property.Identifier())
+		}
+
+		methods = owner.Methods()
+	}
+
+	for _, name := range fields {
+		if name == node.Name {
+			return "self." + fieldName(name)
+		}
+	}
+
+	for _, name := range properties {
+		if name == node.Name {
+			// a trait has no fields, so the accessor is called instead
+			return "self." + fieldName(name) + "()"
+		}
+	}
+
+	for _, method := range methods {
+		if method.Identifier() == node.Name {
+			return "self." + fieldName(method.Identifier())
+		}
+	}
+
+	return ""
+}
+
+// isLocal returns true, if the name is a parameter of the func or is defined within its body.
+func isLocal(fun *ast.Func, name string) bool {
+	for _, param := range fun.Params() {
+		if param.Identifier() == name {
+			return true
+		}
+	}
+
+	found := false
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		var defined []ast.Node
+		switch t := node.(type) {
+		case *ast.Assign:
+			if t.Kind == ast.AssignDefine {
+				for _, lhs := range t.Lhs {
+					defined = append(defined, lhs)
+				}
+			}
+		case *ast.RangeStmt:
+			defined = append(defined, t.Key, t.Val)
+		}
+
+		for _, def := range defined {
+			if ident, ok := def.(*ast.Ident); ok && ident.Name == name {
+				found = true
+			}
+		}
+
+		if p, ok := node.(ast.Parent); ok && !found {
+			for _, child := range p.Children() {
+				walk(child)
+			}
+		}
+	}
+
+	if fun.Body() != nil {
+		walk(fun.Body())
+	}
+
+	return found
+}
+
+// renderQualIdent emits an imported name, e.g. HashMap for std::collections.HashMap.
+func (r *Renderer) renderQualIdent(node *ast.QualIdent, w *render.BufferedWriter) error {
+	w.Printf(string(r.importer(node).shortify(ast.Name(node.Qualifier))))
+
+	return nil
+}
+
+// renderBasicLit emits a literal. Go string literals become Rust string literals and rune literals become
+// character literals.
+func (r *Renderer) renderBasicLit(node *ast.BasicLit, w *render.BufferedWriter) error {
+	if strings.HasPrefix(node.Val, "'") {
+		v, _, _, err := strconv.UnquoteChar(strings.TrimSuffix(node.Val[1:], "'"), '\'')
+		if err != nil {
+			return fmt.Errorf("invalid rune literal %s: %w", node.Val, err)
+		}
+
+		if v == '\'' {
+			w.Printf(`'\''`)
+		} else {
+			w.Print("'" + strings.Trim(rustQuote(string(v)), `"`) + "'")
+		}
+
+		return nil
+	}
+
+	if strings.HasPrefix(node.Val, `"`) || strings.HasPrefix(node.Val, "`") {
+		s, err := strconv.Unquote(node.Val)
+		if err != nil {
+			return fmt.Errorf("invalid string literal %s: %w", node.Val, err)
+		}
+
+		w.Print(rustQuote(s))
+
+		return nil
+	}
+
+	w.Printf(node.Val)
+
+	return nil
+}
+
+// rustQuote returns a double quoted Rust string literal. Unicode escapes use braces.
+func rustQuote(s string) string {
+	sb := &strings.Builder{}
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case 0:
+			sb.WriteString(`\0`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u{%x}`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// renderCompLit emits a composite literal. A slice becomes a vec! macro, named elements (key: value) become a
+// struct expression, e.g. Ticket { id, title: x }, and positional elements become a tuple struct expression.
+func (r *Renderer) renderCompLit(node *ast.CompLit, w *render.BufferedWriter) error {
+	if node.Type == nil {
+		return fmt.Errorf("rust cannot create anonymous literals")
+	}
+
+	open, sep, end := "(", ", ", ")"
+	if _, ok := node.Type.(*ast.SliceTypeDecl); ok {
+		w.Printf("vec!")
+		open, end = "[", "]"
+	} else {
+		if decl, ok := node.Type.(ast.TypeDecl); ok {
+			if err := r.renderTypeDecl(decl, w); err != nil {
+				return err
+			}
+		} else if err := r.renderNode(node.Type, w); err != nil {
+			return fmt.Errorf("unable to render type: %w", err)
+		}
+
+		if len(node.Elements) > 0 {
+			if kv, ok := node.Elements[0].(*ast.BinaryExpr); ok && kv.Op == ast.OpColon {
+				open, end = " { ", " }"
+			}
+		} else {
+			open, end = " {", "}"
+		}
+	}
+
+	w.Printf(open)
+	for i, element := range node.Elements {
+		if kv, ok := element.(*ast.BinaryExpr); ok && kv.Op == ast.OpColon {
+			key, isKey := kv.X.(*ast.Ident)
+			value, isValue := kv.Y.(*ast.Ident)
+			if isKey && isValue && key.Name == value.Name {
+				// the field init shorthand
+				element = kv.X
+			}
+		}
+
+		if err := r.renderNode(element, w); err != nil {
+			return fmt.Errorf("unable to render composite elem: %w", err)
+		}
+
+		if i < len(node.Elements)-1 {
+			w.Printf(sep)
+		}
+	}
+
+	w.Printf(end)
+
+	return nil
+}
+
+// rustBinaryOperators maps the operators to their Rust counterparts.
+var rustBinaryOperators = map[ast.Operator]string{
+	ast.OpAdd: "+", ast.OpSub: "-", ast.OpMul: "*", ast.OpQuo: "/", ast.OpREM: "%",
+	ast.OpAnd: "&", ast.OpOr: "|", ast.OpXOR: "^", ast.OpShl: "<<", ast.OpShr: ">>",
+	ast.OpLAnd: "&&", ast.OpLOr: "||", ast.OpEqual: "==", ast.OpLess: "<", ast.OpGreater: ">",
+	ast.OpNotEqual: "!=", ast.OpLessEqual: "<=", ast.OpGreaterEqual: ">=",
+}
+
+// renderBinaryExpr emits a binary expression. The Go and not operator x &^ y becomes x & !(y) and a key value
+// pair becomes a field initializer, e.g. id: x.
+func (r *Renderer) renderBinaryExpr(node *ast.BinaryExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render x: %w", err)
+	}
+
+	switch node.Op {
+	case ast.OpAndNot:
+		w.Printf(" & !(")
+		if err := r.renderNode(node.Y, w); err != nil {
+			return fmt.Errorf("unable to render y: %w", err)
+		}
+
+		w.Printf(")")
+
+		return nil
+	case ast.OpColon:
+		w.Printf(": ")
+		return r.renderNode(node.Y, w)
+	}
+
+	op, ok := rustBinaryOperators[node.Op]
+	if !ok {
+		return fmt.Errorf("operator not supported by rust: %d", node.Op)
+	}
+
+	w.Printf(" " + op + " ")
+	if err := r.renderNode(node.Y, w); err != nil {
+		return fmt.Errorf("unable to render y: %w", err)
+	}
+
+	return nil
+}
+
+// renderUnaryExpr emits a unary expression. Taking the address borrows the value and the bitwise complement is
+// the not operator. Rust has no increment and decrement operators, so they become compound assignments.
+func (r *Renderer) renderUnaryExpr(node *ast.UnaryExpr, w *render.BufferedWriter) error {
+	switch node.Op {
+	case ast.OpAdd:
+	case ast.OpSub:
+		w.Printf("-")
+	case ast.OpNot, ast.OpXOR:
+		w.Printf("!")
+	case ast.OpAnd:
+		w.Printf("&")
+	case ast.OpMul:
+		w.Printf("*")
+	case ast.OpInc, ast.OpDec:
+	default:
+		return fmt.Errorf("operator not supported by rust: %d", node.Op)
+	}
+
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render x: %w", err)
+	}
+
+	switch node.Op {
+	case ast.OpInc:
+		w.Printf(" += 1")
+	case ast.OpDec:
+		w.Printf(" -= 1")
+	}
+
+	return nil
+}
+
+// renderMacro emits the evaluated nodes of a macro in expression position.
+func (r *Renderer) renderMacro(node *ast.Macro, w *render.BufferedWriter) error {
+	for _, n := range node.Children() {
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render dynamic macro node: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderSym emits a statement terminator or a line break.
+func (r *Renderer) renderSym(node *ast.Sym, w *render.BufferedWriter) error {
+	switch node.Kind {
+	case ast.SymTermStmt:
+		w.Printf(";")
+	case ast.SymNewline:
+		w.Printf("\n")
+	default:
+		return fmt.Errorf("unknown sym: %d", node.Kind)
+	}
+
+	return nil
+}
+
+// renderTpl executes and emits the template text.
+func (r *Renderer) renderTpl(node *ast.Tpl, w *render.BufferedWriter) error {
+	tmpl, err := template.New(node.ObjPos.String()).Parse(node.Template)
+	if err != nil {
+		return fmt.Errorf("cannot parse template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, &tplRenderContext{importer: r.importer(node), tpl: node}); err != nil {
+		return fmt.Errorf("cannot execute template: %w", err)
+	}
+
+	w.Print(buf.String())
+
+	return nil
+}
+
+// ensure that we always implement the full contract
+var _ ast.TplContext = (*tplRenderContext)(nil)
+
+type tplRenderContext struct {
+	importer *importer
+	tpl      *ast.Tpl
+}
+
+func (t *tplRenderContext) Get(key string) interface{} {
+	return t.tpl.Values[key]
+}
+
+func (t *tplRenderContext) Use(name string) string {
+	return string(t.importer.shortify(fromStdlib(ast.Name(name))))
+}
+
+func (t *tplRenderContext) Self() *ast.Tpl {
+	return t.tpl
+}
+
+// renderExpr dispatches the statements and expressions of function bodies. It returns false, if the node is not
+// a statement or an expression.
+func (r *Renderer) renderExpr(node ast.Node, w *render.BufferedWriter) (bool, error) {
+	var err error
+	switch n := node.(type) {
+	case *ast.Block:
+		err = r.renderBlock(n, w)
+	case *ast.Assign:
+		err = r.renderAssign(n, w)
+	case *ast.IfStmt:
+		err = r.renderIfStmt(n, w)
+	case *ast.ForStmt:
+		err = r.renderForStmt(n, w)
+	case *ast.RangeStmt:
+		err = r.renderRangeStmt(n, w)
+	case *ast.ReturnStmt:
+		err = r.renderReturnStmt(n, w)
+	case *ast.CallExpr:
+		err = r.renderCallExpr(n, w)
+	case *ast.SelExpr:
+		err = r.renderSelExpr(n, w)
+	case *ast.Ident:
+		err = r.renderIdent(n, w)
+	case *ast.QualIdent:
+		err = r.renderQualIdent(n, w)
+	case *ast.BasicLit:
+		err = r.renderBasicLit(n, w)
+	case *ast.CompLit:
+		err = r.renderCompLit(n, w)
+	case *ast.BinaryExpr:
+		err = r.renderBinaryExpr(n, w)
+	case *ast.UnaryExpr:
+		err = r.renderUnaryExpr(n, w)
+	case *ast.Macro:
+		err = r.renderMacro(n, w)
+	case *ast.Sym:
+		err = r.renderSym(n, w)
+	case *ast.Tpl:
+		err = r.renderTpl(n, w)
+	case ast.TypeDecl:
+		err = r.renderTypeDecl(n, w)
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("cannot render %s: %w", reflect.TypeOf(node).Elem().Name(), err)
+	}
+
+	return true, nil
+}
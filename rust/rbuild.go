@@ -0,0 +1,231 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// SourceDir is the standard directory of the crate sources, relative to the module.
+	SourceDir = "src"
+
+	// ManifestName is the name of the Cargo manifest.
+	ManifestName = "Cargo.toml"
+
+	// Edition is the Rust edition of the emitted crates.
+	Edition = "2021"
+
+	// defaultVersion is the version of a crate, just like cargo new declares it.
+	defaultVersion = "0.1.0"
+
+	// MimeTypeCargo is the mime type of a Cargo manifest.
+	MimeTypeCargo = "application/toml"
+)
+
+// A knownCrate is a dependency, which is used by the stdlib types or the derived traits, so it is declared
+// without a require directive.
+type knownCrate struct {
+	version string
+	serde   bool // serde is true, if the crate supports serde by its feature of the same name
+}
+
+var knownCrates = map[string]knownCrate{
+	"serde": {version: "1"},
+	"url":   {version: "2", serde: true},
+	"uuid":  {version: "1", serde: true},
+}
+
+// requiredDependencies parses the name of each required dependency line, e.g. serde_json for serde_json = "1".
+func requiredDependencies(mod *ast.Mod) (map[string]string, error) {
+	res := map[string]string{}
+	for _, dep := range mod.Target.Require.Cargo {
+		idx := strings.Index(dep, "=")
+		if idx <= 0 || strings.TrimSpace(dep[:idx]) == "" {
+			return nil, fmt.Errorf("invalid cargo dependency '%s': expected name = \"version\"", dep)
+		}
+
+		res[strings.TrimSpace(dep[:idx])] = strings.TrimSpace(dep)
+	}
+
+	return res, nil
+}
+
+// rustMods returns all Rust modules of the rendered project.
+func (r *Renderer) rustMods() []*ast.Mod {
+	var res []*ast.Mod
+	_ = ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangRust {
+			res = append(res, mod)
+		}
+
+		return nil
+	})
+
+	return res
+}
+
+// crateDependencies returns the sorted names of all crates, which are referred to by the files of the module.
+func (r *Renderer) crateDependencies(mod *ast.Mod) []string {
+	used := map[string]bool{}
+	for _, pkg := range mod.Pkgs {
+		for _, file := range pkg.PkgFiles {
+			for name := range r.importers[file].crates {
+				used[name] = true
+			}
+		}
+	}
+
+	var res []string
+	for name := range used {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// renderBuild emits the Cargo manifest of the module into modDir. A crate of the project is a path dependency,
+// so all crates require an output directory. Known crates, like serde or uuid, are declared automatically and
+// all other crates must be required by the module. If the project contains multiple Rust modules, a workspace
+// manifest is emitted into the root directory.
+func (r *Renderer) renderBuild(mod *ast.Mod, root, modDir *render.Dir) error {
+	if r.opts.SkipBuildFiles {
+		return nil
+	}
+
+	required, err := requiredDependencies(mod)
+	if err != nil {
+		return err
+	}
+
+	crates := map[string]*ast.Mod{}
+	for _, m := range r.rustMods() {
+		crates[crateName(m)] = m
+	}
+
+	deps := r.crateDependencies(mod)
+	usesSerde := false
+	for _, name := range deps {
+		usesSerde = usesSerde || name == "serde"
+	}
+
+	lines := map[string]string{}
+	for name, line := range required {
+		lines[name] = line
+	}
+
+	for _, name := range deps {
+		if _, ok := lines[name]; ok {
+			continue
+		}
+
+		if other, ok := crates[name]; ok {
+			if mod.Target.Out == "" || other.Target.Out == "" {
+				return fmt.Errorf("module '%s' depends on module '%s', which requires an output directory for both", mod.Name, other.Name)
+			}
+
+			lines[name] = name + " = { path = " + tomlQuote(relativePath(mod.Target.Out, other.Target.Out)) + " }"
+			continue
+		}
+
+		known, ok := knownCrates[name]
+		if !ok {
+			return fmt.Errorf("crate '%s' is used but not required by module '%s'", name, mod.Name)
+		}
+
+		switch {
+		case name == "serde":
+			lines[name] = name + " = { version = " + tomlQuote(known.version) + ", features = [\"derive\"] }"
+		case known.serde && usesSerde:
+			lines[name] = name + " = { version = " + tomlQuote(known.version) + ", features = [\"serde\"] }"
+		default:
+			lines[name] = name + " = " + tomlQuote(known.version)
+		}
+	}
+
+	var tmp strings.Builder
+	tmp.WriteString("[package]\n")
+	tmp.WriteString("name = " + tomlQuote(crateName(mod)) + "\n")
+	tmp.WriteString("version = " + tomlQuote(defaultVersion) + "\n")
+	tmp.WriteString("edition = " + tomlQuote(Edition) + "\n")
+	if version := string(mod.Target.MinLangVersion); version != "" {
+		tmp.WriteString("rust-version = " + tomlQuote(version) + "\n")
+	}
+
+	tmp.WriteString("\n[dependencies]\n")
+	var names []string
+	for name := range lines {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		tmp.WriteString(lines[name] + "\n")
+	}
+
+	modDir.Files = append(modDir.Files, cargoFile(tmp.String()))
+
+	mods := r.rustMods()
+	if len(mods) > 1 && root.File(ManifestName) == nil {
+		var members []string
+		for _, m := range mods {
+			if m.Target.Out == "" {
+				return fmt.Errorf("module '%s' requires an output directory, because the project has multiple rust modules", m.Name)
+			}
+
+			members = append(members, path.Clean(m.Target.Out))
+		}
+
+		root.Files = append(root.Files, cargoFile(createWorkspace(members)))
+	}
+
+	return nil
+}
+
+func cargoFile(text string) *render.File {
+	return &render.File{FileName: ManifestName, MimeType: MimeTypeCargo, Buf: []byte(text)}
+}
+
+// createWorkspace emits the manifest of a virtual workspace, which contains the given crate directories.
+func createWorkspace(members []string) string {
+	var tmp strings.Builder
+	tmp.WriteString("[workspace]\n")
+	tmp.WriteString("resolver = \"2\"\n")
+	tmp.WriteString("members = [\n")
+	for _, member := range members {
+		tmp.WriteString("    " + tomlQuote(member) + ",\n")
+	}
+
+	tmp.WriteString("]\n")
+
+	return tmp.String()
+}
+
+// tomlQuote returns a TOML basic string, which has the same escapes as a Rust string literal.
+func tomlQuote(s string) string {
+	return rustQuote(s)
+}
+
+// relativePath returns the path of the directory to, relative to the directory from. Both are relative to the
+// same root, e.g. ../core for rust/app and rust/core.
+func relativePath(from, to string) string {
+	from, to = path.Clean(from), path.Clean(to)
+	fromSegments := strings.Split(from, "/")
+	toSegments := strings.Split(to, "/")
+	i := 0
+	for i < len(fromSegments) && i < len(toSegments) && fromSegments[i] == toSegments[i] {
+		i++
+	}
+
+	var res []string
+	for range fromSegments[i:] {
+		res = append(res, "..")
+	}
+
+	return path.Join(append(res, toSegments[i:]...)...)
+}
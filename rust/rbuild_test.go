@@ -0,0 +1,90 @@
+package rust
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"github.com/golangee/src/stdlib"
+	"testing"
+)
+
+func TestRenderer_RenderBuildMultiModule(t *testing.T) {
+	inventory := ast.NewMod("example.com/shop/inventory").
+		SetLang(ast.LangRust).
+		SetLangVersion("1.60").
+		SetOutputDirectory("rust/inventory").
+		AddPackages(ast.NewPkg("example.com/shop/inventory/model").AddFiles(
+			ast.NewFile("order.rs").AddTypes(ast.NewStruct("Order").AddFields(
+				ast.NewField("id", ast.NewSimpleTypeDecl(stdlib.UUID)),
+			)),
+		))
+
+	app := ast.NewMod("example.com/shop/app").
+		SetLang(ast.LangRust).
+		SetOutputDirectory("rust/app").
+		Require(`serde_json = "1"`).
+		AddPackages(ast.NewPkg("example.com/shop/app/checkout").AddFiles(
+			ast.NewFile("checkout.rs").AddTypes(ast.NewStruct("Checkout").AddFields(
+				ast.NewField("order", ast.NewSimpleTypeDecl("example.com/shop/inventory/model.Order")),
+				ast.NewField("raw", ast.NewSimpleTypeDecl("serde_json.Value")),
+			)),
+		))
+
+	a, err := NewRenderer(Options{}).Render(ast.NewPrj("Shop").AddModules(inventory, app))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "Cargo.toml"),
+		"[workspace]\nresolver = \"2\"\nmembers = [\n    \"rust/inventory\",\n    \"rust/app\",\n]\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "rust/inventory/Cargo.toml"),
+		"name = \"inventory\"\n",
+		"rust-version = \"1.60\"\n",
+		"[dependencies]\nuuid = \"1\"\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "rust/app/Cargo.toml"),
+		"name = \"app\"\n",
+		"[dependencies]\ninventory = { path = \"../inventory\" }\nserde_json = \"1\"\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "rust/app/src/lib.rs"), "pub mod checkout;\n")
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "rust/app/src/checkout/checkout.rs"),
+		"use inventory::model::Order;\nuse serde_json::Value;\n",
+	)
+}
+
+func TestRenderer_RenderBuildUnknownCrate(t *testing.T) {
+	mod := ast.NewMod("example.com/shop").
+		SetLang(ast.LangRust).
+		AddPackages(ast.NewPkg("example.com/shop/cart").AddFiles(
+			ast.NewFile("cart.rs").AddTypes(ast.NewStruct("Cart").AddFields(
+				ast.NewField("raw", ast.NewSimpleTypeDecl("serde_json.Value")),
+			)),
+		))
+
+	if _, err := NewRenderer(Options{}).Render(ast.NewPrj("Shop").AddModules(mod)); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestRenderer_RenderStdCrate(t *testing.T) {
+	mod := ast.NewMod("example.com/core").SetLang(ast.LangRust)
+	if _, err := NewRenderer(Options{}).Render(ast.NewPrj("Core").AddModules(mod)); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	cases := [][3]string{
+		{"rust/app", "rust/inventory", "../inventory"},
+		{"app", "libs/core", "../libs/core"},
+		{"a/b/c", "a", "../.."},
+	}
+
+	for _, c := range cases {
+		if got := relativePath(c[0], c[1]); got != c[2] {
+			t.Fatalf("expected %s but got %s", c[2], got)
+		}
+	}
+}
@@ -0,0 +1,990 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonAnnotation is the name of the field annotation, which declares the key of a field in the JSON encoding,
+// just like the Go struct tag, e.g. json:"id,omitempty" or json:"-".
+const jsonAnnotation = "json"
+
+func writeComment(w *render.BufferedWriter, name, doc string) {
+	myDoc := formatComment(name, doc)
+	if myDoc != "" {
+		w.Print(myDoc)
+		w.Printf("\n")
+	}
+}
+
+func writeCommentNode(w *render.BufferedWriter, name string, comment *ast.Comment) {
+	if comment == nil {
+		return
+	}
+
+	writeComment(w, name, comment.Text)
+}
+
+// writeDeclComment emits the comment of a declaration together with its deprecation notice.
+func writeDeclComment(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation) {
+	text := ""
+	if comment != nil {
+		text = comment.Text
+	}
+
+	writeComment(w, name, ast.DocWithDeprecation(text, deprecation))
+}
+
+// writeDeprecated emits the deprecated attribute in its own line, if required.
+func writeDeprecated(w *render.BufferedWriter, deprecation *ast.Deprecation) {
+	if deprecation != nil {
+		w.Print(deprecatedAttribute(deprecation))
+		w.Printf("\n")
+	}
+}
+
+// deprecatedAttribute returns the deprecated attribute. Rust has no notion of a replacement, so the note is the
+// same text as the notice of the doc comment.
+func deprecatedAttribute(deprecation *ast.Deprecation) string {
+	note := deprecation.Text()
+	if note == "" {
+		return "#[deprecated]"
+	}
+
+	return "#[deprecated(note = " + rustQuote(note) + ")]"
+}
+
+// renderFile tries to emit the file as rust. The file comment documents the module of the file.
+func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
+	w := &render.BufferedWriter{}
+
+	if file.Preamble != nil {
+		writeLineComment(w, file.Preamble)
+		w.Printf("\n")
+	}
+
+	if file.Comment() != nil {
+		writeInnerDoc(w, file.Comment().Text)
+		w.Printf("\n")
+	}
+
+	// the explicit imports denote items, like std::sync.Arc
+	importer := r.importer(file)
+	for _, imp := range file.Imports() {
+		importer.shortify(imp.Name)
+	}
+
+	// render everything into tmp first, the importer collects all required uses on-the-go
+	tmp := &render.BufferedWriter{}
+	for _, node := range file.Nodes {
+		if _, ok := node.(*ast.Import); ok {
+			continue
+		}
+
+		if err := r.renderNode(node, tmp); err != nil {
+			return nil, err
+		}
+
+		tmp.Printf("\n")
+	}
+
+	uses := importer.sortedUses()
+	for _, path := range uses {
+		w.Printf("use %s;\n", path)
+	}
+
+	if len(uses) > 0 {
+		w.Printf("\n")
+	}
+
+	w.Print(tmp.String())
+
+	return Format(w.Bytes())
+}
+
+// renderNode inspects and emits the actual type.
+func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Struct:
+		if err := r.renderStruct(n, w); err != nil {
+			return fmt.Errorf("cannot render struct '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Interface:
+		if err := r.renderInterface(n, w); err != nil {
+			return fmt.Errorf("cannot render interface '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Enum:
+		if err := r.renderEnum(n, w); err != nil {
+			return fmt.Errorf("cannot render enum '%s': %w", n.Identifier(), err)
+		}
+	case *ast.ErrorType:
+		if err := r.renderErrorType(n, w); err != nil {
+			return fmt.Errorf("cannot render error type '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Func:
+		if err := r.renderFunc(n, visibilityAsModifier(n.Visibility()), "", w); err != nil {
+			return fmt.Errorf("cannot render func '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Property:
+		return fmt.Errorf("cannot render property '%s': rust has no global properties", n.Identifier())
+	default:
+		ok, err := r.renderExpr(n, w)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("type not yet implemented: %s", reflect.TypeOf(n).String())
+		}
+	}
+
+	return nil
+}
+
+// renderTypePreamble emits the comment and the attributes of a type declaration. The derive attribute is emitted
+// first, as usual, if any trait is given.
+func (r *Renderer) renderTypePreamble(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation, derives []string, annotations []*ast.Annotation) error {
+	writeDeclComment(w, name, comment, deprecation)
+	writeDeprecated(w, deprecation)
+
+	if len(derives) > 0 {
+		w.Printf("#[derive(%s)]\n", strings.Join(derives, ", "))
+	}
+
+	for _, annotation := range annotations {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
+	}
+
+	return nil
+}
+
+// renderInterface emits a trait, whose embedded types become supertraits. Properties are declared by their
+// accessor methods and methods with a body become default implementations. Rust has no sealed traits, so the
+// permitted implementations are ignored.
+func (r *Renderer) renderInterface(node *ast.Interface, w *render.BufferedWriter) error {
+	if len(node.NamedTypes()) > 0 {
+		return fmt.Errorf("a trait cannot declare nested types")
+	}
+
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), nil, node.Annotations()); err != nil {
+		return err
+	}
+
+	w.Printf(visibilityAsModifier(node.Visibility()))
+	w.Printf("trait %s", node.Identifier())
+
+	for i, decl := range node.Embedded {
+		if i == 0 {
+			w.Printf(": ")
+		} else {
+			w.Printf(" + ")
+		}
+
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(" {\n")
+	for _, property := range node.Properties() {
+		if err := r.renderAccessors(property, true, property.Read.Enabled, property.Write.Enabled, w); err != nil {
+			return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+		}
+	}
+
+	for _, fun := range node.Methods() {
+		if err := r.renderFunc(fun, "", receiver(fun), w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderStruct emits a struct, which derives Debug, Clone and PartialEq. If all fields have a default, it derives
+// Default as well and if a field declares a json annotation, it derives the serde Serialize and Deserialize
+// traits. Rust cannot nest types, so nested types are emitted in front of the struct. Properties become private
+// fields with accessor methods. Methods are emitted into an impl block, except those, which belong to an
+// implemented interface, which are emitted into the impl block of the according trait. Rust has no inheritance,
+// so a struct cannot extend or embed another type.
+func (r *Renderer) renderStruct(node *ast.Struct, w *render.BufferedWriter) error {
+	if node.Extends != "" {
+		return fmt.Errorf("a rust struct cannot extend '%s'", node.Extends)
+	}
+
+	if len(node.Embedded) > 0 {
+		return fmt.Errorf("a rust struct cannot embed types, declare a field instead")
+	}
+
+	for _, typeNode := range node.NamedTypes() {
+		if err := r.renderNode(typeNode, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	importer := r.importer(node)
+	derives := []string{"Debug", "Clone", "PartialEq"}
+	if structHasDefault(node) {
+		derives = append(derives, "Default")
+	}
+
+	codable := isCodable(node)
+	if codable {
+		derives = append(derives, string(importer.shortify("serde.Serialize")), string(importer.shortify("serde.Deserialize")))
+	}
+
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), derives, node.Annotations()); err != nil {
+		return err
+	}
+
+	w.Printf(visibilityAsModifier(node.Visibility()))
+	w.Printf("struct %s {", node.Identifier())
+	if len(node.Fields()) == 0 && len(node.Properties()) == 0 {
+		w.Printf("}\n")
+	} else {
+		w.Printf("\n")
+		for _, field := range node.Fields() {
+			if err := r.renderField(field, codable, w); err != nil {
+				return fmt.Errorf("failed to render field %s: %w", field.Identifier(), err)
+			}
+		}
+
+		for _, property := range node.Properties() {
+			writeCommentNode(w, fieldName(property.Identifier()), property.Comment())
+			w.Printf("%s: ", fieldName(property.Identifier()))
+			if err := r.renderTypeDecl(property.TypeDecl(), w); err != nil {
+				return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+			}
+
+			w.Printf(",\n")
+		}
+
+		w.Printf("}\n")
+	}
+
+	return r.renderImpls(node, w)
+}
+
+// A traitImpl is an implemented interface and the members of the struct, which belong to it.
+type traitImpl struct {
+	name       ast.Name
+	methods    map[string]bool          // the method names of the trait
+	properties map[string]*ast.Property // the properties of the trait by name
+}
+
+// renderImpls emits the impl block of the struct, followed by the impl blocks of the implemented interfaces. An
+// interface, which is not declared by the project, like a marker trait, gets an empty impl block.
+func (r *Renderer) renderImpls(node *ast.Struct, w *render.BufferedWriter) error {
+	var traits []*traitImpl
+	for _, name := range node.Implements {
+		impl := &traitImpl{name: name, methods: map[string]bool{}, properties: map[string]*ast.Property{}}
+		if iface := r.findInterface(node, name); iface != nil {
+			for _, fun := range iface.Methods() {
+				impl.methods[fun.Identifier()] = true
+			}
+
+			for _, property := range iface.Properties() {
+				impl.properties[property.Identifier()] = property
+			}
+		}
+
+		traits = append(traits, impl)
+	}
+
+	ownedBy := func(name string, isProperty bool) *traitImpl {
+		for _, impl := range traits {
+			if isProperty && impl.properties[name] != nil || !isProperty && impl.methods[name] {
+				return impl
+			}
+		}
+
+		return nil
+	}
+
+	// the impl block of the struct itself comes first
+	blocks := []*traitImpl{nil}
+	blocks = append(blocks, traits...)
+	for _, block := range blocks {
+		tmp := &render.BufferedWriter{}
+		for _, property := range node.Properties() {
+			// a trait may declare only some accessors of a property, the others belong to the struct itself
+			read, write := property.Read.Enabled, property.Write.Enabled
+			if owner := ownedBy(property.Identifier(), true); owner != nil {
+				decl := owner.properties[property.Identifier()]
+				if block == nil {
+					read, write = read && !decl.Read.Enabled, write && !decl.Write.Enabled
+				} else if owner == block {
+					read, write = read && decl.Read.Enabled, write && decl.Write.Enabled
+				} else {
+					read, write = false, false
+				}
+			} else if block != nil {
+				read, write = false, false
+			}
+
+			if err := r.renderAccessors(property, block != nil, read, write, tmp); err != nil {
+				return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+			}
+		}
+
+		for _, fun := range node.Methods() {
+			if ownedBy(fun.Identifier(), false) != block {
+				continue
+			}
+
+			modifiers := ""
+			if block == nil {
+				modifiers = visibilityAsModifier(fun.Visibility())
+			}
+
+			if err := r.renderFunc(fun, modifiers, receiver(fun), tmp); err != nil {
+				return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+			}
+
+			tmp.Printf("\n")
+		}
+
+		if block == nil {
+			if tmp.String() != "" {
+				w.Printf("\nimpl %s {\n", node.Identifier())
+				w.Print(tmp.String())
+				w.Printf("}\n")
+			}
+
+			continue
+		}
+
+		w.Printf("\nimpl %s for %s {", r.importer(node).shortify(block.name), node.Identifier())
+		if tmp.String() == "" {
+			w.Printf("}\n")
+		} else {
+			w.Printf("\n")
+			w.Print(tmp.String())
+			w.Printf("}\n")
+		}
+	}
+
+	return nil
+}
+
+// findInterface returns the interface of the given name, which is either qualified by its package or declared in
+// the package of the scope. Returns nil, if the project does not declare such an interface.
+func (r *Renderer) findInterface(scope ast.Node, name ast.Name) *ast.Interface {
+	if name.Qualifier() == "" {
+		pkg := &ast.Pkg{}
+		if ast.ParentAs(scope, &pkg) {
+			name = ast.Name(pkg.Path + "." + string(name))
+		}
+	}
+
+	var res *ast.Interface
+	_ = ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		for _, pkg := range mod.Pkgs {
+			if pkg.Path != name.Qualifier() {
+				continue
+			}
+
+			_ = ast.ForEach(pkg, func(n ast.Node) error {
+				if iface, ok := n.(*ast.Interface); ok && res == nil && iface.Identifier() == name.Identifier() {
+					res = iface
+				}
+
+				return nil
+			})
+		}
+
+		return nil
+	})
+
+	return res
+}
+
+// structHasDefault returns true, if all fields and properties have a type, which implements Default.
+func structHasDefault(node *ast.Struct) bool {
+	for _, field := range node.Fields() {
+		if !hasDefault(field.TypeDecl()) {
+			return false
+		}
+	}
+
+	for _, property := range node.Properties() {
+		if !hasDefault(property.TypeDecl()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isCodable returns true, if any field declares a json annotation.
+func isCodable(node *ast.Struct) bool {
+	for _, field := range node.Fields() {
+		for _, annotation := range field.Annotations() {
+			if annotation.Identifier() == jsonAnnotation {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fieldName returns the escaped snake case name of a field or property, e.g. user_id.
+func fieldName(identifier string) string {
+	return naming.Rust.Escape(naming.Rust.Snake(identifier))
+}
+
+// serdeAttribute returns the serde field attribute of a field of a codable struct, which renames the field, if
+// the key differs from the field name. Just like in Go, a field without an explicit key is encoded with its
+// declared identifier, so that all languages agree on the keys. The key - of a json annotation skips the field,
+// which requires a default, and omitempty skips an empty option, string or collection. Returns the empty string,
+// if no attribute is required.
+func serdeAttribute(node *ast.Field, codable bool) (string, error) {
+	if !codable {
+		return "", nil
+	}
+
+	key := node.Identifier()
+	var opts []string
+	for _, annotation := range node.Annotations() {
+		if annotation.Identifier() != jsonAnnotation {
+			continue
+		}
+
+		opts = strings.Split(annotation.GetLiteral(""), ",")
+		if name := strings.TrimSpace(opts[0]); name != "" {
+			key = name
+		}
+
+		opts = opts[1:]
+		break
+	}
+
+	if key == "-" {
+		if !hasDefault(node.TypeDecl()) {
+			return "", fmt.Errorf("the excluded field requires a type, which implements Default")
+		}
+
+		return "#[serde(skip)]", nil
+	}
+
+	var args []string
+	if key != fieldName(node.Identifier()) {
+		args = append(args, "rename = "+rustQuote(key))
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) != "omitempty" {
+			continue
+		}
+
+		if isEmpty := emptinessCheck(node.TypeDecl()); isEmpty != "" {
+			args = append(args, "skip_serializing_if = "+rustQuote(isEmpty))
+		}
+	}
+
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	return "#[serde(" + strings.Join(args, ", ") + ")]", nil
+}
+
+// emptinessCheck returns the path of the function, which checks if a value of the given type is empty, e.g.
+// Option::is_none. Returns the empty string, if the type has no notion of emptiness.
+func emptinessCheck(decl ast.TypeDecl) string {
+	switch t := decl.(type) {
+	case *ast.TypeDeclPtr:
+		return "Option::is_none"
+	case *ast.SliceTypeDecl:
+		return "Vec::is_empty"
+	case *ast.GenericTypeDecl:
+		if simple, ok := t.TypeDecl.(*ast.SimpleTypeDecl); ok {
+			return emptinessCheck(simple)
+		}
+	case *ast.SimpleTypeDecl:
+		switch t.Name() {
+		case stdlib.String:
+			return "String::is_empty"
+		case stdlib.List:
+			return "Vec::is_empty"
+		case stdlib.Map:
+			return "HashMap::is_empty"
+		}
+	}
+
+	return ""
+}
+
+// renderField emits a field of a struct. The field of a codable struct is serialized with its declared name.
+func (r *Renderer) renderField(node *ast.Field, codable bool, w *render.BufferedWriter) error {
+	name := fieldName(node.Identifier())
+	writeDeclComment(w, name, node.Comment(), node.Deprecated())
+	writeDeprecated(w, node.Deprecated())
+
+	attr, err := serdeAttribute(node, codable)
+	if err != nil {
+		return err
+	}
+
+	if attr != "" {
+		w.Print(attr)
+		w.Printf("\n")
+	}
+
+	for _, annotation := range node.Annotations() {
+		if annotation.Identifier() == jsonAnnotation {
+			continue
+		}
+
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf(visibilityAsModifier(node.Visibility()))
+	w.Printf("%s: ", name)
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+		return err
+	}
+
+	w.Printf(",\n")
+
+	return nil
+}
+
+// renderAccessors emits the getter and the setter of a property, as requested. A getter returns a Copy type by
+// value, a string as a string slice and everything else as a reference. The setter is prefixed with set_, as
+// usual. The accessors of a trait or of a trait implementation have no visibility and a trait declares them
+// without a body.
+func (r *Renderer) renderAccessors(node *ast.Property, inTrait, read, write bool, w *render.BufferedWriter) error {
+	_, isDecl := node.Parent().(*ast.Interface)
+	name := fieldName(node.Identifier())
+
+	if read {
+		writeCommentNode(w, name, node.Comment())
+		if !inTrait {
+			w.Printf(visibilityAsModifier(node.Read.Visibility))
+		}
+
+		w.Printf("fn %s(&self) -> ", name)
+		value := "self." + name
+		switch {
+		case isCopy(node.TypeDecl()):
+			if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+				return err
+			}
+		case isString(node.TypeDecl()):
+			w.Printf("&str")
+			value = "&" + value
+		default:
+			w.Printf("&")
+			if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+				return err
+			}
+
+			value = "&" + value
+		}
+
+		if isDecl {
+			w.Printf(";\n\n")
+		} else {
+			w.Printf(" {\n%s\n}\n\n", value)
+		}
+	}
+
+	if write {
+		if !inTrait {
+			w.Printf(visibilityAsModifier(node.Write.Visibility))
+		}
+
+		w.Printf("fn set_%s(&mut self, %s: ", naming.Rust.Snake(node.Identifier()), name)
+		if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		w.Printf(")")
+		if isDecl {
+			w.Printf(";\n\n")
+		} else {
+			w.Printf(" {\nself.%s = %s;\n}\n\n", name, name)
+		}
+	}
+
+	return nil
+}
+
+// isString returns true, if the type declares the stdlib string.
+func isString(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.String
+}
+
+// receiver returns the self parameter of a method. A static method has none and a method with a pointer receiver
+// borrows self mutably.
+func receiver(fun *ast.Func) string {
+	switch {
+	case fun.Static():
+		return ""
+	case fun.PtrReceiver():
+		return "&mut self"
+	default:
+		return "&self"
+	}
+}
+
+// renderFuncComment returns the comment of the func together with the tags of its parameters and results, which
+// become sections. The deprecation notice precedes the tags, because it would otherwise end up in the last section.
+func (r *Renderer) renderFuncComment(node *ast.Func) string {
+	comment := &strings.Builder{}
+	text := ""
+	if node.ObjComment != nil {
+		text = node.ObjComment.Text
+	}
+	comment.WriteString(ast.DocWithDeprecation(text, node.Deprecated()))
+	comment.WriteString("\n\n")
+
+	for _, parameterNode := range node.Params() {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		comment.WriteString("@param ")
+		comment.WriteString(deEllipsis(fieldName(parameterNode.Identifier()), parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	values, errParam := splitResults(node.Results())
+	for _, parameterNode := range values {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		comment.WriteString("@return ")
+		comment.WriteString(deEllipsis("", parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	if errParam != nil && errParam.ObjComment != nil {
+		comment.WriteString("@throws ")
+		comment.WriteString(deEllipsis("", errParam.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	return comment.String()
+}
+
+// splitResults separates the results of a function into values and the error. Just like in Java, all results
+// except the first one are errors, so the last one is returned as the error. A single result is only an error,
+// if it declares the stdlib error.
+func splitResults(results []*ast.Param) ([]*ast.Param, *ast.Param) {
+	switch {
+	case len(results) > 1:
+		return results[:len(results)-1], results[len(results)-1]
+	case len(results) == 1 && isError(results[0].TypeDecl()):
+		return nil, results[0]
+	default:
+		return results, nil
+	}
+}
+
+// renderFunc emits a function with the given modifiers and self parameter. A function named like its struct
+// becomes the conventional constructor new, which returns Self. A function without a body is a required method
+// of a trait.
+func (r *Renderer) renderFunc(node *ast.Func, modifiers, self string, w *render.BufferedWriter) error {
+	writeComment(w, node.Identifier(), r.renderFuncComment(node))
+	writeDeprecated(w, node.Deprecated())
+
+	for _, annotation := range node.Annotations() {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
+	}
+
+	name := naming.Rust.Escape(naming.Rust.Snake(node.Identifier()))
+	isNew := false
+	if s, ok := node.Parent().(*ast.Struct); ok && s.Identifier() == node.Identifier() {
+		name, self, isNew = "new", "", true
+	}
+
+	w.Printf(modifiers)
+	w.Printf("fn %s(", name)
+	if self != "" {
+		w.Printf(self)
+		if len(node.Params()) > 0 {
+			w.Printf(", ")
+		}
+	}
+
+	for i, parameterNode := range node.Params() {
+		for _, annotationNode := range parameterNode.Annotations() {
+			if err := r.renderAnnotation(annotationNode, w); err != nil {
+				return err
+			}
+
+			w.Printf(" ")
+		}
+
+		w.Printf("%s: ", fieldName(parameterNode.Identifier()))
+		variadic := i == len(node.Params())-1 && node.Variadic()
+		if variadic {
+			// the arguments are passed as a slice
+			w.Printf("&[")
+		}
+
+		if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if variadic {
+			w.Printf("]")
+		}
+
+		if i < len(node.Params())-1 {
+			w.Printf(", ")
+		}
+	}
+	w.Printf(")")
+
+	if isNew {
+		w.Printf(" -> Self")
+	} else if err := r.renderResults(node.Results(), w); err != nil {
+		return err
+	}
+
+	if node.Body() == nil {
+		w.Printf(";\n")
+		return nil
+	}
+
+	w.Printf(" ")
+	if err := r.renderBlock(node.Body(), w); err != nil {
+		return fmt.Errorf("unable to render method body: %w", err)
+	}
+
+	return nil
+}
+
+// renderResults emits the return type of a function including the arrow, if it is not void. Multiple values
+// become a tuple and an error makes it a Result, e.g. Result<Ticket, NotFoundError> or Result<(), Box<dyn Error>>.
+func (r *Renderer) renderResults(results []*ast.Param, w *render.BufferedWriter) error {
+	var values []ast.TypeDecl
+	valueParams, errParam := splitResults(results)
+	for _, param := range valueParams {
+		if !isVoid(param.TypeDecl()) {
+			values = append(values, param.TypeDecl())
+		}
+	}
+
+	if len(values) == 0 && errParam == nil {
+		return nil
+	}
+
+	w.Printf(" -> ")
+	if errParam != nil {
+		w.Printf("Result<")
+	}
+
+	if err := r.renderTuple(values, w); err != nil {
+		return err
+	}
+
+	if errParam != nil {
+		w.Printf(", ")
+		if err := r.renderTypeDecl(errParam.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		w.Printf(">")
+	}
+
+	return nil
+}
+
+// renderTuple emits a single type as is and all others as a tuple, e.g. () or (String, i64).
+func (r *Renderer) renderTuple(decls []ast.TypeDecl, w *render.BufferedWriter) error {
+	if len(decls) == 1 {
+		return r.renderTypeDecl(decls[0], w)
+	}
+
+	w.Printf("(")
+	for i, decl := range decls {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// renderAnnotation emits an outer attribute, e.g. #[inline] or #[cfg(test)]. Named attributes become key value
+// pairs, e.g. #[cfg_attr(feature = "x")].
+func (r *Renderer) renderAnnotation(node *ast.Annotation, w *render.BufferedWriter) error {
+	w.Printf("#[")
+	w.Print(strings.ReplaceAll(string(node.Identifier()), ".", "::"))
+	attrs := node.Attributes()
+	if len(attrs) > 0 {
+		w.Printf("(")
+		// the default case
+		if len(attrs) == 1 && attrs[0] == "" {
+			w.Print(node.GetLiteral(""))
+		} else {
+			// the named argument cases
+			for i, attr := range attrs {
+				w.Print(attr)
+				w.Printf(" = ")
+				w.Print(node.GetLiteral(attr))
+				if i < len(attrs)-1 {
+					w.Printf(", ")
+				}
+			}
+		}
+
+		w.Printf(")")
+	}
+
+	w.Printf("]")
+
+	return nil
+}
+
+// renderTypeDecl emits a type. Pointers become options, which box a declared type, slices become vectors and the
+// stdlib error becomes a boxed trait object.
+func (r *Renderer) renderTypeDecl(node ast.TypeDecl, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+
+	switch t := node.(type) {
+	case *ast.SimpleTypeDecl:
+		name := string(importer.shortify(fromStdlib(t.Name())))
+		if isError(t) {
+			name = "Box<dyn " + name + ">"
+		}
+
+		w.Printf(name)
+	case *ast.TypeDeclPtr:
+		if _, ok := t.TypeDecl().(*ast.TypeDeclPtr); ok {
+			// an option cannot be optional again
+			return r.renderTypeDecl(t.TypeDecl(), w)
+		}
+
+		// a declared type may refer to itself, so it requires an indirection to have a known size
+		boxed := false
+		if simple, ok := t.TypeDecl().(*ast.SimpleTypeDecl); ok {
+			boxed = fromStdlib(simple.Name()) == simple.Name()
+		}
+
+		w.Printf("Option<")
+		if boxed {
+			w.Printf("Box<")
+		}
+
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if boxed {
+			w.Printf(">")
+		}
+
+		w.Printf(">")
+	case *ast.SliceTypeDecl:
+		w.Printf("Vec<")
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+		w.Printf(">")
+	case *ast.GenericTypeDecl:
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+
+		w.Printf("<")
+		for i, decl := range t.Params() {
+			if err := r.renderTypeDecl(decl, w); err != nil {
+				return err
+			}
+
+			if i < len(t.Params())-1 {
+				w.Printf(", ")
+			}
+		}
+
+		w.Printf(">")
+	case *ast.ArrayTypeDecl:
+		w.Printf("[")
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+		w.Printf("; %d]", t.Len())
+	case *ast.FuncTypeDecl:
+		return r.renderFuncTypeDecl(t, w)
+	default:
+		return fmt.Errorf("type declaration not yet implemented: %s", reflect.TypeOf(t).String())
+	}
+
+	return nil
+}
+
+// renderFuncTypeDecl emits a function type as boxed closure trait object, e.g. Box<dyn Fn(String) -> bool>.
+// The results are declared just like those of a function.
+func (r *Renderer) renderFuncTypeDecl(node *ast.FuncTypeDecl, w *render.BufferedWriter) error {
+	w.Printf("Box<dyn Fn(")
+	for i, param := range node.InputParams() {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(param.TypeDecl(), w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(")")
+	if err := r.renderResults(node.OutputParams(), w); err != nil {
+		return err
+	}
+
+	w.Printf(">")
+
+	return nil
+}
+
+// visibilityAsModifier returns the modifier including a trailing space. Each file is a module, so package private
+// items are visible in the parent module, which is the module of the package. Rust has no protected visibility,
+// so it becomes package private as well. Private items are only visible in their file.
+func visibilityAsModifier(v ast.Visibility) string {
+	switch v {
+	case ast.Public:
+		return "pub "
+	case ast.PackagePrivate, ast.Protected:
+		return "pub(super) "
+	case ast.Private:
+		return ""
+	default:
+		panic("visibility not implemented: " + strconv.Itoa(int(v)))
+	}
+}
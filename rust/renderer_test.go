@@ -0,0 +1,163 @@
+package rust_test
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/rust"
+	"github.com/golangee/src/srctest"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"testing"
+)
+
+// newPrj creates a crate with a serializable struct, a trait with properties, an enum and an error group in a
+// domain module, which is used by an app module.
+func newPrj() *ast.Prj {
+	notFound := lang.NewError("NotFound").AddCase(
+		lang.NewErrorCase("Ticket").SetComment("...is returned, if no such ticket exists.").
+			AddProperty("id", ast.NewSimpleTypeDecl(stdlib.UUID), "...is the unknown ticket."),
+		lang.NewErrorCase("Store"),
+	)
+
+	memRepository := ast.NewStruct("MemRepository").
+		SetVisibility(ast.PackagePrivate).
+		AddProperties(
+			ast.NewProperty("size", ast.NewSimpleTypeDecl(stdlib.Int)).Reader(true, ast.Public).Writer(true, ast.Private),
+			ast.NewProperty("name", ast.NewSimpleTypeDecl(stdlib.String)).Reader(true, ast.Public).Writer(true, ast.Public),
+			ast.NewProperty("timeout", ast.NewSimpleTypeDecl(stdlib.Duration)).Reader(true, ast.Private),
+		).
+		AddMethods(
+			ast.NewFunc("find").
+				AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID))).
+				AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket")))).
+				SetBody(ast.NewBlock(ast.NewReturnStmt(ast.NewIdent("nil")))),
+			ast.NewFunc("forEach").
+				AddParams(ast.NewParam("f", ast.NewFuncTypeDecl().
+					AddInputParams(ast.NewParam("", ast.NewSimpleTypeDecl("Ticket"))).
+					AddOutputParams(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool)), ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Error))))).
+				SetBody(ast.NewBlock()),
+			ast.NewFunc("clear").
+				SetPtrReceiver(true).
+				SetBody(ast.NewBlock(ast.NewAssign(ast.Exprs(ast.NewIdent("size")), ast.AssignSimple, ast.Exprs(ast.NewIntLit(0))))),
+		)
+	memRepository.Implements = append(memRepository.Implements, "Repository")
+
+	ifStmt := ast.NewIfStmt(ast.NewBinaryExpr(ast.NewIdent("n"), ast.OpLess, ast.NewIntLit(1)), ast.NewBlock(
+		ast.NewUnaryExpr(ast.NewIdent("n"), ast.OpInc),
+	))
+	ifStmt.Else = ast.NewBlock(ast.NewCallExpr(ast.NewIdent("println!"), ast.NewStrLit("n={}"), ast.NewIdent("n")))
+	ifStmt.Else.(*ast.Block).SetParent(ifStmt)
+
+	return ast.NewPrj("Tickets").AddModules(
+		ast.NewMod("example.com/tickets").
+			SetLang(ast.LangRust).
+			SetOutputDirectory("rust").
+			AddPackages(
+				ast.NewPkg("example.com/tickets/domain").AddFiles(
+					ast.NewFile("ticket.rs").AddTypes(
+						ast.NewStruct("Ticket").
+							SetComment("...is an issue.").
+							SetVisibility(ast.Public).
+							AddFields(
+								ast.NewField("id", ast.NewSimpleTypeDecl(stdlib.UUID)).
+									SetComment("...is the unique id.").
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("ID")),
+								ast.NewField("title", ast.NewSimpleTypeDecl(stdlib.String)).
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("title,omitempty")),
+								ast.NewField("assignee", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl(stdlib.String))),
+								ast.NewField("tags", ast.NewSliceTypeDecl(ast.NewSimpleTypeDecl(stdlib.String))).
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("-")),
+								ast.NewField("Created", ast.NewSimpleTypeDecl(stdlib.Int64)),
+								ast.NewField("parent", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket"))).
+									SetComment("...is the optional ticket, which contains this ticket."),
+							).
+							AddMethods(
+								ast.NewFunc("isAssigned").
+									SetComment("...returns true, if someone works on it.").
+									SetVisibility(ast.Public).
+									AddResults(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool))).
+									SetBody(ast.NewBlock(ast.NewReturnStmt(ast.NewBinaryExpr(ast.NewIdent("assignee"), ast.OpNotEqual, ast.NewIdent("nil"))))),
+								ast.NewFunc("parse").
+									SetStatic(true).
+									AddParams(ast.NewParam("text", ast.NewSimpleTypeDecl(stdlib.String)).SetComment("...is the json.")).
+									AddResults(ast.NewParam("", ast.NewSimpleTypeDecl("Ticket")), ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Error))).
+									SetBody(ast.NewBlock(ast.NewTpl(`Err({{.Use "example.com/tickets/domain.NotFoundError"}}::Store.into())`))),
+							),
+						ast.NewStruct("Point").
+							SetRecord(true).
+							AddFields(
+								ast.NewField("x", ast.NewSimpleTypeDecl(stdlib.Int64)),
+								ast.NewField("y", ast.NewSimpleTypeDecl(stdlib.Int64)).SetVisibility(ast.Private),
+							),
+					),
+					ast.NewFile("repository.rs").AddTypes(
+						ast.NewInterface("Repository").
+							SetComment("...stores tickets.").
+							SetVisibility(ast.Public).
+							AddProperties(
+								ast.NewProperty("size", ast.NewSimpleTypeDecl(stdlib.Int)).Reader(true, ast.Public),
+								ast.NewProperty("name", ast.NewSimpleTypeDecl(stdlib.String)).Reader(true, ast.Public).Writer(true, ast.Public),
+							).
+							AddMethods(
+								ast.NewFunc("find").
+									AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the ticket id.")).
+									AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket")))),
+								ast.NewFunc("findSlow").
+									SetComment("...scans all tickets.").
+									SetDeprecated("too slow", "Repository.find").
+									AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the ticket id.")).
+									AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket")))),
+								ast.NewFunc("forEach").
+									AddParams(ast.NewParam("f", ast.NewFuncTypeDecl().
+										AddInputParams(ast.NewParam("", ast.NewSimpleTypeDecl("Ticket"))).
+										AddOutputParams(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool)), ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Error))))),
+								ast.NewFunc("isEmpty").
+									AddResults(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool))).
+									SetBody(ast.NewBlock(ast.NewReturnStmt(ast.NewBinaryExpr(ast.NewIdent("size"), ast.OpEqual, ast.NewIntLit(0))))),
+							),
+						memRepository,
+					),
+					ast.NewFile("status.rs").AddTypes(
+						ast.NewEnum("Status", stdlib.String).AddCases(
+							ast.NewEnumCase("inProgress").SetComment("...is the state of active work."),
+							ast.NewEnumCase("Done").SetValue(ast.NewStrLit("$done")),
+						),
+					),
+					ast.NewFile("not_found_error.rs").AddNodes(notFound.TypeDecl()),
+				),
+				ast.NewPkg("example.com/tickets/app").AddFiles(
+					ast.NewFile("app.rs").AddNodes(
+						ast.NewFunc("find").
+							SetComment("...fails always.").
+							SetVisibility(ast.Public).
+							AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID))).
+							AddResults(ast.NewParam("", ast.NewSimpleTypeDecl("example.com/tickets/domain.Ticket")), ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Error))).
+							SetBody(ast.NewBlock(
+								ast.NewAssign(ast.Exprs(ast.NewIdent("err")), ast.AssignDefine, ast.Exprs(notFound.Cases[0].Make(ast.NewIdent("id")))),
+								ast.NewAssign(ast.Exprs(ast.NewIdent("n")), ast.AssignDefine, ast.Exprs(ast.NewIntLit(0))),
+								ifStmt,
+								ast.NewReturnStmt(ast.NewIdent("nil"), ast.NewIdent("err")),
+							)),
+					),
+				),
+			),
+	)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	a, err := rust.NewRenderer(rust.Options{}).Render(newPrj())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srctest.Compare(t, srctest.DefaultGoldenDir, a)
+}
+
+func TestRenderer_RenderGlobalProperty(t *testing.T) {
+	prj := newPrj()
+	app := prj.Mods[0].Pkgs[1].PkgFiles[0]
+	app.AddNodes(ast.NewProperty("defaultTimeout", ast.NewSimpleTypeDecl(stdlib.Duration)).Reader(true, ast.Public))
+
+	if _, err := rust.NewRenderer(rust.Options{}).Render(prj); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,101 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"strconv"
+)
+
+// renderEnum emits a public fieldless enum, whose cases are named in Pascal case. A string enum derives the serde
+// traits and renames each case to its value, which is its original name by default. Its value is returned by
+// as_str, which is also used for the Display implementation. Any other enum is represented by its base type and
+// each case gets its value or its index as explicit discriminant, just like iota in Go. Implemented interfaces
+// become empty trait implementations.
+func (r *Renderer) renderEnum(node *ast.Enum, w *render.BufferedWriter) error {
+	baseType := node.BaseType
+	if baseType == "" {
+		baseType = stdlib.Int
+	}
+
+	isString := baseType == stdlib.String
+	importer := r.importer(node)
+	derives := []string{"Debug", "Clone", "Copy", "PartialEq", "Eq", "Hash"}
+	if isString {
+		derives = append(derives, string(importer.shortify("serde.Serialize")), string(importer.shortify("serde.Deserialize")))
+	}
+
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), nil, derives, nil); err != nil {
+		return err
+	}
+
+	if !isString {
+		w.Printf("#[repr(%s)]\n", importer.shortify(fromStdlib(baseType)))
+	}
+
+	w.Printf("pub enum %s {\n", node.Identifier())
+
+	values := make([]string, 0, len(node.Cases))
+	for i, enumCase := range node.Cases {
+		name := caseName(enumCase)
+		writeCommentNode(w, name, enumCase.Comment())
+
+		value := ""
+		switch {
+		case enumCase.EnumValue != nil:
+			tmp := &render.BufferedWriter{}
+			if err := r.renderBasicLit(enumCase.EnumValue, tmp); err != nil {
+				return fmt.Errorf("cannot render value of case '%s': %w", enumCase.Name(), err)
+			}
+
+			value = tmp.String()
+		case isString:
+			value = rustQuote(enumCase.Name())
+		default:
+			value = strconv.Itoa(i)
+		}
+
+		values = append(values, value)
+		if !isString {
+			w.Printf("%s = %s,\n", name, value)
+			continue
+		}
+
+		if value != rustQuote(name) {
+			w.Printf("#[serde(rename = %s)]\n", value)
+		}
+
+		w.Printf("%s,\n", name)
+	}
+
+	w.Printf("}\n")
+
+	if isString {
+		w.Printf("\nimpl %s {\n", node.Identifier())
+		writeComment(w, "as_str", "...returns the value of the case.")
+		w.Printf("pub fn as_str(&self) -> &'static str {\nmatch self {\n")
+		for i, enumCase := range node.Cases {
+			w.Printf("%s::%s => %s,\n", node.Identifier(), caseName(enumCase), values[i])
+		}
+
+		w.Printf("}\n}\n}\n")
+
+		fmtPath := importer.shortify("std.fmt")
+		w.Printf("\nimpl %s::Display for %s {\n", fmtPath, node.Identifier())
+		w.Printf("fn fmt(&self, f: &mut %s::Formatter<'_>) -> %s::Result {\n", fmtPath, fmtPath)
+		w.Printf("f.write_str(self.as_str())\n}\n}\n")
+	}
+
+	for _, name := range node.Implements {
+		w.Printf("\nimpl %s for %s {}\n", importer.shortify(name), node.Identifier())
+	}
+
+	return nil
+}
+
+// caseName returns the Pascal case name of the enum case, e.g. InProgress.
+func caseName(enumCase *ast.EnumCase) string {
+	return naming.Rust.Escape(naming.Rust.Pascal(enumCase.Name()))
+}
@@ -0,0 +1,92 @@
+package rust
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+// renderErrorType emits a public enum, which declares a struct variant for each error case and implements
+// std::error::Error. The properties of a case become the fields of its variant and are part of the Display
+// message, which uses the debug representation of each field.
+func (r *Renderer) renderErrorType(node *ast.ErrorType, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+	groupType := ErrorTypeName(node.TypeName)
+	writeComment(w, groupType, node.Doc())
+	w.Printf("#[derive(Debug)]\n")
+	w.Printf("pub enum %s {\n", groupType)
+
+	display := &strings.Builder{}
+	for i, errorCase := range node.Cases {
+		name := ErrorVariantName(errorCase.Name())
+		if i > 0 {
+			w.Printf("\n")
+		}
+
+		writeComment(w, name, errorCase.Doc(name, groupType))
+		w.Printf(name)
+		display.WriteString(groupType + "::" + name)
+
+		var fields []string
+		for _, property := range errorCase.Properties {
+			fields = append(fields, ErrorFieldName(property.Identifier()))
+		}
+
+		if len(fields) > 0 {
+			w.Printf(" {\n")
+			for j, property := range errorCase.Properties {
+				writeCommentNode(w, fields[j], property.Comment())
+				w.Printf("%s: ", fields[j])
+				if err := r.renderTypeDecl(property.TypeDecl(), w); err != nil {
+					return err
+				}
+
+				w.Printf(",\n")
+			}
+
+			w.Printf("}")
+			display.WriteString(" { " + strings.Join(fields, ", ") + " }")
+		}
+
+		w.Printf(",\n")
+		msg := errorCase.Message(func(*ast.Field) string {
+			return "{:?}"
+		})
+
+		display.WriteString(" => write!(f, \"" + msg + "\"")
+		for _, field := range fields {
+			display.WriteString(", " + field)
+		}
+
+		display.WriteString("),\n")
+	}
+
+	w.Printf("}\n")
+
+	fmtPath := importer.shortify("std.fmt")
+	w.Printf("\nimpl %s::Display for %s {\n", fmtPath, groupType)
+	w.Printf("fn fmt(&self, f: &mut %s::Formatter<'_>) -> %s::Result {\n", fmtPath, fmtPath)
+	w.Printf("match self {\n")
+	w.Print(display.String())
+	w.Printf("}\n}\n}\n")
+	w.Printf("\nimpl %s for %s {}\n", importer.shortify("std::error.Error"), groupType)
+
+	return nil
+}
+
+// ErrorTypeName returns the name of the enum of an error group, e.g. NotFoundError.
+func ErrorTypeName(groupName string) string {
+	const errStr = "Error"
+	return naming.Rust.Public(strings.TrimSuffix(groupName, errStr)) + errStr
+}
+
+// ErrorVariantName returns the name of the enum variant of an error case, e.g. Ticket.
+func ErrorVariantName(caseName string) string {
+	return naming.Rust.Escape(naming.Rust.Public(caseName))
+}
+
+// ErrorFieldName returns the name of the variant field of an error property, e.g. user_id.
+func ErrorFieldName(propertyName string) string {
+	return naming.Rust.Escape(naming.Rust.Snake(propertyName))
+}
@@ -0,0 +1,128 @@
+package rust
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/stdlib"
+	"strings"
+)
+
+// preludeTypes are in scope of each module, so that a type of the same name must be referred to by its path.
+var preludeTypes = []string{"Box", "Option", "Result", "String", "Vec"}
+
+// fromStdlib converts stdlib types (indicated by the macro ! sign at the end) and returns a Rust name for it.
+// Primitive and prelude types are not qualified. All other names are qualified by their module path, so that the
+// importer can declare their use, e.g. std::collections.HashMap. The uuid and url types are provided by the
+// crates of the same name.
+func fromStdlib(name ast.Name) ast.Name {
+	switch name {
+	case stdlib.Bool:
+		return "bool"
+
+	case stdlib.Int:
+		return "i64"
+
+	case stdlib.Byte:
+		return "u8"
+
+	case stdlib.Int16:
+		return "i16"
+
+	case stdlib.Int32:
+		return "i32"
+
+	case stdlib.Int64:
+		return "i64"
+
+	case stdlib.Float32:
+		return "f32"
+
+	case stdlib.Float64:
+		return "f64"
+
+	case stdlib.Map:
+		return "std::collections.HashMap"
+
+	case stdlib.List:
+		return "Vec"
+
+	case stdlib.UUID:
+		return "uuid.Uuid"
+
+	case stdlib.String:
+		return "String"
+
+	case stdlib.Error:
+		// the trait, which is only used as a boxed trait object, see renderTypeDecl
+		return "std::error.Error"
+
+	case stdlib.Time:
+		return "std::time.SystemTime"
+
+	case stdlib.Duration:
+		return "std::time.Duration"
+
+	case stdlib.URL:
+		return "url.Url"
+
+	case stdlib.Rune:
+		return "char"
+
+	case stdlib.Void:
+		return "()"
+
+	default:
+		if strings.HasSuffix(string(name), "!") {
+			panic("not a stdlib type: " + string(name))
+		}
+		return name
+	}
+}
+
+// isCopy returns true, if the type implements Copy, so that it is returned by value instead of a reference.
+func isCopy(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	if !ok {
+		return false
+	}
+
+	switch simple.Name() {
+	case stdlib.Bool, stdlib.Int, stdlib.Byte, stdlib.Int16, stdlib.Int32, stdlib.Int64, stdlib.Float32,
+		stdlib.Float64, stdlib.Rune, stdlib.UUID, stdlib.Time, stdlib.Duration:
+		return true
+	}
+
+	return false
+}
+
+// hasDefault returns true, if the type implements Default, just like Go would initialize it with its zero value.
+// Returns false, if the type is not known to implement it, e.g. for any custom type.
+func hasDefault(decl ast.TypeDecl) bool {
+	switch t := decl.(type) {
+	case *ast.TypeDeclPtr, *ast.SliceTypeDecl:
+		return true
+	case *ast.GenericTypeDecl:
+		if simple, ok := t.TypeDecl.(*ast.SimpleTypeDecl); ok {
+			return simple.Name() == stdlib.List || simple.Name() == stdlib.Map
+		}
+	case *ast.SimpleTypeDecl:
+		switch t.Name() {
+		case stdlib.Bool, stdlib.Int, stdlib.Byte, stdlib.Int16, stdlib.Int32, stdlib.Int64, stdlib.Float32,
+			stdlib.Float64, stdlib.Rune, stdlib.String, stdlib.Duration, stdlib.List, stdlib.Map:
+			return true
+		}
+	}
+
+	return false
+}
+
+// isError returns true, if the type declares the stdlib error.
+func isError(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.Error
+}
+
+// isVoid returns true, if the type declares the stdlib void.
+func isVoid(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.Void
+}
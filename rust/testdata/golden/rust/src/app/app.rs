@@ -0,0 +1,16 @@
+use crate::domain::NotFoundError;
+use crate::domain::Ticket;
+use std::error::Error;
+use uuid::Uuid;
+
+/// find fails always.
+pub fn find(id: Uuid) -> Result<Ticket, Box<dyn Error>> {
+    let err = NotFoundError::Ticket { id };
+    let mut n = 0;
+    if n < 1 {
+        n += 1;
+    } else {
+        println!("n={}", n);
+    }
+    return Err(err.into());
+}
@@ -0,0 +1,2 @@
+mod app;
+pub use self::app::*;
@@ -0,0 +1,8 @@
+mod ticket;
+pub use self::ticket::*;
+mod repository;
+pub use self::repository::*;
+mod status;
+pub use self::status::*;
+mod not_found_error;
+pub use self::not_found_error::*;
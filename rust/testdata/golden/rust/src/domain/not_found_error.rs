@@ -0,0 +1,28 @@
+use std::error::Error;
+use std::fmt;
+use uuid::Uuid;
+
+/// NotFoundError represents the sum type of all NotFound errors.
+#[derive(Debug)]
+pub enum NotFoundError {
+    /// Ticket is returned, if no such ticket exists.
+    /// Ticket is also a NotFoundError.
+    Ticket {
+        /// id is the unknown ticket.
+        id: Uuid,
+    },
+
+    /// Store is also a NotFoundError.
+    Store,
+}
+
+impl fmt::Display for NotFoundError {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        match self {
+            NotFoundError::Ticket { id } => write!(f, "Ticket: id={:?}", id),
+            NotFoundError::Store => write!(f, "Store"),
+        }
+    }
+}
+
+impl Error for NotFoundError {}
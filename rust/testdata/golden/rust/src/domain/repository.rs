@@ -0,0 +1,76 @@
+use std::error::Error;
+use std::time::Duration;
+use super::Ticket;
+use uuid::Uuid;
+
+/// Repository stores tickets.
+pub trait Repository {
+    fn size(&self) -> i64;
+
+    fn name(&self) -> &str;
+
+    fn set_name(&mut self, name: String);
+
+    /// # Arguments
+    ///
+    /// * `id` - is the ticket id.
+    fn find(&self, id: Uuid) -> Option<Box<Ticket>>;
+
+    /// findSlow scans all tickets.
+    ///
+    /// Deprecated: too slow. Use [`find`] instead.
+    ///
+    /// # Arguments
+    ///
+    /// * `id` - is the ticket id.
+    #[deprecated(note = "too slow. Use [Repository.find] instead.")]
+    fn find_slow(&self, id: Uuid) -> Option<Box<Ticket>>;
+
+    fn for_each(&self, f: Box<dyn Fn(Ticket) -> Result<bool, Box<dyn Error>>>);
+
+    fn is_empty(&self) -> bool {
+        return self.size() == 0;
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Default)]
+pub(super) struct MemRepository {
+    size: i64,
+    name: String,
+    timeout: Duration,
+}
+
+impl MemRepository {
+    fn set_size(&mut self, size: i64) {
+        self.size = size;
+    }
+
+    fn timeout(&self) -> Duration {
+        self.timeout
+    }
+
+    pub fn clear(&mut self) {
+        self.size = 0;
+    }
+}
+
+impl Repository for MemRepository {
+    fn size(&self) -> i64 {
+        self.size
+    }
+
+    fn name(&self) -> &str {
+        &self.name
+    }
+
+    fn set_name(&mut self, name: String) {
+        self.name = name;
+    }
+
+    fn find(&self, id: Uuid) -> Option<Box<Ticket>> {
+        return None;
+    }
+
+    fn for_each(&self, f: Box<dyn Fn(Ticket) -> Result<bool, Box<dyn Error>>>) {
+    }
+}
@@ -0,0 +1,28 @@
+use serde::Deserialize;
+use serde::Serialize;
+use std::fmt;
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub enum Status {
+    /// InProgress is the state of active work.
+    #[serde(rename = "inProgress")]
+    InProgress,
+    #[serde(rename = "$done")]
+    Done,
+}
+
+impl Status {
+    /// as_str returns the value of the case.
+    pub fn as_str(&self) -> &'static str {
+        match self {
+            Status::InProgress => "inProgress",
+            Status::Done => "$done",
+        }
+    }
+}
+
+impl fmt::Display for Status {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        f.write_str(self.as_str())
+    }
+}
@@ -0,0 +1,42 @@
+use serde::Deserialize;
+use serde::Serialize;
+use std::error::Error;
+use super::NotFoundError;
+use uuid::Uuid;
+
+/// Ticket is an issue.
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Ticket {
+    /// id is the unique id.
+    #[serde(rename = "ID")]
+    pub id: Uuid,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub title: String,
+    pub assignee: Option<String>,
+    #[serde(skip)]
+    pub tags: Vec<String>,
+    #[serde(rename = "Created")]
+    pub created: i64,
+    /// parent is the optional ticket, which contains this ticket.
+    pub parent: Option<Box<Ticket>>,
+}
+
+impl Ticket {
+    /// isAssigned returns true, if someone works on it.
+    pub fn is_assigned(&self) -> bool {
+        return self.assignee != None;
+    }
+
+    /// # Arguments
+    ///
+    /// * `text` - is the json.
+    pub fn parse(text: String) -> Result<Ticket, Box<dyn Error>> {
+        Err(NotFoundError::Store.into())
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Default)]
+pub struct Point {
+    pub x: i64,
+    y: i64,
+}
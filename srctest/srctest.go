@@ -0,0 +1,216 @@
+// Package srctest provides a golden file harness for generators which are built on this library.
+//
+// A test renders its model and compares the result against a directory of expected files:
+//
+//	func TestGenerator(t *testing.T) {
+//		srctest.Golden(t, newProject())
+//	}
+//
+// Run the tests with the -update flag to (re)create the golden files from the current output:
+//
+//	go test ./... -update
+//
+// The flag is registered by this package, so a test package which imports srctest must not declare its own
+// update flag.
+//
+// Tests which only check a few fragments of the rendered output may use ReadFile and AssertContains instead.
+package srctest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/internal/diff"
+	"github.com/golangee/src/render"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// DefaultGoldenDir is the directory used by Golden, relative to the package directory of the test.
+const DefaultGoldenDir = "testdata/golden"
+
+var update = flag.Bool("update", false, "update the golden files of srctest instead of comparing them")
+
+// Update reports whether the golden files are updated instead of compared.
+func Update() bool {
+	return *update
+}
+
+// Golden renders the project using render.Project and compares the result with DefaultGoldenDir. The
+// renderers of all used languages must have been imported by the test.
+func Golden(t testing.TB, prj *ast.Prj) {
+	t.Helper()
+
+	dir, err := render.Project(prj)
+	if err != nil {
+		if dir != nil {
+			t.Log(dir)
+		}
+
+		t.Fatalf("cannot render project: %v", err)
+	}
+
+	Compare(t, DefaultGoldenDir, dir)
+}
+
+// Compare checks that the artifact equals the golden directory. Each differing, missing or unexpected file is
+// reported as a separate test error with a unified diff. A file artifact is compared with the equally named
+// file in the golden directory and the name of a dir artifact is ignored. If the -update flag is set, the golden
+// directory is replaced by the artifact instead.
+func Compare(t testing.TB, golden string, artifact render.Artifact) {
+	t.Helper()
+
+	got, err := flatten(artifact)
+	if err != nil {
+		t.Fatalf("invalid artifact: %v", err)
+	}
+
+	if *update {
+		if err := write(golden, got); err != nil {
+			t.Fatalf("cannot update golden files: %v", err)
+		}
+
+		return
+	}
+
+	want, err := readDir(golden)
+	if err != nil {
+		t.Fatalf("cannot read golden files (use -update to create them): %v", err)
+	}
+
+	for _, name := range unionKeys(got, want) {
+		gotBuf, inGot := got[name]
+		wantBuf, inWant := want[name]
+		switch {
+		case !inWant:
+			t.Errorf("unexpected file %s (use -update to accept it):\n%s", name, diff.Unified("/dev/null", "b/"+name, nil, gotBuf))
+		case !inGot:
+			t.Errorf("missing file %s (use -update to remove it):\n%s", name, diff.Unified("a/"+name, "/dev/null", wantBuf, nil))
+		case !bytes.Equal(gotBuf, wantBuf):
+			t.Errorf("file %s differs from golden file (use -update to accept it):\n%s", name, diff.Unified("a/"+name, "b/"+name, wantBuf, gotBuf))
+		}
+	}
+}
+
+// ReadFile returns the content of the named file of the rendered directory or fails the test.
+func ReadFile(t testing.TB, dir *render.Dir, name string) string {
+	t.Helper()
+
+	buf, err := fs.ReadFile(dir, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(buf)
+}
+
+// AssertContains fails the test, if the text does not contain each of the wanted fragments. This is an alternative
+// to Compare, if only a few details of the rendered output are of interest.
+func AssertContains(t testing.TB, text string, wants ...string) {
+	t.Helper()
+
+	for _, want := range wants {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q but got\n%s", want, text)
+		}
+	}
+}
+
+// flatten returns the slash separated relative file names and their contents.
+func flatten(artifact render.Artifact) (map[string][]byte, error) {
+	res := map[string][]byte{}
+	switch t := artifact.(type) {
+	case *render.File:
+		res[t.FileName] = t.Buf
+	case *render.Dir:
+		err := fs.WalkDir(t, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			buf, err := fs.ReadFile(t, path)
+			if err != nil {
+				return err
+			}
+
+			res[path] = buf
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported artifact type %T", artifact)
+	}
+
+	return res, nil
+}
+
+// readDir returns all files below dir, just like flatten.
+func readDir(dir string) (map[string][]byte, error) {
+	res := map[string][]byte{}
+	err := fs.WalkDir(os.DirFS(dir), ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		buf, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			return err
+		}
+
+		res[path] = buf
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// write replaces the golden directory with the given files.
+func write(dir string, files map[string][]byte) error {
+	if err := os.RemoveAll(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for name, buf := range files {
+		fname := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fname), 0755); err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(fname, buf, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unionKeys(a, b map[string][]byte) []string {
+	var res []string
+	for k := range a {
+		res = append(res, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			res = append(res, k)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,85 @@
+package srctest
+
+import (
+	"fmt"
+	"github.com/golangee/src/render"
+	"strings"
+	"testing"
+)
+
+// recorder captures the reported errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newDir(main string) *render.Dir {
+	return &render.Dir{
+		Dirs: []*render.Dir{{
+			DirName: "server",
+			Files: []*render.File{
+				{FileName: "main.go", Buf: []byte(main)},
+				{FileName: "go.mod", Buf: []byte("module server\n\ngo 1.16\n")},
+			},
+		}},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	Compare(t, DefaultGoldenDir, newDir("package main\n\nfunc main() {\n}\n"))
+}
+
+func TestCompare_Diff(t *testing.T) {
+	if Update() {
+		t.Skip("would overwrite the golden files with a broken state")
+	}
+
+	dir := newDir("package main\n\nfunc main() {\n\tprintln()\n}\n")
+	dir.Files = append(dir.Files, &render.File{FileName: "README.md", Buf: []byte("# server\n")})
+
+	r := &recorder{TB: t}
+	Compare(r, DefaultGoldenDir, dir)
+
+	if len(r.errors) != 2 {
+		t.Fatalf("expected an error per file but got %v", r.errors)
+	}
+
+	if !strings.Contains(r.errors[0], "unexpected file README.md") {
+		t.Fatalf("unexpected error: %s", r.errors[0])
+	}
+
+	if !strings.Contains(r.errors[1], "server/main.go differs") || !strings.Contains(r.errors[1], "+\tprintln()\n") {
+		t.Fatalf("unexpected error: %s", r.errors[1])
+	}
+}
+
+func TestCompare_Update(t *testing.T) {
+	golden := t.TempDir() + "/golden"
+	dir := newDir("package main\n")
+
+	*update = true
+	defer func() { *update = false }()
+	Compare(t, golden, dir)
+
+	*update = false
+	Compare(t, golden, dir)
+}
+
+func TestAssertContains(t *testing.T) {
+	src := ReadFile(t, newDir("package main\n"), "server/go.mod")
+	AssertContains(t, src, "module server\n", "go 1.16\n")
+
+	r := &recorder{TB: t}
+	AssertContains(r, src, "module client\n")
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "module client") {
+		t.Fatalf("expected a failure but got %v", r.errors)
+	}
+}
@@ -7,8 +7,11 @@ import (
 
 // Sel creates a reference or selector chain through all given names. So a Sel(a, b, c) results in a.b.c
 func Sel(names ...string) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, selRecursive(names...)),
+	sel := selRecursive(names...)
+
+	return ast.NewMacro().SetKind(macroSel, ast.MacroParams{"names": names}).SetMatchers(
+		ast.MatchTargetLanguage(ast.LangGo, sel),
+		ast.MatchTargetLanguage(ast.LangJava, sel),
 	)
 }
 
@@ -21,51 +24,80 @@ func selRecursive(names ...string) ast.Expr {
 }
 
 // CallStatic interprets the name as qualified and causes an import of the qualifier.
+//
+//	Go: fmt.Println
+//	Java: java.util.Objects.requireNonNull
 func CallStatic(name ast.Name, args ...ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, ast.NewCallExpr(ast.NewSelExpr(ast.NewQualIdent(name.Qualifier()), ast.NewIdent(name.Identifier())), args...)),
+	call := ast.NewCallExpr(ast.NewSelExpr(ast.NewQualIdent(name.Qualifier()), ast.NewIdent(name.Identifier())), args...)
+
+	return ast.NewMacro().SetKind(macroCallStatic, ast.MacroParams{"name": string(name), "args": ast.ExprNodes(args...)}).SetMatchers(
+		ast.MatchTargetLanguage(ast.LangGo, call),
+		ast.MatchTargetLanguage(ast.LangJava, call),
 	)
 }
 
 // CallIdent is like CallStatic but does not cause an import because it just uses local identifiers for the receiver and method.
 func CallIdent(ident, method string, args ...ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, ast.NewCallExpr(ast.NewSelExpr(ast.NewIdent(ident), ast.NewIdent(method)), args...)),
+	call := ast.NewCallExpr(ast.NewSelExpr(ast.NewIdent(ident), ast.NewIdent(method)), args...)
+
+	return ast.NewMacro().SetKind(macroCallIdent, ast.MacroParams{"ident": ident, "method": method, "args": ast.ExprNodes(args...)}).SetMatchers(
+		ast.MatchTargetLanguage(ast.LangGo, call),
+		ast.MatchTargetLanguage(ast.LangJava, call),
 	)
 }
 
 // Call is like CallIdent but does not cause an import because it just uses a local identifier (like a static method).
 func Call(ident string, args ...ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, ast.NewCallExpr(ast.NewIdent(ident), args...)),
+	call := ast.NewCallExpr(ast.NewIdent(ident), args...)
+
+	return ast.NewMacro().SetKind(macroCall, ast.MacroParams{"ident": ident, "args": ast.ExprNodes(args...)}).SetMatchers(
+		ast.MatchTargetLanguage(ast.LangGo, call),
+		ast.MatchTargetLanguage(ast.LangJava, call),
 	)
 }
 
 // CreateLiteral takes the
+//
+//	Go: a composite literal like strings.Builder{}
+//	Java: a constructor call like new StringBuilder()
 func CreateLiteral(name ast.Name, args ...ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, ast.NewCompLit(ast.NewSimpleTypeDecl(name), args...)),
+	lit := ast.NewCompLit(ast.NewSimpleTypeDecl(name), args...)
+
+	return ast.NewMacro().SetKind(macroCreateLiteral, ast.MacroParams{"name": string(name), "args": ast.ExprNodes(args...)}).SetMatchers(
+		ast.MatchTargetLanguage(ast.LangGo, lit),
+		ast.MatchTargetLanguage(ast.LangJava, lit),
 	)
 }
 
 // ToString converts the given expression into a string.
 func ToString(expr ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, CallStatic("fmt.Sprintf", ast.NewStrLit("%v"), expr)),
+	return ast.NewMacro().SetKind(macroToString, ast.MacroParams{"expr": expr}).SetMatchers(
+		ast.MatchTargetLanguageWithContext(ast.LangGo, func(m *ast.Macro) []ast.Node {
+			return ast.Nodes(CallStatic("fmt.Sprintf", ast.NewStrLit("%v"), expr))
+		}),
+		ast.MatchTargetLanguageWithContext(ast.LangJava, func(m *ast.Macro) []ast.Node {
+			return ast.Nodes(CallStatic("String.valueOf", expr))
+		}),
 	)
 }
 
 // Itoa performs a more optimized integer to ascii.
 func Itoa(expr ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, CallStatic("strconv.Itoa", expr)),
+	return ast.NewMacro().SetKind(macroItoa, ast.MacroParams{"expr": expr}).SetMatchers(
+		ast.MatchTargetLanguageWithContext(ast.LangGo, func(m *ast.Macro) []ast.Node {
+			return ast.Nodes(CallStatic("strconv.Itoa", expr))
+		}),
+		ast.MatchTargetLanguageWithContext(ast.LangJava, func(m *ast.Macro) []ast.Node {
+			return ast.Nodes(CallStatic("Integer.toString", expr))
+		}),
 	)
 }
 
 // Panic raises a panic, does a halt or throws some kind of implementation exception indicating a serious programming
 // error.
 func Panic(msg string) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	return ast.NewMacro().SetKind(macroPanic, ast.MacroParams{"msg": msg}).SetMatchers(
 		ast.MatchTargetLanguage(ast.LangGo, ast.NewTpl("panic("+strconv.Quote(msg)+")")),
+		ast.MatchTargetLanguage(ast.LangJava, ast.NewTpl("throw new IllegalStateException("+strconv.Quote(msg)+")")),
 	)
 }
@@ -3,6 +3,12 @@ package lang
 import (
 	"github.com/golangee/src/ast"
 	"github.com/golangee/src/golang"
+	"github.com/golangee/src/kotlin"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/rust"
+	"github.com/golangee/src/swift"
+	"github.com/golangee/src/typescript"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -20,7 +26,25 @@ import (
 //   Even more verbose (and perhaps unidiomatic), we generate interface types for each error case.
 //   We do this only for documentation and reference purpose.
 // Java:
-//   model as sealed class or (checked) exception?
+//   Emits an interface named after the GroupName, which nests a final unchecked exception class for each ErrorCase.
+//   Properties become private fields with an accessor method and are part of the message. For Java 17+ the
+//   interface is sealed and permits exactly the cases.
+// Kotlin:
+//   Emits an ast.ErrorType, which the renderer declares as a sealed class named after the GroupName. It extends
+//   RuntimeException and nests a class for each ErrorCase. Properties become read-only properties of the primary
+//   constructor and are part of the message.
+// Swift:
+//   Emits an ast.ErrorType, which the renderer declares as an enum named after the GroupName. It conforms to Error
+//   and declares a case for each ErrorCase. Properties become labeled associated values and are part of the
+//   description.
+// Rust:
+//   Emits an ast.ErrorType, which the renderer declares as an enum named after the GroupName. It implements
+//   std::error::Error and declares a variant for each ErrorCase. Properties become the fields of a struct variant
+//   and are part of the Display message.
+// TypeScript:
+//   Emits an ast.ErrorType, which the renderer declares as a discriminated union named after the GroupName and an
+//   interface for each ErrorCase, whose kind property is the name of the case. Properties become the fields of
+//   the interface.
 //
 type Error struct {
 	GroupName string       // GroupName denotes the actual name of the sealed type set of errors.
@@ -83,13 +107,40 @@ func (n *Error) AddCase(cases ...*ErrorCase) *Error {
 	return n
 }
 
+// params returns the parameters which are required to recreate the error group.
+func (n *Error) params() ast.MacroParams {
+	var cases []ast.MacroParams
+	for _, errorCase := range n.Cases {
+		var props []ast.MacroParams
+		for _, property := range errorCase.Properties {
+			props = append(props, ast.MacroParams{
+				"name":    property.name,
+				"type":    property.decl,
+				"comment": property.comment,
+			})
+		}
+
+		cases = append(cases, ast.MacroParams{
+			"name":       errorCase.TypeName,
+			"comment":    errorCase.Comment,
+			"properties": props,
+		})
+	}
+
+	return ast.MacroParams{
+		"group":   n.GroupName,
+		"comment": n.Comment,
+		"cases":   cases,
+	}
+}
+
 func (n *Error) ID() string {
 	return "error-macro-" + n.GroupName
 }
 
 // TypeDecl creates a macro to declare the according concrete sealed or sum type(s).
 func (n *Error) TypeDecl() *ast.Macro {
-	m := ast.NewMacro().SetID(n.ID()).SetMatchers(
+	m := ast.NewMacro().SetID(n.ID()).SetKind(macroErrorTypeDecl, n.params()).SetMatchers(
 		ast.MatchTargetLanguageWithContext(ast.LangGo,
 			func(m *ast.Macro) []ast.Node {
 				var res []ast.Node
@@ -98,10 +149,10 @@ func (n *Error) TypeDecl() *ast.Macro {
 				docUnwrap := "...unpacks the cause or returns nil."
 				docErr := "...returns the conventional description of this error."
 
-				sumType := ast.NewInterface(golang.MakePublic(n.GroupName)+"Error").
+				sumType := ast.NewInterface(naming.Go.Public(n.GroupName)+"Error").
 					SetComment("...represents the sum type behavior of all "+n.GroupName+" errors.").
 					AddMethods(
-						ast.NewFunc(goErrorMarkerMethod(golang.MakePublic(n.GroupName))).
+						ast.NewFunc(goErrorMarkerMethod(naming.Go.Public(n.GroupName))).
 							SetComment(docSumType).
 							AddResults(ast.NewParam("", ast.NewSimpleTypeDecl("bool"))),
 
@@ -128,13 +179,13 @@ func (n *Error) TypeDecl() *ast.Macro {
 
 								 return nil`).
 							Put("type", sumType.TypeName).
-							Put("sumTypeMarker", goErrorMarkerMethod(golang.MakePublic(n.GroupName))),
+							Put("sumTypeMarker", goErrorMarkerMethod(naming.Go.Public(n.GroupName))),
 					))
 
 				res = append(res, sumType, asSumType)
 
 				for _, errorCase := range n.Cases {
-					contract := ast.NewInterface(golang.MakePublic(errorCase.goStructTypeName())).
+					contract := ast.NewInterface(naming.Go.Public(errorCase.goStructTypeName())).
 						SetComment(errorCase.Comment).
 						AddEmbedded(ast.NewSimpleTypeDecl(ast.Name(sumType.TypeName)))
 
@@ -149,10 +200,10 @@ func (n *Error) TypeDecl() *ast.Macro {
 						)
 
 						// public property getter for struct
-						doc := "...returns the value of " + property.name + ".\n" + golang.DeEllipsis(golang.MakePublic(property.name), property.comment)
+						doc := "...returns the value of " + property.name + ".\n" + golang.DeEllipsis(naming.Go.Public(property.name), property.comment)
 						typ.AddMethods(
 							ast.NewFunc(
-								golang.MakePublic(property.goFieldName())).
+								property.goGetterName()).
 								SetComment(doc).
 								SetRecName("e").
 								AddResults(ast.NewParam("", property.decl.Clone())).
@@ -166,7 +217,7 @@ func (n *Error) TypeDecl() *ast.Macro {
 						// public property getter for interface
 						contract.AddMethods(
 							ast.NewFunc(
-								golang.MakePublic(property.goFieldName())).
+								property.goGetterName()).
 								SetComment(doc).
 								AddResults(ast.NewParam("", property.decl.Clone())),
 						)
@@ -252,7 +303,7 @@ func (n *Error) TypeDecl() *ast.Macro {
 								 return nil`).
 								Put("type", contract.TypeName).
 								Put("caseFunc", goErrorMarkerMethod(errorCase.TypeName)).
-								Put("sumTypeMarker", goErrorMarkerMethod(golang.MakePublic(n.GroupName))),
+								Put("sumTypeMarker", goErrorMarkerMethod(naming.Go.Public(n.GroupName))),
 						))
 
 					res = append(res, contract, asType, typ)
@@ -261,6 +312,11 @@ func (n *Error) TypeDecl() *ast.Macro {
 				return res
 			},
 		),
+		ast.MatchTargetLanguageWithContext(ast.LangJava, n.javaTypeDecl),
+		ast.MatchTargetLanguageWithContext(ast.LangKotlin, n.errorType),
+		ast.MatchTargetLanguageWithContext(ast.LangSwift, n.errorType),
+		ast.MatchTargetLanguageWithContext(ast.LangRust, n.errorType),
+		ast.MatchTargetLanguageWithContext(ast.LangTypeScript, n.errorType),
 	)
 
 	m.PutValue(secretValueErrorKey(n.ID()), n)
@@ -309,16 +365,99 @@ func (n *ErrorCase) AddProperty(name string, decl ast.TypeDecl, comment string)
 //  Go:
 //    - emits a struct literal to a private type, which exposes the according marker interfaces and property getters.
 //  Java:
-//    - either throws a (public?) Checked Exception or creates a new instance of a sealed type?
+//    - creates a new instance of the nested exception class, e.g. new NotFoundError.Ticket(id).
+//  Kotlin:
+//    - calls the constructor of the nested class, e.g. NotFoundError.Ticket(id).
+//  Swift:
+//    - creates the enum case with its labeled associated values, e.g. NotFoundError.ticket(id: id).
+//  Rust:
+//    - creates the enum variant with its fields, e.g. NotFoundError::Ticket { id }.
+//  TypeScript:
+//    - creates an object literal of the union member, which is discriminated by its kind, e.g. { kind: 'Ticket', id: id }.
 func (n *ErrorCase) Make(args ...ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	return newErrorCaseMake(n.params(), func(*ast.Macro) *ErrorCase { return n }, args)
+}
+
+func newErrorCaseMake(params ast.MacroParams, resolve func(m *ast.Macro) *ErrorCase, args []ast.Expr) *ast.Macro {
+	params["args"] = ast.ExprNodes(args...)
+
+	return ast.NewMacro().SetKind(macroErrorCaseMake, params).SetMatchers(
 		ast.MatchTargetLanguageWithContext(ast.LangGo,
 			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
 				compLit := ast.NewCompLit(ast.NewIdent(n.goStructTypeName()))
 				for i, arg := range args {
 					compLit.AddElements(ast.NewBinaryExpr(ast.NewIdent(n.Properties[i].name), ast.OpColon, arg))
 				}
 
+				return []ast.Node{compLit}
+			},
+		),
+		ast.MatchTargetLanguageWithContext(ast.LangJava,
+			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
+				compLit := ast.NewCompLit(ast.NewSelExpr(ast.NewIdent(javaErrorTypeName(n.Parent.GroupName)), ast.NewIdent(n.javaClassName())))
+				for _, arg := range args {
+					compLit.AddElements(arg)
+				}
+
+				return []ast.Node{compLit}
+			},
+		),
+		ast.MatchTargetLanguageWithContext(ast.LangKotlin,
+			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
+				compLit := ast.NewCompLit(ast.NewSelExpr(ast.NewIdent(kotlin.ErrorTypeName(n.Parent.GroupName)), ast.NewIdent(kotlin.ErrorClassName(n.TypeName))))
+				for _, arg := range args {
+					compLit.AddElements(arg)
+				}
+
+				return []ast.Node{compLit}
+			},
+		),
+		ast.MatchTargetLanguageWithContext(ast.LangSwift,
+			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
+				sel := ast.NewSelExpr(ast.NewIdent(swift.ErrorTypeName(n.Parent.GroupName)), ast.NewIdent(swift.ErrorCaseName(n.TypeName)))
+				if len(args) == 0 {
+					// a case without associated values is not called
+					return []ast.Node{sel}
+				}
+
+				compLit := ast.NewCompLit(sel)
+				for i, arg := range args {
+					compLit.AddElements(ast.NewBinaryExpr(ast.NewIdent(swift.ErrorLabel(n.Properties[i].name)), ast.OpColon, arg))
+				}
+
+				return []ast.Node{compLit}
+			},
+		),
+		ast.MatchTargetLanguageWithContext(ast.LangRust,
+			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
+				sel := ast.NewSelExpr(n.Parent.rustTypeExpr(m), ast.NewIdent(rust.ErrorVariantName(n.TypeName)))
+				if len(args) == 0 {
+					// a unit variant has no fields
+					return []ast.Node{sel}
+				}
+
+				compLit := ast.NewCompLit(sel)
+				for i, arg := range args {
+					compLit.AddElements(ast.NewBinaryExpr(ast.NewIdent(rust.ErrorFieldName(n.Properties[i].name)), ast.OpColon, arg))
+				}
+
+				return []ast.Node{compLit}
+			},
+		),
+		ast.MatchTargetLanguageWithContext(ast.LangTypeScript,
+			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
+				compLit := ast.NewCompLit(ast.NewIdent(typescript.ErrorMemberName(n.Parent.GroupName, n.TypeName)))
+				compLit.AddElements(ast.NewBinaryExpr(ast.NewIdent("kind"), ast.OpColon, ast.NewStrLit(n.TypeName)))
+				for i, arg := range args {
+					compLit.AddElements(ast.NewBinaryExpr(ast.NewIdent(typescript.ErrorFieldName(n.Properties[i].name)), ast.OpColon, arg))
+				}
+
 				return []ast.Node{compLit}
 			},
 		),
@@ -334,12 +473,33 @@ const (
 )
 
 // Check creates a new macro, which inspects the given variable and tries to match it against this specific case.
+// If dstVarName is already used within the enclosing func, a fresh identifier is declared instead (see
+// ast.FreshIdent) and the according identifiers within the match block are renamed.
 //  Go:
 //   - creates a new inline type and uses errors.As to Unwrap or match into dstVarName and calls the match block on success.
 func (n *ErrorCase) Check(checkKind ErrorCheckKind, checkVarName, dstVarName string, match *ast.Block) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	return newErrorCaseCheck(n.params(), func(*ast.Macro) *ErrorCase { return n }, checkKind, checkVarName, dstVarName, match)
+}
+
+func newErrorCaseCheck(params ast.MacroParams, resolve func(m *ast.Macro) *ErrorCase, checkKind ErrorCheckKind, checkVarName, dstVarName string, match *ast.Block) *ast.Macro {
+	params["check"] = string(checkKind)
+	params["src"] = checkVarName
+	params["dst"] = dstVarName
+	params["match"] = match
+
+	return ast.NewMacro().SetKind(macroErrorCaseCheck, params).SetMatchers(
 		ast.MatchTargetLanguageWithContext(ast.LangGo,
 			func(m *ast.Macro) []ast.Node {
+				n := resolve(m)
+				dstVarName := dstVarName
+				match := match
+				if fresh := ast.FreshIdent(m, dstVarName); fresh != dstVarName {
+					// rename a copy, so that the model is not changed by rendering
+					match = ast.Clone(match).(*ast.Block)
+					renameIdents(match, dstVarName, fresh)
+					dstVarName = fresh
+				}
+
 				var iface *ast.Interface
 				switch checkKind {
 				case CheckExactBehavior:
@@ -382,6 +542,65 @@ func (n *ErrorCase) Check(checkKind ErrorCheckKind, checkVarName, dstVarName str
 	)
 }
 
+// renameIdents replaces the name of all identifiers within the node. Macros are not evaluated, but their
+// parameters are inspected.
+func renameIdents(node ast.Node, from, to string) {
+	switch t := node.(type) {
+	case *ast.Ident:
+		if t.Name == from {
+			t.Name = to
+		}
+	case *ast.Macro:
+		for _, v := range t.Params {
+			switch p := v.(type) {
+			case ast.Node:
+				renameIdents(p, from, to)
+			case []ast.Node:
+				for _, n := range p {
+					renameIdents(n, from, to)
+				}
+			}
+		}
+
+		return
+	}
+
+	if p, ok := node.(ast.Parent); ok {
+		for _, child := range p.Children() {
+			renameIdents(child, from, to)
+		}
+	}
+}
+
+// params returns the parameters which identify this case within its group.
+func (n *ErrorCase) params() ast.MacroParams {
+	params := ast.MacroParams{"case": n.TypeName}
+	if n.Parent != nil {
+		params["group"] = n.Parent.GroupName
+	}
+
+	return params
+}
+
+// resolveErrorCase returns a lookup function which finds the named case of the named group within the package
+// of the macro.
+func resolveErrorCase(groupName, caseName string) func(m *ast.Macro) *ErrorCase {
+	return func(m *ast.Macro) *ErrorCase {
+		e := FindError(m, groupName)
+		if e == nil {
+			panic("invalid context: error group " + groupName + " is not declared in package")
+		}
+
+		for _, errorCase := range e.Cases {
+			if errorCase.TypeName == caseName {
+				return errorCase
+			}
+		}
+
+		panic("invalid context: error group " + groupName + " does not declare case " + caseName)
+	}
+}
+
 func (n *ErrorCase) goSumTypeInterface() *ast.Interface {
 	iface := ast.NewInterface("").AddMethods(
 		ast.NewFunc(goErrorMarkerMethod(n.Parent.GroupName)).AddResults(ast.NewParam("", ast.NewSimpleTypeDecl("bool"))),
@@ -407,7 +626,7 @@ func (n *ErrorCase) goFullInterface() *ast.Interface {
 	for _, property := range n.Properties {
 		iface.AddMethods(
 			ast.NewFunc(
-				golang.MakePublic(property.goFieldName())).
+				property.goGetterName()).
 				AddResults(ast.NewParam("", property.decl.Clone())),
 		)
 	}
@@ -420,7 +639,7 @@ func (n *ErrorCase) goFullInterface() *ast.Interface {
 // just the local name is returned, otherwise a full qualified identifier within the context of p.
 func (n *ErrorCase) ContractTypeName(p ast.Node) ast.Name {
 	if n.Parent == nil {
-		return ast.Name(golang.MakePublic(n.goStructTypeName()))
+		return ast.Name(naming.Go.Public(n.goStructTypeName()))
 	}
 
 	var target ast.Target
@@ -432,7 +651,19 @@ func (n *ErrorCase) ContractTypeName(p ast.Node) ast.Name {
 	var identifier string
 	switch target.Lang {
 	case ast.LangGo:
-		identifier = golang.MakePublic(n.goStructTypeName())
+		identifier = naming.Go.Public(n.goStructTypeName())
+	case ast.LangJava:
+		identifier = javaErrorTypeName(n.Parent.GroupName) + "." + n.javaClassName()
+	case ast.LangKotlin:
+		identifier = kotlin.ErrorTypeName(n.Parent.GroupName) + "." + kotlin.ErrorClassName(n.TypeName)
+	case ast.LangSwift:
+		// a case is not a type, so the enum is the contract
+		identifier = swift.ErrorTypeName(n.Parent.GroupName)
+	case ast.LangRust:
+		// a variant is not a type, so the enum is the contract
+		identifier = rust.ErrorTypeName(n.Parent.GroupName)
+	case ast.LangTypeScript:
+		identifier = typescript.ErrorTypeName(n.Parent.GroupName)
 	default:
 		panic("target lang not yet implemented: " + target.Lang)
 	}
@@ -457,8 +688,8 @@ func (n *ErrorCase) goStructTypeName() string {
 		prefix = prefix[:len(prefix)-len(errStr)]
 	}
 
-	prefix = golang.MakePrivate(prefix)
-	name := prefix + golang.MakePublic(n.TypeName)
+	prefix = naming.Go.Private(prefix)
+	name := prefix + naming.Go.Public(n.TypeName)
 
 	if !strings.HasSuffix(name, errStr) {
 		name += errStr
@@ -474,7 +705,7 @@ func goErrorMarkerMethod(s string) string {
 		s = s[:len(s)-len(errStr)]
 	}
 
-	return golang.MakePublic(s)
+	return naming.Go.Public(s)
 }
 
 type errProperty struct {
@@ -483,8 +714,14 @@ type errProperty struct {
 	comment string
 }
 
+// goFieldName is the private and escaped field name, e.g. type_ or userID.
 func (n errProperty) goFieldName() string {
-	return golang.MakePrivate(n.name)
+	return naming.Go.Escape(naming.Go.Private(n.name))
+}
+
+// goGetterName is the public name of the getter, e.g. Type or UserID.
+func (n errProperty) goGetterName() string {
+	return naming.Go.Public(n.name)
 }
 
 func grammarAOrAn(s string) string {
@@ -504,3 +741,173 @@ func grammarAOrAn(s string) string {
 	}
 
 }
+
+// javaTypeDecl returns the interface of the error group, which nests a final exception class for each case.
+func (n *Error) javaTypeDecl(m *ast.Macro) []ast.Node {
+	sumType := ast.NewInterface(javaErrorTypeName(n.GroupName))
+	if n.Comment != "" {
+		sumType.SetComment(n.Comment)
+	} else {
+		sumType.SetComment("...represents the sum type of all " + n.GroupName + " errors.")
+	}
+
+	for _, errorCase := range n.Cases {
+		sumType.AddPermits(ast.Name(sumType.TypeName + "." + errorCase.javaClassName()))
+		sumType.AddNamedTypes(errorCase.javaClass(sumType.TypeName))
+	}
+
+	return []ast.Node{sumType}
+}
+
+// javaClass returns the unchecked exception of this case, which implements the given group interface.
+func (n *ErrorCase) javaClass(groupType string) *ast.Struct {
+	name := n.javaClassName()
+	typ := ast.NewStruct(name).
+		SetComment(n.Comment + "\n" + name + " is also " + grammarAOrAn(groupType) + ".").
+		SetStatic(true).
+		SetFinal(true).
+		SetExtends("java.lang.RuntimeException")
+
+	typ.Implements = append(typ.Implements, ast.Name(groupType))
+
+	msgFmt := n.TypeName
+	var fieldNames []string
+	for i, property := range n.Properties {
+		fieldName := property.javaFieldName()
+		fieldNames = append(fieldNames, fieldName)
+		if i == 0 {
+			msgFmt += ": "
+		} else {
+			msgFmt += ", "
+		}
+
+		msgFmt += property.name + "=%s"
+
+		field := ast.NewField(fieldName, property.decl.Clone()).SetVisibility(ast.Private)
+		if property.comment != "" {
+			field.SetComment(property.comment)
+		}
+
+		typ.AddFields(field)
+	}
+
+	msg := strconv.Quote(msgFmt)
+	if len(fieldNames) > 0 {
+		msg = "String.format(" + msg + ", " + strings.Join(fieldNames, ", ") + ")"
+	}
+
+	// the constructor without a cause delegates to the constructor with a cause
+	ctor := ast.NewFunc(name).SetComment("...creates a new " + n.TypeName + " error without a cause.")
+	ctorWithCause := ast.NewFunc(name).SetComment("...creates a new " + n.TypeName + " error with the given cause.")
+	for _, property := range n.Properties {
+		param := ast.NewParam(property.javaFieldName(), property.decl.Clone())
+		paramWithCause := ast.NewParam(property.javaFieldName(), property.decl.Clone())
+		if property.comment != "" {
+			param.SetComment(property.comment)
+			paramWithCause.SetComment(property.comment)
+		}
+
+		ctor.AddParams(param)
+		ctorWithCause.AddParams(paramWithCause)
+	}
+
+	ctorWithCause.AddParams(ast.NewParam("cause", ast.NewSimpleTypeDecl("java.lang.Throwable")).SetComment("...refers to a causing error or null."))
+
+	body := "super(" + msg + ", cause);\n"
+	for _, fieldName := range fieldNames {
+		body += "this." + fieldName + " = " + fieldName + ";\n"
+	}
+
+	ctor.SetBody(ast.NewBlock(ast.NewTpl("this(" + strings.Join(append(fieldNames, "null"), ", ") + ")")))
+	ctorWithCause.SetBody(ast.NewBlock(ast.NewTpl(body)))
+	typ.AddMethods(ctor, ctorWithCause)
+
+	for _, property := range n.Properties {
+		doc := "...returns the value of " + property.name + ".\n" + golang.DeEllipsis(property.javaFieldName(), property.comment)
+		typ.AddMethods(
+			ast.NewFunc(property.javaFieldName()).
+				SetComment(doc).
+				AddResults(ast.NewParam("", property.decl.Clone())).
+				SetBody(ast.NewBlock(ast.NewTpl("return this." + property.javaFieldName()))),
+		)
+	}
+
+	return typ
+}
+
+// javaClassName is the name of the nested exception class, e.g. Ticket.
+func (n *ErrorCase) javaClassName() string {
+	return naming.Java.Public(n.TypeName)
+}
+
+// javaFieldName is the private field, accessor and parameter name, e.g. userId.
+func (n errProperty) javaFieldName() string {
+	return naming.Java.Escape(naming.Java.Private(n.name))
+}
+
+// javaErrorTypeName returns the name of the group interface, e.g. NotFoundError.
+func javaErrorTypeName(groupName string) string {
+	const errStr = "Error"
+	return naming.Java.Public(strings.TrimSuffix(groupName, errStr)) + errStr
+}
+
+// errorType returns the language neutral declaration of the error group, which is rendered natively by the
+// target, see ast.ErrorType.
+func (n *Error) errorType(m *ast.Macro) []ast.Node {
+	typ := ast.NewErrorType(n.GroupName)
+	if n.Comment != "" {
+		typ.SetComment(n.Comment)
+	}
+
+	for _, errorCase := range n.Cases {
+		typeCase := ast.NewErrorTypeCase(errorCase.TypeName)
+		if errorCase.Comment != "" {
+			typeCase.SetComment(errorCase.Comment)
+		}
+
+		for _, property := range errorCase.Properties {
+			field := ast.NewField(property.name, property.decl.Clone())
+			if strings.TrimSpace(property.comment) != "" {
+				field.SetComment(property.comment)
+			}
+
+			typeCase.AddProperties(field)
+		}
+
+		typ.AddCases(typeCase)
+	}
+
+	return []ast.Node{typ}
+}
+
+// rustTypeExpr returns the expression of the error enum in the scope of the given node. Outside of its declaring
+// file, the enum is referred to by its package, so that the renderer can declare its use.
+func (n *Error) rustTypeExpr(scope ast.Node) ast.Expr {
+	name := rust.ErrorTypeName(n.GroupName)
+	file := &ast.File{}
+	if !ast.ParentAs(scope, &file) {
+		return ast.NewIdent(name)
+	}
+
+	key := secretValueErrorKey(n.ID())
+	var declaring *ast.File
+	_ = ast.ForEachMod(scope, func(mod *ast.Mod) error {
+		for _, pkg := range mod.Pkgs {
+			for _, other := range pkg.PkgFiles {
+				for _, node := range other.Nodes {
+					if macro, ok := node.(*ast.Macro); ok && macro.Value(key) == n {
+						declaring = other
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if declaring == nil || declaring == file {
+		return ast.NewIdent(name)
+	}
+
+	return ast.NewQualIdent(ast.PkgFrom(declaring).Path + "." + name)
+}
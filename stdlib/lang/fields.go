@@ -3,8 +3,11 @@ package lang
 import "github.com/golangee/src/ast"
 
 // Attr returns an expression which refers to a member of the enclosing type of the func.
+//
+//	Go: the receiver is used, e.g. h.name
+//	Java: this.name
 func Attr(name string) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	return ast.NewMacro().SetKind(macroAttr, ast.MacroParams{"name": name}).SetMatchers(
 		ast.MatchTargetLanguageWithContext(ast.LangGo,
 			func(m *ast.Macro) []ast.Node {
 				var fun *ast.Func
@@ -22,5 +25,10 @@ func Attr(name string) *ast.Macro {
 				return nil
 			},
 		),
+		ast.MatchTargetLanguageWithContext(ast.LangJava,
+			func(m *ast.Macro) []ast.Node {
+				return ast.Nodes(ast.NewSelExpr(ast.NewIdent("this"), ast.NewIdent(name)))
+			},
+		),
 	)
 }
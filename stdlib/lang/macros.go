@@ -0,0 +1,116 @@
+package lang
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+)
+
+// the registered macro kinds of this package, see also ast.RegisterMacro.
+const (
+	macroSel            = "lang.Sel"
+	macroCallStatic     = "lang.CallStatic"
+	macroCallIdent      = "lang.CallIdent"
+	macroCall           = "lang.Call"
+	macroCreateLiteral  = "lang.CreateLiteral"
+	macroToString       = "lang.ToString"
+	macroItoa           = "lang.Itoa"
+	macroPanic          = "lang.Panic"
+	macroAttr           = "lang.Attr"
+	macroTerm           = "lang.Term"
+	macroCallDefine     = "lang.CallDefine"
+	macroTryDefine      = "lang.TryDefine"
+	macroErrorTypeDecl  = "lang.Error.TypeDecl"
+	macroErrorCaseMake  = "lang.ErrorCase.Make"
+	macroErrorCaseCheck = "lang.ErrorCase.Check"
+)
+
+func init() {
+	ast.RegisterMacro(macroSel, func(p ast.MacroParams) (*ast.Macro, error) {
+		if len(p.Strings("names")) == 0 {
+			return nil, fmt.Errorf("names must not be empty")
+		}
+
+		return Sel(p.Strings("names")...), nil
+	})
+
+	ast.RegisterMacro(macroCallStatic, func(p ast.MacroParams) (*ast.Macro, error) {
+		return CallStatic(ast.Name(p.String("name")), p.Exprs("args")...), nil
+	})
+
+	ast.RegisterMacro(macroCallIdent, func(p ast.MacroParams) (*ast.Macro, error) {
+		return CallIdent(p.String("ident"), p.String("method"), p.Exprs("args")...), nil
+	})
+
+	ast.RegisterMacro(macroCall, func(p ast.MacroParams) (*ast.Macro, error) {
+		return Call(p.String("ident"), p.Exprs("args")...), nil
+	})
+
+	ast.RegisterMacro(macroCreateLiteral, func(p ast.MacroParams) (*ast.Macro, error) {
+		return CreateLiteral(ast.Name(p.String("name")), p.Exprs("args")...), nil
+	})
+
+	ast.RegisterMacro(macroToString, func(p ast.MacroParams) (*ast.Macro, error) {
+		return ToString(p.Expr("expr")), nil
+	})
+
+	ast.RegisterMacro(macroItoa, func(p ast.MacroParams) (*ast.Macro, error) {
+		return Itoa(p.Expr("expr")), nil
+	})
+
+	ast.RegisterMacro(macroPanic, func(p ast.MacroParams) (*ast.Macro, error) {
+		return Panic(p.String("msg")), nil
+	})
+
+	ast.RegisterMacro(macroAttr, func(p ast.MacroParams) (*ast.Macro, error) {
+		return Attr(p.String("name")), nil
+	})
+
+	ast.RegisterMacro(macroTerm, func(p ast.MacroParams) (*ast.Macro, error) {
+		return Term(), nil
+	})
+
+	ast.RegisterMacro(macroCallDefine, func(p ast.MacroParams) (*ast.Macro, error) {
+		return CallDefine(p.Expr("lhs"), p.Expr("rhs")), nil
+	})
+
+	ast.RegisterMacro(macroTryDefine, func(p ast.MacroParams) (*ast.Macro, error) {
+		return TryDefine(p.Expr("lhs"), p.Expr("rhs"), p.String("msg")), nil
+	})
+
+	ast.RegisterMacro(macroErrorTypeDecl, func(p ast.MacroParams) (*ast.Macro, error) {
+		e := NewError(p.String("group")).SetComment(p.String("comment"))
+		for _, c := range p.ParamsList("cases") {
+			errorCase := NewErrorCase(c.String("name")).SetComment(c.String("comment"))
+			for _, prop := range c.ParamsList("properties") {
+				decl, ok := prop.Node("type").(ast.TypeDecl)
+				if !ok {
+					return nil, fmt.Errorf("property '%s' of case '%s' has no type declaration", prop.String("name"), errorCase.TypeName)
+				}
+
+				errorCase.AddProperty(prop.String("name"), decl, prop.String("comment"))
+			}
+
+			e.AddCase(errorCase)
+		}
+
+		return e.TypeDecl(), nil
+	})
+
+	ast.RegisterMacro(macroErrorCaseMake, func(p ast.MacroParams) (*ast.Macro, error) {
+		resolve := resolveErrorCase(p.String("group"), p.String("case"))
+		params := ast.MacroParams{"group": p.String("group"), "case": p.String("case")}
+
+		return newErrorCaseMake(params, resolve, p.Exprs("args")), nil
+	})
+
+	ast.RegisterMacro(macroErrorCaseCheck, func(p ast.MacroParams) (*ast.Macro, error) {
+		resolve := resolveErrorCase(p.String("group"), p.String("case"))
+		params := ast.MacroParams{"group": p.String("group"), "case": p.String("case")}
+		match, ok := p.Node("match").(*ast.Block)
+		if !ok {
+			return nil, fmt.Errorf("match must be a block")
+		}
+
+		return newErrorCaseCheck(params, resolve, ErrorCheckKind(p.String("check")), p.String("src"), p.String("dst"), match), nil
+	})
+}
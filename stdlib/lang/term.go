@@ -4,9 +4,10 @@ import "github.com/golangee/src/ast"
 
 // Term writes one or more terminator symbols, e.g.
 //  Go: \n
-//  Java: ; + \n
+//  Java: \n, because the renderer terminates each statement with a ; anyway
 func Term() *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	return ast.NewMacro().SetKind(macroTerm, nil).SetMatchers(
 		ast.MatchTargetLanguage(ast.LangGo, ast.NewSym(ast.SymNewline)),
+		ast.MatchTargetLanguage(ast.LangJava, ast.NewSym(ast.SymNewline)),
 	)
 }
@@ -7,19 +7,33 @@ import (
 )
 
 // CallDefine emits a variable (re)declaration with an assignment.
+//  Go: lhs := rhs
+//  Java: var lhs = rhs;
 func CallDefine(lhs, rhs ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
-		ast.MatchTargetLanguage(ast.LangGo, ast.NewAssign(ast.Exprs(lhs), ast.AssignDefine, ast.Exprs(rhs))),
+	assign := ast.NewAssign(ast.Exprs(lhs), ast.AssignDefine, ast.Exprs(rhs))
+
+	return ast.NewMacro().SetKind(macroCallDefine, ast.MacroParams{"lhs": lhs, "rhs": rhs}).SetMatchers(
+		ast.MatchTargetLanguage(ast.LangGo, assign),
+		ast.MatchTargetLanguage(ast.LangJava, assign),
 	)
 }
 
 // TryDefine emits a variable (re)declaration with an assignment and an error check with early return.
-// It evaluates the current context to decide how to return and how to re-throw error.
+// It evaluates the current context to decide how to return and how to re-throw error. The error variable is
+// shared by all TryDefine macros of the enclosing func and is named err, unless that name is already used
+// otherwise, see also ast.FreshIdent. In Java, exceptions are just propagated, so only the declaration is
+// emitted.
 func TryDefine(lhs, rhs ast.Expr, errMsg string) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	params := ast.MacroParams{"rhs": rhs, "msg": errMsg}
+	if lhs != nil {
+		params["lhs"] = lhs
+	}
+
+	return ast.NewMacro().SetKind(macroTryDefine, params).SetMatchers(
 		ast.MatchTargetLanguageWithContext(ast.LangGo,
 			func(m *ast.Macro) []ast.Node {
 				myFunc := assertFunc(m)
+				errIdent := ast.FreshIdent(myFunc, "err")
 				if len(myFunc.FunResults) == 0 {
 					panic("func " + myFunc.FunName + " must define at least an error return value")
 				}
@@ -55,13 +69,13 @@ func TryDefine(lhs, rhs ast.Expr, errMsg string) *ast.Macro {
 
 				}
 
-				results = append(results, CallStatic("fmt.Errorf", ast.NewStrLit(errMsg+": %w"), ast.NewIdent("err")))
+				results = append(results, CallStatic("fmt.Errorf", ast.NewStrLit(errMsg+": %w"), ast.NewIdent(errIdent)))
 
 				if lhs == nil {
 					return ast.Nodes(
-						ast.NewIfStmt(ast.NewBinaryExpr(ast.NewIdent("err"), ast.OpNotEqual, ast.NewIdent("nil")), ast.NewBlock(
+						ast.NewIfStmt(ast.NewBinaryExpr(ast.NewIdent(errIdent), ast.OpNotEqual, ast.NewIdent("nil")), ast.NewBlock(
 							ast.NewReturnStmt(results...),
-						)).SetInit(ast.NewAssign(ast.Exprs(lhs, ast.NewIdent("err")), ast.AssignDefine, ast.Exprs(rhs))),
+						)).SetInit(ast.NewAssign(ast.Exprs(lhs, ast.NewIdent(errIdent)), ast.AssignDefine, ast.Exprs(rhs))),
 
 
 						Term(),
@@ -69,9 +83,9 @@ func TryDefine(lhs, rhs ast.Expr, errMsg string) *ast.Macro {
 					)
 				} else {
 					return ast.Nodes(
-						ast.NewAssign(ast.Exprs(lhs, ast.NewIdent("err")), ast.AssignDefine, ast.Exprs(rhs)),
+						ast.NewAssign(ast.Exprs(lhs, ast.NewIdent(errIdent)), ast.AssignDefine, ast.Exprs(rhs)),
 						Term(),
-						ast.NewIfStmt(ast.NewBinaryExpr(ast.NewIdent("err"), ast.OpNotEqual, ast.NewIdent("nil")), ast.NewBlock(
+						ast.NewIfStmt(ast.NewBinaryExpr(ast.NewIdent(errIdent), ast.OpNotEqual, ast.NewIdent("nil")), ast.NewBlock(
 							ast.NewReturnStmt(results...),
 						)),
 						Term(),
@@ -80,6 +94,15 @@ func TryDefine(lhs, rhs ast.Expr, errMsg string) *ast.Macro {
 			},
 
 		),
+		ast.MatchTargetLanguageWithContext(ast.LangJava,
+			func(m *ast.Macro) []ast.Node {
+				if lhs == nil {
+					return ast.Nodes(rhs)
+				}
+
+				return ast.Nodes(ast.NewAssign(ast.Exprs(lhs), ast.AssignDefine, ast.Exprs(rhs)))
+			},
+		),
 	)
 }
 
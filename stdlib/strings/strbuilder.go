@@ -5,15 +5,48 @@ import (
 	"github.com/golangee/src/stdlib/lang"
 )
 
+const macroNewStrBuilder = "strings.NewStrBuilder"
+
+func init() {
+	ast.RegisterMacro(macroNewStrBuilder, func(p ast.MacroParams) (*ast.Macro, error) {
+		return NewStrBuilder(p.String("ident"), p.Exprs("writeStrings")...), nil
+	})
+}
+
+// NewStrBuilder declares a strings.Builder (or a StringBuilder in Java) and writes the given strings into it. If
+// ident is empty, a fresh identifier like sb or sb2 is declared, see also ast.FreshIdent.
 func NewStrBuilder(ident string, writeStrings ...ast.Expr) *ast.Macro {
-	return ast.NewMacro().SetMatchers(
+	params := ast.MacroParams{"ident": ident, "writeStrings": ast.ExprNodes(writeStrings...)}
+
+	return ast.NewMacro().SetKind(macroNewStrBuilder, params).SetMatchers(
 		ast.MatchTargetLanguageWithContext(ast.LangGo,
 			func(m *ast.Macro) []ast.Node {
+				ident := ident
+				if ident == "" {
+					ident = ast.FreshIdent(m, "sb")
+				}
+
 				decl := ast.NewAssign(ast.Exprs(ast.NewIdent(ident)), ast.AssignDefine, ast.Exprs(ast.NewUnaryExpr(lang.CreateLiteral("strings.Builder"), ast.OpAnd)))
 				var nodes []ast.Node
 				nodes = append(nodes, decl, ast.NewSym(ast.SymNewline))
 				for _, writeString := range writeStrings {
-					nodes = append(nodes, lang.CallIdent("sb", "WriteString", writeString), ast.NewSym(ast.SymNewline))
+					nodes = append(nodes, lang.CallIdent(ident, "WriteString", writeString), ast.NewSym(ast.SymNewline))
+				}
+
+				return nodes
+			},
+		),
+		ast.MatchTargetLanguageWithContext(ast.LangJava,
+			func(m *ast.Macro) []ast.Node {
+				ident := ident
+				if ident == "" {
+					ident = ast.FreshIdent(m, "sb")
+				}
+
+				decl := ast.NewAssign(ast.Exprs(ast.NewIdent(ident)), ast.AssignDefine, ast.Exprs(lang.CreateLiteral("StringBuilder")))
+				nodes := []ast.Node{decl}
+				for _, writeString := range writeStrings {
+					nodes = append(nodes, lang.CallIdent(ident, "append", writeString))
 				}
 
 				return nodes
@@ -0,0 +1,236 @@
+package swift
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+const (
+	MimeTypeSwift       = "text/x-swift"
+	MimeTypeDir         = "application/x-directory"
+	MimeTypeSwiftTarget = "application/x-directory-swift-target"
+	MimeTypeSwiftRoot   = "application/x-directory-swift-package"
+)
+
+// Options for the renderer.
+type Options struct {
+	// SkipBuildFiles disables the package manifest, so that only the sources are emitted.
+	SkipBuildFiles bool
+}
+
+// Renderer provides a swift renderer.
+type Renderer struct {
+	opts      Options
+	root      ast.Node
+	targets   map[string]*target     // targets by package path of all Swift modules
+	importers map[ast.Node]*importer // either an *ast.File or an *ast.Pkg
+}
+
+// A target is a Swift module, which is declared for each package.
+type target struct {
+	name string   // the module name, e.g. Tickets
+	mod  *ast.Mod // the module, which declares the package and becomes the Swift package
+}
+
+// NewRenderer creates a new Renderer instance.
+func NewRenderer(opts Options) *Renderer {
+	return &Renderer{opts: opts}
+}
+
+func init() {
+	render.Register(ast.LangSwift, ast.FrameworkSDK, func() render.Renderer {
+		return NewRenderer(Options{})
+	})
+}
+
+// tearUp prepares the ast to be used for source generation.
+func (r *Renderer) tearUp(node ast.Node) error {
+	r.root = ast.Root(node)
+
+	if err := r.installTargets(); err != nil {
+		return err
+	}
+
+	if err := installImporter(r); err != nil {
+		return fmt.Errorf("unable to install importer: %w", err)
+	}
+
+	return nil
+}
+
+// tearDown frees allocated resources.
+func (r *Renderer) tearDown() error {
+	if err := uninstallImporter(r); err != nil {
+		return fmt.Errorf("unable to uninstall importer: %w", err)
+	}
+
+	r.targets = nil
+
+	return nil
+}
+
+// installTargets declares a target for each package of all Swift modules. The target name must be unique within
+// its module, because it is the name of the Swift module.
+func (r *Renderer) installTargets() error {
+	r.targets = map[string]*target{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang != ast.LangSwift {
+			return nil
+		}
+
+		names := map[string]string{}
+		for _, pkg := range mod.Pkgs {
+			name := targetName(pkg)
+			if other, ok := names[name]; ok {
+				return fmt.Errorf("packages '%s' and '%s' of module '%s' declare the same target '%s'", other, pkg.Path, mod.Name, name)
+			}
+
+			names[name] = pkg.Path
+			r.targets[pkg.Path] = &target{name: name, mod: mod}
+		}
+
+		return nil
+	})
+}
+
+// targetName returns the name of the Swift module of the package, e.g. Tickets for the package
+// com.example.tickets. If the package has no name, the last segment of its path is used.
+func targetName(pkg *ast.Pkg) string {
+	name := pkg.Name
+	if name == "" {
+		name = pkg.Path[strings.LastIndexAny(pkg.Path, "./")+1:]
+	}
+
+	return naming.Swift.Pascal(name)
+}
+
+// importer resolves the current importer from the parents file.
+func (r *Renderer) importer(n ast.Node) *importer {
+	return importerFromTree(r, n)
+}
+
+// Render converts the given node into a render.Artifact. A partial result is returned if an error is detected.
+// If node is an *ast.Mod, only that module is rendered and it must target ast.LangSwift. Otherwise all Swift
+// modules of the project are rendered and other modules are ignored. Use render.Project to render mixed projects.
+func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
+	if mod, ok := node.(*ast.Mod); ok && mod.Target.Lang != ast.LangSwift {
+		return nil, fmt.Errorf("cannot render module '%s': expected language '%s' but got '%s'", mod.Name, ast.LangSwift, mod.Target.Lang)
+	}
+
+	if err := r.tearUp(node); err != nil {
+		return nil, fmt.Errorf("unable to tearUp: %w", err)
+	}
+
+	defer func() {
+		if e := r.tearDown(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	root := &render.Dir{}
+	if mod, ok := node.(*ast.Mod); ok {
+		_, err = r.renderMod(mod, root)
+		return root, err
+	}
+
+	err = ast.ForEachMod(node, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangSwift {
+			if _, err := r.renderMod(mod, root); err != nil {
+				return fmt.Errorf("cannot render module '%s': %w", mod.Name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return root, fmt.Errorf("cannot render project: %w", err)
+	}
+
+	return root, nil
+}
+
+// renderMod emits each package into the directory of its target, relative to the SourceDir of the modules
+// output directory. The package manifest is emitted last, because the dependencies of the targets are only
+// known after rendering the sources.
+func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, error) {
+	modDir := r.ensureDir(mod.Target.Out, parent)
+	modDir.MimeType = MimeTypeSwiftRoot
+
+	var firstErr error
+	srcDir := r.ensureDir(SourceDir, modDir)
+	for _, pkg := range mod.Pkgs {
+		pkgDir := r.ensureDir(r.targets[pkg.Path].name, srcDir)
+		pkgDir.MimeType = MimeTypeSwiftTarget
+
+		files, err := r.renderPkg(pkg)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render package '%s': %w", pkg.Path, err)
+		}
+
+		pkgDir.Files = append(pkgDir.Files, files...)
+	}
+
+	if err := r.renderBuild(mod, modDir); firstErr == nil && err != nil {
+		firstErr = fmt.Errorf("cannot render package manifest: %w", err)
+	}
+
+	return modDir, firstErr
+}
+
+// renderPkg emits the files of the package. Swift has no package level documentation file, so the package
+// comment is not emitted.
+func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
+	var res []*render.File
+	var firstErr error
+
+	for _, file := range pkg.PkgFiles {
+		buf, err := r.renderFile(file)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render file '%s': %w", file.Name, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: MimeTypeSwift,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	for _, file := range pkg.RawFiles {
+		buf, err := file.Data(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render raw file: %w", err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: file.MimeType,
+			Buf:      buf,
+		})
+	}
+
+	return res, firstErr
+}
+
+// ensureDir appends for each path segment a directory, if required. Returns the directory denoting
+// the last segment.
+func (r *Renderer) ensureDir(restPath string, parent *render.Dir) *render.Dir {
+	names := strings.Split(restPath, "/")
+
+	dir := parent.Directory(names[0])
+	if dir == nil {
+		dir = &render.Dir{DirName: names[0], MimeType: MimeTypeDir}
+		parent.Dirs = append(parent.Dirs, dir)
+	}
+
+	if len(names) == 1 {
+		return dir
+	}
+
+	return r.ensureDir(strings.Join(names[1:], "/"), dir)
+}
@@ -0,0 +1,164 @@
+package swift
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"strconv"
+	"strings"
+)
+
+// docWidth is the width at which the lines of a documentation comment are wrapped, including the comment prefix.
+const docWidth = 100
+
+// docPrefix starts each line of a documentation comment.
+const docPrefix = "/// "
+
+// formatComment replaces a '...' prefix with the ellipsisName and emits the structured content of the doc as
+// Swift markup, which is markdown: paragraphs are separated by an empty line, lists and code blocks use the
+// markdown syntax and doc links are emitted as symbol links. The block tags @param, @throws and @return are
+// converted into the according callouts and emitted at the end. Lines are prefixed with a '/// ' and wrapped at
+// docWidth.
+func formatComment(ellipsisName, doc string) string {
+	doc = strings.TrimLeft(strings.TrimRight(doc, " \t\n"), "\n")
+	if strings.TrimSpace(doc) == "" {
+		return ""
+	}
+
+	doc = deEllipsis(ellipsisName, strings.TrimSpace(doc))
+
+	description, tags := splitTags(doc)
+
+	tmp := &strings.Builder{}
+	first := true
+	for _, block := range ast.ParseDoc(description) {
+		if !first {
+			tmp.WriteString("///\n")
+		}
+
+		switch block.Kind {
+		case ast.DocParagraph:
+			writeDocLines(tmp, "", "", markupText(block.Text))
+		case ast.DocDeprecated:
+			// the actual marker is the @available attribute
+			writeDocLines(tmp, "", "", ast.DeprecatedPrefix+" "+markupText(block.Text))
+		case ast.DocList:
+			for i, item := range block.Items {
+				marker := "- "
+				if block.Numbered {
+					marker = strconv.Itoa(i+1) + ". "
+				}
+
+				writeDocLines(tmp, marker, strings.Repeat(" ", len(marker)), markupText(item))
+			}
+		case ast.DocCode:
+			tmp.WriteString("/// ```\n")
+			for _, line := range strings.Split(block.Text, "\n") {
+				tmp.WriteString(strings.TrimRight(docPrefix+line, " "))
+				tmp.WriteString("\n")
+			}
+
+			tmp.WriteString("/// ```\n")
+		}
+
+		first = false
+	}
+
+	if len(tags) > 0 {
+		if !first {
+			tmp.WriteString("///\n")
+		}
+
+		for _, tag := range tags {
+			writeDocLines(tmp, "", "  ", markupText(callout(tag)))
+		}
+	}
+
+	return strings.TrimRight(tmp.String(), "\n")
+}
+
+// splitTags separates the description from the block tags, which start at the first line beginning with an @.
+func splitTags(doc string) (string, []string) {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range lines[i:] {
+			switch {
+			case strings.TrimSpace(tag) == "":
+			case strings.HasPrefix(tag, "@") || len(tags) == 0:
+				tags = append(tags, strings.TrimSpace(tag))
+			default:
+				tags[len(tags)-1] += "\n" + strings.TrimSpace(tag)
+			}
+		}
+
+		return strings.Join(lines[:i], "\n"), tags
+	}
+
+	return doc, nil
+}
+
+// callout converts a block tag into a markup callout, e.g. @param id is the key becomes - Parameter id: is
+// the key. Unknown tags are kept as a list item.
+func callout(tag string) string {
+	name, text := tag, ""
+	if idx := strings.IndexAny(tag, " \n"); idx >= 0 {
+		name, text = tag[:idx], strings.TrimSpace(tag[idx+1:])
+	}
+
+	switch name {
+	case "@param":
+		param, rest := text, ""
+		if idx := strings.IndexAny(text, " \n"); idx >= 0 {
+			param, rest = text[:idx], strings.TrimSpace(text[idx+1:])
+		}
+
+		return "- Parameter " + param + ": " + rest
+	case "@throws":
+		return "- Throws: " + text
+	case "@return":
+		return "- Returns: " + text
+	default:
+		return "- " + strings.TrimPrefix(tag, "@")
+	}
+}
+
+// writeDocLines wraps each line of text and emits it with the first prefix, all following lines are indented.
+func writeDocLines(w *strings.Builder, first, indent, text string) {
+	prefix := first
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range render.WrapLine(line, docWidth-len(docPrefix)-len(prefix)) {
+			w.WriteString(strings.TrimRight(docPrefix+prefix+wrapped, " "))
+			w.WriteString("\n")
+			prefix = indent
+		}
+	}
+}
+
+// markupText emits the doc links of the text as symbol links in double backticks, which are resolved by DocC within
+// the imported modules, so a qualified link keeps only its identifier.
+func markupText(text string) string {
+	sb := &strings.Builder{}
+	for _, span := range ast.DocSpans(text) {
+		if span.Link == "" {
+			sb.WriteString(span.Text)
+			continue
+		}
+
+		sb.WriteString("``" + span.Link.Identifier() + "``")
+	}
+
+	return sb.String()
+}
+
+// deEllipsis replaces a '...' prefix of the doc with the given name.
+func deEllipsis(ellipsisName, doc string) string {
+	if strings.HasPrefix(doc, "...") {
+		return ellipsisName + " " + strings.TrimSpace(doc[3:])
+	}
+
+	return doc
+}
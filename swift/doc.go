@@ -0,0 +1,4 @@
+// Package swift provides a renderer for Swift source code and Swift package manifests. Structs become value
+// types, interfaces become protocols, enums are backed by raw values and pointers become optionals. Each
+// package of a module is a target of the Swift package.
+package swift
@@ -0,0 +1,32 @@
+package swift
+
+import (
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+// format follows the indentation of Xcode. Block comments are allowed to nest in Swift and the case labels of a
+// switch are aligned with the switch.
+var format = render.BraceFormat{
+	Indent:         "    ",
+	Quote:          render.QuotedBy(`"`),
+	NestedComments: true,
+	Level: func(line string) int {
+		if isCaseLabel(line) {
+			return -1
+		}
+
+		return 0
+	},
+}
+
+// Format pretty prints Swift source, see render.BraceFormat.Format.
+func Format(source []byte) ([]byte, error) {
+	return format.Format(source)
+}
+
+// isCaseLabel returns true, if the line is a label of a switch case, e.g. case let .ticket(id):. The case
+// declarations of an enum have no trailing colon and are indented as usual.
+func isCaseLabel(line string) bool {
+	return line == "default:" || strings.HasPrefix(line, "case ") && strings.HasSuffix(line, ":")
+}
@@ -0,0 +1,53 @@
+package swift
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	src := `
+import Foundation
+
+
+public enum Status {
+case a
+case b(s: String)
+
+public var description: String {
+switch self {
+case .a:
+return "}"
+default:
+return "\(1)"
+}
+
+}
+}
+`
+
+	want := `import Foundation
+
+public enum Status {
+    case a
+    case b(s: String)
+
+    public var description: String {
+        switch self {
+        case .a:
+            return "}"
+        default:
+            return "\(1)"
+        }
+    }
+}
+`
+
+	buf, err := Format([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+}
@@ -0,0 +1,146 @@
+package swift
+
+import (
+	"github.com/golangee/src/ast"
+	"sort"
+)
+
+// implicitModule is imported by default into every Swift file.
+const implicitModule = "Swift"
+
+// importer manages the rendered import section at the files top. Swift imports entire modules, so the qualifier
+// of a name is either the path of a package, which is resolved to its target, or the name of a module, like
+// Foundation. Types of the own target and of the standard library are in scope without an import. Simple names
+// are unique in the scope, so a colliding name is qualified by its module at the use site.
+type importer struct {
+	module             string              // the target of the scope, e.g. Tickets
+	targets            map[string]*target  // the targets of all Swift packages
+	identifiersInScope map[string]ast.Name // simple type names and their qualified names
+	imported           map[string]bool     // the modules, which require an import directive
+}
+
+// newImporter allocates an importer for a scope of the given package. The declared identifiers are the simple
+// names of the packages types, which always win against any import.
+func newImporter(pkg string, targets map[string]*target, declared []string) *importer {
+	imp := &importer{
+		module:             targets[pkg].name,
+		targets:            targets,
+		identifiersInScope: map[string]ast.Name{},
+		imported:           map[string]bool{},
+	}
+
+	for _, id := range declared {
+		imp.identifiersInScope[id] = ast.Name(pkg + "." + id)
+	}
+
+	return imp
+}
+
+// installImporter allocates a new importer instance for every ast.Pkg and every ast.File of the Swift modules.
+// The importers are owned by the renderer and never attached to the ast.
+func installImporter(r *Renderer) error {
+	r.importers = map[ast.Node]*importer{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang != ast.LangSwift {
+			return nil
+		}
+
+		for _, pkg := range mod.Pkgs {
+			var declared []string
+			for _, file := range pkg.PkgFiles {
+				for _, namedType := range file.Types() {
+					declared = append(declared, namedType.Identifier())
+				}
+			}
+
+			r.importers[pkg] = newImporter(pkg.Path, r.targets, declared)
+			for _, file := range pkg.PkgFiles {
+				r.importers[file] = newImporter(pkg.Path, r.targets, declared)
+			}
+		}
+
+		return nil
+	})
+}
+
+// uninstallImporter releases all importers.
+func uninstallImporter(r *Renderer) error {
+	r.importers = nil
+	return nil
+}
+
+// importerFromTree walks up the tree until it finds the first file or package with an importer.
+func importerFromTree(r *Renderer, n ast.Node) *importer {
+	root := n
+	for root != nil {
+		if imp, ok := r.importers[root]; ok {
+			return imp
+		}
+
+		newRoot := root.Parent()
+		if newRoot == nil {
+			panic("no attached importer found in ast scope")
+		}
+
+		root = newRoot
+	}
+
+	panic("invalid node")
+}
+
+// modules returns the sorted names of the imported modules.
+func (p *importer) modules() []string {
+	var sorted []string
+	for name := range p.imported {
+		sorted = append(sorted, name)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// moduleOf returns the Swift module of the qualifier, which is either the target of a package path or the
+// qualifier itself.
+func (p *importer) moduleOf(qualifier string) string {
+	if t, ok := p.targets[qualifier]; ok {
+		return t.name
+	}
+
+	return qualifier
+}
+
+// use imports the module, if it is neither the own nor the implicit one.
+func (p *importer) use(module string) {
+	if module != p.module && module != implicitModule {
+		p.imported[module] = true
+	}
+}
+
+// shortify returns a name, which is only valid in the importers scope and imports its module. If a collision has
+// been detected, the name is qualified by its module, e.g. Foundation.Data. If the name is not complete, the
+// original name is just returned.
+func (p *importer) shortify(name ast.Name) ast.Name {
+	qual := name.Qualifier()
+	id := name.Identifier()
+	if id == "" || qual == "" {
+		return name
+	}
+
+	module := p.moduleOf(qual)
+	p.use(module)
+
+	otherName, inScope := p.identifiersInScope[id]
+	if inScope {
+		if otherName == name {
+			return ast.Name(id)
+		}
+
+		// name collision, e.g. Foundation.Data and a declared Data
+		return ast.Name(module + "." + id)
+	}
+
+	p.identifiersInScope[id] = name
+
+	return ast.Name(id)
+}
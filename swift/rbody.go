@@ -0,0 +1,663 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// writeLineComment emits the comment as a // line comment, which is used within function bodies.
+func writeLineComment(w *render.BufferedWriter, comment *ast.Comment) {
+	if comment == nil || strings.TrimSpace(comment.Text) == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(comment.Text), "\n") {
+		w.Print("// " + strings.TrimRight(line, " \t") + "\n")
+	}
+}
+
+// renderBlock emits a block and all contained statements.
+func (r *Renderer) renderBlock(node *ast.Block, w *render.BufferedWriter) error {
+	writeLineComment(w, node.ObjComment)
+	w.Printf("{\n")
+	if err := r.renderStmts(node.Nodes, w); err != nil {
+		return err
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderStmts emits each node as a statement.
+func (r *Renderer) renderStmts(nodes []ast.Node, w *render.BufferedWriter) error {
+	for _, n := range nodes {
+		if err := r.renderStmt(n, w); err != nil {
+			return fmt.Errorf("unable to render node in block: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderStmt emits a node in statement position. Each statement is terminated by a line break, because Swift
+// needs no semicolons and macros are expanded into statements.
+func (r *Renderer) renderStmt(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Macro:
+		writeLineComment(w, n.Comment())
+		for _, child := range n.Children() {
+			if err := r.renderStmt(child, w); err != nil {
+				return fmt.Errorf("unable to render dynamic macro node: %w", err)
+			}
+		}
+
+		return nil
+	case *ast.Sym:
+		// each statement is terminated anyway
+		if n.Kind == ast.SymTermStmt {
+			return nil
+		}
+
+		return r.renderNode(n, w)
+	case *ast.Block, *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.ReturnStmt:
+		return r.renderNode(n, w)
+	case *ast.Tpl:
+		tmp := &render.BufferedWriter{}
+		if err := r.renderTpl(n, tmp); err != nil {
+			return err
+		}
+
+		w.Print(strings.TrimSpace(tmp.String()))
+		w.Printf("\n")
+
+		return nil
+	case *ast.Assign:
+		writeLineComment(w, n.ObjComment)
+	}
+
+	if err := r.renderNode(node, w); err != nil {
+		return err
+	}
+
+	w.Printf("\n")
+
+	return nil
+}
+
+// renderAssign emits an assignment. A definition declares a local constant, which becomes a var, if it is
+// assigned again within the enclosing function.
+func (r *Renderer) renderAssign(node *ast.Assign, w *render.BufferedWriter) error {
+	if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+		return fmt.Errorf("swift does not support multiple assignments")
+	}
+
+	if node.Kind == ast.AssignDefine {
+		if isReassigned(node) {
+			w.Printf("var ")
+		} else {
+			w.Printf("let ")
+		}
+	}
+
+	if err := r.renderNode(node.Lhs[0], w); err != nil {
+		return fmt.Errorf("unable to render lhs: %w", err)
+	}
+
+	switch node.Kind {
+	case ast.AssignSimple, ast.AssignDefine:
+		w.Printf(" = ")
+	case ast.AssignAdd:
+		w.Printf(" += ")
+	case ast.AssignSub:
+		w.Printf(" -= ")
+	case ast.AssignMul:
+		w.Printf(" *= ")
+	case ast.AssignRem:
+		w.Print(" %= ")
+	default:
+		return fmt.Errorf("assignment not implemented: %d", node.Kind)
+	}
+
+	if err := r.renderNode(node.Rhs[0], w); err != nil {
+		return fmt.Errorf("unable to render rhs: %w", err)
+	}
+
+	return nil
+}
+
+// isReassigned returns true, if the identifier defined by the assignment is the target of another assignment
+// or of an increment or decrement within the enclosing function.
+func isReassigned(def *ast.Assign) bool {
+	ident, ok := def.Lhs[0].(*ast.Ident)
+	if !ok {
+		return true
+	}
+
+	var scope ast.Node = def
+	fun := &ast.Func{}
+	if ast.ParentAs(def, &fun) {
+		scope = fun
+	}
+
+	found := false
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		switch t := node.(type) {
+		case *ast.Assign:
+			if t != def && t.Kind != ast.AssignDefine && len(t.Lhs) > 0 {
+				if other, ok := t.Lhs[0].(*ast.Ident); ok && other.Name == ident.Name {
+					found = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if t.Op == ast.OpInc || t.Op == ast.OpDec {
+				if other, ok := t.X.(*ast.Ident); ok && other.Name == ident.Name {
+					found = true
+				}
+			}
+		}
+
+		if p, ok := node.(ast.Parent); ok && !found {
+			for _, child := range p.Children() {
+				walk(child)
+			}
+		}
+	}
+
+	walk(scope)
+
+	return found
+}
+
+// renderIfStmt emits an if statement. Swift has no init statement, so it is declared in front and both are
+// wrapped into a do block, to keep the scope.
+func (r *Renderer) renderIfStmt(node *ast.IfStmt, w *render.BufferedWriter) error {
+	if node.Init != nil {
+		w.Printf("do {\n")
+		if err := r.renderStmt(node.Init, w); err != nil {
+			return fmt.Errorf("unable to render init: %w", err)
+		}
+	}
+
+	w.Printf("if ")
+	if err := r.renderNode(node.Cond, w); err != nil {
+		return fmt.Errorf("unable to render cond: %w", err)
+	}
+
+	w.Printf(" ")
+	body := &render.BufferedWriter{}
+	if err := r.renderNode(node.Body, body); err != nil {
+		return fmt.Errorf("unable to render body: %w", err)
+	}
+
+	if node.Else == nil {
+		w.Print(body.String())
+	} else {
+		// the else keyword continues the line of the closing brace
+		w.Print(strings.TrimRight(body.String(), "\n"))
+		w.Printf(" else ")
+		if err := r.renderNode(node.Else, w); err != nil {
+			return fmt.Errorf("unable to render else: %w", err)
+		}
+	}
+
+	if node.Init != nil {
+		w.Printf("}\n")
+	}
+
+	return nil
+}
+
+// renderForStmt emits a while loop. Swift has no three-clause for loop, so the init statement is declared in
+// front of the loop and the post statement is appended to the body. Both are wrapped into a do block, to keep
+// the scope.
+func (r *Renderer) renderForStmt(node *ast.ForStmt, w *render.BufferedWriter) error {
+	if node.Init != nil {
+		w.Printf("do {\n")
+		if err := r.renderStmt(node.Init, w); err != nil {
+			return fmt.Errorf("unable to render init: %w", err)
+		}
+	}
+
+	w.Printf("while ")
+	if node.Cond == nil {
+		w.Printf("true")
+	} else if err := r.renderNode(node.Cond, w); err != nil {
+		return fmt.Errorf("unable to render cond: %w", err)
+	}
+
+	w.Printf(" ")
+
+	if node.Post == nil {
+		if err := r.renderNode(node.Body, w); err != nil {
+			return fmt.Errorf("unable to render body: %w", err)
+		}
+	} else {
+		writeLineComment(w, node.Body.ObjComment)
+		w.Printf("{\n")
+		if err := r.renderStmts(node.Body.Nodes, w); err != nil {
+			return fmt.Errorf("unable to render body: %w", err)
+		}
+
+		if err := r.renderStmt(node.Post, w); err != nil {
+			return fmt.Errorf("unable to render post: %w", err)
+		}
+
+		w.Printf("}\n")
+	}
+
+	if node.Init != nil {
+		w.Printf("}\n")
+	}
+
+	return nil
+}
+
+// renderRangeStmt emits a for-in loop. A key and a value are destructured, e.g. the entries of a dictionary.
+func (r *Renderer) renderRangeStmt(node *ast.RangeStmt, w *render.BufferedWriter) error {
+	w.Printf("for ")
+	switch {
+	case node.Key != nil && node.Val != nil:
+		w.Printf("(")
+		if err := r.renderNode(node.Key, w); err != nil {
+			return fmt.Errorf("unable to render key: %w", err)
+		}
+
+		w.Printf(", ")
+		if err := r.renderNode(node.Val, w); err != nil {
+			return fmt.Errorf("unable to render val: %w", err)
+		}
+
+		w.Printf(")")
+	case node.Key != nil:
+		return fmt.Errorf("swift cannot range over keys, declare the value or both")
+	case node.Val != nil:
+		if err := r.renderNode(node.Val, w); err != nil {
+			return fmt.Errorf("unable to render val: %w", err)
+		}
+	default:
+		w.Printf("_")
+	}
+
+	w.Printf(" in ")
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render range target: %w", err)
+	}
+
+	w.Printf(" ")
+
+	return r.renderNode(node.Body, w)
+}
+
+// renderReturnStmt emits a return statement. Just like in Java, the additional results are thrown errors, so
+// trailing nil results are omitted.
+func (r *Renderer) renderReturnStmt(node *ast.ReturnStmt, w *render.BufferedWriter) error {
+	results := node.Results
+	for len(results) > 1 {
+		if ident, ok := results[len(results)-1].(*ast.Ident); !ok || ident.Name != "nil" {
+			return fmt.Errorf("swift cannot return multiple values, throw an error instead")
+		}
+
+		results = results[:len(results)-1]
+	}
+
+	w.Printf("return")
+	if len(results) == 1 {
+		w.Printf(" ")
+		if err := r.renderNode(results[0], w); err != nil {
+			return fmt.Errorf("unable to render result: %w", err)
+		}
+	}
+
+	w.Printf("\n")
+
+	return nil
+}
+
+// renderCallExpr emits a function call. Named arguments (label: value) keep their argument label. Swift cannot
+// spread an array into variadic parameters.
+func (r *Renderer) renderCallExpr(node *ast.CallExpr, w *render.BufferedWriter) error {
+	if node.Ellipsis {
+		return fmt.Errorf("swift cannot pass an array as variadic arguments")
+	}
+
+	if err := r.renderNode(node.Fun, w); err != nil {
+		return fmt.Errorf("cannot render function expression: %w", err)
+	}
+
+	w.Printf("(")
+	for i, n := range node.Args {
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render argument: %w", err)
+		}
+
+		if i < len(node.Args)-1 {
+			w.Printf(", ")
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// renderSelExpr emits a X.Sel expression.
+func (r *Renderer) renderSelExpr(node *ast.SelExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render selector target: %w", err)
+	}
+
+	w.Printf(".")
+
+	return r.renderIdent(node.Sel, w)
+}
+
+// renderIdent emits an identifier. The receiver this becomes self and other keywords are escaped, except those
+// which are valid expressions.
+func (r *Renderer) renderIdent(node *ast.Ident, w *render.BufferedWriter) error {
+	switch node.Name {
+	case "this":
+		w.Printf("self")
+	case "self", "Self", "super", "true", "false", "nil":
+		w.Printf(node.Name)
+	default:
+		w.Printf(naming.Swift.Escape(node.Name))
+	}
+
+	return nil
+}
+
+// renderQualIdent emits an imported type, e.g. UUID for Foundation.UUID.
+func (r *Renderer) renderQualIdent(node *ast.QualIdent, w *render.BufferedWriter) error {
+	w.Printf(string(r.importer(node).shortify(ast.Name(node.Qualifier))))
+
+	return nil
+}
+
+// renderBasicLit emits a literal. Go string and rune literals are converted into Swift string literals.
+func (r *Renderer) renderBasicLit(node *ast.BasicLit, w *render.BufferedWriter) error {
+	if strings.HasPrefix(node.Val, `"`) || strings.HasPrefix(node.Val, "`") || strings.HasPrefix(node.Val, "'") {
+		s, err := strconv.Unquote(node.Val)
+		if err != nil {
+			return fmt.Errorf("invalid string literal %s: %w", node.Val, err)
+		}
+
+		w.Print(swiftQuote(s))
+
+		return nil
+	}
+
+	w.Printf(node.Val)
+
+	return nil
+}
+
+// swiftQuote returns a double quoted Swift string literal. In contrast to Go, a backslash followed by a
+// parenthesis starts an interpolation, so the backslash is always escaped. Unicode escapes use braces.
+func swiftQuote(s string) string {
+	sb := &strings.Builder{}
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case 0:
+			sb.WriteString(`\0`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u{%x}`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// renderCompLit emits an initializer call. Named elements (key: value) become argument labels.
+func (r *Renderer) renderCompLit(node *ast.CompLit, w *render.BufferedWriter) error {
+	if node.Type == nil {
+		return fmt.Errorf("swift cannot create anonymous literals")
+	}
+
+	if decl, ok := node.Type.(ast.TypeDecl); ok {
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+	} else if err := r.renderNode(node.Type, w); err != nil {
+		return fmt.Errorf("unable to render type: %w", err)
+	}
+
+	w.Printf("(")
+	for i, element := range node.Elements {
+		if kv, ok := element.(*ast.BinaryExpr); ok && kv.Op == ast.OpColon {
+			if err := r.renderNode(kv.X, w); err != nil {
+				return fmt.Errorf("unable to render argument name: %w", err)
+			}
+
+			w.Printf(": ")
+			element = kv.Y
+		}
+
+		if err := r.renderNode(element, w); err != nil {
+			return fmt.Errorf("unable to render composite elem: %w", err)
+		}
+
+		if i < len(node.Elements)-1 {
+			w.Printf(", ")
+		}
+	}
+
+	w.Printf(")")
+
+	return nil
+}
+
+// swiftBinaryOperators maps the operators to their Swift counterparts.
+var swiftBinaryOperators = map[ast.Operator]string{
+	ast.OpAdd: "+", ast.OpSub: "-", ast.OpMul: "*", ast.OpQuo: "/", ast.OpREM: "%",
+	ast.OpAnd: "&", ast.OpOr: "|", ast.OpXOR: "^", ast.OpShl: "<<", ast.OpShr: ">>",
+	ast.OpLAnd: "&&", ast.OpLOr: "||", ast.OpEqual: "==", ast.OpLess: "<", ast.OpGreater: ">",
+	ast.OpNotEqual: "!=", ast.OpLessEqual: "<=", ast.OpGreaterEqual: ">=",
+}
+
+// renderBinaryExpr emits a binary expression. The Go and not operator x &^ y becomes x & ~(y) and a key value
+// pair becomes an argument label, e.g. id: x.
+func (r *Renderer) renderBinaryExpr(node *ast.BinaryExpr, w *render.BufferedWriter) error {
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render x: %w", err)
+	}
+
+	switch node.Op {
+	case ast.OpAndNot:
+		w.Printf(" & ~(")
+		if err := r.renderNode(node.Y, w); err != nil {
+			return fmt.Errorf("unable to render y: %w", err)
+		}
+
+		w.Printf(")")
+
+		return nil
+	case ast.OpColon:
+		w.Printf(": ")
+		return r.renderNode(node.Y, w)
+	}
+
+	op, ok := swiftBinaryOperators[node.Op]
+	if !ok {
+		return fmt.Errorf("operator not supported by swift: %d", node.Op)
+	}
+
+	w.Printf(" " + op + " ")
+	if err := r.renderNode(node.Y, w); err != nil {
+		return fmt.Errorf("unable to render y: %w", err)
+	}
+
+	return nil
+}
+
+// renderUnaryExpr emits a unary expression. Swift has no pointers, so taking the address just refers to the
+// value itself and dereferencing unwraps the optional. Swift has no increment and decrement operators, so they
+// become compound assignments.
+func (r *Renderer) renderUnaryExpr(node *ast.UnaryExpr, w *render.BufferedWriter) error {
+	switch node.Op {
+	case ast.OpAdd:
+		w.Printf("+")
+	case ast.OpSub:
+		w.Printf("-")
+	case ast.OpNot:
+		w.Printf("!")
+	case ast.OpXOR:
+		w.Printf("~")
+	case ast.OpAnd, ast.OpMul, ast.OpInc, ast.OpDec:
+	default:
+		return fmt.Errorf("operator not supported by swift: %d", node.Op)
+	}
+
+	if err := r.renderNode(node.X, w); err != nil {
+		return fmt.Errorf("unable to render x: %w", err)
+	}
+
+	switch node.Op {
+	case ast.OpMul:
+		w.Printf("!")
+	case ast.OpInc:
+		w.Printf(" += 1")
+	case ast.OpDec:
+		w.Printf(" -= 1")
+	}
+
+	return nil
+}
+
+// renderMacro emits the evaluated nodes of a macro in expression position.
+func (r *Renderer) renderMacro(node *ast.Macro, w *render.BufferedWriter) error {
+	for _, n := range node.Children() {
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render dynamic macro node: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderSym emits a line break. A statement terminator is omitted, because Swift does not need it.
+func (r *Renderer) renderSym(node *ast.Sym, w *render.BufferedWriter) error {
+	switch node.Kind {
+	case ast.SymTermStmt:
+	case ast.SymNewline:
+		w.Printf("\n")
+	default:
+		return fmt.Errorf("unknown sym: %d", node.Kind)
+	}
+
+	return nil
+}
+
+// renderTpl executes and emits the template text.
+func (r *Renderer) renderTpl(node *ast.Tpl, w *render.BufferedWriter) error {
+	tmpl, err := template.New(node.ObjPos.String()).Parse(node.Template)
+	if err != nil {
+		return fmt.Errorf("cannot parse template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, &tplRenderContext{importer: r.importer(node), tpl: node}); err != nil {
+		return fmt.Errorf("cannot execute template: %w", err)
+	}
+
+	w.Print(buf.String())
+
+	return nil
+}
+
+// ensure that we always implement the full contract
+var _ ast.TplContext = (*tplRenderContext)(nil)
+
+type tplRenderContext struct {
+	importer *importer
+	tpl      *ast.Tpl
+}
+
+func (t *tplRenderContext) Get(key string) interface{} {
+	return t.tpl.Values[key]
+}
+
+func (t *tplRenderContext) Use(name string) string {
+	return string(t.importer.shortify(fromStdlib(ast.Name(name))))
+}
+
+func (t *tplRenderContext) Self() *ast.Tpl {
+	return t.tpl
+}
+
+// renderExpr dispatches the statements and expressions of function bodies. It returns false, if the node is not
+// a statement or an expression.
+func (r *Renderer) renderExpr(node ast.Node, w *render.BufferedWriter) (bool, error) {
+	var err error
+	switch n := node.(type) {
+	case *ast.Block:
+		err = r.renderBlock(n, w)
+	case *ast.Assign:
+		err = r.renderAssign(n, w)
+	case *ast.IfStmt:
+		err = r.renderIfStmt(n, w)
+	case *ast.ForStmt:
+		err = r.renderForStmt(n, w)
+	case *ast.RangeStmt:
+		err = r.renderRangeStmt(n, w)
+	case *ast.ReturnStmt:
+		err = r.renderReturnStmt(n, w)
+	case *ast.CallExpr:
+		err = r.renderCallExpr(n, w)
+	case *ast.SelExpr:
+		err = r.renderSelExpr(n, w)
+	case *ast.Ident:
+		err = r.renderIdent(n, w)
+	case *ast.QualIdent:
+		err = r.renderQualIdent(n, w)
+	case *ast.BasicLit:
+		err = r.renderBasicLit(n, w)
+	case *ast.CompLit:
+		err = r.renderCompLit(n, w)
+	case *ast.BinaryExpr:
+		err = r.renderBinaryExpr(n, w)
+	case *ast.UnaryExpr:
+		err = r.renderUnaryExpr(n, w)
+	case *ast.Macro:
+		err = r.renderMacro(n, w)
+	case *ast.Sym:
+		err = r.renderSym(n, w)
+	case *ast.Tpl:
+		err = r.renderTpl(n, w)
+	case ast.TypeDecl:
+		err = r.renderTypeDecl(n, w)
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("cannot render %s: %w", reflect.TypeOf(node).Elem().Name(), err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,160 @@
+package swift
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// SourceDir is the standard directory of the targets, relative to the module.
+	SourceDir = "Sources"
+
+	// ManifestName is the name of the package manifest.
+	ManifestName = "Package.swift"
+
+	// DeploymentTargetIOS is the minimum iOS version, which supports Swift 5.1, Combine and SwiftUI.
+	DeploymentTargetIOS = "v13"
+
+	// DeploymentTargetMacOS is the according minimum macOS version.
+	DeploymentTargetMacOS = "v10_15"
+
+	MimeTypeSwiftManifest = "text/x-swift-package"
+)
+
+// packageName returns the name of the Swift package, which is the last segment of the module name, e.g. tickets
+// for example.com/tickets or com.example.tickets.
+func packageName(mod *ast.Mod) string {
+	return mod.Name[strings.LastIndexAny(mod.Name, "./:")+1:]
+}
+
+// targetDependencies returns the sorted targets, which are imported by the files of the package. Modules which
+// are not declared by the project, like Foundation, are ignored.
+func (r *Renderer) targetDependencies(pkg *ast.Pkg) []*target {
+	own := r.targets[pkg.Path]
+	used := map[string]bool{}
+	for _, file := range pkg.PkgFiles {
+		for _, module := range r.importers[file].modules() {
+			used[module] = true
+		}
+	}
+
+	var res []*target
+	for _, t := range r.targets {
+		if t != own && used[t.name] {
+			res = append(res, t)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+
+	return res
+}
+
+// renderBuild emits the package manifest of the module into modDir. Each package becomes a target with a library
+// product of the same name. A target, which imports a package of another Swift module, depends on it as a local
+// package, so all modules require an output directory.
+func (r *Renderer) renderBuild(mod *ast.Mod, modDir *render.Dir) error {
+	if r.opts.SkipBuildFiles {
+		return nil
+	}
+
+	toolsVersion := mod.Target.MinLangVersion
+	if toolsVersion == "" {
+		toolsVersion = ast.LangVersionSwift
+	}
+
+	var tmp strings.Builder
+	tmp.WriteString("// swift-tools-version:" + string(toolsVersion) + "\n")
+	tmp.WriteString("import PackageDescription\n\n")
+	tmp.WriteString("let package = Package(\n")
+	tmp.WriteString("    name: " + swiftQuote(packageName(mod)) + ",\n")
+
+	switch mod.Target.Os {
+	case ast.OSIOS:
+		tmp.WriteString("    platforms: [\n        .iOS(." + DeploymentTargetIOS + "),\n    ],\n")
+	case ast.OSDarwin:
+		tmp.WriteString("    platforms: [\n        .macOS(." + DeploymentTargetMacOS + "),\n    ],\n")
+	}
+
+	tmp.WriteString("    products: [\n")
+	for _, pkg := range mod.Pkgs {
+		name := swiftQuote(r.targets[pkg.Path].name)
+		tmp.WriteString("        .library(name: " + name + ", targets: [" + name + "]),\n")
+	}
+
+	tmp.WriteString("    ],\n")
+
+	var targets strings.Builder
+	locals := map[string]bool{}
+	for _, pkg := range mod.Pkgs {
+		var deps []string
+		for _, dep := range r.targetDependencies(pkg) {
+			deps = append(deps, swiftQuote(dep.name))
+			if dep.mod == mod {
+				continue
+			}
+
+			if mod.Target.Out == "" || dep.mod.Target.Out == "" {
+				return fmt.Errorf("module '%s' depends on module '%s', which requires an output directory for both", mod.Name, dep.mod.Name)
+			}
+
+			locals[relativePath(mod.Target.Out, dep.mod.Target.Out)] = true
+		}
+
+		targets.WriteString("        .target(name: " + swiftQuote(r.targets[pkg.Path].name) + ", dependencies: [" + strings.Join(deps, ", ") + "]),\n")
+	}
+
+	if len(locals) > 0 {
+		var sorted []string
+		for local := range locals {
+			sorted = append(sorted, local)
+		}
+
+		sort.Strings(sorted)
+
+		tmp.WriteString("    dependencies: [\n")
+		for _, local := range sorted {
+			tmp.WriteString("        .package(path: " + swiftQuote(local) + "),\n")
+		}
+
+		tmp.WriteString("    ],\n")
+	}
+
+	tmp.WriteString("    targets: [\n")
+	tmp.WriteString(targets.String())
+	tmp.WriteString("    ]\n")
+	tmp.WriteString(")\n")
+
+	modDir.Files = append(modDir.Files, &render.File{
+		FileName: ManifestName,
+		MimeType: MimeTypeSwiftManifest,
+		Buf:      []byte(tmp.String()),
+	})
+
+	return nil
+}
+
+// relativePath returns the path of the directory to, relative to the directory from. Both are relative to the
+// same root, e.g. ../core for ios/app and ios/core.
+func relativePath(from, to string) string {
+	from, to = path.Clean(from), path.Clean(to)
+	fromSegments := strings.Split(from, "/")
+	toSegments := strings.Split(to, "/")
+	i := 0
+	for i < len(fromSegments) && i < len(toSegments) && fromSegments[i] == toSegments[i] {
+		i++
+	}
+
+	var res []string
+	for range fromSegments[i:] {
+		res = append(res, "..")
+	}
+
+	return path.Join(append(res, toSegments[i:]...)...)
+}
@@ -0,0 +1,60 @@
+package swift
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"testing"
+)
+
+func TestRenderer_RenderBuildMultiModule(t *testing.T) {
+	core := ast.NewMod("example.com/shop/core").
+		SetLang(ast.LangSwift).
+		SetLangVersion("5.5").
+		SetOS(ast.OSDarwin).
+		SetOutputDirectory("swift/core").
+		AddPackages(ast.NewPkg("example.com/shop/core/model").AddFiles(
+			ast.NewFile("Order.swift").AddTypes(ast.NewStruct("Order")),
+		))
+
+	app := ast.NewMod("example.com/shop/app").
+		SetLang(ast.LangSwift).
+		SetOutputDirectory("swift/app").
+		AddPackages(ast.NewPkg("example.com/shop/app/checkout").AddFiles(
+			ast.NewFile("Checkout.swift").AddTypes(ast.NewStruct("Checkout").AddFields(
+				ast.NewField("order", ast.NewSimpleTypeDecl("example.com/shop/core/model.Order")),
+			)),
+		))
+
+	a, err := NewRenderer(Options{}).Render(ast.NewPrj("Shop").AddModules(core, app))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := a.(*render.Dir)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "swift/core/Package.swift"),
+		"// swift-tools-version:5.5\n",
+		"    platforms: [\n        .macOS(.v10_15),\n    ],\n",
+		"        .target(name: \"Model\", dependencies: []),\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "swift/app/Package.swift"),
+		"    name: \"app\",\n",
+		"    dependencies: [\n        .package(path: \"../core\"),\n    ],\n",
+		"        .target(name: \"Checkout\", dependencies: [\"Model\"]),\n",
+	)
+	srctest.AssertContains(t, srctest.ReadFile(t, dir, "swift/app/Sources/Checkout/Checkout.swift"), "import Model\n")
+}
+
+func TestRelativePath(t *testing.T) {
+	cases := [][3]string{
+		{"ios/app", "ios/core", "../core"},
+		{"app", "libs/core", "../libs/core"},
+		{"a/b/c", "a", "../.."},
+	}
+
+	for _, c := range cases {
+		if got := relativePath(c[0], c[1]); got != c[2] {
+			t.Fatalf("expected %s but got %s", c[2], got)
+		}
+	}
+}
@@ -0,0 +1,922 @@
+package swift
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonAnnotation is the name of the field annotation, which declares the key of a field in the JSON encoding,
+// just like the Go struct tag, e.g. json:"id,omitempty" or json:"-".
+const jsonAnnotation = "json"
+
+func writeComment(w *render.BufferedWriter, name, doc string) {
+	myDoc := formatComment(name, doc)
+	if myDoc != "" {
+		w.Print(myDoc)
+		w.Printf("\n")
+	}
+}
+
+func writeCommentNode(w *render.BufferedWriter, name string, comment *ast.Comment) {
+	if comment == nil {
+		return
+	}
+
+	writeComment(w, name, comment.Text)
+}
+
+// writeDeclComment emits the comment of a declaration together with its deprecation notice.
+func writeDeclComment(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation) {
+	text := ""
+	if comment != nil {
+		text = comment.Text
+	}
+
+	writeComment(w, name, ast.DocWithDeprecation(text, deprecation))
+}
+
+// writeDeprecated emits the @available attribute in its own line, if required.
+func writeDeprecated(w *render.BufferedWriter, deprecation *ast.Deprecation) {
+	if deprecation != nil {
+		w.Print(deprecatedAttribute(deprecation))
+		w.Printf("\n")
+	}
+}
+
+// deprecatedAttribute returns the @available attribute, which deprecates the declaration on all platforms. A
+// replacement becomes the renamed argument, so that Xcode offers a fix-it.
+func deprecatedAttribute(deprecation *ast.Deprecation) string {
+	attr := "@available(*, deprecated"
+	if reason := strings.TrimSpace(deprecation.Reason); reason != "" {
+		attr += ", message: " + swiftQuote(reason)
+	}
+
+	if deprecation.Replacement != "" {
+		attr += ", renamed: " + swiftQuote(string(deprecation.Replacement))
+	}
+
+	return attr + ")"
+}
+
+// renderFile tries to emit the file as swift.
+func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
+	w := &render.BufferedWriter{}
+
+	if file.Preamble != nil {
+		writeLineComment(w, file.Preamble)
+		w.Printf("\n")
+	}
+
+	if file.Comment() != nil {
+		writeLineComment(w, file.Comment())
+		w.Printf("\n")
+	}
+
+	// the explicit imports denote modules or packages
+	importer := r.importer(file)
+	for _, imp := range file.Imports() {
+		importer.use(importer.moduleOf(string(imp.Name)))
+	}
+
+	// render everything into tmp first, the importer collects all required modules on-the-go
+	tmp := &render.BufferedWriter{}
+	for _, node := range file.Nodes {
+		if _, ok := node.(*ast.Import); ok {
+			continue
+		}
+
+		if err := r.renderNode(node, tmp); err != nil {
+			return nil, err
+		}
+
+		tmp.Printf("\n")
+	}
+
+	modules := importer.modules()
+	for _, module := range modules {
+		w.Printf("import %s\n", module)
+	}
+
+	if len(modules) > 0 {
+		w.Printf("\n")
+	}
+
+	w.Print(tmp.String())
+
+	return Format(w.Bytes())
+}
+
+// renderNode inspects and emits the actual type.
+func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Struct:
+		if err := r.renderStruct(n, w); err != nil {
+			return fmt.Errorf("cannot render struct '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Interface:
+		if err := r.renderInterface(n, w); err != nil {
+			return fmt.Errorf("cannot render interface '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Enum:
+		if err := r.renderEnum(n, w); err != nil {
+			return fmt.Errorf("cannot render enum '%s': %w", n.Identifier(), err)
+		}
+	case *ast.ErrorType:
+		if err := r.renderErrorType(n, w); err != nil {
+			return fmt.Errorf("cannot render error type '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Func:
+		if err := r.renderFunc(n, w); err != nil {
+			return fmt.Errorf("cannot render func '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Property:
+		if err := r.renderProperty(n, w); err != nil {
+			return fmt.Errorf("cannot render property '%s': %w", n.Identifier(), err)
+		}
+	default:
+		ok, err := r.renderExpr(n, w)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("type not yet implemented: %s", reflect.TypeOf(n).String())
+		}
+	}
+
+	return nil
+}
+
+func (r *Renderer) renderTypePreamble(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation, annotations []*ast.Annotation) error {
+	writeDeclComment(w, name, comment, deprecation)
+	writeDeprecated(w, deprecation)
+
+	for _, annotation := range annotations {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
+	}
+
+	return nil
+}
+
+// renderInterface emits a protocol, whose embedded types become inherited protocols. A protocol cannot declare
+// a method body, so methods with a body are emitted as default implementations in a protocol extension. Swift
+// has no sealed protocols, so the permitted implementations are ignored.
+func (r *Renderer) renderInterface(node *ast.Interface, w *render.BufferedWriter) error {
+	if len(node.NamedTypes()) > 0 {
+		return fmt.Errorf("a protocol cannot declare nested types")
+	}
+
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), node.Annotations()); err != nil {
+		return err
+	}
+
+	visibility := visibilityAsModifier(node.Visibility())
+	w.Printf(visibility)
+	w.Printf("protocol %s", node.Identifier())
+
+	for i, decl := range node.Embedded {
+		if i == 0 {
+			w.Printf(": ")
+		} else {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(" {\n")
+	for _, property := range node.Properties() {
+		if err := r.renderProperty(property, w); err != nil {
+			return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	var defaults []*ast.Func
+	for _, fun := range node.Methods() {
+		if fun.Body() != nil {
+			defaults = append(defaults, fun)
+		}
+
+		modifiers := ""
+		if fun.Static() {
+			modifiers = "static "
+		}
+
+		if err := r.renderFuncDecl(fun, modifiers, false, w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+		}
+
+		w.Printf("\n\n")
+	}
+
+	w.Printf("}\n")
+
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	w.Printf("\n%sextension %s {\n", visibility, node.Identifier())
+	for _, fun := range defaults {
+		if err := r.renderFunc(fun, w); err != nil {
+			return fmt.Errorf("failed to render default func %s: %w", fun.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderStruct emits a struct, which is a value type. Fields are mutable, unless the struct is declared as a
+// record. Swift synthesizes only an internal memberwise initializer, so an initializer with the visibility of
+// the struct is emitted, whose parameters default to the zero values. If a field declares a json annotation,
+// the struct conforms to Codable and the coding keys are emitted as required. Swift structs cannot inherit, so
+// only the implemented interfaces are conformed.
+func (r *Renderer) renderStruct(node *ast.Struct, w *render.BufferedWriter) error {
+	if node.Extends != "" {
+		return fmt.Errorf("a swift struct cannot extend '%s'", node.Extends)
+	}
+
+	if err := r.renderTypePreamble(w, node.Identifier(), node.Comment(), node.Deprecated(), node.Annotations()); err != nil {
+		return err
+	}
+
+	keys, codable := codingKeys(node)
+
+	w.Printf(visibilityAsModifier(node.Visibility()))
+	w.Printf("struct %s", node.Identifier())
+
+	var conformances []string
+	if codable {
+		conformances = append(conformances, string(r.importer(node).shortify("Swift.Codable")))
+	}
+
+	for _, name := range node.Implements {
+		conformances = append(conformances, string(r.importer(node).shortify(name)))
+	}
+
+	if len(conformances) > 0 {
+		w.Printf(": %s", strings.Join(conformances, ", "))
+	}
+
+	w.Printf(" {\n")
+
+	for _, typeNode := range node.NamedTypes() {
+		if err := r.renderNode(typeNode, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	for _, field := range node.Fields() {
+		if err := r.renderField(field, node.Record(), keys, w); err != nil {
+			return fmt.Errorf("failed to render field %s: %w", field.Identifier(), err)
+		}
+	}
+
+	if len(node.Fields()) > 0 {
+		w.Printf("\n")
+	}
+
+	for _, property := range node.Properties() {
+		if err := r.renderProperty(property, w); err != nil {
+			return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	if len(node.Fields()) > 0 {
+		if err := r.renderMemberwiseInit(node, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	if keys != nil {
+		renderCodingKeys(node, keys, w)
+		w.Printf("\n")
+	}
+
+	for _, fun := range node.Methods() {
+		if err := r.renderFunc(fun, w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// codingKeys returns the JSON keys of the fields, if the struct is codable, which is the case if any field
+// declares a json annotation. The options after the key, like omitempty, are ignored and a field with the key -
+// is excluded. The keys are nil, if the synthesized keys are equal to the property names.
+func codingKeys(node *ast.Struct) (map[*ast.Field]string, bool) {
+	codable := false
+	custom := false
+	keys := map[*ast.Field]string{}
+	for _, field := range node.Fields() {
+		name := fieldName(field)
+		keys[field] = name
+		for _, annotation := range field.Annotations() {
+			if annotation.Identifier() != jsonAnnotation {
+				continue
+			}
+
+			codable = true
+			key := strings.TrimSpace(strings.Split(annotation.GetLiteral(""), ",")[0])
+			if key != "" {
+				keys[field] = key
+			}
+
+			if key != "" && key != name {
+				custom = true
+			}
+		}
+	}
+
+	if !custom {
+		keys = nil
+	}
+
+	return keys, codable
+}
+
+// renderCodingKeys emits the CodingKeys enumeration, which declares a case for each encoded field.
+func renderCodingKeys(node *ast.Struct, keys map[*ast.Field]string, w *render.BufferedWriter) {
+	w.Printf("enum CodingKeys: String, CodingKey {\n")
+	for _, field := range node.Fields() {
+		key := keys[field]
+		if key == "-" {
+			continue
+		}
+
+		w.Printf("case %s", fieldName(field))
+		if key != fieldName(field) {
+			w.Printf(" = ")
+			w.Print(swiftQuote(key))
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf("}\n")
+}
+
+// fieldName returns the escaped property name of the field.
+func fieldName(field *ast.Field) string {
+	return naming.Swift.Escape(field.Identifier())
+}
+
+// renderField emits a stored property. A field, which is excluded from the coding keys, must be initialized at
+// its declaration, so that it can be decoded, and therefore it is always mutable.
+func (r *Renderer) renderField(node *ast.Field, readOnly bool, keys map[*ast.Field]string, w *render.BufferedWriter) error {
+	writeDeclComment(w, fieldName(node), node.Comment(), node.Deprecated())
+	writeDeprecated(w, node.Deprecated())
+
+	for _, annotation := range node.Annotations() {
+		if annotation.Identifier() == jsonAnnotation {
+			continue
+		}
+
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	excluded := keys[node] == "-"
+	w.Printf(visibilityAsModifier(node.Visibility()))
+	if readOnly && !excluded {
+		w.Printf("let ")
+	} else {
+		w.Printf("var ")
+	}
+
+	w.Printf("%s: ", fieldName(node))
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+		return err
+	}
+
+	if excluded {
+		w.Printf(" = ")
+		if err := r.renderFieldDefault(node, w); err != nil {
+			return fmt.Errorf("the excluded field requires a default: %w", err)
+		}
+	}
+
+	w.Printf("\n")
+
+	return nil
+}
+
+// renderFieldDefault emits the default of the field or its zero value.
+func (r *Renderer) renderFieldDefault(node *ast.Field, w *render.BufferedWriter) error {
+	if node.FieldDefault != nil {
+		return r.renderBasicLit(node.FieldDefault, w)
+	}
+
+	zero, ok := zeroValue(node.TypeDecl())
+	if !ok {
+		return fmt.Errorf("type %s has no zero value", node.TypeDecl().String())
+	}
+
+	w.Printf(zero)
+
+	return nil
+}
+
+// renderMemberwiseInit emits an initializer, which assigns each field. Parameters of fields with a default or a
+// zero value are optional.
+func (r *Renderer) renderMemberwiseInit(node *ast.Struct, w *render.BufferedWriter) error {
+	doc := &strings.Builder{}
+	doc.WriteString("...creates a new " + node.Identifier() + ".\n\n")
+	for _, field := range node.Fields() {
+		if field.Comment() != nil {
+			doc.WriteString("@param " + deEllipsis(fieldName(field), field.Comment().Text) + "\n")
+		}
+	}
+
+	writeComment(w, "init", doc.String())
+	w.Printf(visibilityAsModifier(node.Visibility()))
+	w.Printf("init(")
+	for i, field := range node.Fields() {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		w.Printf("%s: ", fieldName(field))
+		if err := r.renderTypeDecl(field.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if _, ok := zeroValue(field.TypeDecl()); ok || field.FieldDefault != nil {
+			w.Printf(" = ")
+			if err := r.renderFieldDefault(field, w); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Printf(") {\n")
+	for _, field := range node.Fields() {
+		w.Printf("self.%s = %s\n", fieldName(field), fieldName(field))
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderProperty emits a var, if the property has a writer or no zero value, otherwise a let. A disabled reader
+// makes the property private and a writer with a more restrictive visibility restricts the setter, e.g.
+// public private(set) var. Properties of a protocol declare their accessors. All others are initialized with
+// their zero value, or if there is none, they are declared as implicitly unwrapped optional, which is the Swift
+// way of a late initialization.
+func (r *Renderer) renderProperty(node *ast.Property, w *render.BufferedWriter) error {
+	writeCommentNode(w, node.Identifier(), node.Comment())
+
+	name := naming.Swift.Escape(node.Identifier())
+	if _, ok := node.Parent().(*ast.Interface); ok {
+		w.Printf("var %s: ", name)
+		if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if node.Write.Enabled {
+			w.Printf(" { get set }\n")
+		} else {
+			w.Printf(" { get }\n")
+		}
+
+		return nil
+	}
+
+	visibility := ast.Private
+	if node.Read.Enabled {
+		visibility = node.Read.Visibility
+	}
+
+	setter := ast.Private
+	if node.Write.Enabled {
+		setter = node.Write.Visibility
+	}
+
+	zero, hasZero := zeroValue(node.TypeDecl())
+	mutable := node.Write.Enabled || !hasZero
+
+	w.Printf(visibilityAsModifier(visibility))
+
+	// the visibility constants are ordered from public to private
+	if mutable && setter > visibility {
+		w.Printf("%s(set) ", strings.TrimSpace(setterModifier(setter)))
+	}
+
+	if mutable {
+		w.Printf("var ")
+	} else {
+		w.Printf("let ")
+	}
+
+	w.Printf("%s: ", name)
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+		return err
+	}
+
+	if hasZero {
+		w.Printf(" = %s", zero)
+	} else {
+		w.Printf("!")
+	}
+
+	w.Printf("\n")
+
+	return nil
+}
+
+// renderFuncComment returns the comment of the func together with the tags of its parameters and results. The
+// deprecation notice precedes the tags, because the markup would otherwise append it to the last list item.
+func (r *Renderer) renderFuncComment(node *ast.Func) string {
+	comment := &strings.Builder{}
+	text := ""
+	if node.ObjComment != nil {
+		text = node.ObjComment.Text
+	}
+	comment.WriteString(ast.DocWithDeprecation(text, node.Deprecated()))
+	comment.WriteString("\n\n")
+
+	for _, parameterNode := range node.Params() {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		comment.WriteString("@param ")
+		comment.WriteString(deEllipsis(naming.Swift.Escape(parameterNode.Identifier()), parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	for i, parameterNode := range node.Results() {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		if i == 0 {
+			comment.WriteString("@return ")
+			comment.WriteString(deEllipsis("", parameterNode.ObjComment.Text))
+			comment.WriteString("\n")
+			continue
+		}
+
+		comment.WriteString("@throws ")
+		name := parameterNode.Identifier()
+		if name == "" {
+			name = fromStdlib(ast.Name(parameterNode.TypeDecl().String())).Identifier()
+		}
+
+		comment.WriteString(deEllipsis(name, parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	return comment.String()
+}
+
+// renderFunc emits a function. Depending on the parent, which is either an ast.Struct, an ast.Interface or an
+// ast.File, the function is rendered as a method, a default implementation of a protocol extension or a global
+// function. A function named like its struct becomes an initializer. A method of a struct with a pointer receiver
+// mutates the value, so it is declared as mutating.
+func (r *Renderer) renderFunc(node *ast.Func, w *render.BufferedWriter) error {
+	modifiers := ""
+	isInit := false
+	switch t := node.Parent().(type) {
+	case *ast.Interface:
+		// default implementations have the visibility of the extension
+	case *ast.Struct:
+		isInit = node.Identifier() == t.Identifier()
+		modifiers = visibilityAsModifier(node.Visibility())
+		switch {
+		case node.Static():
+			modifiers += "static "
+		case node.PtrReceiver() && !isInit:
+			modifiers += "mutating "
+		}
+	default:
+		modifiers = visibilityAsModifier(node.Visibility())
+	}
+
+	if err := r.renderFuncDecl(node, modifiers, isInit, w); err != nil {
+		return err
+	}
+
+	if node.Body() == nil {
+		w.Printf("\n")
+		return nil
+	}
+
+	w.Printf(" ")
+	if err := r.renderBlock(node.Body(), w); err != nil {
+		return fmt.Errorf("unable to render method body: %w", err)
+	}
+
+	return nil
+}
+
+// renderFuncDecl emits the comment and the signature of a function without a line break. Just like in Java, all results except the
+// first one are errors, so such a function throws.
+func (r *Renderer) renderFuncDecl(node *ast.Func, modifiers string, isInit bool, w *render.BufferedWriter) error {
+	writeComment(w, node.Identifier(), r.renderFuncComment(node))
+	writeDeprecated(w, node.Deprecated())
+
+	for _, annotation := range node.Annotations() {
+		if err := r.renderAnnotation(annotation, w); err != nil {
+			return err
+		}
+		w.Printf("\n")
+	}
+
+	w.Printf(modifiers)
+	if isInit {
+		w.Printf("init(")
+	} else {
+		w.Printf("func %s(", naming.Swift.Escape(node.Identifier()))
+	}
+
+	for i, parameterNode := range node.Params() {
+		for _, annotationNode := range parameterNode.Annotations() {
+			if err := r.renderAnnotation(annotationNode, w); err != nil {
+				return err
+			}
+
+			w.Printf(" ")
+		}
+
+		w.Printf("%s: ", naming.Swift.Escape(parameterNode.Identifier()))
+		if err := r.renderTypeDecl(parameterNode.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if i == len(node.Params())-1 && node.Variadic() {
+			w.Printf("...")
+		}
+
+		if i < len(node.Params())-1 {
+			w.Printf(", ")
+		}
+	}
+	w.Printf(")")
+
+	if len(node.Results()) > 1 {
+		w.Printf(" throws")
+	}
+
+	if len(node.Results()) > 0 && !isVoid(node.Results()[0].TypeDecl()) && !isInit {
+		w.Printf(" -> ")
+		if err := r.renderTypeDecl(node.Results()[0].TypeDecl(), w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderAnnotation emits an attribute, e.g. a property wrapper like @Published or @objc.
+func (r *Renderer) renderAnnotation(node *ast.Annotation, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+
+	w.Printf("@")
+	w.Printf(string(importer.shortify(node.Identifier())))
+	attrs := node.Attributes()
+	if len(attrs) > 0 {
+		w.Printf("(")
+		// the default case
+		if len(attrs) == 1 && attrs[0] == "" {
+			w.Print(node.GetLiteral(""))
+		} else {
+			// the labeled argument cases
+			for i, attr := range attrs {
+				w.Print(attr)
+				w.Printf(": ")
+				w.Print(node.GetLiteral(attr))
+				if i < len(attrs)-1 {
+					w.Printf(", ")
+				}
+			}
+		}
+
+		w.Printf(")")
+	}
+
+	return nil
+}
+
+// renderTypeDecl emits a type. Pointers become optionals, slices and arrays become arrays and maps become
+// dictionaries.
+func (r *Renderer) renderTypeDecl(node ast.TypeDecl, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+
+	switch t := node.(type) {
+	case *ast.SimpleTypeDecl:
+		w.Printf(string(importer.shortify(fromStdlib(t.Name()))))
+	case *ast.TypeDeclPtr:
+		if _, ok := t.TypeDecl().(*ast.TypeDeclPtr); ok {
+			// an optional cannot be optional again
+			return r.renderTypeDecl(t.TypeDecl(), w)
+		}
+
+		_, isFunc := t.TypeDecl().(*ast.FuncTypeDecl)
+		if isFunc {
+			w.Printf("(")
+		}
+
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if isFunc {
+			w.Printf(")")
+		}
+
+		w.Printf("?")
+	case *ast.SliceTypeDecl:
+		w.Printf("[")
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+		w.Printf("]")
+	case *ast.GenericTypeDecl:
+		return r.renderGenericTypeDecl(t, w)
+	case *ast.ArrayTypeDecl:
+		// the length is not part of the type
+		w.Printf("[")
+		if err := r.renderTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+		w.Printf("]")
+	case *ast.FuncTypeDecl:
+		return r.renderFuncTypeDecl(t, w)
+	default:
+		return fmt.Errorf("type declaration not yet implemented: %s", reflect.TypeOf(t).String())
+	}
+
+	return nil
+}
+
+// renderGenericTypeDecl emits a generic type. The stdlib list and map use the sugar of arrays and dictionaries,
+// e.g. [String] and [String: Int].
+func (r *Renderer) renderGenericTypeDecl(node *ast.GenericTypeDecl, w *render.BufferedWriter) error {
+	if simple, ok := node.TypeDecl.(*ast.SimpleTypeDecl); ok {
+		switch {
+		case simple.Name() == stdlib.List && len(node.Params()) == 1:
+			w.Printf("[")
+			if err := r.renderTypeDecl(node.Params()[0], w); err != nil {
+				return err
+			}
+
+			w.Printf("]")
+
+			return nil
+		case simple.Name() == stdlib.Map && len(node.Params()) == 2:
+			w.Printf("[")
+			if err := r.renderTypeDecl(node.Params()[0], w); err != nil {
+				return err
+			}
+
+			w.Printf(": ")
+			if err := r.renderTypeDecl(node.Params()[1], w); err != nil {
+				return err
+			}
+
+			w.Printf("]")
+
+			return nil
+		}
+	}
+
+	if err := r.renderTypeDecl(node.TypeDecl, w); err != nil {
+		return err
+	}
+
+	w.Printf("<")
+	for i, decl := range node.Params() {
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+
+		if i < len(node.Params())-1 {
+			w.Printf(", ")
+		}
+	}
+
+	w.Printf(">")
+
+	return nil
+}
+
+// renderFuncTypeDecl emits a function type, e.g. (String, Int) -> Bool. A trailing error result makes the
+// function throw and multiple results become a tuple.
+func (r *Renderer) renderFuncTypeDecl(node *ast.FuncTypeDecl, w *render.BufferedWriter) error {
+	var results []ast.TypeDecl
+	for _, param := range node.OutputParams() {
+		if !isVoid(param.TypeDecl()) {
+			results = append(results, param.TypeDecl())
+		}
+	}
+
+	throws := false
+	if len(results) > 0 {
+		if simple, ok := results[len(results)-1].(*ast.SimpleTypeDecl); ok && simple.Name() == stdlib.Error {
+			results = results[:len(results)-1]
+			throws = true
+		}
+	}
+
+	w.Printf("(")
+	for i, param := range node.InputParams() {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(param.TypeDecl(), w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(")")
+	if throws {
+		w.Printf(" throws")
+	}
+
+	w.Printf(" -> ")
+	switch len(results) {
+	case 0:
+		w.Printf("Void")
+	case 1:
+		return r.renderTypeDecl(results[0], w)
+	default:
+		w.Printf("(")
+		for i, result := range results {
+			if i > 0 {
+				w.Printf(", ")
+			}
+
+			if err := r.renderTypeDecl(result, w); err != nil {
+				return err
+			}
+		}
+
+		w.Printf(")")
+	}
+
+	return nil
+}
+
+// isVoid returns true, if the type declares the stdlib void.
+func isVoid(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.Void
+}
+
+// visibilityAsModifier returns the modifier including a trailing space. Package private is the default
+// internal access level, which is visible in the entire module. Swift has no protected access level, so it
+// becomes internal as well.
+func visibilityAsModifier(v ast.Visibility) string {
+	switch v {
+	case ast.Public:
+		return "public "
+	case ast.PackagePrivate, ast.Protected:
+		return ""
+	case ast.Private:
+		return "private "
+	default:
+		panic("visibility not implemented: " + strconv.Itoa(int(v)))
+	}
+}
+
+// setterModifier returns the explicit access level of a restricted setter, which must not be empty.
+func setterModifier(v ast.Visibility) string {
+	if modifier := visibilityAsModifier(v); modifier != "" {
+		return modifier
+	}
+
+	return "internal "
+}
@@ -0,0 +1,70 @@
+package swift
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"strconv"
+)
+
+// renderEnum emits a public enum with raw values, which conforms to Codable and CaseIterable. Each case is named
+// in lower camel case. Cases without a value get their name, if the base type is a string, otherwise their index,
+// just like iota in Go. A string raw value is omitted, if it is equal to the case name, because Swift uses
+// the case name by default. The synthesized init(rawValue:) resolves a case by its value.
+func (r *Renderer) renderEnum(node *ast.Enum, w *render.BufferedWriter) error {
+	writeCommentNode(w, node.Identifier(), node.Comment())
+
+	baseType := node.BaseType
+	if baseType == "" {
+		baseType = stdlib.Int
+	}
+
+	isString := baseType == stdlib.String
+	conformances := []string{
+		string(r.importer(node).shortify(fromStdlib(baseType))),
+		string(r.importer(node).shortify("Swift.Codable")),
+		string(r.importer(node).shortify("Swift.CaseIterable")),
+	}
+
+	for _, name := range node.Implements {
+		conformances = append(conformances, string(r.importer(node).shortify(name)))
+	}
+
+	w.Printf("public enum %s: ", node.Identifier())
+	for i, conformance := range conformances {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		w.Printf(conformance)
+	}
+
+	w.Printf(" {\n")
+
+	for i, enumCase := range node.Cases {
+		name := naming.Swift.Escape(naming.Swift.Private(enumCase.Name()))
+		writeCommentNode(w, name, enumCase.Comment())
+		w.Printf("case %s", name)
+		switch {
+		case enumCase.EnumValue != nil:
+			w.Printf(" = ")
+			if err := r.renderBasicLit(enumCase.EnumValue, w); err != nil {
+				return fmt.Errorf("cannot render value of case '%s': %w", enumCase.Name(), err)
+			}
+		case isString:
+			if enumCase.Name() != name {
+				w.Print(" = " + swiftQuote(enumCase.Name()))
+			}
+		default:
+			w.Printf(" = " + strconv.Itoa(i))
+		}
+
+		w.Printf("\n")
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
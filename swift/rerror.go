@@ -0,0 +1,86 @@
+package swift
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+// renderErrorType emits a public enum, which conforms to Error and declares a case for each error case. The
+// properties of a case become its labeled associated values and are part of the description.
+func (r *Renderer) renderErrorType(node *ast.ErrorType, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+	groupType := ErrorTypeName(node.TypeName)
+	writeComment(w, groupType, node.Doc())
+	w.Printf("public enum %s: %s, %s {\n", groupType, importer.shortify("Swift.Error"), importer.shortify("Swift.CustomStringConvertible"))
+
+	description := &strings.Builder{}
+	for _, errorCase := range node.Cases {
+		name := ErrorCaseName(errorCase.Name())
+		doc := errorCase.Doc(name, groupType) + "\n"
+		var labels []string
+		for _, property := range errorCase.Properties {
+			label := ErrorLabel(property.Identifier())
+			labels = append(labels, label)
+			if property.Comment() != nil {
+				doc += "\n@param " + deEllipsis(label, property.Comment().Text)
+			}
+		}
+
+		w.Printf("\n")
+		writeComment(w, name, doc)
+		w.Printf("case %s", name)
+		description.WriteString("case ")
+		if len(labels) > 0 {
+			w.Printf("(")
+			for i, property := range errorCase.Properties {
+				if i > 0 {
+					w.Printf(", ")
+				}
+
+				w.Printf("%s: ", labels[i])
+				if err := r.renderTypeDecl(property.TypeDecl(), w); err != nil {
+					return err
+				}
+			}
+
+			w.Printf(")")
+			description.WriteString("let ." + name + "(" + strings.Join(labels, ", ") + ")")
+		} else {
+			description.WriteString("." + name)
+		}
+
+		w.Printf("\n")
+		msg := errorCase.Message(func(property *ast.Field) string {
+			return `\(` + ErrorLabel(property.Identifier()) + ")"
+		})
+
+		description.WriteString(":\nreturn \"" + msg + "\"\n")
+	}
+
+	w.Printf("\n")
+	writeComment(w, "description", "...returns the conventional description of this error.")
+	w.Printf("public var description: String {\n")
+	w.Printf("switch self {\n")
+	w.Print(description.String())
+	w.Printf("}\n}\n}\n")
+
+	return nil
+}
+
+// ErrorTypeName returns the name of the enum of an error group, e.g. NotFoundError.
+func ErrorTypeName(groupName string) string {
+	const errStr = "Error"
+	return naming.Swift.Public(strings.TrimSuffix(groupName, errStr)) + errStr
+}
+
+// ErrorCaseName returns the name of the enum case of an error case, e.g. ticket.
+func ErrorCaseName(caseName string) string {
+	return naming.Swift.Escape(naming.Swift.Private(caseName))
+}
+
+// ErrorLabel returns the label of the associated value of an error property, e.g. userID.
+func ErrorLabel(propertyName string) string {
+	return naming.Swift.Escape(naming.Swift.Private(propertyName))
+}
@@ -0,0 +1,113 @@
+package swift
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/stdlib"
+	"strings"
+)
+
+// fromStdlib converts stdlib types (indicated by the macro ! sign at the end) and returns a Swift name for it.
+// The names are always qualified by their module, so that the importer can import Foundation and detect
+// collisions. The generic list and map types are rendered with the Swift sugar for arrays and dictionaries.
+func fromStdlib(name ast.Name) ast.Name {
+	switch name {
+	case stdlib.Bool:
+		return "Swift.Bool"
+
+	case stdlib.Int:
+		return "Swift.Int"
+
+	case stdlib.Byte:
+		return "Swift.UInt8"
+
+	case stdlib.Int16:
+		return "Swift.Int16"
+
+	case stdlib.Int32:
+		return "Swift.Int32"
+
+	case stdlib.Int64:
+		return "Swift.Int64"
+
+	case stdlib.Float32:
+		return "Swift.Float"
+
+	case stdlib.Float64:
+		return "Swift.Double"
+
+	case stdlib.Map:
+		return "Swift.Dictionary"
+
+	case stdlib.List:
+		return "Swift.Array"
+
+	case stdlib.UUID:
+		return "Foundation.UUID"
+
+	case stdlib.String:
+		return "Swift.String"
+
+	case stdlib.Error:
+		return "Swift.Error"
+
+	case stdlib.Time:
+		return "Foundation.Date"
+
+	case stdlib.Duration:
+		// a TimeInterval is a Double of seconds
+		return "Foundation.TimeInterval"
+
+	case stdlib.URL:
+		return "Foundation.URL"
+
+	case stdlib.Rune:
+		// a Character is an extended grapheme cluster, so a code point is a unicode scalar value
+		return "Swift.UInt32"
+
+	case stdlib.Void:
+		return "Swift.Void"
+
+	default:
+		if strings.HasSuffix(string(name), "!") {
+			panic("not a stdlib type: " + string(name))
+		}
+		return name
+	}
+}
+
+// zeroValue returns the Swift literal of the zero value of the given type, just like Go would initialize it.
+// Optionals become nil. Returns false, if the type has no reasonable zero value, e.g. for any custom type.
+func zeroValue(decl ast.TypeDecl) (string, bool) {
+	switch t := decl.(type) {
+	case *ast.TypeDeclPtr:
+		return "nil", true
+	case *ast.SliceTypeDecl:
+		return "[]", true
+	case *ast.GenericTypeDecl:
+		if simple, ok := t.TypeDecl.(*ast.SimpleTypeDecl); ok {
+			switch simple.Name() {
+			case stdlib.List:
+				return "[]", true
+			case stdlib.Map:
+				return "[:]", true
+			}
+		}
+	case *ast.SimpleTypeDecl:
+		switch t.Name() {
+		case stdlib.Bool:
+			return "false", true
+		case stdlib.Int, stdlib.Int32, stdlib.Byte, stdlib.Int16, stdlib.Int64, stdlib.Rune:
+			return "0", true
+		case stdlib.Float32, stdlib.Float64, stdlib.Duration:
+			return "0.0", true
+		case stdlib.String:
+			return `""`, true
+		case stdlib.List:
+			return "[]", true
+		case stdlib.Map:
+			return "[:]", true
+		}
+	}
+
+	return "", false
+}
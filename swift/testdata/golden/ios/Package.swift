@@ -0,0 +1,17 @@
+// swift-tools-version:5.1
+import PackageDescription
+
+let package = Package(
+    name: "tickets",
+    platforms: [
+        .iOS(.v13),
+    ],
+    products: [
+        .library(name: "Domain", targets: ["Domain"]),
+        .library(name: "App", targets: ["App"]),
+    ],
+    targets: [
+        .target(name: "Domain", dependencies: []),
+        .target(name: "App", dependencies: ["Domain"]),
+    ]
+)
@@ -0,0 +1,16 @@
+import Domain
+import Foundation
+
+/// find fails always.
+public func find(id: UUID) throws -> Ticket {
+    let err = NotFoundError.ticket(id: id)
+    var n = 0
+    if n < 1 {
+        n += 1
+    } else {
+        print("n=\\(n)")
+    }
+    throw err
+}
+
+public let defaultTimeout: TimeInterval = 0.0
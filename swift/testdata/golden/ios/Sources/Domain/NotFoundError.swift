@@ -0,0 +1,23 @@
+import Foundation
+
+/// NotFoundError represents the sum type of all NotFound errors.
+public enum NotFoundError: Error, CustomStringConvertible {
+    /// ticket is returned, if no such ticket exists.
+    /// ticket is also a NotFoundError.
+    ///
+    /// - Parameter id: is the unknown ticket.
+    case ticket(id: UUID)
+
+    /// store is also a NotFoundError.
+    case store
+
+    /// description returns the conventional description of this error.
+    public var description: String {
+        switch self {
+        case let .ticket(id):
+            return "Ticket: id=\(id)"
+        case .store:
+            return "Store"
+        }
+    }
+}
@@ -0,0 +1,41 @@
+import Foundation
+
+/// Repository stores tickets.
+public protocol Repository {
+    var size: Int { get }
+
+    var name: String { get set }
+
+    /// - Parameter id: is the ticket id.
+    func find(id: UUID) -> Ticket?
+
+    /// findSlow scans all tickets.
+    ///
+    /// Deprecated: too slow. Use ``find`` instead.
+    ///
+    /// - Parameter id: is the ticket id.
+    @available(*, deprecated, message: "too slow", renamed: "Repository.find")
+    func findSlow(id: UUID) -> Ticket?
+
+    func forEach(f: (Ticket) throws -> Bool)
+
+    func isEmpty() -> Bool
+}
+
+public extension Repository {
+    func isEmpty() -> Bool {
+        return size == 0
+    }
+}
+
+struct MemRepository: Repository {
+    public private(set) var size: Int = 0
+
+    public var name: String = ""
+
+    private var clock: Clock!
+
+    public mutating func clear() {
+        size = 0
+    }
+}
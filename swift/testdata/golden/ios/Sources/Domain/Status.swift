@@ -0,0 +1,5 @@
+public enum Status: String, Codable, CaseIterable {
+    /// inProgress is the state of active work.
+    case inProgress
+    case done = "$done"
+}
@@ -0,0 +1,47 @@
+import Foundation
+
+/// Ticket is an issue.
+public struct Ticket: Codable {
+    /// id is the unique id.
+    public var id: UUID
+    public var title: String
+    public var assignee: String?
+    public var tags: [String] = []
+
+    /// init creates a new Ticket.
+    ///
+    /// - Parameter id: is the unique id.
+    public init(id: UUID, title: String = "", assignee: String? = nil, tags: [String] = []) {
+        self.id = id
+        self.title = title
+        self.assignee = assignee
+        self.tags = tags
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case id = "ID"
+        case title
+        case assignee
+    }
+
+    /// isAssigned returns true, if someone works on it.
+    public func isAssigned() -> Bool {
+        return assignee != nil
+    }
+
+    /// - Parameter text: is the json.
+    public static func parse(text: String) throws -> Ticket {
+        throw NotFoundError.store
+    }
+}
+
+public struct Point {
+    public let x: Int64
+    private let y: Int64
+
+    /// init creates a new Point.
+    public init(x: Int64 = 0, y: Int64 = 0) {
+        self.x = x
+        self.y = y
+    }
+}
@@ -0,0 +1,305 @@
+package typescript
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"path"
+	"strings"
+)
+
+const (
+	MimeTypeTypeScript = "text/x-typescript"
+	MimeTypeDir        = "application/x-directory"
+)
+
+const (
+	// SourceDir is the directory of the sources, relative to the module.
+	SourceDir = "src"
+
+	// IndexName is the module name of the barrel file, which re-exports the files of a package.
+	IndexName = "index"
+
+	// fileExt is the extension of all rendered source files.
+	fileExt = ".ts"
+)
+
+// Options for the renderer.
+type Options struct {
+	// Int64AsBigInt declares stdlib.Int64 as bigint instead of number. A JSON number is always parsed as a number
+	// and loses precision beyond 2^53, so the application must convert it, e.g. by a reviver of JSON.parse.
+	Int64AsBigInt bool
+
+	// TimeAsDate declares stdlib.Time as Date instead of string. The JSON encoding of a time is its RFC 3339 string,
+	// so the application must convert it, e.g. by a reviver of JSON.parse.
+	TimeAsDate bool
+}
+
+// Renderer provides a typescript renderer.
+type Renderer struct {
+	opts      Options
+	root      ast.Node
+	packages  map[string]*tsPackage  // packages by path of all TypeScript modules
+	importers map[ast.Node]*importer // each *ast.File
+}
+
+// A tsPackage is the directory of a package within the source directory of its module.
+type tsPackage struct {
+	dir   []string             // the directory, relative to the source directory, which is empty for the module itself
+	mod   *ast.Mod             // the module, which declares the package
+	types map[string]*ast.File // the files of the declared types by name
+}
+
+// path returns the slash separated path of the package directory, relative to the output root.
+func (p *tsPackage) path() string {
+	return path.Join(append([]string{p.mod.Target.Out, SourceDir}, p.dir...)...)
+}
+
+// NewRenderer creates a new Renderer instance.
+func NewRenderer(opts Options) *Renderer {
+	return &Renderer{opts: opts}
+}
+
+func init() {
+	render.Register(ast.LangTypeScript, ast.FrameworkSDK, func() render.Renderer {
+		return NewRenderer(Options{})
+	})
+}
+
+// tearUp prepares the ast to be used for source generation.
+func (r *Renderer) tearUp(node ast.Node) error {
+	r.root = ast.Root(node)
+
+	if err := r.installPackages(); err != nil {
+		return err
+	}
+
+	if err := installImporter(r); err != nil {
+		return fmt.Errorf("unable to install importer: %w", err)
+	}
+
+	return nil
+}
+
+// tearDown frees allocated resources.
+func (r *Renderer) tearDown() error {
+	if err := uninstallImporter(r); err != nil {
+		return fmt.Errorf("unable to uninstall importer: %w", err)
+	}
+
+	r.packages = nil
+
+	return nil
+}
+
+// installPackages declares a directory for each package of all TypeScript modules. The file names must be unique
+// within their package and the index is reserved for the barrel.
+func (r *Renderer) installPackages() error {
+	r.packages = map[string]*tsPackage{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang != ast.LangTypeScript {
+			return nil
+		}
+
+		for _, pkg := range mod.Pkgs {
+			dir, err := packageDir(mod, pkg)
+			if err != nil {
+				return err
+			}
+
+			p := &tsPackage{dir: dir, mod: mod, types: map[string]*ast.File{}}
+			files := map[string]bool{}
+			for _, file := range pkg.PkgFiles {
+				name := fileModuleName(file)
+				if files[name] || name == IndexName {
+					return fmt.Errorf("file '%s' of package '%s' declares the module '%s', which is already declared", file.Name, pkg.Path, name)
+				}
+
+				files[name] = true
+				for _, namedType := range file.Types() {
+					p.types[namedType.Identifier()] = file
+				}
+			}
+
+			r.packages[pkg.Path] = p
+		}
+
+		return nil
+	})
+}
+
+// packageDir returns the directory of the package relative to the source directory of its module, e.g. [domain]
+// for the package example.com/tickets/domain of the module example.com/tickets. The package of the module itself
+// is the source directory.
+func packageDir(mod *ast.Mod, pkg *ast.Pkg) ([]string, error) {
+	if pkg.Path == mod.Name {
+		return nil, nil
+	}
+
+	rest := strings.TrimPrefix(pkg.Path, mod.Name)
+	if rest == pkg.Path || rest[0] != '/' && rest[0] != '.' {
+		return nil, fmt.Errorf("package '%s' is not contained in module '%s'", pkg.Path, mod.Name)
+	}
+
+	return strings.FieldsFunc(rest, func(r rune) bool { return r == '/' || r == '.' }), nil
+}
+
+// fileModuleName returns the name of the module, which is declared by the file, e.g. ticket for ticket.ts.
+func fileModuleName(file *ast.File) string {
+	return strings.TrimSuffix(file.Name, fileExt)
+}
+
+// importer resolves the current importer from the parents file.
+func (r *Renderer) importer(n ast.Node) *importer {
+	return importerFromTree(r, n)
+}
+
+// Render converts the given node into a render.Artifact. A partial result is returned if an error is detected.
+// If node is an *ast.Mod, only that module is rendered and it must target ast.LangTypeScript. Otherwise all
+// TypeScript modules of the project are rendered and other modules are ignored. Use render.Project to render
+// mixed projects.
+func (r *Renderer) Render(node ast.Node) (a render.Artifact, err error) {
+	if mod, ok := node.(*ast.Mod); ok && mod.Target.Lang != ast.LangTypeScript {
+		return nil, fmt.Errorf("cannot render module '%s': expected language '%s' but got '%s'", mod.Name, ast.LangTypeScript, mod.Target.Lang)
+	}
+
+	if err := r.tearUp(node); err != nil {
+		return nil, fmt.Errorf("unable to tearUp: %w", err)
+	}
+
+	defer func() {
+		if e := r.tearDown(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	root := &render.Dir{}
+	if mod, ok := node.(*ast.Mod); ok {
+		_, err = r.renderMod(mod, root)
+		return root, err
+	}
+
+	err = ast.ForEachMod(node, func(mod *ast.Mod) error {
+		if mod.Target.Lang == ast.LangTypeScript {
+			if _, err := r.renderMod(mod, root); err != nil {
+				return fmt.Errorf("cannot render module '%s': %w", mod.Name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return root, fmt.Errorf("cannot render project: %w", err)
+	}
+
+	return root, nil
+}
+
+// renderMod emits each package of the module as a directory within the SourceDir of the modules output
+// directory. Each directory contains the files of the package and the barrel, which re-exports them.
+func (r *Renderer) renderMod(mod *ast.Mod, parent *render.Dir) (*render.Dir, error) {
+	modDir := r.ensureDir(mod.Target.Out, parent)
+	srcDir := r.ensureDir(SourceDir, modDir)
+
+	for _, pkg := range mod.Pkgs {
+		dir := srcDir
+		if p := r.packages[pkg.Path]; len(p.dir) > 0 {
+			dir = r.ensureDir(strings.Join(p.dir, "/"), srcDir)
+		}
+
+		files, err := r.renderPkg(pkg)
+		dir.Files = append(dir.Files, files...)
+		if err != nil {
+			return modDir, fmt.Errorf("cannot render package '%s': %w", pkg.Path, err)
+		}
+	}
+
+	return modDir, nil
+}
+
+// renderPkg emits the barrel of the package, followed by its files.
+func (r *Renderer) renderPkg(pkg *ast.Pkg) ([]*render.File, error) {
+	var res []*render.File
+	var firstErr error
+
+	buf, err := r.renderIndex(pkg)
+	res = append(res, &render.File{
+		FileName: IndexName + fileExt,
+		MimeType: MimeTypeTypeScript,
+		Buf:      buf,
+		Error:    err,
+	})
+
+	if err != nil {
+		firstErr = fmt.Errorf("cannot render index: %w", err)
+	}
+
+	for _, file := range pkg.PkgFiles {
+		buf, err := r.renderFile(file)
+		if firstErr == nil && err != nil {
+			firstErr = fmt.Errorf("cannot render file '%s': %w", file.Name, err)
+		}
+
+		res = append(res, &render.File{
+			FileName: fileModuleName(file) + fileExt,
+			MimeType: MimeTypeTypeScript,
+			Buf:      buf,
+			Error:    err,
+		})
+	}
+
+	for _, file := range pkg.RawFiles {
+		buf, err := file.Data(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render raw file: %w", err)
+		}
+
+		res = append(res, &render.File{
+			FileName: file.Name,
+			MimeType: file.MimeType,
+			Buf:      buf,
+		})
+	}
+
+	return res, firstErr
+}
+
+// renderIndex emits the barrel of the package, which is documented by the package comment and re-exports all
+// files of the package, so that the package is imported just like in Go, e.g. from '../domain'.
+func (r *Renderer) renderIndex(pkg *ast.Pkg) ([]byte, error) {
+	w := &render.BufferedWriter{}
+	if pkg.Preamble != nil {
+		writeLineComment(w, pkg.Preamble)
+		w.Printf("\n")
+	}
+
+	if pkg.Comment() != nil {
+		writeFileOverview(w, pkg.Name, pkg.Comment().Text)
+		w.Printf("\n")
+	}
+
+	for _, file := range pkg.PkgFiles {
+		w.Print("export * from " + tsQuote("./"+fileModuleName(file)) + ";\n")
+	}
+
+	return Format(w.Bytes())
+}
+
+// ensureDir appends for each path segment a directory, if required. Returns the directory denoting
+// the last segment.
+func (r *Renderer) ensureDir(restPath string, parent *render.Dir) *render.Dir {
+	names := strings.Split(restPath, "/")
+
+	dir := parent.Directory(names[0])
+	if dir == nil {
+		dir = &render.Dir{DirName: names[0], MimeType: MimeTypeDir}
+		parent.Dirs = append(parent.Dirs, dir)
+	}
+
+	if len(names) == 1 {
+		return dir
+	}
+
+	return r.ensureDir(strings.Join(names[1:], "/"), dir)
+}
@@ -0,0 +1,194 @@
+package typescript
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"strconv"
+	"strings"
+)
+
+// docWidth is the width at which the lines of a TSDoc comment are wrapped, including the comment prefix.
+const docWidth = 100
+
+// packageDocTag marks the comment at the top of a file as the documentation of the file itself.
+const packageDocTag = "@packageDocumentation"
+
+// formatComment replaces a '...' prefix with the ellipsisName and emits the structured content of the doc as
+// TSDoc, which uses markdown: paragraphs are separated by an empty line, lists and code blocks use the markdown
+// syntax and doc links become {@link name} tags. A deprecation notice becomes the @deprecated tag and all block
+// tags are emitted at the end, where a @param separates the name from its description by a hyphen. Lines are
+// prefixed with a ' * ' and wrapped at docWidth. Also a new first line (/**) and a new last line ( */) is added,
+// except for a single short paragraph, which fits into a single line.
+func formatComment(ellipsisName, doc string) string {
+	doc = strings.TrimLeft(strings.TrimRight(doc, " \t\n"), "\n")
+	if strings.TrimSpace(doc) == "" {
+		return ""
+	}
+
+	doc = deEllipsis(ellipsisName, strings.TrimSpace(doc))
+
+	description, tags := splitTags(doc)
+
+	tmp := &strings.Builder{}
+	first := true
+	for _, block := range ast.ParseDoc(description) {
+		if block.Kind == ast.DocDeprecated {
+			tags = append(tags, "@deprecated "+strings.TrimSpace(block.Text))
+			continue
+		}
+
+		if !first {
+			tmp.WriteString(" *\n")
+		}
+
+		switch block.Kind {
+		case ast.DocParagraph:
+			writeDocLines(tmp, "", "", tsdocText(block.Text))
+		case ast.DocList:
+			for i, item := range block.Items {
+				marker := "- "
+				if block.Numbered {
+					marker = strconv.Itoa(i+1) + ". "
+				}
+
+				writeDocLines(tmp, marker, strings.Repeat(" ", len(marker)), tsdocText(item))
+			}
+		case ast.DocCode:
+			tmp.WriteString(" * ```\n")
+			for _, line := range strings.Split(block.Text, "\n") {
+				tmp.WriteString(strings.TrimRight(" * "+escapeDoc(line), " "))
+				tmp.WriteString("\n")
+			}
+
+			tmp.WriteString(" * ```\n")
+		}
+
+		first = false
+	}
+
+	if len(tags) > 0 {
+		if !first {
+			tmp.WriteString(" *\n")
+		}
+
+		for _, tag := range tags {
+			writeDocLines(tmp, "", "    ", tsdocText(tsdocTag(tag)))
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(tmp.String(), "\n"), "\n")
+	if len(lines) == 1 {
+		if line := "/** " + strings.TrimPrefix(lines[0], " * ") + " */"; len(line) <= docWidth {
+			return line
+		}
+	}
+
+	return "/**\n" + tmp.String() + " */"
+}
+
+// writeFileOverview emits the documentation of the file itself, which is marked by the packageDocTag. A '...'
+// prefix is replaced with the name of the file or package.
+func writeFileOverview(w *render.BufferedWriter, name, doc string) {
+	if strings.TrimSpace(doc) == "" {
+		return
+	}
+
+	w.Print(formatComment(name, strings.TrimSpace(doc)+"\n\n"+packageDocTag))
+	w.Printf("\n")
+}
+
+// splitTags separates the description from the block tags, which start at the first line beginning with an @.
+func splitTags(doc string) (string, []string) {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range lines[i:] {
+			switch {
+			case strings.TrimSpace(tag) == "":
+			case strings.HasPrefix(tag, "@") || len(tags) == 0:
+				tags = append(tags, strings.TrimSpace(tag))
+			default:
+				tags[len(tags)-1] += "\n" + strings.TrimSpace(tag)
+			}
+		}
+
+		return strings.Join(lines[:i], "\n"), tags
+	}
+
+	return doc, nil
+}
+
+// tsdocTag converts a block tag into its TSDoc form, e.g. @param id is the key becomes @param id - is the key
+// and @return becomes @returns.
+func tsdocTag(tag string) string {
+	switch {
+	case strings.HasPrefix(tag, "@param "):
+		text := strings.TrimSpace(strings.TrimPrefix(tag, "@param "))
+		if idx := strings.IndexAny(text, " \n"); idx >= 0 && !strings.HasPrefix(strings.TrimSpace(text[idx:]), "-") {
+			return "@param " + text[:idx] + " - " + strings.TrimSpace(text[idx:])
+		}
+
+		return tag
+	case strings.HasPrefix(tag, "@return ") || tag == "@return":
+		return "@returns" + strings.TrimPrefix(tag, "@return")
+	default:
+		return tag
+	}
+}
+
+// writeDocLines wraps each line of text and emits it with the first prefix, all following lines are indented.
+func writeDocLines(w *strings.Builder, first, indent, text string) {
+	prefix := first
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range render.WrapLine(line, docWidth-len(" * ")-len(prefix)) {
+			w.WriteString(strings.TrimRight(" * "+prefix+wrapped, " "))
+			w.WriteString("\n")
+			prefix = indent
+		}
+	}
+}
+
+// tsdocText emits the doc links of the text as inline link tags, which are resolved by their identifier in the
+// scope of the file, e.g. {@link Ticket}.
+func tsdocText(text string) string {
+	sb := &strings.Builder{}
+	for _, span := range ast.DocSpans(text) {
+		if span.Link == "" {
+			sb.WriteString(escapeDoc(span.Text))
+			continue
+		}
+
+		sb.WriteString("{@link " + span.Link.Identifier() + "}")
+	}
+
+	return sb.String()
+}
+
+// escapeDoc prevents that the text terminates the comment.
+func escapeDoc(text string) string {
+	return strings.ReplaceAll(text, "*/", "*&#47;")
+}
+
+// deEllipsis replaces a '...' prefix of the doc with the given name.
+func deEllipsis(ellipsisName, doc string) string {
+	if strings.HasPrefix(doc, "...") {
+		return strings.TrimSpace(ellipsisName + " " + strings.TrimSpace(doc[3:]))
+	}
+
+	return doc
+}
+
+// writeLineComment emits the comment as line comments, e.g. a license preamble.
+func writeLineComment(w *render.BufferedWriter, comment *ast.Comment) {
+	if comment == nil || strings.TrimSpace(comment.Text) == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(comment.Text), "\n") {
+		w.Print("// " + strings.TrimRight(line, " \t") + "\n")
+	}
+}
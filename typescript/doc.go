@@ -0,0 +1,4 @@
+// Package typescript provides a renderer for TypeScript declarations of the data models, which are exchanged with
+// the services, e.g. as JSON. Structs become interfaces, enums become unions of literal types and error groups
+// become discriminated unions. Each package becomes a directory with an index.ts, which re-exports its files.
+package typescript
@@ -0,0 +1,16 @@
+package typescript
+
+import "github.com/golangee/src/render"
+
+// format follows the default of the formatter of the TypeScript language service, which indents by 4 spaces. Note
+// that prettier indents by 2 spaces instead. Block comments do not nest in TypeScript and template literals are
+// quoted by backticks.
+var format = render.BraceFormat{
+	Indent: "    ",
+	Quote:  render.QuotedBy("\"'`"),
+}
+
+// Format pretty prints TypeScript source, see render.BraceFormat.Format.
+func Format(source []byte) ([]byte, error) {
+	return format.Format(source)
+}
@@ -0,0 +1,43 @@
+package typescript
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	src := `
+import type { Ticket } from './ticket';
+
+
+/**
+ * Store { persists tickets.
+ */
+export interface Store {
+readonly open: '{';
+find(id: string): Ticket | null;
+forEach(f: (t: Ticket) => void): void;
+
+}
+`
+
+	want := `import type { Ticket } from './ticket';
+
+/**
+ * Store { persists tickets.
+ */
+export interface Store {
+    readonly open: '{';
+    find(id: string): Ticket | null;
+    forEach(f: (t: Ticket) => void): void;
+}
+`
+
+	buf, err := Format([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+}
@@ -0,0 +1,219 @@
+package typescript
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importer manages the import declarations at the files top. The qualifier of a name is either the path of a
+// package, which is imported from the barrel of its directory, e.g. ../domain, or an npm module specifier, like
+// rxjs. The types of the other files of the same package are imported from their file instead, so that the
+// barrel never imports itself. All imports are type-only, because the rendered declarations have no runtime
+// representation. Simple names are unique in the scope, so a colliding name is imported with an alias, which is
+// prefixed by the last segment of its qualifier.
+type importer struct {
+	pkg                string                       // the package path of the scope
+	packages           map[string]*tsPackage        // the packages of all TypeScript modules
+	identifiersInScope map[string]ast.Name          // the local names and their qualified names
+	locals             map[ast.Name]string          // the qualified names and their local names
+	imports            map[string]map[string]string // the local names of the imported identifiers by specifier
+}
+
+// newImporter allocates an importer for a file of the given package. The declared identifiers are the simple
+// names of the files types, which always win against any import.
+func newImporter(pkg string, packages map[string]*tsPackage, declared []string) *importer {
+	imp := &importer{
+		pkg:                pkg,
+		packages:           packages,
+		identifiersInScope: map[string]ast.Name{},
+		locals:             map[ast.Name]string{},
+		imports:            map[string]map[string]string{},
+	}
+
+	for _, id := range declared {
+		name := ast.Name(pkg + "." + id)
+		imp.identifiersInScope[id] = name
+		imp.locals[name] = id
+	}
+
+	return imp
+}
+
+// installImporter allocates a new importer instance for every ast.File of the TypeScript modules. The importers
+// are owned by the renderer and never attached to the ast.
+func installImporter(r *Renderer) error {
+	r.importers = map[ast.Node]*importer{}
+	return ast.ForEachMod(r.root, func(mod *ast.Mod) error {
+		if mod.Target.Lang != ast.LangTypeScript {
+			return nil
+		}
+
+		for _, pkg := range mod.Pkgs {
+			for _, file := range pkg.PkgFiles {
+				var declared []string
+				for _, namedType := range file.Types() {
+					declared = append(declared, namedType.Identifier())
+				}
+
+				r.importers[file] = newImporter(pkg.Path, r.packages, declared)
+			}
+		}
+
+		return nil
+	})
+}
+
+// uninstallImporter releases all importers.
+func uninstallImporter(r *Renderer) error {
+	r.importers = nil
+	return nil
+}
+
+// importerFromTree walks up the tree until it finds the first file with an importer.
+func importerFromTree(r *Renderer, n ast.Node) *importer {
+	root := n
+	for root != nil {
+		if imp, ok := r.importers[root]; ok {
+			return imp
+		}
+
+		newRoot := root.Parent()
+		if newRoot == nil {
+			panic("no attached importer found in ast scope")
+		}
+
+		root = newRoot
+	}
+
+	panic("invalid node")
+}
+
+// importDecls returns the import declarations sorted by their specifier.
+func (p *importer) importDecls() []string {
+	var specifiers []string
+	for specifier := range p.imports {
+		specifiers = append(specifiers, specifier)
+	}
+
+	sort.Strings(specifiers)
+
+	var res []string
+	for _, specifier := range specifiers {
+		var ids []string
+		for id := range p.imports[specifier] {
+			ids = append(ids, id)
+		}
+
+		sort.Strings(ids)
+
+		var names []string
+		for _, id := range ids {
+			if local := p.imports[specifier][id]; local != id {
+				names = append(names, id+" as "+local)
+			} else {
+				names = append(names, id)
+			}
+		}
+
+		res = append(res, "import type { "+strings.Join(names, ", ")+" } from "+tsQuote(specifier)+";")
+	}
+
+	return res
+}
+
+// specifier returns the module specifier, which exports the identifier of the qualifier, as seen from the
+// package of the importer.
+func (p *importer) specifier(qualifier, id string) string {
+	pkg, ok := p.packages[qualifier]
+	if !ok {
+		return qualifier
+	}
+
+	if qualifier == p.pkg {
+		if file, ok := pkg.types[id]; ok {
+			return "./" + fileModuleName(file)
+		}
+
+		// e.g. a type which is declared by a macro, so its file is unknown
+		return "./" + IndexName
+	}
+
+	rel := relativePath(p.packages[p.pkg].path(), pkg.path())
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
+	}
+
+	return rel
+}
+
+// shortify returns a name, which is only valid in the importers scope and declares its import. A simple name,
+// which is declared by another file of the same package, is imported as well. If the name is not complete, the
+// original name is just returned.
+func (p *importer) shortify(name ast.Name) ast.Name {
+	qual := name.Qualifier()
+	id := name.Identifier()
+	if pkg, ok := p.packages[p.pkg]; ok && qual == "" && pkg.types[id] != nil {
+		qual = p.pkg
+		name = ast.Name(qual + "." + id)
+	}
+
+	if id == "" || qual == "" {
+		return name
+	}
+
+	if local, ok := p.locals[name]; ok {
+		return ast.Name(local)
+	}
+
+	local := id
+	if _, taken := p.identifiersInScope[local]; taken {
+		// name collision, e.g. two Ticket types of different packages
+		prefix := naming.TypeScript.Pascal(qual[strings.LastIndexAny(qual, "/.@")+1:])
+		local = prefix + id
+		for i := 2; p.identifiersInScope[local] != ""; i++ {
+			local = prefix + id + strconv.Itoa(i)
+		}
+	}
+
+	specifier := p.specifier(qual, id)
+	if p.imports[specifier] == nil {
+		p.imports[specifier] = map[string]string{}
+	}
+
+	p.imports[specifier][id] = local
+	p.identifiersInScope[local] = name
+	p.locals[name] = local
+
+	return ast.Name(local)
+}
+
+// relativePath returns the path of the directory to, relative to the directory from. Both are relative to the
+// same root, e.g. ../domain for src/app and src/domain.
+func relativePath(from, to string) string {
+	from, to = path.Clean(from), path.Clean(to)
+	fromSegments := strings.Split(from, "/")
+	toSegments := strings.Split(to, "/")
+	if from == "." {
+		fromSegments = nil
+	}
+
+	if to == "." {
+		toSegments = nil
+	}
+
+	i := 0
+	for i < len(fromSegments) && i < len(toSegments) && fromSegments[i] == toSegments[i] {
+		i++
+	}
+
+	var res []string
+	for range fromSegments[i:] {
+		res = append(res, "..")
+	}
+
+	return path.Join(append(res, toSegments[i:]...)...)
+}
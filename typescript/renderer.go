@@ -0,0 +1,569 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// jsonAnnotation is the name of the field annotation, which declares the key of a field in the JSON encoding,
+// just like the Go struct tag, e.g. json:"id,omitempty" or json:"-".
+const jsonAnnotation = "json"
+
+// identifierPattern matches a key, which is a valid property name without quotes.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func writeComment(w *render.BufferedWriter, name, doc string) {
+	myDoc := formatComment(name, doc)
+	if myDoc != "" {
+		w.Print(myDoc)
+		w.Printf("\n")
+	}
+}
+
+func writeCommentNode(w *render.BufferedWriter, name string, comment *ast.Comment) {
+	if comment == nil {
+		return
+	}
+
+	writeComment(w, name, comment.Text)
+}
+
+// writeDeclComment emits the comment of a declaration together with its deprecation notice, which becomes the
+// @deprecated tag.
+func writeDeclComment(w *render.BufferedWriter, name string, comment *ast.Comment, deprecation *ast.Deprecation) {
+	text := ""
+	if comment != nil {
+		text = comment.Text
+	}
+
+	writeComment(w, name, ast.DocWithDeprecation(text, deprecation))
+}
+
+// renderFile tries to emit the file as typescript. The file comment documents the file itself.
+func (r *Renderer) renderFile(file *ast.File) ([]byte, error) {
+	w := &render.BufferedWriter{}
+
+	if file.Preamble != nil {
+		writeLineComment(w, file.Preamble)
+		w.Printf("\n")
+	}
+
+	if file.Comment() != nil {
+		writeFileOverview(w, fileModuleName(file), file.Comment().Text)
+		w.Printf("\n")
+	}
+
+	// the explicit imports denote types of other modules, like rxjs.Observable
+	importer := r.importer(file)
+	for _, imp := range file.Imports() {
+		importer.shortify(imp.Name)
+	}
+
+	// render everything into tmp first, the importer collects all required imports on-the-go
+	tmp := &render.BufferedWriter{}
+	for _, node := range file.Nodes {
+		if _, ok := node.(*ast.Import); ok {
+			continue
+		}
+
+		if err := r.renderNode(node, tmp); err != nil {
+			return nil, err
+		}
+
+		tmp.Printf("\n")
+	}
+
+	imports := importer.importDecls()
+	for _, decl := range imports {
+		w.Print(decl)
+		w.Printf("\n")
+	}
+
+	if len(imports) > 0 {
+		w.Printf("\n")
+	}
+
+	w.Print(tmp.String())
+
+	return Format(w.Bytes())
+}
+
+// renderNode inspects and emits the actual type. Only type declarations and values are supported, because the
+// models have no behavior in the frontend.
+func (r *Renderer) renderNode(node ast.Node, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Struct:
+		if err := r.renderStruct(n, w); err != nil {
+			return fmt.Errorf("cannot render struct '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Interface:
+		if err := r.renderInterface(n, w); err != nil {
+			return fmt.Errorf("cannot render interface '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Enum:
+		if err := r.renderEnum(n, w); err != nil {
+			return fmt.Errorf("cannot render enum '%s': %w", n.Identifier(), err)
+		}
+	case *ast.ErrorType:
+		if err := r.renderErrorType(n, w); err != nil {
+			return fmt.Errorf("cannot render error type '%s': %w", n.Identifier(), err)
+		}
+	case *ast.Macro:
+		return r.renderMacro(n, w)
+	case *ast.Tpl:
+		return r.renderTpl(n, w)
+	case *ast.Ident, *ast.BasicLit, *ast.CompLit:
+		return r.renderExpr(n.(ast.Expr), w)
+	case *ast.Func:
+		return fmt.Errorf("cannot render func '%s': typescript only declares types", n.Identifier())
+	case *ast.Property:
+		return fmt.Errorf("cannot render property '%s': typescript only declares types", n.Identifier())
+	default:
+		return fmt.Errorf("type not yet implemented: %s", reflect.TypeOf(n).String())
+	}
+
+	return nil
+}
+
+// renderStruct emits an interface, which declares the JSON representation of the struct. Each field is named
+// after its JSON key and is optional, if it is omitted when empty. Unexported or excluded fields are not encoded,
+// so they are not declared. Methods, properties and implemented interfaces are the behavior of the services and
+// are not declared either. The extended and embedded types become extended interfaces, just like the JSON
+// encoding of Go flattens embedded structs. The fields of a record are read-only. TypeScript cannot nest types,
+// so nested types are emitted in front of the interface.
+func (r *Renderer) renderStruct(node *ast.Struct, w *render.BufferedWriter) error {
+	for _, typeNode := range node.NamedTypes() {
+		if err := r.renderNode(typeNode, w); err != nil {
+			return err
+		}
+
+		w.Printf("\n")
+	}
+
+	writeDeclComment(w, node.Identifier(), node.Comment(), node.Deprecated())
+	w.Printf("export interface %s", node.Identifier())
+
+	var supertypes []string
+	if node.Extends != "" {
+		supertypes = append(supertypes, string(r.importer(node).shortify(node.Extends)))
+	}
+
+	for _, decl := range node.Embedded {
+		tmp := &render.BufferedWriter{}
+		if err := r.renderTypeDecl(decl, tmp); err != nil {
+			return err
+		}
+
+		supertypes = append(supertypes, tmp.String())
+	}
+
+	if len(supertypes) > 0 {
+		w.Printf(" extends %s", strings.Join(supertypes, ", "))
+	}
+
+	w.Printf(" {\n")
+	for _, field := range node.Fields() {
+		if err := r.renderField(field, node.Record(), w); err != nil {
+			return fmt.Errorf("cannot render field '%s': %w", field.Identifier(), err)
+		}
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// jsonKey returns the key of the field in the JSON encoding and whether it is omitted, if empty. Just like in Go,
+// a field without an explicit key is encoded with its declared identifier. Returns the empty string, if the field
+// is not encoded at all.
+func jsonKey(node *ast.Field) (string, bool) {
+	if node.Visibility() != ast.Public {
+		return "", false
+	}
+
+	for _, annotation := range node.Annotations() {
+		if annotation.Identifier() != jsonAnnotation {
+			continue
+		}
+
+		opts := strings.Split(annotation.GetLiteral(""), ",")
+		key := strings.TrimSpace(opts[0])
+		if key == "-" {
+			return "", false
+		}
+
+		if key == "" {
+			key = node.Identifier()
+		}
+
+		omitEmpty := false
+		for _, opt := range opts[1:] {
+			omitEmpty = omitEmpty || strings.TrimSpace(opt) == "omitempty"
+		}
+
+		return key, omitEmpty
+	}
+
+	return node.Identifier(), false
+}
+
+// renderField emits a field of an interface, which declares a struct.
+func (r *Renderer) renderField(node *ast.Field, readonly bool, w *render.BufferedWriter) error {
+	key, optional := jsonKey(node)
+	if key == "" {
+		return nil
+	}
+
+	writeDeclComment(w, key, node.Comment(), node.Deprecated())
+	if readonly {
+		w.Printf("readonly ")
+	}
+
+	w.Print(propertyKey(key))
+	if optional {
+		w.Printf("?")
+	}
+
+	w.Printf(": ")
+	if err := r.renderTypeDecl(node.TypeDecl(), w); err != nil {
+		return err
+	}
+
+	w.Printf(";\n")
+
+	return nil
+}
+
+// propertyKey returns the key as an identifier or as a string literal, if it is not a valid identifier.
+func propertyKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return key
+	}
+
+	return tsQuote(key)
+}
+
+// renderInterface emits an interface, whose embedded types become extended interfaces. Properties become
+// properties, which are read-only without a setter, and methods become method signatures.
+func (r *Renderer) renderInterface(node *ast.Interface, w *render.BufferedWriter) error {
+	if len(node.NamedTypes()) > 0 {
+		return fmt.Errorf("an interface cannot declare nested types")
+	}
+
+	writeDeclComment(w, node.Identifier(), node.Comment(), node.Deprecated())
+	w.Printf("export interface %s", node.Identifier())
+
+	for i, decl := range node.Embedded {
+		if i == 0 {
+			w.Printf(" extends ")
+		} else {
+			w.Printf(", ")
+		}
+
+		if err := r.renderTypeDecl(decl, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(" {\n")
+	for _, property := range node.Properties() {
+		writeCommentNode(w, property.Identifier(), property.Comment())
+		if !property.Write.Enabled {
+			w.Printf("readonly ")
+		}
+
+		w.Printf("%s: ", property.Identifier())
+		if err := r.renderTypeDecl(property.TypeDecl(), w); err != nil {
+			return fmt.Errorf("failed to render property %s: %w", property.Identifier(), err)
+		}
+
+		w.Printf(";\n")
+	}
+
+	for _, fun := range node.Methods() {
+		if err := r.renderMethodSignature(fun, w); err != nil {
+			return fmt.Errorf("failed to render func %s: %w", fun.Identifier(), err)
+		}
+	}
+
+	w.Printf("}\n")
+
+	return nil
+}
+
+// renderMethodSignature emits the declaration of a method without a body.
+func (r *Renderer) renderMethodSignature(node *ast.Func, w *render.BufferedWriter) error {
+	writeComment(w, node.Identifier(), r.renderFuncComment(node))
+	w.Printf("%s(", naming.TypeScript.Escape(node.Identifier()))
+	if err := r.renderParams(node.Params(), node.Variadic(), w); err != nil {
+		return err
+	}
+
+	w.Printf("): ")
+	if err := r.renderResults(node.Results(), w); err != nil {
+		return err
+	}
+
+	w.Printf(";\n")
+
+	return nil
+}
+
+// renderFuncComment returns the comment of the func together with the comments of its parameters and results,
+// which become the @param, @returns and @throws tags. The deprecation notice is a part of the description, so that
+// it becomes the @deprecated tag instead of a continuation of the last tag.
+func (r *Renderer) renderFuncComment(node *ast.Func) string {
+	comment := &strings.Builder{}
+	text := ""
+	if node.ObjComment != nil {
+		text = node.ObjComment.Text
+	}
+	comment.WriteString(ast.DocWithDeprecation(text, node.Deprecated()))
+	comment.WriteString("\n\n")
+
+	for _, parameterNode := range node.Params() {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		comment.WriteString("@param ")
+		comment.WriteString(deEllipsis(naming.TypeScript.Escape(parameterNode.Identifier()), parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	for _, parameterNode := range node.Results() {
+		if parameterNode.ObjComment == nil {
+			continue
+		}
+
+		if isError(parameterNode.TypeDecl()) {
+			comment.WriteString("@throws ")
+		} else {
+			comment.WriteString("@return ")
+		}
+
+		comment.WriteString(deEllipsis("", parameterNode.ObjComment.Text))
+		comment.WriteString("\n")
+	}
+
+	return comment.String()
+}
+
+// renderParams emits the named parameters. A variadic parameter becomes a rest parameter.
+func (r *Renderer) renderParams(params []*ast.Param, variadic bool, w *render.BufferedWriter) error {
+	for i, param := range params {
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		if variadic && i == len(params)-1 {
+			w.Printf("...")
+		}
+
+		name := param.Identifier()
+		if name == "" {
+			name = fmt.Sprintf("p%d", i)
+		}
+
+		w.Printf("%s: ", naming.TypeScript.Escape(name))
+		if err := r.renderTypeDecl(param.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		if variadic && i == len(params)-1 {
+			w.Printf("[]")
+		}
+	}
+
+	return nil
+}
+
+// renderResults emits the result type of a func. TypeScript throws errors instead of returning them, so a
+// trailing error result is omitted. Without results, the func returns void and multiple results become a tuple.
+func (r *Renderer) renderResults(results []*ast.Param, w *render.BufferedWriter) error {
+	if len(results) > 0 && isError(results[len(results)-1].TypeDecl()) {
+		results = results[:len(results)-1]
+	}
+
+	switch len(results) {
+	case 0:
+		w.Printf("void")
+	case 1:
+		return r.renderTypeDecl(results[0].TypeDecl(), w)
+	default:
+		w.Printf("[")
+		for i, result := range results {
+			if i > 0 {
+				w.Printf(", ")
+			}
+
+			if err := r.renderTypeDecl(result.TypeDecl(), w); err != nil {
+				return err
+			}
+		}
+
+		w.Printf("]")
+	}
+
+	return nil
+}
+
+// isError returns true, if the type declares the stdlib error.
+func isError(decl ast.TypeDecl) bool {
+	simple, ok := decl.(*ast.SimpleTypeDecl)
+	return ok && simple.Name() == stdlib.Error
+}
+
+// renderTypeDecl emits a type declaration. A pointer is nullable, because Go encodes a nil pointer as null.
+// Lists become arrays and maps become records.
+func (r *Renderer) renderTypeDecl(node ast.TypeDecl, w *render.BufferedWriter) error {
+	importer := r.importer(node)
+
+	switch t := node.(type) {
+	case *ast.SimpleTypeDecl:
+		w.Printf(string(importer.shortify(r.fromStdlib(t.Name()))))
+	case *ast.TypeDeclPtr:
+		if _, ok := t.TypeDecl().(*ast.TypeDeclPtr); ok {
+			// null cannot be null again
+			return r.renderTypeDecl(t.TypeDecl(), w)
+		}
+
+		if err := r.renderElementTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		w.Printf(" | null")
+	case *ast.SliceTypeDecl:
+		if err := r.renderElementTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+
+		w.Printf("[]")
+	case *ast.ArrayTypeDecl:
+		if err := r.renderElementTypeDecl(t.TypeDecl(), w); err != nil {
+			return err
+		}
+
+		w.Printf("[]")
+	case *ast.GenericTypeDecl:
+		if simple, ok := t.TypeDecl.(*ast.SimpleTypeDecl); ok && simple.Name() == stdlib.List && len(t.Params()) == 1 {
+			if err := r.renderElementTypeDecl(t.Params()[0], w); err != nil {
+				return err
+			}
+
+			w.Printf("[]")
+
+			return nil
+		}
+
+		if err := r.renderTypeDecl(t.TypeDecl, w); err != nil {
+			return err
+		}
+
+		w.Printf("<")
+		for i, decl := range t.Params() {
+			if err := r.renderTypeDecl(decl, w); err != nil {
+				return err
+			}
+
+			if i < len(t.Params())-1 {
+				w.Printf(", ")
+			}
+		}
+
+		w.Printf(">")
+	case *ast.FuncTypeDecl:
+		w.Printf("(")
+		if err := r.renderParams(t.InputParams(), false, w); err != nil {
+			return err
+		}
+
+		w.Printf(") => ")
+
+		return r.renderResults(t.OutputParams(), w)
+	default:
+		return fmt.Errorf("type declaration not yet implemented: %s", reflect.TypeOf(t).String())
+	}
+
+	return nil
+}
+
+// renderElementTypeDecl emits the type declaration of an array element or a nullable type, which requires
+// parentheses, if it is a union or a function type itself.
+func (r *Renderer) renderElementTypeDecl(node ast.TypeDecl, w *render.BufferedWriter) error {
+	switch node.(type) {
+	case *ast.TypeDeclPtr, *ast.FuncTypeDecl:
+		w.Printf("(")
+		if err := r.renderTypeDecl(node, w); err != nil {
+			return err
+		}
+
+		w.Printf(")")
+
+		return nil
+	default:
+		return r.renderTypeDecl(node, w)
+	}
+}
+
+// renderMacro emits the evaluated nodes of a macro.
+func (r *Renderer) renderMacro(node *ast.Macro, w *render.BufferedWriter) error {
+	for _, n := range node.Children() {
+		if err := r.renderNode(n, w); err != nil {
+			return fmt.Errorf("unable to render dynamic macro node: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderTpl executes and emits the template text.
+func (r *Renderer) renderTpl(node *ast.Tpl, w *render.BufferedWriter) error {
+	tmpl, err := template.New(node.ObjPos.String()).Parse(node.Template)
+	if err != nil {
+		return fmt.Errorf("cannot parse template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, &tplRenderContext{r: r, importer: r.importer(node), tpl: node}); err != nil {
+		return fmt.Errorf("cannot execute template: %w", err)
+	}
+
+	w.Print(buf.String())
+
+	return nil
+}
+
+// ensure that we always implement the full contract
+var _ ast.TplContext = (*tplRenderContext)(nil)
+
+type tplRenderContext struct {
+	r        *Renderer
+	importer *importer
+	tpl      *ast.Tpl
+}
+
+func (t *tplRenderContext) Get(key string) interface{} {
+	return t.tpl.Values[key]
+}
+
+func (t *tplRenderContext) Use(name string) string {
+	return string(t.importer.shortify(t.r.fromStdlib(ast.Name(name))))
+}
+
+func (t *tplRenderContext) Self() *ast.Tpl {
+	return t.tpl
+}
+
+// tsQuote returns a single quoted string literal, as usual in TypeScript.
+func tsQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s) + "'"
+}
@@ -0,0 +1,145 @@
+package typescript_test
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/srctest"
+	"github.com/golangee/src/stdlib"
+	"github.com/golangee/src/stdlib/lang"
+	"github.com/golangee/src/typescript"
+	"testing"
+)
+
+// newPrj creates a module with a struct, an interface, an enum and an error group in a domain package, which is
+// used by an app package.
+func newPrj() *ast.Prj {
+	notFound := lang.NewError("NotFound").AddCase(
+		lang.NewErrorCase("Ticket").SetComment("...is returned, if no such ticket exists.").
+			AddProperty("id", ast.NewSimpleTypeDecl(stdlib.UUID), "...is the unknown ticket."),
+		lang.NewErrorCase("Store"),
+	)
+
+	return ast.NewPrj("Tickets").AddModules(
+		ast.NewMod("example.com/tickets").
+			SetLang(ast.LangTypeScript).
+			SetOutputDirectory("web").
+			AddPackages(
+				ast.NewPkg("example.com/tickets/domain").SetComment("...contains the ticket model.").AddFiles(
+					ast.NewFile("ticket.ts").AddTypes(
+						ast.NewStruct("Ticket").
+							SetComment("...is an issue.").
+							SetVisibility(ast.Public).
+							AddFields(
+								ast.NewField("id", ast.NewSimpleTypeDecl(stdlib.UUID)).
+									SetComment("...is the unique id.").
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("ID")),
+								ast.NewField("title", ast.NewSimpleTypeDecl(stdlib.String)).
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("title,omitempty")),
+								ast.NewField("assignee", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl(stdlib.String))),
+								ast.NewField("tags", ast.NewSliceTypeDecl(ast.NewSimpleTypeDecl(stdlib.String))).
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("-")),
+								ast.NewField("status", ast.NewSimpleTypeDecl("Status")).
+									AddAnnotations(ast.NewAnnotation("json").SetDefault("ticket-status")),
+								ast.NewField("created", ast.NewSimpleTypeDecl(stdlib.Time)),
+								ast.NewField("revision", ast.NewSimpleTypeDecl(stdlib.Int64)),
+								ast.NewField("labels", ast.NewMapDecl(ast.NewSimpleTypeDecl(stdlib.String), ast.NewSimpleTypeDecl(stdlib.Int))),
+								ast.NewField("secret", ast.NewSimpleTypeDecl(stdlib.String)).SetVisibility(ast.Private),
+							).
+							AddMethods(
+								ast.NewFunc("isAssigned").
+									SetVisibility(ast.Public).
+									AddResults(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool))).
+									SetBody(ast.NewBlock(ast.NewReturnStmt(ast.NewIdent("true")))),
+							),
+						ast.NewStruct("Point").
+							SetDeprecated("points are not precise", "Ticket").
+							SetRecord(true).
+							AddFields(
+								ast.NewField("x", ast.NewSimpleTypeDecl(stdlib.Float64)),
+								ast.NewField("y", ast.NewSimpleTypeDecl(stdlib.Float64)),
+							),
+					),
+					ast.NewFile("repository.ts").AddTypes(
+						ast.NewInterface("Repository").
+							SetComment("...stores tickets.").
+							SetVisibility(ast.Public).
+							AddProperties(
+								ast.NewProperty("size", ast.NewSimpleTypeDecl(stdlib.Int)).Reader(true, ast.Public),
+								ast.NewProperty("name", ast.NewSimpleTypeDecl(stdlib.String)).Reader(true, ast.Public).Writer(true, ast.Public),
+							).
+							AddMethods(
+								ast.NewFunc("find").
+									AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the ticket id.")).
+									AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket"))), ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Error)).SetComment("...if the store fails.")),
+								ast.NewFunc("findSlow").
+									SetComment("...scans all tickets.").
+									SetDeprecated("too slow", "Repository.find").
+									AddParams(ast.NewParam("id", ast.NewSimpleTypeDecl(stdlib.UUID)).SetComment("...is the ticket id.")).
+									AddResults(ast.NewParam("", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("Ticket")))),
+								ast.NewFunc("forEach").
+									AddParams(ast.NewParam("f", ast.NewFuncTypeDecl().
+										AddInputParams(ast.NewParam("t", ast.NewSimpleTypeDecl("Ticket"))).
+										AddOutputParams(ast.NewParam("", ast.NewSimpleTypeDecl(stdlib.Bool))))),
+							),
+					),
+					ast.NewFile("status.ts").AddTypes(
+						ast.NewEnum("Status", stdlib.String).SetComment("...is the state of a ticket.").AddCases(
+							ast.NewEnumCase("inProgress").SetComment("...is the state of active work."),
+							ast.NewEnumCase("Done").SetValue(ast.NewStrLit("$done")),
+						),
+						ast.NewEnum("Priority", "").AddCases(
+							ast.NewEnumCase("Low"),
+							ast.NewEnumCase("High"),
+						),
+					),
+					ast.NewFile("not_found_error.ts").AddNodes(notFound.TypeDecl()),
+				),
+				ast.NewPkg("example.com/tickets/app").AddFiles(
+					ast.NewFile("page.ts").AddTypes(
+						ast.NewStruct("Page").
+							AddFields(
+								ast.NewField("tickets", ast.NewSliceTypeDecl(ast.NewSimpleTypeDecl("example.com/tickets/domain.Ticket"))),
+								ast.NewField("error", ast.NewTypeDeclPtr(ast.NewSimpleTypeDecl("example.com/tickets/domain.NotFoundError"))),
+							),
+					),
+					ast.NewFile("missing.ts").AddNodes(
+						ast.NewMacro().SetMatchers(ast.MatchTargetLanguage(ast.LangTypeScript,
+							ast.NewTpl("export const missing: {{.Use \"example.com/tickets/domain.NotFoundError\"}} = "),
+							notFound.Cases[0].Make(ast.NewStrLit("42")),
+							ast.NewTpl(";"),
+						)),
+					),
+				),
+			),
+	)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	a, err := typescript.NewRenderer(typescript.Options{}).Render(newPrj())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srctest.Compare(t, srctest.DefaultGoldenDir, a)
+}
+
+func TestRenderer_RenderOptions(t *testing.T) {
+	a, err := typescript.NewRenderer(typescript.Options{Int64AsBigInt: true, TimeAsDate: true}).Render(newPrj())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srctest.AssertContains(t, srctest.ReadFile(t, a.(*render.Dir), "web/src/domain/ticket.ts"),
+		"    created: Date;\n    revision: bigint;\n",
+	)
+}
+
+func TestRenderer_RenderGlobalFunc(t *testing.T) {
+	prj := newPrj()
+	page := prj.Mods[0].Pkgs[1].PkgFiles[0]
+	page.AddNodes(ast.NewFunc("find").SetVisibility(ast.Public).SetBody(ast.NewBlock()))
+
+	if _, err := typescript.NewRenderer(typescript.Options{}).Render(prj); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,92 @@
+package typescript
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/render"
+	"github.com/golangee/src/stdlib"
+	"strconv"
+	"strings"
+)
+
+// renderEnum emits a union of literal types, which are the values of the cases in the JSON encoding. A string enum
+// uses the value of each case, which is its name by default. Any other enum uses the value or the index of each
+// case, just like iota in Go. A union type cannot document its members, so the documented cases are appended as a
+// list to the comment of the type.
+func (r *Renderer) renderEnum(node *ast.Enum, w *render.BufferedWriter) error {
+	baseType := node.BaseType
+	if baseType == "" {
+		baseType = stdlib.Int
+	}
+
+	isString := baseType == stdlib.String
+	values := make([]string, 0, len(node.Cases))
+	var caseDocs []string
+	for i, enumCase := range node.Cases {
+		value := ""
+		switch {
+		case enumCase.EnumValue != nil:
+			v, err := literalValue(enumCase.EnumValue)
+			if err != nil {
+				return fmt.Errorf("cannot render value of case '%s': %w", enumCase.Name(), err)
+			}
+
+			value = v
+		case isString:
+			value = tsQuote(enumCase.Name())
+		default:
+			value = strconv.Itoa(i)
+		}
+
+		values = append(values, value)
+		if enumCase.Comment() != nil && strings.TrimSpace(enumCase.Comment().Text) != "" {
+			text := strings.Join(strings.Fields(deEllipsis(enumCase.Name(), enumCase.Comment().Text)), " ")
+			caseDocs = append(caseDocs, fmt.Sprintf("  * %s: %s", value, text))
+		}
+	}
+
+	doc := ""
+	if node.Comment() != nil {
+		doc = node.Comment().Text
+	}
+
+	if len(caseDocs) > 0 {
+		doc = strings.TrimRight(doc, "\n") + "\n\n" + strings.Join(caseDocs, "\n")
+	}
+
+	writeComment(w, node.Identifier(), doc)
+
+	if len(values) == 0 {
+		w.Printf("export type %s = never;\n", node.Identifier())
+		return nil
+	}
+
+	w.Printf("export type %s = %s;\n", node.Identifier(), strings.Join(values, " | "))
+
+	return nil
+}
+
+// literalValue returns the TypeScript literal of a Go literal. Strings become single quoted strings and a rune
+// becomes its code point, just like Go encodes it.
+func literalValue(node *ast.BasicLit) (string, error) {
+	switch node.Kind {
+	case ast.TokenString:
+		s, err := strconv.Unquote(node.Val)
+		if err != nil {
+			return "", fmt.Errorf("invalid string literal %s: %w", node.Val, err)
+		}
+
+		return tsQuote(s), nil
+	case ast.TokenChar:
+		v, _, _, err := strconv.UnquoteChar(strings.TrimSuffix(strings.TrimPrefix(node.Val, "'"), "'"), '\'')
+		if err != nil {
+			return "", fmt.Errorf("invalid rune literal %s: %w", node.Val, err)
+		}
+
+		return strconv.Itoa(int(v)), nil
+	case ast.TokenInt, ast.TokenFloat:
+		return node.Val, nil
+	default:
+		return "", fmt.Errorf("unsupported literal %s", node.Val)
+	}
+}
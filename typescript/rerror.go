@@ -0,0 +1,63 @@
+package typescript
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+	"strings"
+)
+
+// renderErrorType emits a union type, which is discriminated by the kind of each member, and an interface for each
+// error case. The kind is the declared name of the case and its properties become the fields of the interface.
+func (r *Renderer) renderErrorType(node *ast.ErrorType, w *render.BufferedWriter) error {
+	groupType := ErrorTypeName(node.TypeName)
+	var members []string
+	for _, errorCase := range node.Cases {
+		members = append(members, ErrorMemberName(node.TypeName, errorCase.Name()))
+	}
+
+	writeComment(w, groupType, node.Doc())
+	if len(members) == 0 {
+		w.Printf("export type %s = never;\n", groupType)
+	} else {
+		w.Printf("export type %s = %s;\n", groupType, strings.Join(members, " | "))
+	}
+
+	for i, errorCase := range node.Cases {
+		w.Printf("\n")
+		writeComment(w, members[i], errorCase.Doc(members[i], groupType))
+		w.Printf("export interface %s {\n", members[i])
+		w.Printf("kind: %s;\n", tsQuote(errorCase.Name()))
+		for _, property := range errorCase.Properties {
+			field := ErrorFieldName(property.Identifier())
+			writeCommentNode(w, field, property.Comment())
+			w.Printf("%s: ", field)
+			if err := r.renderTypeDecl(property.TypeDecl(), w); err != nil {
+				return err
+			}
+
+			w.Printf(";\n")
+		}
+
+		w.Printf("}\n")
+	}
+
+	return nil
+}
+
+// ErrorTypeName returns the name of the union type of an error group, e.g. NotFoundError.
+func ErrorTypeName(groupName string) string {
+	const errStr = "Error"
+	return naming.TypeScript.Public(strings.TrimSuffix(groupName, errStr)) + errStr
+}
+
+// ErrorMemberName returns the name of the interface of an error case, e.g. NotFoundTicketError.
+func ErrorMemberName(groupName, caseName string) string {
+	const errStr = "Error"
+	return naming.TypeScript.Public(strings.TrimSuffix(groupName, errStr)) + naming.TypeScript.Public(caseName) + errStr
+}
+
+// ErrorFieldName returns the name of the interface field of an error property, e.g. userId.
+func ErrorFieldName(propertyName string) string {
+	return naming.TypeScript.Private(propertyName)
+}
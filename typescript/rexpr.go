@@ -0,0 +1,96 @@
+package typescript
+
+import (
+	"fmt"
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/naming"
+	"github.com/golangee/src/render"
+)
+
+// renderExpr emits a value expression, e.g. a constant created by an error case. Only identifiers, literals and
+// composite literals are supported, because the models have no behavior in the frontend.
+func (r *Renderer) renderExpr(node ast.Expr, w *render.BufferedWriter) error {
+	switch n := node.(type) {
+	case *ast.Ident:
+		if n.Name == "nil" {
+			w.Printf("null")
+			return nil
+		}
+
+		w.Printf(naming.TypeScript.Escape(n.Name))
+	case *ast.BasicLit:
+		value, err := literalValue(n)
+		if err != nil {
+			return err
+		}
+
+		w.Printf(value)
+	case *ast.CompLit:
+		return r.renderCompLit(n, w)
+	case *ast.Macro:
+		for _, child := range n.Children() {
+			expr, ok := child.(ast.Expr)
+			if !ok {
+				return fmt.Errorf("expected an expression but got %T", child)
+			}
+
+			if err := r.renderExpr(expr, w); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot render expression %T: typescript only declares types and values", node)
+	}
+
+	return nil
+}
+
+// renderCompLit emits an object literal, if the elements are key value pairs, otherwise an array literal.
+// TypeScript is structurally typed, so the type of the literal is given by its context, e.g. a declared constant.
+func (r *Renderer) renderCompLit(node *ast.CompLit, w *render.BufferedWriter) error {
+	keyed := len(node.Elements) > 0
+	for _, element := range node.Elements {
+		if pair, ok := element.(*ast.BinaryExpr); !ok || pair.Op != ast.OpColon {
+			keyed = false
+		}
+	}
+
+	if !keyed {
+		w.Printf("[")
+		for i, element := range node.Elements {
+			if i > 0 {
+				w.Printf(", ")
+			}
+
+			if err := r.renderExpr(element, w); err != nil {
+				return err
+			}
+		}
+
+		w.Printf("]")
+
+		return nil
+	}
+
+	w.Printf("{ ")
+	for i, element := range node.Elements {
+		pair := element.(*ast.BinaryExpr)
+		key, ok := pair.X.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("expected an identifier as key but got %T", pair.X)
+		}
+
+		if i > 0 {
+			w.Printf(", ")
+		}
+
+		w.Printf("%s: ", key.Name)
+		if err := r.renderExpr(pair.Y, w); err != nil {
+			return err
+		}
+	}
+
+	w.Printf(" }")
+
+	return nil
+}
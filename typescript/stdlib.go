@@ -0,0 +1,60 @@
+package typescript
+
+import (
+	"github.com/golangee/src/ast"
+	"github.com/golangee/src/stdlib"
+	"strings"
+)
+
+// fromStdlib converts stdlib types (indicated by the macro ! sign at the end) and returns a TypeScript name for
+// it, which represents the JSON encoding of the Go type. All numbers are a number, except a stdlib.Int64, which
+// is a bigint, if declared by the options. A stdlib.Time is the RFC 3339 string or a Date, if declared by the
+// options, and a stdlib.Duration is the number of nanoseconds. None of them require an import. The generic list
+// and map types are rendered as arrays and records.
+func (r *Renderer) fromStdlib(name ast.Name) ast.Name {
+	switch name {
+	case stdlib.Bool:
+		return "boolean"
+
+	case stdlib.Int, stdlib.Byte, stdlib.Int16, stdlib.Int32, stdlib.Float32, stdlib.Float64, stdlib.Rune:
+		return "number"
+
+	case stdlib.Int64:
+		if r.opts.Int64AsBigInt {
+			return "bigint"
+		}
+
+		return "number"
+
+	case stdlib.Map:
+		return "Record"
+
+	case stdlib.List:
+		return "Array"
+
+	case stdlib.UUID, stdlib.String, stdlib.URL:
+		return "string"
+
+	case stdlib.Error:
+		return "Error"
+
+	case stdlib.Time:
+		if r.opts.TimeAsDate {
+			return "Date"
+		}
+
+		return "string"
+
+	case stdlib.Duration:
+		return "number"
+
+	case stdlib.Void:
+		return "void"
+
+	default:
+		if strings.HasSuffix(string(name), "!") {
+			panic("not a stdlib type: " + string(name))
+		}
+		return name
+	}
+}
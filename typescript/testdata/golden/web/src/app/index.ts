@@ -0,0 +1,2 @@
+export * from './page';
+export * from './missing';
@@ -0,0 +1,3 @@
+import type { NotFoundError } from '../domain';
+
+export const missing: NotFoundError = { kind: 'Ticket', id: '42' };
@@ -0,0 +1,6 @@
+import type { NotFoundError, Ticket } from '../domain';
+
+export interface Page {
+    tickets: Ticket[];
+    error: NotFoundError | null;
+}
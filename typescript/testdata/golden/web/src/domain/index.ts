@@ -0,0 +1,10 @@
+/**
+ * domain contains the ticket model.
+ *
+ * @packageDocumentation
+ */
+
+export * from './ticket';
+export * from './repository';
+export * from './status';
+export * from './not_found_error';
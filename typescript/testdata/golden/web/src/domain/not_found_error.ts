@@ -0,0 +1,17 @@
+/** NotFoundError represents the sum type of all NotFound errors. */
+export type NotFoundError = NotFoundTicketError | NotFoundStoreError;
+
+/**
+ * NotFoundTicketError is returned, if no such ticket exists.
+ * NotFoundTicketError is also a NotFoundError.
+ */
+export interface NotFoundTicketError {
+    kind: 'Ticket';
+    /** id is the unknown ticket. */
+    id: string;
+}
+
+/** NotFoundStoreError is also a NotFoundError. */
+export interface NotFoundStoreError {
+    kind: 'Store';
+}
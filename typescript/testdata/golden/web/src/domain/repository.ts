@@ -0,0 +1,20 @@
+import type { Ticket } from './ticket';
+
+/** Repository stores tickets. */
+export interface Repository {
+    readonly size: number;
+    name: string;
+    /**
+     * @param id - is the ticket id.
+     * @throws if the store fails.
+     */
+    find(id: string): Ticket | null;
+    /**
+     * findSlow scans all tickets.
+     *
+     * @param id - is the ticket id.
+     * @deprecated too slow. Use {@link find} instead.
+     */
+    findSlow(id: string): Ticket | null;
+    forEach(f: (t: Ticket) => boolean): void;
+}
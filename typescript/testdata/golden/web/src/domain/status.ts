@@ -0,0 +1,8 @@
+/**
+ * Status is the state of a ticket.
+ *
+ * - 'inProgress': inProgress is the state of active work.
+ */
+export type Status = 'inProgress' | '$done';
+
+export type Priority = 0 | 1;
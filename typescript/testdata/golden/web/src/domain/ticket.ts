@@ -0,0 +1,19 @@
+import type { Status } from './status';
+
+/** Ticket is an issue. */
+export interface Ticket {
+    /** ID is the unique id. */
+    ID: string;
+    title?: string;
+    assignee: string | null;
+    'ticket-status': Status;
+    created: string;
+    revision: number;
+    labels: Record<string, number>;
+}
+
+/** @deprecated points are not precise. Use {@link Ticket} instead. */
+export interface Point {
+    readonly x: number;
+    readonly y: number;
+}